@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"log/slog"
 	handlers "music-streaming/internal/adapter/handlers"
+	"music-streaming/internal/adapter/metadata"
 	"music-streaming/internal/adapter/repositories"
+	"music-streaming/internal/adapter/transcoding"
 	"music-streaming/internal/core/config"
 	"music-streaming/internal/core/services"
 	"net/http"
@@ -16,7 +18,7 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 	"github.com/redis/go-redis/v9"
 )
@@ -69,11 +71,12 @@ func main() {
 		jsonLogger.Error("POSTGRES_CONNECTION_STRING environment variable is not set")
 	}
 
-	db, err := pgx.Connect(context.Background(), dbURL)
+	// A pool is required since background scans write concurrently with request handlers
+	db, err := pgxpool.New(context.Background(), dbURL)
 	if err != nil {
 		jsonLogger.Error("Failed to connect to database", slog.String("error", err.Error()))
 	}
-	defer db.Close(context.Background()) // nolint:errcheck
+	defer db.Close()
 
 	// Test database connection
 	if err := db.Ping(context.Background()); err != nil {
@@ -105,13 +108,57 @@ func main() {
 	// Repositories
 	userManagementRepository := repositories.NewSQLUserManagementRepository(db, redisClient)
 	mediaBrowsingRepository := repositories.NewSQLMediaBrowsingRepository(db)
+	mediaScanningRepository := repositories.NewSQLMediaScanningRepository(db)
+	playlistRepository := repositories.NewSQLPlaylistRepository(db)
+	lyricsRepository := repositories.NewSQLLyricsRepository(db)
+	mediaAnnotationRepository := repositories.NewSQLMediaAnnotationRepository(db)
+	nowPlayingRepository := repositories.NewInMemoryNowPlayingRepository()
+
+	// Metadata extraction
+	var metadataExtractorName string
+	if config != nil {
+		metadataExtractorName = config.MetadataExtractor
+	}
+	metadataExtractor, err := metadata.NewMetadataExtractor(metadataExtractorName, jsonLogger)
+	if err != nil {
+		jsonLogger.Error("Invalid metadata extractor, falling back to ffprobe", slog.String("error", err.Error()))
+		metadataExtractor = metadata.NewFFProbeMetadataExtractor(jsonLogger)
+	}
+	loudnessAnalyzer := metadata.NewFFmpegLoudnessAnalyzer(jsonLogger)
+	transcoder := transcoding.NewFFmpegTranscoder(jsonLogger)
 
 	// Services
 	userAuthenticationService := services.NewUserAuthenticationService(userManagementRepository, jsonLogger)
 	userManagementService := services.NewUserManagementService(userManagementRepository, jsonLogger)
-	mediaBrowsingService := services.NewMediaBrowsingService(mediaBrowsingRepository, jsonLogger)
-	mediaRetrievalService := services.NewMediaRetrievalService(mediaBrowsingRepository, jsonLogger)
-	mediaScanningService := services.NewMediaScanningService(mediaBrowsingRepository, config, jsonLogger)
+	mediaBrowsingService := services.NewMediaBrowsingService(mediaBrowsingRepository, mediaAnnotationRepository, config, jsonLogger)
+	mediaRetrievalService := services.NewMediaRetrievalService(mediaBrowsingRepository, nowPlayingRepository, transcoder, jsonLogger)
+	lyricsService := services.NewLyricsService(mediaBrowsingRepository, lyricsRepository, jsonLogger)
+	mediaAnnotationService := services.NewMediaAnnotationService(mediaBrowsingRepository, mediaAnnotationRepository, nowPlayingRepository, jsonLogger)
+	playlistService := services.NewPlaylistService(playlistRepository, mediaBrowsingRepository, mediaAnnotationRepository, jsonLogger)
+	mediaScanningService := services.NewMediaScanningService(mediaBrowsingRepository, mediaScanningRepository, playlistRepository, lyricsRepository, metadataExtractor, loudnessAnalyzer, config, jsonLogger)
+
+	// Music folders are listed from the data store, which only scans write to otherwise
+	if err := mediaScanningService.SyncMusicFolders(context.Background()); err != nil {
+		jsonLogger.Error("Failed to sync music folders", slog.String("error", err.Error()))
+	}
+
+	// Index library changes as they happen and scan the library on schedule
+	backgroundCtx, stopBackgroundTasks := context.WithCancel(context.Background())
+	defer stopBackgroundTasks()
+	if config != nil && config.Watch {
+		go func() {
+			if err := mediaScanningService.Watch(backgroundCtx); err != nil {
+				jsonLogger.Error("Failed to watch music directories", slog.String("error", err.Error()))
+			}
+		}()
+	}
+	if config != nil && config.ScanSchedule != "" {
+		go func() {
+			if err := mediaScanningService.Schedule(backgroundCtx); err != nil {
+				jsonLogger.Error("Failed to schedule media scans", slog.String("error", err.Error()))
+			}
+		}()
+	}
 
 	// Middleware
 	userAuthenticationMiddleware := handlers.NewUserManagementMiddleware(userAuthenticationService, jsonLogger)
@@ -121,6 +168,9 @@ func main() {
 	mediaBrowsingHandler := handlers.NewMediaBrowsingHandler(mediaBrowsingService, jsonLogger)
 	mediaRetrievalHandler := handlers.NewMediaRetrievalHandler(mediaRetrievalService, jsonLogger)
 	mediaScanningHandler := handlers.NewMediaScanningHandler(mediaScanningService, jsonLogger)
+	lyricsHandler := handlers.NewLyricsHandler(lyricsService, jsonLogger)
+	mediaAnnotationHandler := handlers.NewMediaAnnotationHandler(mediaAnnotationService, jsonLogger)
+	playlistHandler := handlers.NewPlaylistHandler(playlistService, jsonLogger)
 	systemHandler := handlers.NewSystemHandler(jsonLogger)
 
 	app := handlers.
@@ -134,6 +184,9 @@ func main() {
 			mediaBrowsingHandler,
 			mediaRetrievalHandler,
 			mediaScanningHandler,
+			lyricsHandler,
+			mediaAnnotationHandler,
+			playlistHandler,
 			systemHandler,
 		).
 		RegisterHandlers()
@@ -0,0 +1,21 @@
+package ffmpeg
+
+import "strconv"
+
+// SliceArgs returns the ffmpeg input options restricting the input to the audio between start and end, in milliseconds.
+// A start or end of 0 leaves that side of the input unbounded.
+func SliceArgs(start int, end int) []string {
+	var args []string
+	if start > 0 {
+		args = append(args, "-ss", formatMilliseconds(start))
+	}
+	if end > 0 {
+		args = append(args, "-to", formatMilliseconds(end))
+	}
+	return args
+}
+
+// formatMilliseconds formats a position in milliseconds as the seconds expected by ffmpeg time options.
+func formatMilliseconds(ms int) string {
+	return strconv.FormatFloat(float64(ms)/1000, 'f', 3, 64)
+}
@@ -2,9 +2,16 @@ package handlers
 
 import (
 	"encoding/xml"
+	"fmt"
 	"music-streaming/internal/core/domain"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// directoryIDPrefix sets the IDs of directories apart from those of songs, both being children of a directory
+const directoryIDPrefix = "dir-"
+
 // UserDTO represents the HTTP layer representation of a User
 type UserDTO struct {
 	XMLName             xml.Name `xml:"user" json:"-"`
@@ -31,48 +38,300 @@ type UserDTO struct {
 
 // ArtistDTO represents the HTTP layer representation of an Artist
 type ArtistDTO struct {
-	Id         int    `json:"id" xml:"id,attr"`
-	Name       string `json:"name" xml:"name,attr"`
-	CoverArt   string `json:"coverArt" xml:"coverArt,attr"`
-	AlbumCount int    `json:"albumCount" xml:"albumCount,attr"`
+	Id            int        `json:"id" xml:"id,attr"`
+	Name          string     `json:"name" xml:"name,attr"`
+	CoverArt      string     `json:"coverArt" xml:"coverArt,attr"`
+	AlbumCount    int        `json:"albumCount" xml:"albumCount,attr"`
+	SortName      string     `json:"sortName,omitempty" xml:"sortName,attr,omitempty"`
+	MusicBrainzId string     `json:"musicBrainzId,omitempty" xml:"musicBrainzId,attr,omitempty"`
+	Starred       string     `json:"starred,omitempty" xml:"starred,attr,omitempty"`
+	UserRating    int        `json:"userRating,omitempty" xml:"userRating,attr,omitempty"`
+	Albums        []AlbumDTO `json:"album,omitempty" xml:"album,omitempty"`
+}
+
+// ArtistsDTO represents the HTTP layer representation of ArtistIndexes
+type ArtistsDTO struct {
+	IgnoredArticles string           `json:"ignoredArticles" xml:"ignoredArticles,attr"`
+	Index           []ArtistIndexDTO `json:"index,omitempty" xml:"index,omitempty"`
+}
+
+// ArtistIndexDTO represents the HTTP layer representation of an ArtistIndex
+type ArtistIndexDTO struct {
+	Name    string      `json:"name" xml:"name,attr"`
+	Artists []ArtistDTO `json:"artist" xml:"artist"`
 }
 
 // AlbumDTO represents the HTTP layer representation of an Album
 type AlbumDTO struct {
-	Id        int    `json:"id" xml:"id,attr"`
-	ArtistId  int    `json:"artistId" xml:"artistId,attr"`
-	Name      string `json:"name" xml:"name,attr"`
-	CoverArt  string `json:"coverArt" xml:"coverArt,attr"`
-	SongCount int    `json:"songCount" xml:"songCount,attr"`
-	Created   string `json:"created" xml:"created,attr"`
-	Duration  int    `json:"duration" xml:"duration,attr"`
-	Artist    string `json:"artist" xml:"artist,attr"`
+	Id            int       `json:"id" xml:"id,attr"`
+	ArtistId      int       `json:"artistId" xml:"artistId,attr"`
+	Name          string    `json:"name" xml:"name,attr"`
+	CoverArt      string    `json:"coverArt" xml:"coverArt,attr"`
+	SongCount     int       `json:"songCount" xml:"songCount,attr"`
+	Created       string    `json:"created" xml:"created,attr"`
+	Duration      int       `json:"duration" xml:"duration,attr"`
+	Artist        string    `json:"artist" xml:"artist,attr"`
+	Year          int       `json:"year,omitempty" xml:"year,attr,omitempty"`
+	IsCompilation bool      `json:"isCompilation" xml:"isCompilation,attr"`
+	SortName      string    `json:"sortName,omitempty" xml:"sortName,attr,omitempty"`
+	MusicBrainzId string    `json:"musicBrainzId,omitempty" xml:"musicBrainzId,attr,omitempty"`
+	Starred       string    `json:"starred,omitempty" xml:"starred,attr,omitempty"`
+	UserRating    int       `json:"userRating,omitempty" xml:"userRating,attr,omitempty"`
+	PlayCount     int       `json:"playCount,omitempty" xml:"playCount,attr,omitempty"`
+	Played        string    `json:"played,omitempty" xml:"played,attr,omitempty"`
+	Songs         []SongDTO `json:"song,omitempty" xml:"song,omitempty"`
 }
 
 // SongDTO represents the HTTP layer representation of a Song
 type SongDTO struct {
-	Id          int    `json:"id" xml:"id,attr"`
-	AlbumId     int    `json:"albumId" xml:"albumId,attr"`
-	Title       string `json:"title" xml:"title,attr"`
-	Album       string `json:"album" xml:"album,attr"`
-	Artist      string `json:"artist" xml:"artist,attr"`
-	IsDir       bool   `json:"isDir" xml:"isDir,attr"`
-	CoverArt    string `json:"coverArt" xml:"coverArt,attr"`
-	Created     string `json:"created" xml:"created,attr"`
-	Duration    int    `json:"duration" xml:"duration,attr"`
-	BitRate     int    `json:"bitRate" xml:"bitRate,attr"`
-	Size        int64  `json:"size" xml:"size,attr"`
-	Suffix      string `json:"suffix" xml:"suffix,attr"`
-	ContentType string `json:"contentType" xml:"contentType,attr"`
-	IsVideo     bool   `json:"isVideo" xml:"isVideo,attr"`
-	Path        string `json:"path" xml:"path,attr"`
+	Id            string         `json:"id" xml:"id,attr"`
+	Parent        string         `json:"parent,omitempty" xml:"parent,attr,omitempty"`
+	AlbumId       int            `json:"albumId" xml:"albumId,attr"`
+	Title         string         `json:"title" xml:"title,attr"`
+	Album         string         `json:"album" xml:"album,attr"`
+	Artist        string         `json:"artist" xml:"artist,attr"`
+	IsDir         bool           `json:"isDir" xml:"isDir,attr"`
+	CoverArt      string         `json:"coverArt" xml:"coverArt,attr"`
+	Created       string         `json:"created" xml:"created,attr"`
+	Duration      int            `json:"duration" xml:"duration,attr"`
+	BitRate       int            `json:"bitRate" xml:"bitRate,attr"`
+	Size          int64          `json:"size" xml:"size,attr"`
+	Suffix        string         `json:"suffix" xml:"suffix,attr"`
+	ContentType   string         `json:"contentType" xml:"contentType,attr"`
+	IsVideo       bool           `json:"isVideo" xml:"isVideo,attr"`
+	Path          string         `json:"path" xml:"path,attr"`
+	Track         int            `json:"track,omitempty" xml:"track,attr,omitempty"`
+	DiscNumber    int            `json:"discNumber,omitempty" xml:"discNumber,attr,omitempty"`
+	Year          int            `json:"year,omitempty" xml:"year,attr,omitempty"`
+	SortName      string         `json:"sortName,omitempty" xml:"sortName,attr,omitempty"`
+	MusicBrainzId string         `json:"musicBrainzId,omitempty" xml:"musicBrainzId,attr,omitempty"`
+	Genre         string         `json:"genre,omitempty" xml:"genre,attr,omitempty"`
+	Starred       string         `json:"starred,omitempty" xml:"starred,attr,omitempty"`
+	UserRating    int            `json:"userRating,omitempty" xml:"userRating,attr,omitempty"`
+	PlayCount     int            `json:"playCount,omitempty" xml:"playCount,attr,omitempty"`
+	Played        string         `json:"played,omitempty" xml:"played,attr,omitempty"`
+	Genres        []ItemGenreDTO `json:"genres,omitempty" xml:"genres,omitempty"`
+	ReplayGain    *ReplayGainDTO `json:"replayGain,omitempty" xml:"replayGain,omitempty"`
+}
+
+// ItemGenreDTO represents the HTTP layer representation of a genre of a song, as defined by OpenSubsonic
+type ItemGenreDTO struct {
+	Name string `json:"name" xml:"name,attr"`
+}
+
+// ReplayGainDTO represents the HTTP layer representation of ReplayGain, as defined by OpenSubsonic.
+// Values that are not known are omitted.
+type ReplayGainDTO struct {
+	TrackGain *float64 `json:"trackGain,omitempty" xml:"trackGain,attr,omitempty"`
+	AlbumGain *float64 `json:"albumGain,omitempty" xml:"albumGain,attr,omitempty"`
+	TrackPeak *float64 `json:"trackPeak,omitempty" xml:"trackPeak,attr,omitempty"`
+	AlbumPeak *float64 `json:"albumPeak,omitempty" xml:"albumPeak,attr,omitempty"`
 }
 
 // ScanStatusDTO represents the HTTP layer representation of ScanStatus
 type ScanStatusDTO struct {
-	XMLName  xml.Name `xml:"scanStatus" json:"-"`
-	Scanning bool     `xml:"scanning,attr" json:"scanning"`
-	Count    int      `xml:"count,attr" json:"count"`
+	XMLName           xml.Name `xml:"scanStatus" json:"-"`
+	Scanning          bool     `xml:"scanning,attr" json:"scanning"`
+	Count             int      `xml:"count,attr" json:"count"`
+	NextScheduledScan string   `xml:"nextScheduledScan,attr,omitempty" json:"nextScheduledScan,omitempty"`
+}
+
+// ScanProgressDTO represents the HTTP layer representation of ScanProgress, sent as server-sent events
+type ScanProgressDTO struct {
+	XMLName   xml.Name `xml:"scanProgress" json:"-"`
+	Scanning  bool     `xml:"scanning,attr" json:"scanning"`
+	Phase     string   `xml:"phase,attr" json:"phase"`
+	Trigger   string   `xml:"trigger,attr,omitempty" json:"trigger,omitempty"`
+	Started   string   `xml:"started,attr,omitempty" json:"started,omitempty"`
+	Directory string   `xml:"directory,attr,omitempty" json:"directory,omitempty"`
+	Seen      int      `xml:"seen,attr" json:"seen"`
+	Queued    int      `xml:"queued,attr" json:"queued"`
+	Indexed   int      `xml:"indexed,attr" json:"indexed"`
+	Failed    int      `xml:"failed,attr" json:"failed"`
+	ETA       *int     `xml:"eta,attr,omitempty" json:"eta,omitempty"`
+}
+
+// ScanRunDTO represents the HTTP layer representation of a ScanRun
+type ScanRunDTO struct {
+	XMLName   xml.Name       `xml:"scanRun" json:"-"`
+	Id        int            `xml:"id,attr" json:"id"`
+	Trigger   string         `xml:"trigger,attr" json:"trigger"`
+	Partial   bool           `xml:"partial,attr" json:"partial"`
+	Cancelled bool           `xml:"cancelled,attr" json:"cancelled"`
+	Started   string         `xml:"started,attr" json:"started"`
+	Finished  string         `xml:"finished,attr,omitempty" json:"finished,omitempty"`
+	Added     int            `xml:"added,attr" json:"added"`
+	Updated   int            `xml:"updated,attr" json:"updated"`
+	Removed   int            `xml:"removed,attr" json:"removed"`
+	Failed    int            `xml:"failed,attr" json:"failed"`
+	Errors    []ScanErrorDTO `xml:"error,omitempty" json:"error,omitempty"`
+}
+
+// ScanErrorDTO represents the HTTP layer representation of a ScanError
+type ScanErrorDTO struct {
+	Path    string `xml:"path,attr" json:"path"`
+	Message string `xml:"message,attr" json:"message"`
+}
+
+// ScanRunsDTO represents the HTTP layer representation of a list of ScanRun
+type ScanRunsDTO struct {
+	ScanRuns []ScanRunDTO `xml:"scanRun" json:"scanRun"`
+}
+
+// MusicFoldersDTO represents the HTTP layer representation of the music folders of getMusicFolders
+type MusicFoldersDTO struct {
+	MusicFolders []MusicFolderDTO `xml:"musicFolder" json:"musicFolder"`
+}
+
+// MusicFolderDTO represents the HTTP layer representation of a MusicFolder
+type MusicFolderDTO struct {
+	Id   int    `xml:"id,attr" json:"id"`
+	Name string `xml:"name,attr,omitempty" json:"name,omitempty"`
+}
+
+// IndexesDTO represents the HTTP layer representation of Indexes
+type IndexesDTO struct {
+	LastModified    int64      `xml:"lastModified,attr" json:"lastModified"`
+	IgnoredArticles string     `xml:"ignoredArticles,attr" json:"ignoredArticles"`
+	Index           []IndexDTO `xml:"index,omitempty" json:"index,omitempty"`
+	Songs           []SongDTO  `xml:"child,omitempty" json:"child,omitempty"`
+}
+
+// IndexDTO represents the HTTP layer representation of an Index, whose directories Subsonic calls artists
+type IndexDTO struct {
+	Name    string           `xml:"name,attr" json:"name"`
+	Artists []IndexArtistDTO `xml:"artist" json:"artist"`
+}
+
+// IndexArtistDTO represents the HTTP layer representation of a Directory listed in an index
+type IndexArtistDTO struct {
+	Id         string `xml:"id,attr" json:"id"`
+	Name       string `xml:"name,attr" json:"name"`
+	Starred    string `xml:"starred,attr,omitempty" json:"starred,omitempty"`
+	UserRating int    `xml:"userRating,attr,omitempty" json:"userRating,omitempty"`
+}
+
+// DirectoryDTO represents the HTTP layer representation of a Directory with its children
+type DirectoryDTO struct {
+	Id       string    `xml:"id,attr" json:"id"`
+	Parent   string    `xml:"parent,attr,omitempty" json:"parent,omitempty"`
+	Name     string    `xml:"name,attr" json:"name"`
+	Children []SongDTO `xml:"child" json:"child"`
+}
+
+// GenresDTO represents the HTTP layer representation of the genres of getGenres
+type GenresDTO struct {
+	Genres []GenreDTO `xml:"genre" json:"genre"`
+}
+
+// GenreDTO represents the HTTP layer representation of a Genre, named by its element text
+type GenreDTO struct {
+	Value      string `xml:",chardata" json:"value"`
+	SongCount  int    `xml:"songCount,attr" json:"songCount"`
+	AlbumCount int    `xml:"albumCount,attr" json:"albumCount"`
+}
+
+// SongsByGenreDTO represents the HTTP layer representation of the songs of getSongsByGenre
+type SongsByGenreDTO struct {
+	Songs []SongDTO `xml:"song" json:"song"`
+}
+
+// AlbumListDTO represents the HTTP layer representation of the albums of getAlbumList, as directories
+type AlbumListDTO struct {
+	Albums []SongDTO `xml:"album" json:"album"`
+}
+
+// AlbumList2DTO represents the HTTP layer representation of the albums of getAlbumList2
+type AlbumList2DTO struct {
+	Albums []AlbumDTO `xml:"album" json:"album"`
+}
+
+// SearchResult2DTO represents the HTTP layer representation of the matches of search2, artists and albums as directories
+type SearchResult2DTO struct {
+	Artists []IndexArtistDTO `xml:"artist" json:"artist"`
+	Albums  []SongDTO        `xml:"album" json:"album"`
+	Songs   []SongDTO        `xml:"song" json:"song"`
+}
+
+// SearchResult3DTO represents the HTTP layer representation of the matches of search3
+type SearchResult3DTO struct {
+	Artists []ArtistDTO `xml:"artist" json:"artist"`
+	Albums  []AlbumDTO  `xml:"album" json:"album"`
+	Songs   []SongDTO   `xml:"song" json:"song"`
+}
+
+// StarredDTO represents the HTTP layer representation of the starred items of getStarred, artists and albums as directories
+type StarredDTO struct {
+	Artists []IndexArtistDTO `xml:"artist" json:"artist"`
+	Albums  []SongDTO        `xml:"album" json:"album"`
+	Songs   []SongDTO        `xml:"song" json:"song"`
+}
+
+// Starred2DTO represents the HTTP layer representation of the starred items of getStarred2
+type Starred2DTO struct {
+	Artists []ArtistDTO `xml:"artist" json:"artist"`
+	Albums  []AlbumDTO  `xml:"album" json:"album"`
+	Songs   []SongDTO   `xml:"song" json:"song"`
+}
+
+// NowPlayingDTO represents the HTTP layer representation of the songs playing now of getNowPlaying
+type NowPlayingDTO struct {
+	Entries []NowPlayingEntryDTO `xml:"entry" json:"entry"`
+}
+
+// NowPlayingEntryDTO represents the HTTP layer representation of NowPlaying, a song with who plays it
+type NowPlayingEntryDTO struct {
+	SongDTO
+	Username   string `json:"username" xml:"username,attr"`
+	MinutesAgo int    `json:"minutesAgo" xml:"minutesAgo,attr"`
+	PlayerName string `json:"playerName,omitempty" xml:"playerName,attr,omitempty"`
+}
+
+// PlaylistDTO represents the HTTP layer representation of a Playlist, with its songs as entries for getPlaylist
+type PlaylistDTO struct {
+	Id        int       `json:"id" xml:"id,attr"`
+	Name      string    `json:"name" xml:"name,attr"`
+	Comment   string    `json:"comment,omitempty" xml:"comment,attr,omitempty"`
+	Owner     string    `json:"owner" xml:"owner,attr"`
+	Public    bool      `json:"public" xml:"public,attr"`
+	SongCount int       `json:"songCount" xml:"songCount,attr"`
+	Duration  int       `json:"duration" xml:"duration,attr"`
+	Created   string    `json:"created" xml:"created,attr"`
+	Changed   string    `json:"changed" xml:"changed,attr"`
+	Entries   []SongDTO `json:"entry,omitempty" xml:"entry,omitempty"`
+}
+
+// PlaylistsDTO represents the HTTP layer representation of the playlists of getPlaylists
+type PlaylistsDTO struct {
+	Playlists []PlaylistDTO `json:"playlist" xml:"playlist"`
+}
+
+// LyricsDTO represents the HTTP layer representation of the plain text lyrics of getLyrics
+type LyricsDTO struct {
+	Artist string `xml:"artist,attr,omitempty" json:"artist,omitempty"`
+	Title  string `xml:"title,attr,omitempty" json:"title,omitempty"`
+	Value  string `xml:",chardata" json:"value"`
+}
+
+// LyricsListDTO represents the HTTP layer representation of the lyrics of a song
+type LyricsListDTO struct {
+	StructuredLyrics []StructuredLyricsDTO `xml:"structuredLyrics" json:"structuredLyrics"`
+}
+
+// StructuredLyricsDTO represents the HTTP layer representation of Lyrics
+type StructuredLyricsDTO struct {
+	DisplayArtist string          `xml:"displayArtist,attr,omitempty" json:"displayArtist,omitempty"`
+	DisplayTitle  string          `xml:"displayTitle,attr,omitempty" json:"displayTitle,omitempty"`
+	Lang          string          `xml:"lang,attr" json:"lang"`
+	Offset        int             `xml:"offset,attr,omitempty" json:"offset,omitempty"`
+	Synced        bool            `xml:"synced,attr" json:"synced"`
+	Lines         []LyricsLineDTO `xml:"line" json:"line"`
+}
+
+// LyricsLineDTO represents the HTTP layer representation of a LyricsLine, Start being left out for unsynced lyrics
+type LyricsLineDTO struct {
+	Start *int   `xml:"start,attr,omitempty" json:"start,omitempty"`
+	Value string `xml:",chardata" json:"value"`
 }
 
 // Mapper functions from Domain to DTO
@@ -113,55 +372,471 @@ func UsersToDTO(users []domain.User) []UserDTO {
 
 // ArtistToDTO converts a domain Artist to an ArtistDTO
 func ArtistToDTO(artist domain.Artist) ArtistDTO {
-	return ArtistDTO{
-		Id:         artist.Id,
-		Name:       artist.Name,
-		CoverArt:   artist.CoverArt,
-		AlbumCount: artist.AlbumCount,
+	dto := ArtistDTO{
+		Id:            artist.Id,
+		Name:          artist.Name,
+		CoverArt:      artist.CoverArt,
+		AlbumCount:    artist.AlbumCount,
+		SortName:      artist.SortName,
+		MusicBrainzId: artist.MusicBrainzId,
+		Starred:       artist.Starred,
+		UserRating:    artist.UserRating,
+	}
+	for _, album := range artist.Albums {
+		dto.Albums = append(dto.Albums, AlbumToDTO(album))
+	}
+	return dto
+}
+
+// ArtistIndexesToDTO converts domain ArtistIndexes to an ArtistsDTO
+func ArtistIndexesToDTO(indexes domain.ArtistIndexes) ArtistsDTO {
+	dto := ArtistsDTO{IgnoredArticles: strings.Join(indexes.IgnoredArticles, " ")}
+	for _, index := range indexes.Index {
+		indexDTO := ArtistIndexDTO{Name: index.Name, Artists: make([]ArtistDTO, 0, len(index.Artists))}
+		for _, artist := range index.Artists {
+			indexDTO.Artists = append(indexDTO.Artists, ArtistToDTO(artist))
+		}
+		dto.Index = append(dto.Index, indexDTO)
 	}
+	return dto
 }
 
 // AlbumToDTO converts a domain Album to an AlbumDTO
 func AlbumToDTO(album domain.Album) AlbumDTO {
-	return AlbumDTO{
-		Id:        album.Id,
-		ArtistId:  album.ArtistId,
-		Name:      album.Name,
-		CoverArt:  album.CoverArt,
-		SongCount: album.SongCount,
-		Created:   album.Created,
-		Duration:  album.Duration,
-		Artist:    album.Artist,
+	dto := AlbumDTO{
+		Id:            album.Id,
+		ArtistId:      album.ArtistId,
+		Name:          album.Name,
+		CoverArt:      album.CoverArt,
+		SongCount:     album.SongCount,
+		Created:       album.Created,
+		Duration:      album.Duration,
+		Artist:        album.Artist,
+		Year:          album.Year,
+		IsCompilation: album.Compilation,
+		SortName:      album.SortName,
+		MusicBrainzId: album.MusicBrainzId,
+		Starred:       album.Starred,
+		UserRating:    album.UserRating,
+		PlayCount:     album.PlayCount,
+		Played:        album.Played,
+	}
+	for _, song := range album.Songs {
+		dto.Songs = append(dto.Songs, SongToDTO(song))
 	}
+	return dto
 }
 
 // SongToDTO converts a domain Song to a SongDTO
 func SongToDTO(song domain.Song) SongDTO {
-	return SongDTO{
-		Id:          song.Id,
-		AlbumId:     song.AlbumId,
-		Title:       song.Title,
-		Album:       song.Album,
-		Artist:      song.Artist,
-		IsDir:       song.IsDir,
-		CoverArt:    song.CoverArt,
-		Created:     song.Created,
-		Duration:    song.Duration,
-		BitRate:     song.BitRate,
-		Size:        song.Size,
-		Suffix:      song.Suffix,
-		ContentType: song.ContentType,
-		IsVideo:     song.IsVideo,
-		Path:        song.Path,
+	dto := SongDTO{
+		Parent:        directoryID(song.DirectoryId),
+		AlbumId:       song.AlbumId,
+		Title:         song.Title,
+		Album:         song.Album,
+		Artist:        song.Artist,
+		IsDir:         song.IsDir,
+		CoverArt:      song.CoverArt,
+		Created:       song.Created,
+		Duration:      song.Duration,
+		BitRate:       song.BitRate,
+		Size:          song.Size,
+		Suffix:        song.Suffix,
+		ContentType:   song.ContentType,
+		IsVideo:       song.IsVideo,
+		Path:          song.Path,
+		Track:         song.Track,
+		DiscNumber:    song.DiscNumber,
+		Year:          song.Year,
+		SortName:      song.SortName,
+		MusicBrainzId: song.MusicBrainzId,
+		Starred:       song.Starred,
+		UserRating:    song.UserRating,
+		PlayCount:     song.PlayCount,
+		Played:        song.Played,
+	}
+	// Subdirectories are listed among the songs of a directory
+	if song.IsDir {
+		dto.Id = directoryID(song.Id)
+	} else {
+		dto.Id = strconv.Itoa(song.Id)
+	}
+	// Subsonic clients only know a single genre, OpenSubsonic clients get them all
+	if len(song.Genres) > 0 {
+		dto.Genre = song.Genres[0]
+	}
+	for _, genre := range song.Genres {
+		dto.Genres = append(dto.Genres, ItemGenreDTO{Name: genre})
+	}
+	if song.ReplayGain.HasTrack() || song.ReplayGain.HasAlbum() {
+		dto.ReplayGain = ReplayGainToDTO(song.ReplayGain)
 	}
+	return dto
+}
+
+// ReplayGainToDTO converts a domain ReplayGain to a ReplayGainDTO, leaving out unknown values
+func ReplayGainToDTO(replayGain domain.ReplayGain) *ReplayGainDTO {
+	dto := &ReplayGainDTO{}
+	if replayGain.HasTrack() {
+		dto.TrackGain = &replayGain.TrackGain
+		dto.TrackPeak = &replayGain.TrackPeak
+	}
+	if replayGain.HasAlbum() {
+		dto.AlbumGain = &replayGain.AlbumGain
+		dto.AlbumPeak = &replayGain.AlbumPeak
+	}
+	return dto
 }
 
 // ScanStatusToDTO converts a domain ScanStatus to a ScanStatusDTO
 func ScanStatusToDTO(status domain.ScanStatus) ScanStatusDTO {
 	return ScanStatusDTO{
-		Scanning: status.Scanning,
-		Count:    status.Count,
+		Scanning:          status.Scanning,
+		Count:             status.Count,
+		NextScheduledScan: status.NextScheduledScan,
+	}
+}
+
+// ScanProgressToDTO converts a domain ScanProgress to a ScanProgressDTO, leaving out an unknown ETA
+func ScanProgressToDTO(progress domain.ScanProgress) ScanProgressDTO {
+	dto := ScanProgressDTO{
+		Scanning:  progress.Scanning,
+		Phase:     string(progress.Phase),
+		Trigger:   string(progress.Trigger),
+		Started:   progress.Started,
+		Directory: progress.Directory,
+		Seen:      progress.Seen,
+		Queued:    progress.Queued,
+		Indexed:   progress.Indexed,
+		Failed:    progress.Failed,
+	}
+	if progress.ETA >= 0 {
+		dto.ETA = &progress.ETA
+	}
+	return dto
+}
+
+// ScanRunToDTO converts a domain ScanRun to a ScanRunDTO, errors included
+func ScanRunToDTO(run domain.ScanRun) ScanRunDTO {
+	dto := ScanRunDTO{
+		Id:        run.Id,
+		Trigger:   string(run.Trigger),
+		Partial:   run.Partial,
+		Cancelled: run.Cancelled,
+		Started:   run.Started,
+		Finished:  run.Finished,
+		Added:     run.Added,
+		Updated:   run.Updated,
+		Removed:   run.Removed,
+		Failed:    run.Failed,
+	}
+	for _, scanError := range run.Errors {
+		dto.Errors = append(dto.Errors, ScanErrorDTO{
+			Path:    scanError.Path,
+			Message: scanError.Message,
+		})
+	}
+	return dto
+}
+
+// ScanRunsToDTO converts a list of domain ScanRun to a ScanRunsDTO
+func ScanRunsToDTO(runs []domain.ScanRun) ScanRunsDTO {
+	dto := ScanRunsDTO{ScanRuns: make([]ScanRunDTO, 0, len(runs))}
+	for _, run := range runs {
+		dto.ScanRuns = append(dto.ScanRuns, ScanRunToDTO(run))
+	}
+	return dto
+}
+
+// MusicFoldersToDTO converts domain MusicFolders to a MusicFoldersDTO, without their paths
+func MusicFoldersToDTO(folders []domain.MusicFolder) MusicFoldersDTO {
+	dto := MusicFoldersDTO{MusicFolders: make([]MusicFolderDTO, 0, len(folders))}
+	for _, folder := range folders {
+		dto.MusicFolders = append(dto.MusicFolders, MusicFolderDTO{
+			Id:   folder.Id,
+			Name: folder.Name,
+		})
+	}
+	return dto
+}
+
+// IndexesToDTO converts domain Indexes to an IndexesDTO
+func IndexesToDTO(indexes domain.Indexes) IndexesDTO {
+	dto := IndexesDTO{
+		LastModified:    indexes.LastModified,
+		IgnoredArticles: strings.Join(indexes.IgnoredArticles, " "),
+	}
+	for _, index := range indexes.Index {
+		indexDTO := IndexDTO{Name: index.Name, Artists: make([]IndexArtistDTO, 0, len(index.Directories))}
+		for _, directory := range index.Directories {
+			indexDTO.Artists = append(indexDTO.Artists, IndexArtistDTO{
+				Id:   directoryID(directory.Id),
+				Name: directory.Name,
+			})
+		}
+		dto.Index = append(dto.Index, indexDTO)
+	}
+	for _, song := range indexes.Songs {
+		dto.Songs = append(dto.Songs, SongToDTO(song))
+	}
+	return dto
+}
+
+// DirectoryToDTO converts a domain Directory to a DirectoryDTO with its children
+func DirectoryToDTO(directory domain.Directory) DirectoryDTO {
+	dto := DirectoryDTO{
+		Id:       directoryID(directory.Id),
+		Parent:   directoryID(directory.ParentId),
+		Name:     directory.Name,
+		Children: make([]SongDTO, 0, len(directory.Children)),
+	}
+	for _, child := range directory.Children {
+		dto.Children = append(dto.Children, SongToDTO(child))
+	}
+	return dto
+}
+
+// directoryID formats the ID of a directory for clients, 0 standing for no directory
+func directoryID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return directoryIDPrefix + strconv.Itoa(id)
+}
+
+// parseDirectoryID parses the ID of a directory formatted by directoryID
+func parseDirectoryID(value string) (int, error) {
+	id, ok := strings.CutPrefix(value, directoryIDPrefix)
+	if !ok {
+		return 0, fmt.Errorf("not a directory ID: %q", value)
+	}
+	return strconv.Atoi(id)
+}
+
+// GenresToDTO converts domain Genres to a GenresDTO
+func GenresToDTO(genres []domain.Genre) GenresDTO {
+	dto := GenresDTO{Genres: make([]GenreDTO, 0, len(genres))}
+	for _, genre := range genres {
+		dto.Genres = append(dto.Genres, GenreDTO{
+			Value:      genre.Name,
+			SongCount:  genre.SongCount,
+			AlbumCount: genre.AlbumCount,
+		})
+	}
+	return dto
+}
+
+// SongsByGenreToDTO converts the domain Songs of a genre to a SongsByGenreDTO
+func SongsByGenreToDTO(songs []domain.Song) SongsByGenreDTO {
+	dto := SongsByGenreDTO{Songs: make([]SongDTO, 0, len(songs))}
+	for _, song := range songs {
+		dto.Songs = append(dto.Songs, SongToDTO(song))
+	}
+	return dto
+}
+
+// AlbumListToDTO converts domain Albums to an AlbumListDTO, each album being the directory holding its songs
+func AlbumListToDTO(albums []domain.Album) AlbumListDTO {
+	dto := AlbumListDTO{Albums: make([]SongDTO, 0, len(albums))}
+	for _, album := range albums {
+		dto.Albums = append(dto.Albums, albumDirectoryToDTO(album))
+	}
+	return dto
+}
+
+// artistDirectoryToDTO converts a domain Artist to the IndexArtistDTO of the directory holding its album directories
+func artistDirectoryToDTO(artist domain.Artist) IndexArtistDTO {
+	return IndexArtistDTO{
+		Id:         directoryID(artist.DirectoryId),
+		Name:       artist.Name,
+		Starred:    artist.Starred,
+		UserRating: artist.UserRating,
+	}
+}
+
+// albumDirectoryToDTO converts a domain Album to the SongDTO of the directory holding its songs
+func albumDirectoryToDTO(album domain.Album) SongDTO {
+	return SongDTO{
+		Id:         directoryID(album.DirectoryId),
+		AlbumId:    album.Id,
+		Title:      album.Name,
+		Album:      album.Name,
+		Artist:     album.Artist,
+		IsDir:      true,
+		CoverArt:   album.CoverArt,
+		Created:    album.Created,
+		Duration:   album.Duration,
+		Year:       album.Year,
+		SortName:   album.SortName,
+		Starred:    album.Starred,
+		UserRating: album.UserRating,
+		PlayCount:  album.PlayCount,
+		Played:     album.Played,
+	}
+}
+
+// AlbumList2ToDTO converts domain Albums to an AlbumList2DTO
+func AlbumList2ToDTO(albums []domain.Album) AlbumList2DTO {
+	dto := AlbumList2DTO{Albums: make([]AlbumDTO, 0, len(albums))}
+	for _, album := range albums {
+		dto.Albums = append(dto.Albums, AlbumToDTO(album))
+	}
+	return dto
+}
+
+// SearchResult2ToDTO converts a domain SearchResult to a SearchResult2DTO, artists and albums being their directories
+func SearchResult2ToDTO(result domain.SearchResult) SearchResult2DTO {
+	dto := SearchResult2DTO{
+		Artists: make([]IndexArtistDTO, 0, len(result.Artists)),
+		Albums:  make([]SongDTO, 0, len(result.Albums)),
+		Songs:   make([]SongDTO, 0, len(result.Songs)),
+	}
+	for _, artist := range result.Artists {
+		dto.Artists = append(dto.Artists, artistDirectoryToDTO(artist))
+	}
+	for _, album := range result.Albums {
+		dto.Albums = append(dto.Albums, albumDirectoryToDTO(album))
+	}
+	for _, song := range result.Songs {
+		dto.Songs = append(dto.Songs, SongToDTO(song))
+	}
+	return dto
+}
+
+// SearchResult3ToDTO converts a domain SearchResult to a SearchResult3DTO
+func SearchResult3ToDTO(result domain.SearchResult) SearchResult3DTO {
+	dto := SearchResult3DTO{
+		Artists: make([]ArtistDTO, 0, len(result.Artists)),
+		Albums:  make([]AlbumDTO, 0, len(result.Albums)),
+		Songs:   make([]SongDTO, 0, len(result.Songs)),
+	}
+	for _, artist := range result.Artists {
+		dto.Artists = append(dto.Artists, ArtistToDTO(artist))
+	}
+	for _, album := range result.Albums {
+		dto.Albums = append(dto.Albums, AlbumToDTO(album))
+	}
+	for _, song := range result.Songs {
+		dto.Songs = append(dto.Songs, SongToDTO(song))
+	}
+	return dto
+}
+
+// StarredToDTO converts domain Starred items to a StarredDTO, artists and albums being their directories
+func StarredToDTO(starred domain.Starred) StarredDTO {
+	dto := StarredDTO{
+		Artists: make([]IndexArtistDTO, 0, len(starred.Artists)),
+		Albums:  make([]SongDTO, 0, len(starred.Albums)),
+		Songs:   make([]SongDTO, 0, len(starred.Songs)),
+	}
+	for _, artist := range starred.Artists {
+		dto.Artists = append(dto.Artists, artistDirectoryToDTO(artist))
+	}
+	for _, album := range starred.Albums {
+		dto.Albums = append(dto.Albums, albumDirectoryToDTO(album))
+	}
+	for _, song := range starred.Songs {
+		dto.Songs = append(dto.Songs, SongToDTO(song))
+	}
+	return dto
+}
+
+// Starred2ToDTO converts domain Starred items to a Starred2DTO
+func Starred2ToDTO(starred domain.Starred) Starred2DTO {
+	dto := Starred2DTO{
+		Artists: make([]ArtistDTO, 0, len(starred.Artists)),
+		Albums:  make([]AlbumDTO, 0, len(starred.Albums)),
+		Songs:   make([]SongDTO, 0, len(starred.Songs)),
+	}
+	for _, artist := range starred.Artists {
+		dto.Artists = append(dto.Artists, ArtistToDTO(artist))
+	}
+	for _, album := range starred.Albums {
+		dto.Albums = append(dto.Albums, AlbumToDTO(album))
+	}
+	for _, song := range starred.Songs {
+		dto.Songs = append(dto.Songs, SongToDTO(song))
+	}
+	return dto
+}
+
+// NowPlayingToDTO converts domain NowPlaying entries to a NowPlayingDTO, counting minutes since each song started
+func NowPlayingToDTO(entries []domain.NowPlaying) NowPlayingDTO {
+	dto := NowPlayingDTO{Entries: make([]NowPlayingEntryDTO, 0, len(entries))}
+	for _, entry := range entries {
+		dto.Entries = append(dto.Entries, NowPlayingEntryDTO{
+			SongDTO:    SongToDTO(entry.Song),
+			Username:   entry.Username,
+			MinutesAgo: int(time.Since(entry.Started).Minutes()),
+			PlayerName: entry.PlayerName,
+		})
+	}
+	return dto
+}
+
+// PlaylistToDTO converts a domain Playlist to a PlaylistDTO, its songs becoming entries
+func PlaylistToDTO(playlist domain.Playlist) PlaylistDTO {
+	dto := PlaylistDTO{
+		Id:        playlist.Id,
+		Name:      playlist.Name,
+		Comment:   playlist.Comment,
+		Owner:     playlist.Owner,
+		Public:    playlist.Public,
+		SongCount: playlist.SongCount,
+		Duration:  playlist.Duration,
+		Created:   playlist.Created,
+		Changed:   playlist.Changed,
+	}
+	for _, song := range playlist.Songs {
+		dto.Entries = append(dto.Entries, SongToDTO(song))
+	}
+	return dto
+}
+
+// PlaylistsToDTO converts domain Playlists to a PlaylistsDTO
+func PlaylistsToDTO(playlists []domain.Playlist) PlaylistsDTO {
+	dto := PlaylistsDTO{Playlists: make([]PlaylistDTO, 0, len(playlists))}
+	for _, playlist := range playlists {
+		dto.Playlists = append(dto.Playlists, PlaylistToDTO(playlist))
+	}
+	return dto
+}
+
+// LyricsToDTO converts domain Lyrics to a LyricsDTO, joining their lines
+func LyricsToDTO(lyrics domain.Lyrics) LyricsDTO {
+	lines := make([]string, 0, len(lyrics.Lines))
+	for _, line := range lyrics.Lines {
+		lines = append(lines, line.Value)
+	}
+	return LyricsDTO{
+		Artist: lyrics.DisplayArtist,
+		Title:  lyrics.DisplayTitle,
+		Value:  strings.Join(lines, "\n"),
+	}
+}
+
+// LyricsListToDTO converts the domain Lyrics of a song to a LyricsListDTO
+func LyricsListToDTO(lyrics []domain.Lyrics) LyricsListDTO {
+	dto := LyricsListDTO{StructuredLyrics: make([]StructuredLyricsDTO, 0, len(lyrics))}
+	for _, l := range lyrics {
+		structured := StructuredLyricsDTO{
+			DisplayArtist: l.DisplayArtist,
+			DisplayTitle:  l.DisplayTitle,
+			Lang:          l.Lang,
+			Offset:        l.Offset,
+			Synced:        l.Synced,
+			Lines:         make([]LyricsLineDTO, 0, len(l.Lines)),
+		}
+		for _, line := range l.Lines {
+			lineDTO := LyricsLineDTO{Value: line.Value}
+			if l.Synced {
+				lineDTO.Start = &line.Start
+			}
+			structured.Lines = append(structured.Lines, lineDTO)
+		}
+		dto.StructuredLyrics = append(dto.StructuredLyrics, structured)
 	}
+	return dto
 }
 
 // Mapper functions from DTO to Domain
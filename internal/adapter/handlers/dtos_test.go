@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"music-streaming/internal/core/domain"
+	"testing"
+)
+
+func TestDirectoryToDTO(t *testing.T) {
+	directory := domain.Directory{
+		Id:       7,
+		ParentId: 1,
+		Name:     "Radiohead",
+		Children: []domain.Song{
+			{Id: 7, Title: "OK Computer", IsDir: true, DirectoryId: 7},
+			{Id: 7, Title: "Airbag", DirectoryId: 7},
+		},
+	}
+
+	dto := DirectoryToDTO(directory)
+
+	if dto.Id != "dir-7" || dto.Parent != "dir-1" {
+		t.Errorf("expected directory dir-7 in dir-1, got %s in %s", dto.Id, dto.Parent)
+	}
+	if dto.Children[0].Id != "dir-7" || dto.Children[1].Id != "7" {
+		t.Errorf("expected children dir-7 and 7, got %s and %s", dto.Children[0].Id, dto.Children[1].Id)
+	}
+	if dto.Children[1].Parent != "dir-7" {
+		t.Errorf("expected song parent dir-7, got %s", dto.Children[1].Parent)
+	}
+	if root := DirectoryToDTO(domain.Directory{Id: 1}); root.Parent != "" {
+		t.Errorf("expected no parent for a music folder directory, got %s", root.Parent)
+	}
+}
+
+func TestParseDirectoryID(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expectedID    int
+		expectedError bool
+	}{
+		{name: "directory ID", value: "dir-7", expectedID: 7},
+		{name: "song ID", value: "7", expectedError: true},
+		{name: "missing number", value: "dir-", expectedError: true},
+		{name: "empty", value: "", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := parseDirectoryID(tt.value)
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected an error, got ID %d", id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id != tt.expectedID {
+				t.Errorf("expected ID %d, got %d", tt.expectedID, id)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"music-streaming/internal/core/ports"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LyricsHandler struct {
+	lyricsService ports.LyricsPort
+	logger        *slog.Logger
+}
+
+func NewLyricsHandler(lyricsService ports.LyricsPort, logger *slog.Logger) *LyricsHandler {
+	return &LyricsHandler{
+		lyricsService: lyricsService,
+		logger:        logger,
+	}
+}
+
+func (h *LyricsHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/getLyrics", h.handleGetLyrics)
+	group.GET("/getLyricsBySongId", h.handleGetLyricsBySongID)
+}
+
+func (h *LyricsHandler) handleGetLyrics(c *gin.Context) {
+	var (
+		ctx    = c.Request.Context()
+		artist = c.Query("artist")
+		title  = c.Query("title")
+	)
+
+	h.logger.Info("Get lyrics handler called", slog.String("artist", artist), slog.String("title", title))
+	lyrics, err := h.lyricsService.GetLyrics(ctx, artist, title)
+	var notFoundErr *ports.NotFoundError
+	if err != nil && !errors.As(err, &notFoundErr) {
+		h.logger.Warn("Get lyrics handler error", slog.String("artist", artist), slog.String("title", title), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	// Songs without lyrics get an empty lyrics element rather than an error
+	h.logger.Info("Get lyrics handler success", slog.String("artist", artist), slog.String("title", title), slog.Int("lines", len(lyrics.Lines)))
+
+	// Convert to DTO
+	lyricsDTO := LyricsToDTO(lyrics)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:   Xmlns,
+		Status:  "ok",
+		Version: SubsonicVersion,
+		Lyrics:  &lyricsDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *LyricsHandler) handleGetLyricsBySongID(c *gin.Context) {
+	var (
+		ctx     = c.Request.Context()
+		paramId = c.Query("id")
+	)
+
+	id, err := strconv.Atoi(paramId)
+	if paramId == "" || err != nil {
+		h.logger.Warn("Get lyrics by song id handler - invalid id parameter", slog.String("id", paramId))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	h.logger.Info("Get lyrics by song id handler called", slog.Int("id", id))
+	lyrics, err := h.lyricsService.GetLyricsBySongID(ctx, id)
+	if err != nil {
+		h.logger.Warn("Get lyrics by song id handler error", slog.Int("id", id), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get lyrics by song id handler success", slog.Int("id", id), slog.Int("count", len(lyrics)))
+
+	// Convert to DTO
+	lyricsListDTO := LyricsListToDTO(lyrics)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:      Xmlns,
+		Status:     "ok",
+		Version:    SubsonicVersion,
+		LyricsList: &lyricsListDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
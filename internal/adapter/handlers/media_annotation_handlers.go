@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"log/slog"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MediaAnnotationHandler struct {
+	mediaAnnotationService ports.MediaAnnotationPort
+	logger                 *slog.Logger
+}
+
+func NewMediaAnnotationHandler(mediaAnnotationService ports.MediaAnnotationPort, logger *slog.Logger) *MediaAnnotationHandler {
+	return &MediaAnnotationHandler{
+		mediaAnnotationService: mediaAnnotationService,
+		logger:                 logger,
+	}
+}
+
+func (h *MediaAnnotationHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/star", h.handleStar)
+	group.GET("/unstar", h.handleUnstar)
+	group.GET("/setRating", h.handleSetRating)
+	group.GET("/getStarred", h.handleGetStarred)
+	group.GET("/getStarred2", h.handleGetStarred2)
+	group.GET("/scrobble", h.handleScrobble)
+	group.GET("/getNowPlaying", h.handleGetNowPlaying)
+}
+
+func (h *MediaAnnotationHandler) handleStar(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	songIDs, albumIDs, artistIDs, ok := h.annotationIDs(c)
+	if !ok {
+		return
+	}
+
+	h.logger.Info("Star handler called", slog.Any("id", songIDs), slog.Any("albumId", albumIDs), slog.Any("artistId", artistIDs))
+	if err := h.mediaAnnotationService.Star(ctx, songIDs, albumIDs, artistIDs); err != nil {
+		h.logger.Warn("Star handler error", slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Star handler success", slog.Int("count", len(songIDs)+len(albumIDs)+len(artistIDs)))
+	subsonicRes := SubsonicResponse{
+		Xmlns:   Xmlns,
+		Status:  "ok",
+		Version: SubsonicVersion,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaAnnotationHandler) handleUnstar(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	songIDs, albumIDs, artistIDs, ok := h.annotationIDs(c)
+	if !ok {
+		return
+	}
+
+	h.logger.Info("Unstar handler called", slog.Any("id", songIDs), slog.Any("albumId", albumIDs), slog.Any("artistId", artistIDs))
+	if err := h.mediaAnnotationService.Unstar(ctx, songIDs, albumIDs, artistIDs); err != nil {
+		h.logger.Warn("Unstar handler error", slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Unstar handler success", slog.Int("count", len(songIDs)+len(albumIDs)+len(artistIDs)))
+	subsonicRes := SubsonicResponse{
+		Xmlns:   Xmlns,
+		Status:  "ok",
+		Version: SubsonicVersion,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaAnnotationHandler) handleSetRating(c *gin.Context) {
+	var (
+		ctx         = c.Request.Context()
+		paramRating = c.Query("rating")
+		itemType    = domain.AnnotationItemSong
+		paramId     = c.Query("id")
+	)
+
+	// Song, album and artist IDs overlap, so albums and artists are rated by albumId and artistId
+	if albumID := c.Query("albumId"); albumID != "" {
+		itemType, paramId = domain.AnnotationItemAlbum, albumID
+	} else if artistID := c.Query("artistId"); artistID != "" {
+		itemType, paramId = domain.AnnotationItemArtist, artistID
+	}
+
+	id, idErr := strconv.Atoi(paramId)
+	rating, ratingErr := strconv.Atoi(paramRating)
+	if idErr != nil || ratingErr != nil {
+		h.logger.Warn("Set rating handler - invalid id or rating parameter", slog.String("id", paramId), slog.String("rating", paramRating))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	h.logger.Info("Set rating handler called", slog.String("type", string(itemType)), slog.Int("id", id), slog.Int("rating", rating))
+	if err := h.mediaAnnotationService.SetRating(ctx, itemType, id, rating); err != nil {
+		h.logger.Warn("Set rating handler error", slog.String("type", string(itemType)), slog.Int("id", id), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Set rating handler success", slog.String("type", string(itemType)), slog.Int("id", id), slog.Int("rating", rating))
+	subsonicRes := SubsonicResponse{
+		Xmlns:   Xmlns,
+		Status:  "ok",
+		Version: SubsonicVersion,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaAnnotationHandler) handleGetStarred(c *gin.Context) {
+	var (
+		ctx         = c.Request.Context()
+		paramFolder = c.DefaultQuery("musicFolderId", "0")
+	)
+
+	musicFolderID, err := strconv.Atoi(paramFolder)
+	if err != nil {
+		h.logger.Warn("Get starred handler - invalid musicFolderId parameter", slog.String("musicFolderId", paramFolder))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	h.logger.Info("Get starred handler called", slog.Int("musicFolderId", musicFolderID))
+	starred, err := h.mediaAnnotationService.GetStarred(ctx, musicFolderID)
+	if err != nil {
+		h.logger.Warn("Get starred handler error", slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get starred handler success", slog.Int("artists", len(starred.Artists)), slog.Int("albums", len(starred.Albums)), slog.Int("songs", len(starred.Songs)))
+
+	// Convert to DTO
+	starredDTO := StarredToDTO(starred)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:   Xmlns,
+		Status:  "ok",
+		Version: SubsonicVersion,
+		Starred: &starredDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaAnnotationHandler) handleGetStarred2(c *gin.Context) {
+	var (
+		ctx         = c.Request.Context()
+		paramFolder = c.DefaultQuery("musicFolderId", "0")
+	)
+
+	musicFolderID, err := strconv.Atoi(paramFolder)
+	if err != nil {
+		h.logger.Warn("Get starred2 handler - invalid musicFolderId parameter", slog.String("musicFolderId", paramFolder))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	h.logger.Info("Get starred2 handler called", slog.Int("musicFolderId", musicFolderID))
+	starred, err := h.mediaAnnotationService.GetStarred(ctx, musicFolderID)
+	if err != nil {
+		h.logger.Warn("Get starred2 handler error", slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get starred2 handler success", slog.Int("artists", len(starred.Artists)), slog.Int("albums", len(starred.Albums)), slog.Int("songs", len(starred.Songs)))
+
+	// Convert to DTO
+	starred2DTO := Starred2ToDTO(starred)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:    Xmlns,
+		Status:   "ok",
+		Version:  SubsonicVersion,
+		Starred2: &starred2DTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaAnnotationHandler) handleScrobble(c *gin.Context) {
+	var (
+		ctx             = c.Request.Context()
+		paramSubmission = c.DefaultQuery("submission", "true")
+		times           []time.Time
+	)
+
+	songIDs, idsOk := queryInts(c, "id")
+	// Times are in milliseconds since the epoch, as Subsonic clients send them
+	millis, timesOk := queryInts(c, "time")
+	submission, err := strconv.ParseBool(paramSubmission)
+	if !idsOk || !timesOk || err != nil {
+		h.logger.Warn("Scrobble handler - invalid id, time or submission parameter", slog.Any("id", c.QueryArray("id")), slog.Any("time", c.QueryArray("time")), slog.String("submission", paramSubmission))
+		buildAndSendError(c, "10")
+		return
+	}
+	for _, ms := range millis {
+		times = append(times, time.UnixMilli(int64(ms)))
+	}
+
+	h.logger.Info("Scrobble handler called", slog.Any("id", songIDs), slog.Bool("submission", submission))
+	if err := h.mediaAnnotationService.Scrobble(ctx, songIDs, times, submission); err != nil {
+		h.logger.Warn("Scrobble handler error", slog.Any("id", songIDs), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Scrobble handler success", slog.Int("count", len(songIDs)))
+	subsonicRes := SubsonicResponse{
+		Xmlns:   Xmlns,
+		Status:  "ok",
+		Version: SubsonicVersion,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaAnnotationHandler) handleGetNowPlaying(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	h.logger.Info("Get now playing handler called")
+	entries, err := h.mediaAnnotationService.GetNowPlaying(ctx)
+	if err != nil {
+		h.logger.Warn("Get now playing handler error", slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get now playing handler success", slog.Int("count", len(entries)))
+
+	// Convert to DTO
+	nowPlayingDTO := NowPlayingToDTO(entries)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:      Xmlns,
+		Status:     "ok",
+		Version:    SubsonicVersion,
+		NowPlaying: &nowPlayingDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+// annotationIDs reads the repeatable id, albumId and artistId parameters of star and unstar,
+// sending an error when one of them is not a number.
+func (h *MediaAnnotationHandler) annotationIDs(c *gin.Context) (songIDs []int, albumIDs []int, artistIDs []int, ok bool) {
+	songIDs, songsOk := queryInts(c, "id")
+	albumIDs, albumsOk := queryInts(c, "albumId")
+	artistIDs, artistsOk := queryInts(c, "artistId")
+	if !songsOk || !albumsOk || !artistsOk {
+		h.logger.Warn("Annotation handler - invalid id, albumId or artistId parameter", slog.Any("id", c.QueryArray("id")), slog.Any("albumId", c.QueryArray("albumId")), slog.Any("artistId", c.QueryArray("artistId")))
+		buildAndSendError(c, "10")
+		return nil, nil, nil, false
+	}
+	return songIDs, albumIDs, artistIDs, true
+}
+
+// queryInts reads every value of a repeatable integer query parameter, reporting whether they are all numbers.
+func queryInts(c *gin.Context, name string) ([]int, bool) {
+	var values []int
+	for _, param := range c.QueryArray(name) {
+		value, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+	return values, true
+}
@@ -2,12 +2,22 @@ package handlers
 
 import (
 	"log/slog"
+	"music-streaming/internal/core/domain"
 	"music-streaming/internal/core/ports"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// defaultSongsByGenreCount is the number of songs returned by getSongsByGenre when count is not set
+	defaultSongsByGenreCount = 10
+	// defaultAlbumListSize is the number of albums returned by getAlbumList and getAlbumList2 when size is not set
+	defaultAlbumListSize = 10
+	// defaultSearchCount is the number of artists, albums or songs returned by search2 and search3 when their count is not set
+	defaultSearchCount = 20
+)
+
 type MediaBrowsingHandler struct {
 	MediaBrowsingService ports.MediaBrowsingPort
 	logger               *slog.Logger
@@ -21,9 +31,155 @@ func NewMediaBrowsingHandler(mediaBrowsingServ ports.MediaBrowsingPort, logger *
 }
 
 func (h *MediaBrowsingHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/getMusicFolders", h.handleGetMusicFolders)
+	group.GET("/getIndexes", h.handleGetIndexes)
+	group.GET("/getMusicDirectory", h.handleGetMusicDirectory)
+	group.GET("/getArtists", h.handleGetArtists)
 	group.GET("/getArtist", h.handleGetArtist)
 	group.GET("/getAlbum", h.handleGetAlbum)
 	group.GET("/getSong", h.handleGetSong)
+	group.GET("/getGenres", h.handleGetGenres)
+	group.GET("/getSongsByGenre", h.handleGetSongsByGenre)
+	group.GET("/getAlbumList", h.handleGetAlbumList)
+	group.GET("/getAlbumList2", h.handleGetAlbumList2)
+	group.GET("/search2", h.handleSearch2)
+	group.GET("/search3", h.handleSearch3)
+}
+
+func (h *MediaBrowsingHandler) handleGetMusicFolders(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	h.logger.Info("Get music folders handler called")
+	folders, err := h.MediaBrowsingService.GetMusicFolders(ctx)
+	if err != nil {
+		h.logger.Warn("Get music folders handler error", slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get music folders handler success", slog.Int("count", len(folders)))
+
+	// Convert to DTO
+	foldersDTO := MusicFoldersToDTO(folders)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:        Xmlns,
+		Status:       "ok",
+		Version:      SubsonicVersion,
+		MusicFolders: &foldersDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaBrowsingHandler) handleGetIndexes(c *gin.Context) {
+	var (
+		ctx                  = c.Request.Context()
+		paramFolder          = c.DefaultQuery("musicFolderId", "0")
+		paramIfModifiedSince = c.DefaultQuery("ifModifiedSince", "0")
+	)
+
+	musicFolderID, folderErr := strconv.Atoi(paramFolder)
+	ifModifiedSince, sinceErr := strconv.ParseInt(paramIfModifiedSince, 10, 64)
+	if folderErr != nil || sinceErr != nil {
+		h.logger.Warn("Get indexes handler - invalid musicFolderId or ifModifiedSince parameter", slog.String("musicFolderId", paramFolder), slog.String("ifModifiedSince", paramIfModifiedSince))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	h.logger.Info("Get indexes handler called", slog.Int("musicFolderId", musicFolderID), slog.Int64("ifModifiedSince", ifModifiedSince))
+	indexes, err := h.MediaBrowsingService.GetIndexes(ctx, musicFolderID, ifModifiedSince)
+	if err != nil {
+		h.logger.Warn("Get indexes handler error", slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get indexes handler success", slog.Int("count", len(indexes.Index)), slog.Int64("lastModified", indexes.LastModified))
+
+	// Convert to DTO
+	indexesDTO := IndexesToDTO(indexes)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:   Xmlns,
+		Status:  "ok",
+		Version: SubsonicVersion,
+		Indexes: &indexesDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaBrowsingHandler) handleGetMusicDirectory(c *gin.Context) {
+	var (
+		ctx     = c.Request.Context()
+		paramId = c.Query("id")
+	)
+
+	id, err := parseDirectoryID(paramId)
+	if err != nil {
+		h.logger.Warn("Get music directory handler - invalid id parameter", slog.String("id", paramId))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	h.logger.Info("Get music directory handler called", slog.Int("id", id))
+	directory, err := h.MediaBrowsingService.GetMusicDirectory(ctx, id)
+	if err != nil {
+		h.logger.Warn("Get music directory handler error", slog.Int("id", id), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get music directory handler success", slog.Int("id", id), slog.String("name", directory.Name))
+
+	// Convert to DTO
+	directoryDTO := DirectoryToDTO(directory)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:     Xmlns,
+		Status:    "ok",
+		Version:   SubsonicVersion,
+		Directory: &directoryDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaBrowsingHandler) handleGetArtists(c *gin.Context) {
+	var (
+		ctx         = c.Request.Context()
+		paramFolder = c.DefaultQuery("musicFolderId", "0")
+	)
+
+	musicFolderID, err := strconv.Atoi(paramFolder)
+	if err != nil {
+		h.logger.Warn("Get artists handler - invalid musicFolderId parameter", slog.String("musicFolderId", paramFolder))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	h.logger.Info("Get artists handler called", slog.Int("musicFolderId", musicFolderID))
+	indexes, err := h.MediaBrowsingService.GetArtists(ctx, musicFolderID)
+	if err != nil {
+		h.logger.Warn("Get artists handler error", slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get artists handler success", slog.Int("count", len(indexes.Index)))
+
+	// Convert to DTO
+	artistsDTO := ArtistIndexesToDTO(indexes)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:   Xmlns,
+		Status:  "ok",
+		Version: SubsonicVersion,
+		Artists: &artistsDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
 }
 
 func (h *MediaBrowsingHandler) handleGetArtist(c *gin.Context) {
@@ -133,3 +289,273 @@ func (h *MediaBrowsingHandler) handleGetSong(c *gin.Context) {
 
 	SerializeAndSendBody(c, subsonicRes)
 }
+
+func (h *MediaBrowsingHandler) handleGetGenres(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	h.logger.Info("Get genres handler called")
+	genres, err := h.MediaBrowsingService.GetGenres(ctx)
+	if err != nil {
+		h.logger.Warn("Get genres handler error", slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get genres handler success", slog.Int("count", len(genres)))
+
+	// Convert to DTO
+	genresDTO := GenresToDTO(genres)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:   Xmlns,
+		Status:  "ok",
+		Version: SubsonicVersion,
+		Genres:  &genresDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaBrowsingHandler) handleGetSongsByGenre(c *gin.Context) {
+	var (
+		ctx         = c.Request.Context()
+		genre       = c.Query("genre")
+		paramCount  = c.DefaultQuery("count", strconv.Itoa(defaultSongsByGenreCount))
+		paramOffset = c.DefaultQuery("offset", "0")
+		paramFolder = c.DefaultQuery("musicFolderId", "0")
+	)
+
+	count, countErr := strconv.Atoi(paramCount)
+	offset, offsetErr := strconv.Atoi(paramOffset)
+	musicFolderID, folderErr := strconv.Atoi(paramFolder)
+	if genre == "" || countErr != nil || offsetErr != nil || folderErr != nil {
+		h.logger.Warn("Get songs by genre handler - invalid genre, count, offset or musicFolderId parameter", slog.String("genre", genre), slog.String("count", paramCount), slog.String("offset", paramOffset), slog.String("musicFolderId", paramFolder))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	h.logger.Info("Get songs by genre handler called", slog.String("genre", genre), slog.Int("count", count), slog.Int("offset", offset), slog.Int("musicFolderId", musicFolderID))
+	songs, err := h.MediaBrowsingService.GetSongsByGenre(ctx, genre, count, offset, musicFolderID)
+	if err != nil {
+		h.logger.Warn("Get songs by genre handler error", slog.String("genre", genre), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get songs by genre handler success", slog.String("genre", genre), slog.Int("count", len(songs)))
+
+	// Convert to DTO
+	songsDTO := SongsByGenreToDTO(songs)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:        Xmlns,
+		Status:       "ok",
+		Version:      SubsonicVersion,
+		SongsByGenre: &songsDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaBrowsingHandler) handleGetAlbumList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	query, ok := h.albumListQuery(c)
+	if !ok {
+		return
+	}
+
+	h.logger.Info("Get album list handler called", slog.String("type", string(query.Type)), slog.Int("size", query.Size), slog.Int("offset", query.Offset))
+	albums, err := h.MediaBrowsingService.GetAlbumList(ctx, query)
+	if err != nil {
+		h.logger.Warn("Get album list handler error", slog.String("type", string(query.Type)), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get album list handler success", slog.String("type", string(query.Type)), slog.Int("count", len(albums)))
+
+	// Convert to DTO
+	albumListDTO := AlbumListToDTO(albums)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:     Xmlns,
+		Status:    "ok",
+		Version:   SubsonicVersion,
+		AlbumList: &albumListDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaBrowsingHandler) handleGetAlbumList2(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	query, ok := h.albumListQuery(c)
+	if !ok {
+		return
+	}
+
+	h.logger.Info("Get album list 2 handler called", slog.String("type", string(query.Type)), slog.Int("size", query.Size), slog.Int("offset", query.Offset))
+	albums, err := h.MediaBrowsingService.GetAlbumList(ctx, query)
+	if err != nil {
+		h.logger.Warn("Get album list 2 handler error", slog.String("type", string(query.Type)), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get album list 2 handler success", slog.String("type", string(query.Type)), slog.Int("count", len(albums)))
+
+	// Convert to DTO
+	albumList2DTO := AlbumList2ToDTO(albums)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:      Xmlns,
+		Status:     "ok",
+		Version:    SubsonicVersion,
+		AlbumList2: &albumList2DTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+// albumListQuery reads the parameters of getAlbumList and getAlbumList2, sending an error when they are invalid.
+// fromYear and toYear are required by byYear lists, genre by byGenre lists.
+func (h *MediaBrowsingHandler) albumListQuery(c *gin.Context) (domain.AlbumListQuery, bool) {
+	var (
+		listType    = domain.AlbumListType(c.Query("type"))
+		paramSize   = c.DefaultQuery("size", strconv.Itoa(defaultAlbumListSize))
+		paramOffset = c.DefaultQuery("offset", "0")
+		paramFolder = c.DefaultQuery("musicFolderId", "0")
+		paramFrom   = c.Query("fromYear")
+		paramTo     = c.Query("toYear")
+	)
+
+	size, sizeErr := strconv.Atoi(paramSize)
+	offset, offsetErr := strconv.Atoi(paramOffset)
+	musicFolderID, folderErr := strconv.Atoi(paramFolder)
+	if listType == "" || sizeErr != nil || offsetErr != nil || folderErr != nil {
+		h.logger.Warn("Get album list handler - invalid type, size, offset or musicFolderId parameter", slog.String("type", string(listType)), slog.String("size", paramSize), slog.String("offset", paramOffset), slog.String("musicFolderId", paramFolder))
+		buildAndSendError(c, "10")
+		return domain.AlbumListQuery{}, false
+	}
+
+	query := domain.AlbumListQuery{
+		Type:          listType,
+		Size:          size,
+		Offset:        offset,
+		Genre:         c.Query("genre"),
+		MusicFolderId: musicFolderID,
+	}
+	if listType == domain.AlbumListByYear {
+		fromYear, fromErr := strconv.Atoi(paramFrom)
+		toYear, toErr := strconv.Atoi(paramTo)
+		if fromErr != nil || toErr != nil {
+			h.logger.Warn("Get album list handler - invalid fromYear or toYear parameter", slog.String("fromYear", paramFrom), slog.String("toYear", paramTo))
+			buildAndSendError(c, "10")
+			return domain.AlbumListQuery{}, false
+		}
+		query.FromYear, query.ToYear = fromYear, toYear
+	}
+	return query, true
+}
+
+func (h *MediaBrowsingHandler) handleSearch2(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	query, ok := h.searchQuery(c)
+	if !ok {
+		return
+	}
+
+	h.logger.Info("Search 2 handler called", slog.String("query", query.Query))
+	result, err := h.MediaBrowsingService.Search(ctx, query)
+	if err != nil {
+		h.logger.Warn("Search 2 handler error", slog.String("query", query.Query), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Search 2 handler success", slog.String("query", query.Query), slog.Int("artists", len(result.Artists)), slog.Int("albums", len(result.Albums)), slog.Int("songs", len(result.Songs)))
+
+	// Convert to DTO
+	resultDTO := SearchResult2ToDTO(result)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:         Xmlns,
+		Status:        "ok",
+		Version:       SubsonicVersion,
+		SearchResult2: &resultDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaBrowsingHandler) handleSearch3(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	query, ok := h.searchQuery(c)
+	if !ok {
+		return
+	}
+
+	h.logger.Info("Search 3 handler called", slog.String("query", query.Query))
+	result, err := h.MediaBrowsingService.Search(ctx, query)
+	if err != nil {
+		h.logger.Warn("Search 3 handler error", slog.String("query", query.Query), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Search 3 handler success", slog.String("query", query.Query), slog.Int("artists", len(result.Artists)), slog.Int("albums", len(result.Albums)), slog.Int("songs", len(result.Songs)))
+
+	// Convert to DTO
+	resultDTO := SearchResult3ToDTO(result)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:         Xmlns,
+		Status:        "ok",
+		Version:       SubsonicVersion,
+		SearchResult3: &resultDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+// searchQuery reads the parameters of search2 and search3, sending an error when they are invalid.
+// query is required but may be empty, every count and offset defaults separately.
+func (h *MediaBrowsingHandler) searchQuery(c *gin.Context) (domain.SearchQuery, bool) {
+	query, hasQuery := c.GetQuery("query")
+	paramFolder := c.DefaultQuery("musicFolderId", "0")
+	musicFolderID, folderErr := strconv.Atoi(paramFolder)
+	if !hasQuery || folderErr != nil {
+		h.logger.Warn("Search handler - missing query or invalid musicFolderId parameter", slog.String("musicFolderId", paramFolder))
+		buildAndSendError(c, "10")
+		return domain.SearchQuery{}, false
+	}
+
+	searchQuery := domain.SearchQuery{Query: query, MusicFolderId: musicFolderID}
+	pages := []struct {
+		param string
+		value *int
+		def   int
+	}{
+		{"artistCount", &searchQuery.ArtistCount, defaultSearchCount},
+		{"artistOffset", &searchQuery.ArtistOffset, 0},
+		{"albumCount", &searchQuery.AlbumCount, defaultSearchCount},
+		{"albumOffset", &searchQuery.AlbumOffset, 0},
+		{"songCount", &searchQuery.SongCount, defaultSearchCount},
+		{"songOffset", &searchQuery.SongOffset, 0},
+	}
+	for _, page := range pages {
+		paramValue := c.DefaultQuery(page.param, strconv.Itoa(page.def))
+		value, err := strconv.Atoi(paramValue)
+		if err != nil {
+			h.logger.Warn("Search handler - invalid "+page.param+" parameter", slog.String(page.param, paramValue))
+			buildAndSendError(c, "10")
+			return domain.SearchQuery{}, false
+		}
+		*page.value = value
+	}
+	return searchQuery, true
+}
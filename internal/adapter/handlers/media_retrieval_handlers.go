@@ -3,8 +3,10 @@ package handlers
 import (
 	"context"
 	"log/slog"
+	"mime"
 	"music-streaming/internal/core/domain"
 	"music-streaming/internal/core/ports"
+	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -31,7 +33,7 @@ func (h *MediaRetrievalHandler) RegisterRoutes(group *gin.RouterGroup) {
 func (h *MediaRetrievalHandler) handleDownload(c *gin.Context) {
 	var (
 		rUser   = c.MustGet(RequestingUserKey).(*domain.User)
-		ctx     = context.WithValue(c.Request.Context(), ports.KeyRequestingUserID, rUser)
+		ctx     = c.Request.Context()
 		paramId = c.Query("id")
 	)
 
@@ -51,6 +53,10 @@ func (h *MediaRetrievalHandler) handleDownload(c *gin.Context) {
 	}
 
 	h.logger.Info("Download handler success", slog.Int("id", id), slog.String("title", song.Title), slog.String("username", rUser.Username))
+	if song.IsSlice() {
+		h.sendSongSlice(ctx, c, song, true)
+		return
+	}
 	c.FileAttachment(song.Path, song.Title)
 }
 
@@ -64,7 +70,7 @@ type StreamParameters struct {
 func (h *MediaRetrievalHandler) handleStream(c *gin.Context) {
 	var (
 		rUser = c.MustGet(RequestingUserKey).(*domain.User)
-		ctx   = context.WithValue(c.Request.Context(), ports.KeyRequestingUserID, rUser)
+		ctx   = c.Request.Context()
 	)
 
 	var params StreamParameters
@@ -104,9 +110,32 @@ func (h *MediaRetrievalHandler) handleStream(c *gin.Context) {
 	// }
 
 	h.logger.Info("Stream handler success", slog.Int("id", id), slog.String("title", song.Title), slog.String("username", rUser.Username))
+	if song.IsSlice() {
+		h.sendSongSlice(ctx, c, song, false)
+		return
+	}
 	c.File(song.Path)
 }
 
+// sendSongSlice sends the part of its file covered by a song split from a CUE sheet, transcoded as it is read.
+func (h *MediaRetrievalHandler) sendSongSlice(ctx context.Context, c *gin.Context, song domain.Song, attachment bool) {
+	c.Header("Content-Type", song.ContentType)
+	if attachment {
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": song.Title + "." + song.Suffix}))
+	}
+	c.Status(http.StatusOK)
+
+	if err := h.MediaRetrievalService.WriteSongSlice(ctx, song, c.Writer); err != nil {
+		h.logger.Warn("Song slice transcoding error", slog.Int("id", song.Id), slog.String("error", err.Error()))
+		if !c.Writer.Written() {
+			// Nothing was sent yet, answer with a Subsonic error instead of the audio
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			handleServiceError(c, err)
+		}
+	}
+}
+
 func (h *MediaRetrievalHandler) handleGetCoverArt(c *gin.Context) {
 	var (
 		ctx     = c.Request.Context()
@@ -1,14 +1,25 @@
 package handlers
 
 import (
-	"context"
+	"io"
 	"log/slog"
 	"music-streaming/internal/core/domain"
 	"music-streaming/internal/core/ports"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// defaultScanRunsSize is the number of scan runs returned when size is not set
+	defaultScanRunsSize = 20
+
+	// scanProgressKeepAlive is the delay after which an idle scan progress stream gets a comment,
+	// so that proxies do not close it
+	scanProgressKeepAlive = 15 * time.Second
+)
+
 type MediaScanningHandler struct {
 	mediaScanningService ports.MediaScanningPort
 	logger               *slog.Logger
@@ -23,15 +34,24 @@ func NewMediaScanningHandler(mediaScanningService ports.MediaScanningPort, logge
 
 func (h *MediaScanningHandler) RegisterRoutes(group *gin.RouterGroup) {
 	group.GET("/getScanStatus", h.handleGetScanStatus)
+	group.GET("/getScanProgress", h.handleGetScanProgress)
 	group.POST("/startScan", h.handleStartScan)
+	group.POST("/stopScan", h.handleStopScan)
+	group.GET("/getScanRuns", h.handleGetScanRuns)
+	group.GET("/getScanRun", h.handleGetScanRun)
 }
 
 func (h *MediaScanningHandler) handleGetScanStatus(c *gin.Context) {
-	h.logger.Info("Get scan status handler called")
-	scanStatus, err := h.mediaScanningService.GetScanStatus(c.Request.Context())
+	var (
+		rUser = c.MustGet(RequestingUserKey).(*domain.User)
+		ctx   = c.Request.Context()
+	)
+
+	h.logger.Info("Get scan status handler called", slog.String("username", rUser.Username))
+	scanStatus, err := h.mediaScanningService.GetScanStatus(ctx)
 	if err != nil {
-		h.logger.Warn("Get scan status handler error", slog.String("error", err.Error()))
-		buildAndSendError(c, "0")
+		h.logger.Warn("Get scan status handler error", slog.String("username", rUser.Username), slog.String("error", err.Error()))
+		handleServiceError(c, err)
 		return
 	}
 
@@ -50,10 +70,52 @@ func (h *MediaScanningHandler) handleGetScanStatus(c *gin.Context) {
 	SerializeAndSendBody(c, subsonicRes)
 }
 
+// handleGetScanProgress streams the progress of media library scans as server-sent events, JSON encoded,
+// until the client disconnects
+func (h *MediaScanningHandler) handleGetScanProgress(c *gin.Context) {
+	var (
+		rUser = c.MustGet(RequestingUserKey).(*domain.User)
+		ctx   = c.Request.Context()
+	)
+
+	h.logger.Info("Get scan progress handler called", slog.String("username", rUser.Username))
+	updates, err := h.mediaScanningService.WatchScanProgress(ctx)
+	if err != nil {
+		h.logger.Warn("Get scan progress handler error", slog.String("username", rUser.Username), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	keepAlive := time.NewTicker(scanProgressKeepAlive)
+	defer keepAlive.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case progress, ok := <-updates:
+			if !ok {
+				return false
+			}
+			c.SSEvent("progress", ScanProgressToDTO(progress))
+			keepAlive.Reset(scanProgressKeepAlive)
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return false
+			}
+		}
+		return true
+	})
+
+	h.logger.Info("Get scan progress handler done", slog.String("username", rUser.Username))
+}
+
 func (h *MediaScanningHandler) handleStartScan(c *gin.Context) {
 	var (
 		rUser = c.MustGet(RequestingUserKey).(*domain.User)
-		ctx   = context.WithValue(c.Request.Context(), ports.KeyRequestingUserID, rUser)
+		ctx   = c.Request.Context()
 	)
 
 	h.logger.Info("Start scan handler called", slog.String("username", rUser.Username))
@@ -78,3 +140,108 @@ func (h *MediaScanningHandler) handleStartScan(c *gin.Context) {
 
 	SerializeAndSendBody(c, subsonicRes)
 }
+
+func (h *MediaScanningHandler) handleStopScan(c *gin.Context) {
+	var (
+		rUser = c.MustGet(RequestingUserKey).(*domain.User)
+		ctx   = c.Request.Context()
+	)
+
+	h.logger.Info("Stop scan handler called", slog.String("username", rUser.Username))
+	scanStatus, err := h.mediaScanningService.StopScan(ctx)
+	if err != nil {
+		h.logger.Warn("Stop scan handler error", slog.String("username", rUser.Username), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Stop scan handler success", slog.String("username", rUser.Username), slog.Bool("scanning", scanStatus.Scanning))
+
+	// Convert to DTO
+	scanStatusDTO := ScanStatusToDTO(scanStatus)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:      Xmlns,
+		Status:     "ok",
+		Version:    SubsonicVersion,
+		ScanStatus: &scanStatusDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaScanningHandler) handleGetScanRuns(c *gin.Context) {
+	var (
+		rUser       = c.MustGet(RequestingUserKey).(*domain.User)
+		ctx         = c.Request.Context()
+		paramSize   = c.DefaultQuery("size", strconv.Itoa(defaultScanRunsSize))
+		paramOffset = c.DefaultQuery("offset", "0")
+	)
+
+	size, sizeErr := strconv.Atoi(paramSize)
+	offset, offsetErr := strconv.Atoi(paramOffset)
+	if sizeErr != nil || offsetErr != nil {
+		h.logger.Warn("Get scan runs handler - invalid size or offset parameter", slog.String("size", paramSize), slog.String("offset", paramOffset))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	h.logger.Info("Get scan runs handler called", slog.String("username", rUser.Username), slog.Int("size", size), slog.Int("offset", offset))
+	runs, err := h.mediaScanningService.GetScanRuns(ctx, size, offset)
+	if err != nil {
+		h.logger.Warn("Get scan runs handler error", slog.String("username", rUser.Username), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get scan runs handler success", slog.String("username", rUser.Username), slog.Int("count", len(runs)))
+
+	// Convert to DTO
+	scanRunsDTO := ScanRunsToDTO(runs)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:    Xmlns,
+		Status:   "ok",
+		Version:  SubsonicVersion,
+		ScanRuns: &scanRunsDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *MediaScanningHandler) handleGetScanRun(c *gin.Context) {
+	var (
+		rUser   = c.MustGet(RequestingUserKey).(*domain.User)
+		ctx     = c.Request.Context()
+		paramId = c.Query("id")
+	)
+
+	id, err := strconv.Atoi(paramId)
+	if paramId == "" || err != nil {
+		h.logger.Warn("Get scan run handler - invalid id parameter", slog.String("id", paramId))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	h.logger.Info("Get scan run handler called", slog.String("username", rUser.Username), slog.Int("id", id))
+	run, err := h.mediaScanningService.GetScanRun(ctx, id)
+	if err != nil {
+		h.logger.Warn("Get scan run handler error", slog.String("username", rUser.Username), slog.Int("id", id), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get scan run handler success", slog.String("username", rUser.Username), slog.Int("id", id), slog.Int("failed", run.Failed))
+
+	// Convert to DTO
+	scanRunDTO := ScanRunToDTO(run)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:   Xmlns,
+		Status:  "ok",
+		Version: SubsonicVersion,
+		ScanRun: &scanRunDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"log/slog"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PlaylistHandler struct {
+	playlistService ports.PlaylistPort
+	logger          *slog.Logger
+}
+
+func NewPlaylistHandler(playlistService ports.PlaylistPort, logger *slog.Logger) *PlaylistHandler {
+	return &PlaylistHandler{
+		playlistService: playlistService,
+		logger:          logger,
+	}
+}
+
+func (h *PlaylistHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/getPlaylists", h.handleGetPlaylists)
+	group.GET("/getPlaylist", h.handleGetPlaylist)
+	group.GET("/createPlaylist", h.handleCreatePlaylist)
+	group.GET("/updatePlaylist", h.handleUpdatePlaylist)
+	group.GET("/deletePlaylist", h.handleDeletePlaylist)
+}
+
+func (h *PlaylistHandler) handleGetPlaylists(c *gin.Context) {
+	var (
+		ctx      = c.Request.Context()
+		username = c.Query("username")
+	)
+
+	h.logger.Info("Get playlists handler called", slog.String("username", username))
+	playlists, err := h.playlistService.GetPlaylists(ctx, username)
+	if err != nil {
+		h.logger.Warn("Get playlists handler error", slog.String("username", username), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get playlists handler success", slog.String("username", username), slog.Int("count", len(playlists)))
+
+	// Convert to DTO
+	playlistsDTO := PlaylistsToDTO(playlists)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:     Xmlns,
+		Status:    "ok",
+		Version:   SubsonicVersion,
+		Playlists: &playlistsDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *PlaylistHandler) handleGetPlaylist(c *gin.Context) {
+	var (
+		ctx     = c.Request.Context()
+		paramId = c.Query("id")
+	)
+
+	id, err := strconv.Atoi(paramId)
+	if paramId == "" || err != nil {
+		h.logger.Warn("Get playlist handler - invalid id parameter", slog.String("id", paramId))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	h.logger.Info("Get playlist handler called", slog.Int("id", id))
+	playlist, err := h.playlistService.GetPlaylist(ctx, id)
+	if err != nil {
+		h.logger.Warn("Get playlist handler error", slog.Int("id", id), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Get playlist handler success", slog.Int("id", id), slog.Int("songs", playlist.SongCount))
+	h.sendPlaylist(c, playlist)
+}
+
+// handleCreatePlaylist creates a playlist from name, or replaces the songs of the playlist with playlistId
+func (h *PlaylistHandler) handleCreatePlaylist(c *gin.Context) {
+	var (
+		ctx           = c.Request.Context()
+		paramPlaylist = c.Query("playlistId")
+		name          = c.Query("name")
+	)
+
+	songIDs, ok := queryInts(c, "songId")
+	playlistID, err := strconv.Atoi(paramPlaylist)
+	if !ok || (paramPlaylist == "" && name == "") || (paramPlaylist != "" && err != nil) {
+		h.logger.Warn("Create playlist handler - invalid playlistId, name or songId parameter", slog.String("playlistId", paramPlaylist), slog.String("name", name), slog.Any("songId", c.QueryArray("songId")))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	var playlist domain.Playlist
+	if paramPlaylist != "" {
+		h.logger.Info("Create playlist handler called to replace songs", slog.Int("playlistId", playlistID), slog.Int("songs", len(songIDs)))
+		playlist, err = h.playlistService.ReplacePlaylistSongs(ctx, playlistID, songIDs)
+	} else {
+		h.logger.Info("Create playlist handler called", slog.String("name", name), slog.Int("songs", len(songIDs)))
+		playlist, err = h.playlistService.CreatePlaylist(ctx, name, songIDs)
+	}
+	if err != nil {
+		h.logger.Warn("Create playlist handler error", slog.String("playlistId", paramPlaylist), slog.String("name", name), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Create playlist handler success", slog.Int("id", playlist.Id), slog.Int("songs", playlist.SongCount))
+	h.sendPlaylist(c, playlist)
+}
+
+func (h *PlaylistHandler) handleUpdatePlaylist(c *gin.Context) {
+	var (
+		ctx           = c.Request.Context()
+		paramPlaylist = c.Query("playlistId")
+		paramPublic   = c.Query("public")
+		update        domain.PlaylistUpdate
+	)
+
+	playlistID, err := strconv.Atoi(paramPlaylist)
+	songIDsToAdd, addOk := queryInts(c, "songIdToAdd")
+	songIndexesToRemove, removeOk := queryInts(c, "songIndexToRemove")
+	// Reordering is not part of the Subsonic API, each songIndexToMove goes with the songIndexToMoveTo at the same position
+	songIndexesToMove, moveOk := queryInts(c, "songIndexToMove")
+	songIndexesToMoveTo, moveToOk := queryInts(c, "songIndexToMoveTo")
+	if err != nil || !addOk || !removeOk || !moveOk || !moveToOk || len(songIndexesToMove) != len(songIndexesToMoveTo) {
+		h.logger.Warn("Update playlist handler - invalid playlistId, songIdToAdd, songIndexToRemove, songIndexToMove or songIndexToMoveTo parameter", slog.String("playlistId", paramPlaylist), slog.Any("songIdToAdd", c.QueryArray("songIdToAdd")), slog.Any("songIndexToRemove", c.QueryArray("songIndexToRemove")), slog.Any("songIndexToMove", c.QueryArray("songIndexToMove")), slog.Any("songIndexToMoveTo", c.QueryArray("songIndexToMoveTo")))
+		buildAndSendError(c, "10")
+		return
+	}
+	update.SongIdsToAdd, update.SongIndexesToRemove = songIDsToAdd, songIndexesToRemove
+	for i, from := range songIndexesToMove {
+		update.SongMoves = append(update.SongMoves, domain.SongMove{From: from, To: songIndexesToMoveTo[i]})
+	}
+	if name, exists := c.GetQuery("name"); exists {
+		update.Name = &name
+	}
+	if comment, exists := c.GetQuery("comment"); exists {
+		update.Comment = &comment
+	}
+	if paramPublic != "" {
+		public, err := strconv.ParseBool(paramPublic)
+		if err != nil {
+			h.logger.Warn("Update playlist handler - invalid public parameter", slog.String("public", paramPublic))
+			buildAndSendError(c, "10")
+			return
+		}
+		update.Public = &public
+	}
+
+	h.logger.Info("Update playlist handler called", slog.Int("playlistId", playlistID), slog.Int("songsToAdd", len(songIDsToAdd)), slog.Int("songsToRemove", len(songIndexesToRemove)), slog.Int("songsToMove", len(update.SongMoves)))
+	if err := h.playlistService.UpdatePlaylist(ctx, playlistID, update); err != nil {
+		h.logger.Warn("Update playlist handler error", slog.Int("playlistId", playlistID), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Update playlist handler success", slog.Int("playlistId", playlistID))
+	subsonicRes := SubsonicResponse{
+		Xmlns:   Xmlns,
+		Status:  "ok",
+		Version: SubsonicVersion,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+func (h *PlaylistHandler) handleDeletePlaylist(c *gin.Context) {
+	var (
+		ctx     = c.Request.Context()
+		paramId = c.Query("id")
+	)
+
+	id, err := strconv.Atoi(paramId)
+	if paramId == "" || err != nil {
+		h.logger.Warn("Delete playlist handler - invalid id parameter", slog.String("id", paramId))
+		buildAndSendError(c, "10")
+		return
+	}
+
+	h.logger.Info("Delete playlist handler called", slog.Int("id", id))
+	if err := h.playlistService.DeletePlaylist(ctx, id); err != nil {
+		h.logger.Warn("Delete playlist handler error", slog.Int("id", id), slog.String("error", err.Error()))
+		handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Delete playlist handler success", slog.Int("id", id))
+	subsonicRes := SubsonicResponse{
+		Xmlns:   Xmlns,
+		Status:  "ok",
+		Version: SubsonicVersion,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
+
+// sendPlaylist sends a playlist with its songs as entries, as getPlaylist and createPlaylist answer
+func (h *PlaylistHandler) sendPlaylist(c *gin.Context, playlist domain.Playlist) {
+	// Convert to DTO
+	playlistDTO := PlaylistToDTO(playlist)
+
+	subsonicRes := SubsonicResponse{
+		Xmlns:    Xmlns,
+		Status:   "ok",
+		Version:  SubsonicVersion,
+		Playlist: &playlistDTO,
+	}
+
+	SerializeAndSendBody(c, subsonicRes)
+}
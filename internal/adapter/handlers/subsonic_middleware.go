@@ -75,17 +75,36 @@ var (
 )
 
 type SubsonicResponse struct {
-	XMLName    xml.Name        `xml:"subsonic-response" json:"-"`
-	Xmlns      string          `xml:"xmlns,attr" json:"-"`
-	Status     string          `xml:"status,attr" json:"status"`
-	Version    string          `xml:"version,attr" json:"version"`
-	Error      *SubsonicError  `xml:"error,omitempty" json:"error,omitempty"`
-	User       *UserDTO        `xml:"user,omitempty" json:"user,omitempty"`
-	ScanStatus *ScanStatusDTO  `xml:"scanStatus,omitempty" json:"scanStatus,omitempty"`
-	Users      *[]UserDTO      `xml:"users,omitempty" json:"users,omitempty"`
-	Artist     *ArtistDTO      `xml:"artist,omitempty" json:"artist,omitempty"`
-	Album      *AlbumDTO       `xml:"album,omitempty" json:"album,omitempty"`
-	Song       *SongDTO        `xml:"song,omitempty" json:"song,omitempty"`
+	XMLName       xml.Name           `xml:"subsonic-response" json:"-"`
+	Xmlns         string             `xml:"xmlns,attr" json:"-"`
+	Status        string             `xml:"status,attr" json:"status"`
+	Version       string             `xml:"version,attr" json:"version"`
+	Error         *SubsonicError     `xml:"error,omitempty" json:"error,omitempty"`
+	User          *UserDTO           `xml:"user,omitempty" json:"user,omitempty"`
+	ScanStatus    *ScanStatusDTO     `xml:"scanStatus,omitempty" json:"scanStatus,omitempty"`
+	ScanRuns      *ScanRunsDTO       `xml:"scanRuns,omitempty" json:"scanRuns,omitempty"`
+	ScanRun       *ScanRunDTO        `xml:"scanRun,omitempty" json:"scanRun,omitempty"`
+	Users         *[]UserDTO         `xml:"users,omitempty" json:"users,omitempty"`
+	Artists       *ArtistsDTO        `xml:"artists,omitempty" json:"artists,omitempty"`
+	Artist        *ArtistDTO         `xml:"artist,omitempty" json:"artist,omitempty"`
+	Album         *AlbumDTO          `xml:"album,omitempty" json:"album,omitempty"`
+	Song          *SongDTO           `xml:"song,omitempty" json:"song,omitempty"`
+	Lyrics        *LyricsDTO         `xml:"lyrics,omitempty" json:"lyrics,omitempty"`
+	LyricsList    *LyricsListDTO     `xml:"lyricsList,omitempty" json:"lyricsList,omitempty"`
+	Genres        *GenresDTO         `xml:"genres,omitempty" json:"genres,omitempty"`
+	SongsByGenre  *SongsByGenreDTO   `xml:"songsByGenre,omitempty" json:"songsByGenre,omitempty"`
+	MusicFolders  *MusicFoldersDTO   `xml:"musicFolders,omitempty" json:"musicFolders,omitempty"`
+	Indexes       *IndexesDTO        `xml:"indexes,omitempty" json:"indexes,omitempty"`
+	Directory     *DirectoryDTO      `xml:"directory,omitempty" json:"directory,omitempty"`
+	AlbumList     *AlbumListDTO      `xml:"albumList,omitempty" json:"albumList,omitempty"`
+	AlbumList2    *AlbumList2DTO     `xml:"albumList2,omitempty" json:"albumList2,omitempty"`
+	SearchResult2 *SearchResult2DTO  `xml:"searchResult2,omitempty" json:"searchResult2,omitempty"`
+	SearchResult3 *SearchResult3DTO  `xml:"searchResult3,omitempty" json:"searchResult3,omitempty"`
+	Starred       *StarredDTO        `xml:"starred,omitempty" json:"starred,omitempty"`
+	Starred2      *Starred2DTO       `xml:"starred2,omitempty" json:"starred2,omitempty"`
+	Playlists     *PlaylistsDTO      `xml:"playlists,omitempty" json:"playlists,omitempty"`
+	Playlist      *PlaylistDTO       `xml:"playlist,omitempty" json:"playlist,omitempty"`
+	NowPlaying    *NowPlayingDTO     `xml:"nowPlaying,omitempty" json:"nowPlaying,omitempty"`
 }
 
 type SubsonicError struct {
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"log/slog"
 	"music-streaming/internal/core/ports"
 
@@ -39,5 +40,9 @@ func (m *UserManagementMiddleware) WithAuth(c *gin.Context) {
 	}
 
 	m.logger.Info("Authentication successful", slog.String("username", qUser))
-	c.Set(RequestingUserKey, user)
+	c.Set(RequestingUserKey, &user)
+	// Services read the requesting user and the player the request comes from from the request context
+	ctx = context.WithValue(ctx, ports.KeyRequestingUserID, &user)
+	ctx = context.WithValue(ctx, ports.KeyPlayerName, requiredParams.C)
+	c.Request = c.Request.WithContext(ctx)
 }
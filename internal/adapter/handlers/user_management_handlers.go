@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"context"
 	"log/slog"
 	"music-streaming/internal/core/domain"
 	"music-streaming/internal/core/ports"
@@ -34,7 +33,7 @@ func (h *UserManagementHandler) hangleGetUser(c *gin.Context) {
 	var (
 		rUser    = c.MustGet(RequestingUserKey).(*domain.User)
 		username = c.Query("username")
-		ctx      = context.WithValue(c.Request.Context(), ports.KeyRequestingUserID, rUser)
+		ctx      = c.Request.Context()
 	)
 
 	h.logger.Info("Get user handler called", slog.String("requesting_user", rUser.Username), slog.String("target_username", username))
@@ -64,7 +63,7 @@ func (h *UserManagementHandler) hangleGetUser(c *gin.Context) {
 func (h *UserManagementHandler) hangleGetUsers(c *gin.Context) {
 	var (
 		rUser = c.MustGet(RequestingUserKey).(*domain.User)
-		ctx   = context.WithValue(c.Request.Context(), ports.KeyRequestingUserID, rUser)
+		ctx   = c.Request.Context()
 	)
 
 	h.logger.Info("Get users handler called", slog.String("requesting_user", rUser.Username))
@@ -96,7 +95,7 @@ func (h *UserManagementHandler) hangleGetUsers(c *gin.Context) {
 func (h *UserManagementHandler) handleCreateUser(c *gin.Context) {
 	var (
 		rUser = c.MustGet(RequestingUserKey).(*domain.User)
-		ctx   = context.WithValue(c.Request.Context(), ports.KeyRequestingUserID, rUser)
+		ctx   = c.Request.Context()
 	)
 
 	userDTO := &UserDTO{}
@@ -130,7 +129,7 @@ func (h *UserManagementHandler) handleUpdateUser(c *gin.Context) {
 	var (
 		rUser    = c.MustGet(RequestingUserKey).(*domain.User)
 		username = c.PostForm("username")
-		ctx      = context.WithValue(c.Request.Context(), ports.KeyRequestingUserID, rUser)
+		ctx      = c.Request.Context()
 	)
 
 	userDTO := &UserDTO{}
@@ -164,7 +163,7 @@ func (h *UserManagementHandler) handleDeleteUser(c *gin.Context) {
 	var (
 		rUser    = c.MustGet(RequestingUserKey).(*domain.User)
 		username = c.PostForm("username")
-		ctx      = context.WithValue(c.Request.Context(), ports.KeyRequestingUserID, rUser)
+		ctx      = c.Request.Context()
 	)
 
 	h.logger.Info("Delete user handler called", slog.String("requesting_user", rUser.Username), slog.String("target_username", username))
@@ -189,7 +188,7 @@ func (h *UserManagementHandler) handleChangePassword(c *gin.Context) {
 		rUser    = c.MustGet(RequestingUserKey).(*domain.User)
 		username = c.PostForm("username")
 		password = c.PostForm("password")
-		ctx      = context.WithValue(c.Request.Context(), ports.KeyRequestingUserID, rUser)
+		ctx      = c.Request.Context()
 	)
 
 	h.logger.Info("Change password handler called", slog.String("requesting_user", rUser.Username), slog.String("target_username", username))
@@ -0,0 +1,93 @@
+package metadata
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"music-streaming/internal/adapter/ffmpeg"
+	"music-streaming/internal/core/domain"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// FFmpegLoudnessAnalyzer measures loudness with the ebur128 filter of the ffmpeg binary.
+type FFmpegLoudnessAnalyzer struct {
+	logger *slog.Logger
+}
+
+func NewFFmpegLoudnessAnalyzer(logger *slog.Logger) *FFmpegLoudnessAnalyzer {
+	return &FFmpegLoudnessAnalyzer{
+		logger: logger,
+	}
+}
+
+func (a *FFmpegLoudnessAnalyzer) Analyze(ctx context.Context, path string, start int, end int) (domain.Loudness, error) {
+	a.logger.Debug("FFmpeg analyzing loudness", slog.String("path", path), slog.Int("start", start), slog.Int("end", end))
+
+	args := []string{"-hide_banner", "-nostats"}
+	args = append(args, ffmpeg.SliceArgs(start, end)...)
+	args = append(args, "-i", path, "-map", "0:a:0", "-af", "ebur128=peak=true", "-f", "null", "-")
+
+	// The filter prints its summary to stderr once the whole stream is decoded
+	var stderr bytes.Buffer
+	// #nosec G204 -- path comes from walking the configured music directories
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return domain.Loudness{}, fmt.Errorf("ffmpeg failed for %s: %w", path, err)
+	}
+
+	loudness, err := parseEBUR128Summary(stderr.String())
+	if err != nil {
+		return domain.Loudness{}, fmt.Errorf("failed to parse ffmpeg loudness summary for %s: %w", path, err)
+	}
+	return loudness, nil
+}
+
+// parseEBUR128Summary reads the integrated loudness and true peak from the summary of the ebur128 filter:
+//
+//	Integrated loudness:
+//	  I:         -19.6 LUFS
+//	...
+//	True peak:
+//	  Peak:       -0.3 dBFS
+func parseEBUR128Summary(output string) (domain.Loudness, error) {
+	summary := strings.LastIndex(output, "Summary:")
+	if summary < 0 {
+		return domain.Loudness{}, errors.New("no summary found")
+	}
+
+	var (
+		loudness             domain.Loudness
+		hasLoudness, hasPeak bool
+	)
+	scanner := bufio.NewScanner(strings.NewReader(output[summary:]))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "I:":
+			loudness.Integrated = value
+			hasLoudness = true
+		case "Peak:":
+			// Silent tracks report a peak of -inf dBFS, which converts to 0
+			loudness.TruePeak = math.Pow(10, value/20)
+			hasPeak = true
+		}
+	}
+	if !hasLoudness || !hasPeak {
+		return domain.Loudness{}, errors.New("integrated loudness or true peak missing")
+	}
+	return loudness, nil
+}
@@ -0,0 +1,24 @@
+package metadata
+
+import (
+	"fmt"
+	"log/slog"
+	"music-streaming/internal/core/ports"
+)
+
+const (
+	ExtractorFFProbe = "ffprobe"
+	ExtractorNative  = "native"
+)
+
+// NewMetadataExtractor returns the metadata extractor selected by name, ffprobe when name is empty.
+func NewMetadataExtractor(name string, logger *slog.Logger) (ports.MetadataExtractor, error) {
+	switch name {
+	case "", ExtractorFFProbe:
+		return NewFFProbeMetadataExtractor(logger), nil
+	case ExtractorNative:
+		return NewNativeMetadataExtractor(logger), nil
+	default:
+		return nil, fmt.Errorf("unknown metadata extractor %q, expected %q or %q", name, ExtractorFFProbe, ExtractorNative)
+	}
+}
@@ -0,0 +1,109 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ffprobeFormat represents the format information from ffprobe
+type ffprobeFormat struct {
+	Duration string            `json:"duration"`
+	BitRate  string            `json:"bit_rate"`
+	Tags     map[string]string `json:"tags"`
+}
+
+// ffprobeStream represents the stream information from ffprobe
+type ffprobeStream struct {
+	Index       int    `json:"index"`
+	CodecType   string `json:"codec_type"`
+	Disposition struct {
+		AttachedPic int `json:"attached_pic"`
+	} `json:"disposition"`
+	Tags map[string]string `json:"tags"`
+}
+
+// ffprobeInfo represents the full information from ffprobe
+type ffprobeInfo struct {
+	Format  *ffprobeFormat  `json:"format"`
+	Streams []ffprobeStream `json:"streams"`
+}
+
+// FFProbeMetadataExtractor reads metadata with the ffprobe binary and extracts artwork with ffmpeg.
+type FFProbeMetadataExtractor struct {
+	logger *slog.Logger
+}
+
+func NewFFProbeMetadataExtractor(logger *slog.Logger) *FFProbeMetadataExtractor {
+	return &FFProbeMetadataExtractor{
+		logger: logger,
+	}
+}
+
+func (e *FFProbeMetadataExtractor) Extract(ctx context.Context, path string) (domain.MediaMetadata, error) {
+	e.logger.Debug("FFProbe processing file", slog.String("path", path))
+
+	// #nosec G204 -- path comes from walking the configured music directories
+	out, err := exec.CommandContext(ctx, "ffprobe", "-v", "quiet", "-print_format", "json", "-show_format", "-show_streams", path).Output()
+	if err != nil {
+		return domain.MediaMetadata{}, fmt.Errorf("ffprobe failed for %s: %w", path, err)
+	}
+
+	var info ffprobeInfo
+	if err := json.Unmarshal(out, &info); err != nil {
+		return domain.MediaMetadata{}, fmt.Errorf("failed to parse ffprobe output for %s: %w", path, err)
+	}
+	if info.Format == nil {
+		return domain.MediaMetadata{}, fmt.Errorf("ffprobe returned no format information for %s", path)
+	}
+
+	metadata := domain.MediaMetadata{Tags: make(map[string]string)}
+	addTags(metadata.Tags, info.Format.Tags)
+	for _, stream := range info.Streams {
+		if stream.CodecType == "audio" {
+			// Ogg containers keep the comments on the audio stream
+			addTags(metadata.Tags, stream.Tags)
+		}
+		if stream.Disposition.AttachedPic == 1 {
+			metadata.HasPicture = true
+		}
+	}
+	if duration, err := strconv.ParseFloat(info.Format.Duration, 64); err == nil {
+		metadata.Duration = duration
+	}
+	if bitRate, err := strconv.Atoi(info.Format.BitRate); err == nil {
+		metadata.BitRate = bitRate
+	}
+	return metadata, nil
+}
+
+func (e *FFProbeMetadataExtractor) ExtractPicture(ctx context.Context, path string) (domain.Picture, error) {
+	e.logger.Debug("FFmpeg extracting picture", slog.String("path", path))
+
+	// #nosec G204 -- path comes from walking the configured music directories
+	out, err := exec.CommandContext(ctx, "ffmpeg", "-v", "quiet", "-i", path, "-map", "0:v:0", "-frames:v", "1", "-c:v", "copy", "-f", "image2pipe", "-").Output()
+	if err != nil {
+		return domain.Picture{}, fmt.Errorf("ffmpeg failed for %s: %w", path, err)
+	}
+	if len(out) == 0 {
+		return domain.Picture{}, &ports.NotFoundError{Message: "embedded picture not found"}
+	}
+	return domain.Picture{MimeType: http.DetectContentType(out), Data: out}, nil
+}
+
+// addTags copies tags into dst with lower case keys, keeping values already present.
+func addTags(dst map[string]string, tags map[string]string) {
+	for key, value := range tags {
+		key = normalizeTagKey(key)
+		if _, exists := dst[key]; !exists && strings.TrimSpace(value) != "" {
+			dst[key] = strings.TrimSpace(value)
+		}
+	}
+}
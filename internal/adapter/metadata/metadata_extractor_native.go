@@ -0,0 +1,166 @@
+package metadata
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// pictureTypeFrontCover is the picture type of the front cover in ID3 APIC frames and FLAC PICTURE blocks.
+const pictureTypeFrontCover = 3
+
+// tagKeyAliases maps the tag names used by some formats to the keys reported by ffprobe.
+var tagKeyAliases = map[string]string{
+	"albumartist":  "album_artist",
+	"album artist": "album_artist",
+	"tracknumber":  "track",
+	"discnumber":   "disc",
+	"year":         "date",
+}
+
+// NativeMetadataExtractor reads metadata by parsing the tag formats directly, without external tools.
+// It understands ID3v1 and ID3v2 (MP3), Vorbis comments (FLAC, Ogg Vorbis, Opus), MP4 atoms (M4A)
+// and RIFF INFO chunks (WAV). Files in other formats are reported without tags.
+type NativeMetadataExtractor struct {
+	logger *slog.Logger
+}
+
+func NewNativeMetadataExtractor(logger *slog.Logger) *NativeMetadataExtractor {
+	return &NativeMetadataExtractor{
+		logger: logger,
+	}
+}
+
+// parsedFile collects what the format parsers read from a file.
+type parsedFile struct {
+	tags         map[string]string
+	duration     float64
+	bitRate      int
+	picture      *domain.Picture
+	frontPicture bool
+}
+
+// setTag records a tag value, the first value read for a key wins.
+func (p *parsedFile) setTag(key string, value string) {
+	value = strings.TrimSpace(strings.TrimRight(value, "\x00"))
+	if key == "" || value == "" {
+		return
+	}
+	key = normalizeTagKey(key)
+	if _, exists := p.tags[key]; !exists {
+		p.tags[key] = value
+	}
+}
+
+// setPicture records embedded artwork, the first front cover wins over any other picture.
+func (p *parsedFile) setPicture(mimeType string, data []byte, pictureType int) {
+	if len(data) == 0 || p.frontPicture || (p.picture != nil && pictureType != pictureTypeFrontCover) {
+		return
+	}
+	if mimeType == "" || !strings.HasPrefix(mimeType, "image/") {
+		mimeType = http.DetectContentType(data)
+	}
+	p.picture = &domain.Picture{MimeType: mimeType, Data: data}
+	p.frontPicture = pictureType == pictureTypeFrontCover
+}
+
+func (e *NativeMetadataExtractor) Extract(ctx context.Context, path string) (domain.MediaMetadata, error) {
+	e.logger.Debug("Native metadata processing file", slog.String("path", path))
+
+	parsed, err := parseFile(path)
+	if err != nil {
+		return domain.MediaMetadata{}, err
+	}
+	return domain.MediaMetadata{
+		Tags:       parsed.tags,
+		Duration:   parsed.duration,
+		BitRate:    parsed.bitRate,
+		HasPicture: parsed.picture != nil,
+	}, nil
+}
+
+func (e *NativeMetadataExtractor) ExtractPicture(ctx context.Context, path string) (domain.Picture, error) {
+	e.logger.Debug("Native metadata extracting picture", slog.String("path", path))
+
+	parsed, err := parseFile(path)
+	if err != nil {
+		return domain.Picture{}, err
+	}
+	if parsed.picture == nil {
+		return domain.Picture{}, &ports.NotFoundError{Message: "embedded picture not found"}
+	}
+	return *parsed.picture, nil
+}
+
+// parseFile detects the format of the file at path from its leading bytes and reads its metadata.
+func parseFile(path string) (*parsedFile, error) {
+	// #nosec G304 -- path comes from walking the configured music directories
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint:errcheck
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return nil, fmt.Errorf("failed to read header of %s: %w", path, err)
+	}
+
+	parsed := &parsedFile{tags: make(map[string]string)}
+	switch {
+	case bytes.HasPrefix(header, []byte("ID3")):
+		err = parseID3File(f, stat.Size(), parsed)
+	case bytes.HasPrefix(header, []byte("fLaC")):
+		err = parseFLAC(f, 0, stat.Size(), parsed)
+	case bytes.HasPrefix(header, []byte("OggS")):
+		err = parseOgg(f, stat.Size(), parsed)
+	case bytes.Equal(header[4:8], []byte("ftyp")):
+		err = parseMP4(f, stat.Size(), parsed)
+	case bytes.HasPrefix(header, []byte("RIFF")) && bytes.Equal(header[8:12], []byte("WAVE")):
+		err = parseWAV(f, stat.Size(), parsed)
+	case header[0] == 0xFF && header[1]&0xE0 == 0xE0:
+		err = parseMPEGFile(f, 0, stat.Size(), parsed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata of %s: %w", path, err)
+	}
+	return parsed, nil
+}
+
+// normalizeTagKey lower cases key and maps format specific names to the ffprobe ones.
+func normalizeTagKey(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if alias, ok := tagKeyAliases[key]; ok {
+		return alias
+	}
+	return key
+}
+
+// readAt reads exactly n bytes at offset off.
+func readAt(r io.ReaderAt, off int64, n int) ([]byte, error) {
+	buf := make([]byte, n)
+	if _, err := r.ReadAt(buf, off); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// bitRateFor returns the average bit rate of size bytes of audio lasting duration seconds.
+func bitRateFor(size int64, duration float64) int {
+	if duration <= 0 || size <= 0 {
+		return 0
+	}
+	return int(float64(size) * 8 / duration)
+}
@@ -0,0 +1,74 @@
+package metadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newParsedFile returns an empty parsedFile for the format parsers to fill.
+func newParsedFile() *parsedFile {
+	return &parsedFile{tags: make(map[string]string)}
+}
+
+// equalTags reports whether the parsed tags are exactly the expected ones.
+func equalTags(parsed map[string]string, expected map[string]string) bool {
+	if len(parsed) != len(expected) {
+		return false
+	}
+	for key, value := range expected {
+		if parsed[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParseFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       []byte
+		expectedTags  map[string]string
+		expectedError bool
+	}{
+		{
+			name:         "id3v2 tagged file",
+			content:      id3Tag(3, 0, id3v23Frame("TIT2", 0, textFrame("Airbag"))),
+			expectedTags: map[string]string{"title": "Airbag"},
+		},
+		{
+			name:         "unknown format read without tags",
+			content:      []byte("not an audio file"),
+			expectedTags: map[string]string{},
+		},
+		{
+			name:          "file shorter than any header",
+			content:       []byte("ID3"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "song")
+			if err := os.WriteFile(path, tt.content, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			parsed, err := parseFile(path)
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equalTags(parsed.tags, tt.expectedTags) {
+				t.Errorf("expected tags %v, got %v", tt.expectedTags, parsed.tags)
+			}
+		})
+	}
+}
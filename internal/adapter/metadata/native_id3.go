@@ -0,0 +1,484 @@
+package metadata
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	id3v1Size = 128
+
+	// ID3v2 text encodings
+	encodingLatin1  = 0
+	encodingUTF16   = 1
+	encodingUTF16BE = 2
+	encodingUTF8    = 3
+)
+
+var (
+	// id3TextFrames maps the ID3v2.3 and v2.4 text frames to the tag keys reported by ffprobe.
+	id3TextFrames = map[string]string{
+		"TIT1": "grouping",
+		"TIT2": "title",
+		"TIT3": "subtitle",
+		"TPE1": "artist",
+		"TPE2": "album_artist",
+		"TPE3": "performer",
+		"TALB": "album",
+		"TRCK": "track",
+		"TPOS": "disc",
+		"TYER": "date",
+		"TDRC": "date",
+		"TDOR": "originaldate",
+		"TCON": "genre",
+		"TCOM": "composer",
+		"TPUB": "publisher",
+		"TCOP": "copyright",
+		"TLAN": "language",
+		"TENC": "encoded_by",
+		"TSSE": "encoder",
+		"TBPM": "bpm",
+		"TCMP": "compilation",
+		"TSOA": "album-sort",
+		"TSOP": "artist-sort",
+		"TSOT": "title-sort",
+		"TSO2": "album_artist-sort",
+	}
+
+	// id3v22Frames maps the three character ID3v2.2 frames to their ID3v2.3 equivalent.
+	id3v22Frames = map[string]string{
+		"TT1": "TIT1",
+		"TT2": "TIT2",
+		"TT3": "TIT3",
+		"TP1": "TPE1",
+		"TP2": "TPE2",
+		"TP3": "TPE3",
+		"TAL": "TALB",
+		"TRK": "TRCK",
+		"TPA": "TPOS",
+		"TYE": "TYER",
+		"TCO": "TCON",
+		"TCM": "TCOM",
+		"TPB": "TPUB",
+		"TCR": "TCOP",
+		"TLA": "TLAN",
+		"TEN": "TENC",
+		"TSS": "TSSE",
+		"TBP": "TBPM",
+		"TCP": "TCMP",
+		"TXX": "TXXX",
+		"COM": "COMM",
+		"ULT": "USLT",
+		"SLT": "SYLT",
+		"PIC": "PIC",
+	}
+
+	// id3GenreReference matches ID3v1 genre references such as "(17)" optionally followed by a refinement.
+	id3GenreReference = regexp.MustCompile(`^\((\d+)\)(.*)$`)
+
+	// id3Genres lists the ID3v1 genres, including the Winamp extensions.
+	id3Genres = []string{
+		"Blues", "Classic Rock", "Country", "Dance", "Disco", "Funk", "Grunge", "Hip-Hop",
+		"Jazz", "Metal", "New Age", "Oldies", "Other", "Pop", "R&B", "Rap",
+		"Reggae", "Rock", "Techno", "Industrial", "Alternative", "Ska", "Death Metal", "Pranks",
+		"Soundtrack", "Euro-Techno", "Ambient", "Trip-Hop", "Vocal", "Jazz+Funk", "Fusion", "Trance",
+		"Classical", "Instrumental", "Acid", "House", "Game", "Sound Clip", "Gospel", "Noise",
+		"AlternRock", "Bass", "Soul", "Punk", "Space", "Meditative", "Instrumental Pop", "Instrumental Rock",
+		"Ethnic", "Gothic", "Darkwave", "Techno-Industrial", "Electronic", "Pop-Folk", "Eurodance", "Dream",
+		"Southern Rock", "Comedy", "Cult", "Gangsta", "Top 40", "Christian Rap", "Pop/Funk", "Jungle",
+		"Native American", "Cabaret", "New Wave", "Psychedelic", "Rave", "Showtunes", "Trailer", "Lo-Fi",
+		"Tribal", "Acid Punk", "Acid Jazz", "Polka", "Retro", "Musical", "Rock & Roll", "Hard Rock",
+		"Folk", "Folk-Rock", "National Folk", "Swing", "Fast Fusion", "Bebop", "Latin", "Revival",
+		"Celtic", "Bluegrass", "Avantgarde", "Gothic Rock", "Progressive Rock", "Psychedelic Rock", "Symphonic Rock", "Slow Rock",
+		"Big Band", "Chorus", "Easy Listening", "Acoustic", "Humour", "Speech", "Chanson", "Opera",
+		"Chamber Music", "Sonata", "Symphony", "Booty Bass", "Primus", "Porn Groove", "Satire", "Slow Jam",
+		"Club", "Tango", "Samba", "Folklore", "Ballad", "Power Ballad", "Rhythmic Soul", "Freestyle",
+		"Duet", "Punk Rock", "Drum Solo", "A capella", "Euro-House", "Dance Hall", "Goa", "Drum & Bass",
+		"Club-House", "Hardcore", "Terror", "Indie", "BritPop", "Negerpunk", "Polsk Punk", "Beat",
+		"Christian Gangsta", "Heavy Metal", "Black Metal", "Crossover", "Contemporary Christian", "Christian Rock", "Merengue", "Salsa",
+		"Thrash Metal", "Anime", "JPop", "Synthpop", "Abstract", "Art Rock", "Baroque", "Bhangra",
+		"Big Beat", "Breakbeat", "Chillout", "Downtempo", "Dub", "EBM", "Eclectic", "Electro",
+		"Electroclash", "Emo", "Experimental", "Garage", "Global", "IDM", "Illbient", "Industro-Goth",
+		"Jam Band", "Krautrock", "Leftfield", "Lounge", "Math Rock", "New Romantic", "Nu-Breakz", "Post-Punk",
+		"Post-Rock", "Psytrance", "Shoegaze", "Space Rock", "Trop Rock", "World Music", "Neoclassical", "Audiobook",
+		"Audio Theatre", "Neue Deutsche Welle", "Podcast", "Indie Rock", "G-Funk", "Dubstep", "Garage Rock", "Psybient",
+	}
+)
+
+// parseID3File reads an ID3v2 tagged file, which is either an MPEG audio stream or a FLAC stream.
+func parseID3File(r io.ReaderAt, size int64, parsed *parsedFile) error {
+	tagSize, err := parseID3v2(r, size, parsed)
+	if err != nil {
+		return err
+	}
+	if magic, err := readAt(r, tagSize, 4); err == nil && bytes.Equal(magic, []byte("fLaC")) {
+		return parseFLAC(r, tagSize, size, parsed)
+	}
+	return parseMPEGFile(r, tagSize, size, parsed)
+}
+
+// parseID3v2 reads the ID3v2 tag at the start of r, holding size bytes, and returns its total size.
+func parseID3v2(r io.ReaderAt, size int64, parsed *parsedFile) (int64, error) {
+	header, err := readAt(r, 0, 10)
+	if err != nil || !bytes.HasPrefix(header, []byte("ID3")) {
+		return 0, err
+	}
+	major, flags := header[3], header[5]
+	tagSize := int64(syncsafe(header[6:10]))
+	total := 10 + tagSize
+	if flags&0x10 != 0 {
+		total += 10
+	}
+	if major < 2 || major > 4 {
+		// Unknown version, skip the tag
+		return total, nil
+	}
+	if 10+tagSize > size {
+		return total, fmt.Errorf("id3v2 tag exceeds file size")
+	}
+
+	body, err := readAt(r, 10, int(tagSize))
+	if err != nil {
+		return total, err
+	}
+	if flags&0x80 != 0 && major < 4 {
+		body = removeUnsynchronisation(body)
+	}
+	if flags&0x40 != 0 && major >= 3 && len(body) >= 4 {
+		extended := int(binary.BigEndian.Uint32(body[0:4])) + 4
+		if major == 4 {
+			extended = syncsafe(body[0:4])
+		}
+		if extended > len(body) {
+			return total, nil
+		}
+		body = body[extended:]
+	}
+
+	parseID3v2Frames(body, major, parsed)
+	return total, nil
+}
+
+func parseID3v2Frames(body []byte, major byte, parsed *parsedFile) {
+	headerSize := 10
+	if major == 2 {
+		headerSize = 6
+	}
+
+	for len(body) >= headerSize && body[0] != 0 {
+		var (
+			id    string
+			size  int
+			flags uint16
+		)
+		if major == 2 {
+			id = string(body[0:3])
+			size = int(body[3])<<16 | int(body[4])<<8 | int(body[5])
+		} else {
+			id = string(body[0:4])
+			size = int(binary.BigEndian.Uint32(body[4:8]))
+			if major == 4 {
+				size = syncsafe(body[4:8])
+			}
+			flags = binary.BigEndian.Uint16(body[8:10])
+		}
+		if size <= 0 || headerSize+size > len(body) {
+			return
+		}
+		data := body[headerSize : headerSize+size]
+		body = body[headerSize+size:]
+
+		if major == 2 {
+			if id = id3v22Frames[id]; id == "" {
+				continue
+			}
+		}
+		if data = decodeFrameData(data, major, flags); len(data) > 0 {
+			parseID3Frame(id, data, parsed)
+		}
+	}
+}
+
+// maxDecompressedFrameSize bounds the size of a compressed frame once decompressed, whatever size the frame declares.
+const maxDecompressedFrameSize = 16 << 20
+
+// decodeFrameData strips the extra frame header bytes and undoes compression and unsynchronisation.
+// Returns nil for encrypted frames, and for compressed frames larger than declared or than maxDecompressedFrameSize.
+func decodeFrameData(data []byte, major byte, flags uint16) []byte {
+	var compressed, unsynchronised bool
+	skip, decompressedSize := 0, maxDecompressedFrameSize
+	switch major {
+	case 3:
+		if flags&0x0040 != 0 {
+			return nil
+		}
+		if flags&0x0080 != 0 {
+			if len(data) < 4 {
+				return nil
+			}
+			compressed = true
+			decompressedSize = min(int(binary.BigEndian.Uint32(data[0:4])), decompressedSize)
+			skip += 4
+		}
+		if flags&0x0020 != 0 {
+			skip++
+		}
+	case 4:
+		if flags&0x0004 != 0 {
+			return nil
+		}
+		if flags&0x0040 != 0 {
+			skip++
+		}
+		if flags&0x0001 != 0 {
+			if len(data) < skip+4 {
+				return nil
+			}
+			decompressedSize = min(syncsafe(data[skip:skip+4]), decompressedSize)
+			skip += 4
+		}
+		compressed = flags&0x0008 != 0
+		unsynchronised = flags&0x0002 != 0
+	}
+	if skip > len(data) {
+		return nil
+	}
+	data = data[skip:]
+
+	if unsynchronised {
+		data = removeUnsynchronisation(data)
+	}
+	if compressed {
+		zr, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil
+		}
+		defer zr.Close() // nolint:errcheck
+		// A byte past the declared size tells a frame decompressing to more than it declares
+		if data, err = io.ReadAll(io.LimitReader(zr, int64(decompressedSize)+1)); err != nil || len(data) > decompressedSize {
+			return nil
+		}
+	}
+	return data
+}
+
+func parseID3Frame(id string, data []byte, parsed *parsedFile) {
+	encoding := data[0]
+	switch {
+	case id == "TXXX":
+		description, value := splitTerminated(data[1:], encoding)
+		parsed.setTag(decodeText(description, encoding), decodeTextValues(value, encoding))
+	case id[0] == 'T':
+		key, ok := id3TextFrames[id]
+		if !ok {
+			return
+		}
+		value := decodeTextValues(data[1:], encoding)
+		if key == "genre" {
+			value = id3Genre(value)
+		}
+		parsed.setTag(key, value)
+	case id == "COMM" || id == "USLT":
+		if len(data) < 4 {
+			return
+		}
+		description, text := splitTerminated(data[4:], encoding)
+		if len(description) > 0 && decodeText(description, encoding) != "" {
+			return
+		}
+		key := "comment"
+		if id == "USLT" {
+			key = lyricsTagKey("lyrics", data[1:4])
+		}
+		parsed.setTag(key, decodeText(text, encoding))
+	case id == "SYLT":
+		// Only timestamps in milliseconds are supported, MPEG frame counts depending on the stream
+		if len(data) < 6 || data[4] != 2 {
+			return
+		}
+		_, body := splitTerminated(data[6:], encoding)
+		parsed.setTag(lyricsTagKey("syncedlyrics", data[1:4]), syncedLyricsToLRC(body, encoding))
+	case id == "APIC":
+		mimeType, rest := splitTerminated(data[1:], encodingLatin1)
+		if len(rest) < 1 {
+			return
+		}
+		pictureType := int(rest[0])
+		_, picture := splitTerminated(rest[1:], encoding)
+		parsed.setPicture(decodeText(mimeType, encodingLatin1), picture, pictureType)
+	case id == "PIC":
+		if len(data) < 5 {
+			return
+		}
+		pictureType := int(data[4])
+		_, picture := splitTerminated(data[5:], encoding)
+		parsed.setPicture("", picture, pictureType)
+	}
+}
+
+// lyricsTagKey names a lyrics tag after its language as ffprobe does, such as "lyrics-eng".
+func lyricsTagKey(name string, language []byte) string {
+	lang := strings.ToLower(string(language))
+	for _, c := range lang {
+		if c < 'a' || c > 'z' {
+			return name
+		}
+	}
+	return name + "-" + lang
+}
+
+// syncedLyricsToLRC converts the text and timestamp pairs of a SYLT frame to LRC lines.
+func syncedLyricsToLRC(b []byte, encoding byte) string {
+	var lrc strings.Builder
+	for len(b) > 0 {
+		text, rest := splitTerminated(b, encoding)
+		if len(rest) < 4 {
+			break
+		}
+		ms := int(binary.BigEndian.Uint32(rest[:4]))
+		b = rest[4:]
+		line := strings.TrimSpace(decodeText(text, encoding))
+		fmt.Fprintf(&lrc, "[%02d:%02d.%02d]%s\n", ms/60000, ms/1000%60, ms%1000/10, line)
+	}
+	return lrc.String()
+}
+
+// parseID3v1 reads the ID3v1 tag at the end of a file of the given size, filling tags not already read.
+// Returns whether the file ends with an ID3v1 tag.
+func parseID3v1(r io.ReaderAt, size int64, parsed *parsedFile) bool {
+	if size < id3v1Size {
+		return false
+	}
+	tag, err := readAt(r, size-id3v1Size, id3v1Size)
+	if err != nil || !bytes.HasPrefix(tag, []byte("TAG")) {
+		return false
+	}
+
+	field := func(b []byte) string {
+		if i := bytes.IndexByte(b, 0); i >= 0 {
+			b = b[:i]
+		}
+		return strings.TrimSpace(decodeText(b, encodingLatin1))
+	}
+	parsed.setTag("title", field(tag[3:33]))
+	parsed.setTag("artist", field(tag[33:63]))
+	parsed.setTag("album", field(tag[63:93]))
+	parsed.setTag("date", field(tag[93:97]))
+	if tag[125] == 0 && tag[126] != 0 {
+		// ID3v1.1 stores the track number at the end of the comment
+		parsed.setTag("comment", field(tag[97:125]))
+		parsed.setTag("track", strconv.Itoa(int(tag[126])))
+	} else {
+		parsed.setTag("comment", field(tag[97:127]))
+	}
+	if genre := int(tag[127]); genre < len(id3Genres) {
+		parsed.setTag("genre", id3Genres[genre])
+	}
+	return true
+}
+
+// id3Genre resolves ID3v1 genre references such as "(17)" or "17" to the genre name.
+func id3Genre(value string) string {
+	if match := id3GenreReference.FindStringSubmatch(value); match != nil {
+		if refinement := strings.TrimSpace(match[2]); refinement != "" {
+			return refinement
+		}
+		value = match[1]
+	}
+	switch value {
+	case "RX":
+		return "Remix"
+	case "CR":
+		return "Cover"
+	}
+	if index, err := strconv.Atoi(value); err == nil && index >= 0 && index < len(id3Genres) {
+		return id3Genres[index]
+	}
+	return value
+}
+
+// decodeTextValues decodes an ID3v2 text frame value, joining the null separated values of ID3v2.4.
+func decodeTextValues(b []byte, encoding byte) string {
+	var values []string
+	for _, value := range strings.Split(decodeText(b, encoding), "\x00") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return strings.Join(values, "; ")
+}
+
+// decodeText decodes b using the given ID3v2 text encoding.
+func decodeText(b []byte, encoding byte) string {
+	switch encoding {
+	case encodingUTF16, encodingUTF16BE:
+		order := binary.ByteOrder(binary.BigEndian)
+		if encoding == encodingUTF16 {
+			// Writers without a byte order mark are almost always little endian
+			order = binary.LittleEndian
+		}
+		if len(b) >= 2 {
+			switch {
+			case b[0] == 0xFF && b[1] == 0xFE:
+				order, b = binary.LittleEndian, b[2:]
+			case b[0] == 0xFE && b[1] == 0xFF:
+				order, b = binary.BigEndian, b[2:]
+			}
+		}
+		units := make([]uint16, 0, len(b)/2)
+		for i := 0; i+1 < len(b); i += 2 {
+			if unit := order.Uint16(b[i:]); unit != 0xFEFF {
+				units = append(units, unit)
+			}
+		}
+		return strings.TrimRight(string(utf16.Decode(units)), "\x00")
+	case encodingUTF8:
+		return strings.TrimRight(string(b), "\x00")
+	default:
+		runes := make([]rune, len(b))
+		for i, c := range b {
+			runes[i] = rune(c)
+		}
+		return strings.TrimRight(string(runes), "\x00")
+	}
+}
+
+// splitTerminated splits b after the first string terminator of the given encoding.
+func splitTerminated(b []byte, encoding byte) ([]byte, []byte) {
+	if encoding == encodingUTF16 || encoding == encodingUTF16BE {
+		for i := 0; i+1 < len(b); i += 2 {
+			if b[i] == 0 && b[i+1] == 0 {
+				return b[:i], b[i+2:]
+			}
+		}
+		return b, nil
+	}
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		return b[:i], b[i+1:]
+	}
+	return b, nil
+}
+
+// syncsafe decodes a 28 bit integer stored in 4 bytes using 7 bits each.
+func syncsafe(b []byte) int {
+	return int(b[0]&0x7F)<<21 | int(b[1]&0x7F)<<14 | int(b[2]&0x7F)<<7 | int(b[3]&0x7F)
+}
+
+// removeUnsynchronisation drops the zero bytes inserted after 0xFF by ID3v2 unsynchronisation.
+func removeUnsynchronisation(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+	for i := 0; i < len(b); i++ {
+		out = append(out, b[i])
+		if b[i] == 0xFF && i+1 < len(b) && b[i+1] == 0 {
+			i++
+		}
+	}
+	return out
+}
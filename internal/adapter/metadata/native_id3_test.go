@@ -0,0 +1,304 @@
+package metadata
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"testing"
+)
+
+// pngHeader is the start of a PNG file, enough for a picture fixture.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func syncsafeBytes(n int) []byte {
+	return []byte{byte(n >> 21 & 0x7F), byte(n >> 14 & 0x7F), byte(n >> 7 & 0x7F), byte(n & 0x7F)}
+}
+
+// id3Tag builds an ID3v2 tag holding frames, unsynchronising them when flags ask for it before ID3v2.4.
+func id3Tag(major byte, flags byte, frames ...[]byte) []byte {
+	body := bytes.Join(frames, nil)
+	if flags&0x80 != 0 && major < 4 {
+		body = addUnsynchronisation(body)
+	}
+	tag := append([]byte{'I', 'D', '3', major, 0, flags}, syncsafeBytes(len(body))...)
+	return append(tag, body...)
+}
+
+func id3v22Frame(id string, data []byte) []byte {
+	frame := append([]byte(id), byte(len(data)>>16), byte(len(data)>>8), byte(len(data)))
+	return append(frame, data...)
+}
+
+func id3v23Frame(id string, flags uint16, data []byte) []byte {
+	frame := binary.BigEndian.AppendUint32([]byte(id), uint32(len(data)))
+	frame = binary.BigEndian.AppendUint16(frame, flags)
+	return append(frame, data...)
+}
+
+func id3v24Frame(id string, flags uint16, data []byte) []byte {
+	frame := append([]byte(id), syncsafeBytes(len(data))...)
+	frame = binary.BigEndian.AppendUint16(frame, flags)
+	return append(frame, data...)
+}
+
+// textFrame builds the content of a Latin-1 text frame.
+func textFrame(text string) []byte {
+	return append([]byte{encodingLatin1}, text...)
+}
+
+// addUnsynchronisation inserts a zero byte after every 0xFF, as ID3v2 unsynchronisation does.
+func addUnsynchronisation(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+	for _, c := range b {
+		out = append(out, c)
+		if c == 0xFF {
+			out = append(out, 0)
+		}
+	}
+	return out
+}
+
+func zlibCompress(b []byte) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, _ = zw.Write(b)
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+func TestParseID3v2(t *testing.T) {
+	title := textFrame("Airbag")
+	tests := []struct {
+		name            string
+		content         []byte
+		expectedTags    map[string]string
+		expectedSize    int64 // checked when not 0
+		expectedPicture string
+		expectedError   bool
+	}{
+		{
+			name:         "id3v2.3 text frames",
+			content:      id3Tag(3, 0, id3v23Frame("TIT2", 0, title), id3v23Frame("TPE1", 0, textFrame("Radiohead"))),
+			expectedTags: map[string]string{"title": "Airbag", "artist": "Radiohead"},
+			expectedSize: 10 + 2*10 + 7 + 10,
+		},
+		{
+			name:         "id3v2.4 multiple values",
+			content:      id3Tag(4, 0, id3v24Frame("TPE1", 0, textFrame("Thom\x00Jonny"))),
+			expectedTags: map[string]string{"artist": "Thom; Jonny"},
+		},
+		{
+			name:         "id3v2.2 frames and genre reference",
+			content:      id3Tag(2, 0, id3v22Frame("TT2", title), id3v22Frame("TCO", textFrame("(17)"))),
+			expectedTags: map[string]string{"title": "Airbag", "genre": "Rock"},
+		},
+		{
+			name:         "id3v2.3 tag unsynchronisation",
+			content:      id3Tag(3, 0x80, id3v23Frame("TIT2", 0, textFrame("\xffx"))),
+			expectedTags: map[string]string{"title": "ÿx"},
+		},
+		{
+			name:         "id3v2.4 frame unsynchronisation",
+			content:      id3Tag(4, 0, id3v24Frame("TIT2", 0x0002, addUnsynchronisation(textFrame("\xffx")))),
+			expectedTags: map[string]string{"title": "ÿx"},
+		},
+		{
+			name:         "id3v2.4 compressed frame with data length indicator",
+			content:      id3Tag(4, 0, id3v24Frame("TIT2", 0x0009, append(syncsafeBytes(len(title)), zlibCompress(title)...))),
+			expectedTags: map[string]string{"title": "Airbag"},
+		},
+		{
+			name:         "id3v2.4 compressed frame larger than declared skipped",
+			content:      id3Tag(4, 0, id3v24Frame("TIT2", 0x0009, append(syncsafeBytes(3), zlibCompress(title)...))),
+			expectedTags: map[string]string{},
+		},
+		{
+			name:         "id3v2.3 compressed frame",
+			content:      id3Tag(3, 0, id3v23Frame("TIT2", 0x0080, append(binary.BigEndian.AppendUint32(nil, uint32(len(title))), zlibCompress(title)...))),
+			expectedTags: map[string]string{"title": "Airbag"},
+		},
+		{
+			name:         "id3v2.3 compressed frame larger than declared skipped",
+			content:      id3Tag(3, 0, id3v23Frame("TIT2", 0x0080, append(binary.BigEndian.AppendUint32(nil, 2), zlibCompress(title)...))),
+			expectedTags: map[string]string{},
+		},
+		{
+			name:         "compressed frame without its size skipped",
+			content:      id3Tag(3, 0, id3v23Frame("TIT2", 0x0080, []byte{0, 0})),
+			expectedTags: map[string]string{},
+		},
+		{
+			name:         "encrypted frame skipped",
+			content:      id3Tag(3, 0, id3v23Frame("TIT2", 0x0040, title), id3v23Frame("TPE1", 0, textFrame("Radiohead"))),
+			expectedTags: map[string]string{"artist": "Radiohead"},
+		},
+		{
+			name: "frame larger than the tag ends the frames",
+			content: id3Tag(3, 0, id3v23Frame("TIT2", 0, title),
+				binary.BigEndian.AppendUint32([]byte("TPE1"), 1000), []byte{0, 0, 0, 'x'}),
+			expectedTags: map[string]string{"title": "Airbag"},
+		},
+		{
+			name:         "extended header larger than the tag",
+			content:      id3Tag(3, 0x40, []byte{0, 0, 0x03, 0xE8}, id3v23Frame("TIT2", 0, title)),
+			expectedTags: map[string]string{},
+		},
+		{
+			name:         "unknown version skipped",
+			content:      id3Tag(5, 0, id3v24Frame("TIT2", 0, title)),
+			expectedTags: map[string]string{},
+			expectedSize: 10 + 10 + 7,
+		},
+		{
+			name:          "truncated header",
+			content:       []byte("ID3\x03\x00"),
+			expectedError: true,
+		},
+		{
+			name:          "tag larger than the file",
+			content:       append([]byte{'I', 'D', '3', 3, 0, 0, 0x7F, 0x7F, 0x7F, 0x7F}, title...),
+			expectedError: true,
+		},
+		{
+			name: "front cover",
+			content: id3Tag(3, 0, id3v23Frame("APIC", 0, bytes.Join([][]byte{
+				{encodingLatin1}, []byte("image/png\x00"), {pictureTypeFrontCover}, []byte("cover\x00"), pngHeader,
+			}, nil))),
+			expectedTags:    map[string]string{},
+			expectedPicture: "image/png",
+		},
+		{
+			name: "comment and lyrics by language",
+			content: id3Tag(3, 0,
+				id3v23Frame("COMM", 0, append([]byte{encodingLatin1, 'e', 'n', 'g', 0}, "Great"...)),
+				id3v23Frame("USLT", 0, append([]byte{encodingLatin1, 'e', 'n', 'g', 0}, "Words"...))),
+			expectedTags: map[string]string{"comment": "Great", "lyrics-eng": "Words"},
+		},
+		{
+			name: "synced lyrics in milliseconds",
+			content: id3Tag(3, 0, id3v23Frame("SYLT", 0, bytes.Join([][]byte{
+				{encodingLatin1, 'e', 'n', 'g', 2, 1, 0},
+				[]byte("Line one\x00"), binary.BigEndian.AppendUint32(nil, 1500),
+				[]byte("Line two\x00"), binary.BigEndian.AppendUint32(nil, 62000),
+			}, nil))),
+			expectedTags: map[string]string{"syncedlyrics-eng": "[00:01.50]Line one\n[01:02.00]Line two"},
+		},
+		{
+			name: "utf-16 text with byte order mark",
+			content: id3Tag(3, 0, id3v23Frame("TIT2", 0, []byte{
+				encodingUTF16, 0xFE, 0xFF, 0, 'A', 0, 'i', 0, 'r', 0, 0,
+			})),
+			expectedTags: map[string]string{"title": "Air"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := newParsedFile()
+
+			size, err := parseID3v2(bytes.NewReader(tt.content), int64(len(tt.content)), parsed)
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectedSize != 0 && size != tt.expectedSize {
+				t.Errorf("expected tag size %d, got %d", tt.expectedSize, size)
+			}
+			if !equalTags(parsed.tags, tt.expectedTags) {
+				t.Errorf("expected tags %v, got %v", tt.expectedTags, parsed.tags)
+			}
+			switch {
+			case tt.expectedPicture == "" && parsed.picture != nil:
+				t.Errorf("expected no picture, got %s", parsed.picture.MimeType)
+			case tt.expectedPicture != "" && (parsed.picture == nil || parsed.picture.MimeType != tt.expectedPicture):
+				t.Errorf("expected a %s picture, got %+v", tt.expectedPicture, parsed.picture)
+			}
+		})
+	}
+}
+
+func TestParseID3v1(t *testing.T) {
+	tag := func(fill func(tag []byte)) []byte {
+		tag := make([]byte, id3v1Size)
+		copy(tag, "TAG")
+		fill(tag)
+		return append([]byte("audio"), tag...)
+	}
+	tests := []struct {
+		name         string
+		content      []byte
+		expectedTags map[string]string
+		expectedTag  bool
+	}{
+		{
+			name: "id3v1.1 with track number",
+			content: tag(func(tag []byte) {
+				copy(tag[3:], "Airbag")
+				copy(tag[33:], "Radiohead")
+				copy(tag[93:], "1997")
+				copy(tag[97:], "Great")
+				tag[126], tag[127] = 1, 17
+			}),
+			expectedTags: map[string]string{"title": "Airbag", "artist": "Radiohead", "date": "1997", "comment": "Great", "track": "1", "genre": "Rock"},
+			expectedTag:  true,
+		},
+		{
+			name: "unknown genre",
+			content: tag(func(tag []byte) {
+				copy(tag[3:], "Airbag")
+				tag[127] = 0xFF
+			}),
+			expectedTags: map[string]string{"title": "Airbag"},
+			expectedTag:  true,
+		},
+		{
+			name:         "no tag",
+			content:      bytes.Repeat([]byte{0}, 200),
+			expectedTags: map[string]string{},
+		},
+		{
+			name:         "file shorter than a tag",
+			content:      []byte("TAG"),
+			expectedTags: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := newParsedFile()
+
+			found := parseID3v1(bytes.NewReader(tt.content), int64(len(tt.content)), parsed)
+
+			if found != tt.expectedTag {
+				t.Errorf("expected tag found %v, got %v", tt.expectedTag, found)
+			}
+			if !equalTags(parsed.tags, tt.expectedTags) {
+				t.Errorf("expected tags %v, got %v", tt.expectedTags, parsed.tags)
+			}
+		})
+	}
+}
+
+func FuzzParseID3v2(f *testing.F) {
+	f.Add(id3Tag(3, 0x80, id3v23Frame("TIT2", 0, textFrame("\xffx"))))
+	f.Add(id3Tag(4, 0x40, []byte{0, 0, 0, 6, 1, 0}, id3v24Frame("TIT2", 0x0009, append(syncsafeBytes(7), zlibCompress(textFrame("Airbag"))...))))
+	f.Add(id3Tag(2, 0, id3v22Frame("PIC", append([]byte{encodingLatin1, 'P', 'N', 'G', 3, 0}, pngHeader...))))
+	f.Fuzz(func(t *testing.T, content []byte) {
+		_, _ = parseID3v2(bytes.NewReader(content), int64(len(content)), newParsedFile())
+	})
+}
+
+func FuzzParseID3v2Frames(f *testing.F) {
+	f.Add(id3v23Frame("TIT2", 0x0080, append(binary.BigEndian.AppendUint32(nil, 7), zlibCompress(textFrame("Airbag"))...)), byte(3))
+	f.Add(id3v24Frame("SYLT", 0x0002, []byte{encodingUTF16, 'e', 'n', 'g', 2, 1, 0, 0, 0xFF, 0, 0, 0, 0, 0}), byte(4))
+	f.Add(id3v22Frame("COM", []byte{encodingLatin1, 'e', 'n', 'g'}), byte(2))
+	f.Fuzz(func(t *testing.T, body []byte, major byte) {
+		parseID3v2Frames(body, 2+major%3, newParsedFile())
+	})
+}
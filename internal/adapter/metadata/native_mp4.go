@@ -0,0 +1,230 @@
+package metadata
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// mp4MoovLimit bounds the size of the movie atom read in memory, it may embed pictures.
+	mp4MoovLimit = 64 << 20
+
+	// MP4 data atom types of cover art
+	mp4DataJPEG = 13
+	mp4DataPNG  = 14
+	mp4DataBMP  = 27
+)
+
+// mp4ItemKeys maps the iTunes metadata items to the tag keys reported by ffprobe.
+var mp4ItemKeys = map[string]string{
+	"\xa9nam": "title",
+	"\xa9ART": "artist",
+	"\xa9alb": "album",
+	"aART":    "album_artist",
+	"\xa9day": "date",
+	"\xa9gen": "genre",
+	"\xa9wrt": "composer",
+	"\xa9cmt": "comment",
+	"\xa9lyr": "lyrics",
+	"\xa9grp": "grouping",
+	"\xa9too": "encoder",
+	"cprt":    "copyright",
+	"sonm":    "title-sort",
+	"soar":    "artist-sort",
+	"soal":    "album-sort",
+	"soaa":    "album_artist-sort",
+	"soco":    "composer-sort",
+}
+
+// parseMP4 reads the iTunes metadata and the duration from the movie atom of an MP4 file.
+func parseMP4(r io.ReaderAt, size int64, parsed *parsedFile) error {
+	var (
+		pos       int64
+		mediaSize int64
+		timescale int64
+		duration  int64
+	)
+
+	for pos+8 <= size {
+		header, err := readAt(r, pos, int(min(16, size-pos)))
+		if err != nil {
+			return err
+		}
+		atomSize := int64(binary.BigEndian.Uint32(header[0:4]))
+		atomType := string(header[4:8])
+		headerSize := int64(8)
+		switch atomSize {
+		case 0:
+			atomSize = size - pos
+		case 1:
+			if len(header) < 16 {
+				return fmt.Errorf("malformed mp4 atom %q", atomType)
+			}
+			atomSize = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerSize = 16
+		}
+		if atomSize < headerSize || atomSize > size-pos {
+			return fmt.Errorf("malformed mp4 atom %q", atomType)
+		}
+
+		switch atomType {
+		case "moov":
+			if atomSize-headerSize > mp4MoovLimit {
+				return fmt.Errorf("mp4 movie atom exceeds %d bytes", mp4MoovLimit)
+			}
+			moov, err := readAt(r, pos+headerSize, int(atomSize-headerSize))
+			if err != nil {
+				return err
+			}
+			timescale, duration = parseMP4Moov(moov, parsed)
+		case "mdat":
+			mediaSize += atomSize - headerSize
+		}
+		pos += atomSize
+	}
+
+	if timescale > 0 && duration > 0 {
+		parsed.duration = float64(duration) / float64(timescale)
+		if mediaSize == 0 {
+			mediaSize = size
+		}
+		parsed.bitRate = bitRateFor(mediaSize, parsed.duration)
+	}
+	return nil
+}
+
+// parseMP4Moov reads the metadata items of the movie atom content and returns its timescale and duration.
+func parseMP4Moov(moov []byte, parsed *parsedFile) (int64, int64) {
+	var timescale, duration int64
+	mp4Atoms(moov, func(atomType string, data []byte) {
+		switch atomType {
+		case "mvhd":
+			if len(data) >= 32 && data[0] == 1 {
+				timescale = int64(binary.BigEndian.Uint32(data[20:24]))
+				duration = int64(binary.BigEndian.Uint64(data[24:32]))
+			} else if len(data) >= 20 {
+				timescale = int64(binary.BigEndian.Uint32(data[12:16]))
+				duration = int64(binary.BigEndian.Uint32(data[16:20]))
+			}
+		case "udta":
+			mp4Atoms(data, func(atomType string, data []byte) {
+				if atomType == "meta" {
+					parseMP4Meta(data, parsed)
+				}
+			})
+		case "meta":
+			parseMP4Meta(data, parsed)
+		}
+	})
+	return timescale, duration
+}
+
+func parseMP4Meta(meta []byte, parsed *parsedFile) {
+	// The meta atom is a full atom in MP4 files but not in QuickTime ones
+	if len(meta) >= 8 && string(meta[4:8]) != "hdlr" {
+		meta = meta[4:]
+	}
+	mp4Atoms(meta, func(atomType string, ilst []byte) {
+		if atomType != "ilst" {
+			return
+		}
+		mp4Atoms(ilst, func(item string, content []byte) {
+			parseMP4Item(item, content, parsed)
+		})
+	})
+}
+
+func parseMP4Item(item string, content []byte, parsed *parsedFile) {
+	var (
+		name     string
+		dataType uint32
+		value    []byte
+	)
+	mp4Atoms(content, func(atomType string, data []byte) {
+		switch atomType {
+		case "name":
+			if len(data) > 4 {
+				name = string(data[4:])
+			}
+		case "data":
+			if len(data) >= 8 && value == nil {
+				dataType = binary.BigEndian.Uint32(data[0:4]) & 0x00FFFFFF
+				value = data[8:]
+			}
+		}
+	})
+	if value == nil {
+		return
+	}
+
+	switch item {
+	case "----":
+		// Free form items such as com.apple.iTunes:MusicBrainz Track Id
+		parsed.setTag(name, string(value))
+	case "trkn", "disk":
+		if len(value) < 6 {
+			return
+		}
+		number := strconv.Itoa(int(binary.BigEndian.Uint16(value[2:4])))
+		if total := binary.BigEndian.Uint16(value[4:6]); total > 0 {
+			number += "/" + strconv.Itoa(int(total))
+		}
+		key := "track"
+		if item == "disk" {
+			key = "disc"
+		}
+		parsed.setTag(key, number)
+	case "cpil":
+		if len(value) > 0 && value[0] != 0 {
+			parsed.setTag("compilation", "1")
+		}
+	case "gnre":
+		if len(value) >= 2 {
+			if index := int(binary.BigEndian.Uint16(value[0:2])) - 1; index >= 0 && index < len(id3Genres) {
+				parsed.setTag("genre", id3Genres[index])
+			}
+		}
+	case "covr":
+		mimeType := ""
+		switch dataType {
+		case mp4DataJPEG:
+			mimeType = "image/jpeg"
+		case mp4DataPNG:
+			mimeType = "image/png"
+		case mp4DataBMP:
+			mimeType = "image/bmp"
+		}
+		parsed.setPicture(mimeType, value, pictureTypeFrontCover)
+	default:
+		if key, ok := mp4ItemKeys[item]; ok {
+			parsed.setTag(key, strings.TrimRight(string(value), "\x00"))
+		}
+	}
+}
+
+// mp4Atoms calls fn with the type and content of each atom in b.
+func mp4Atoms(b []byte, fn func(atomType string, data []byte)) {
+	for len(b) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(b[0:4]))
+		atomType := string(b[4:8])
+		headerSize := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(b))
+		case 1:
+			if len(b) < 16 {
+				return
+			}
+			size = binary.BigEndian.Uint64(b[8:16])
+			headerSize = 16
+		}
+		if size < headerSize || size > uint64(len(b)) {
+			return
+		}
+		fn(atomType, b[headerSize:size])
+		b = b[size:]
+	}
+}
@@ -0,0 +1,185 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// mp4Atom builds an atom from its type and the concatenation of its content.
+func mp4Atom(atomType string, content ...[]byte) []byte {
+	data := bytes.Join(content, nil)
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(data)))
+	b = append(b, atomType...)
+	return append(b, data...)
+}
+
+// mp4Data builds a data atom holding value.
+func mp4Data(dataType uint32, value []byte) []byte {
+	return mp4Atom("data", binary.BigEndian.AppendUint32(nil, dataType), make([]byte, 4), value)
+}
+
+// mp4Mvhd builds a version 0 movie header atom.
+func mp4Mvhd(timescale, duration uint32) []byte {
+	data := make([]byte, 100)
+	binary.BigEndian.PutUint32(data[12:16], timescale)
+	binary.BigEndian.PutUint32(data[16:20], duration)
+	return mp4Atom("mvhd", data)
+}
+
+// mp4Meta builds a full meta atom holding the metadata items.
+func mp4Meta(items ...[]byte) []byte {
+	return mp4Atom("meta", make([]byte, 4), mp4Atom("hdlr", make([]byte, 25)), mp4Atom("ilst", items...))
+}
+
+func TestParseMP4(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          []byte
+		expectedTags     map[string]string
+		expectedDuration float64
+		expectedBitRate  int
+		expectedPicture  string
+		expectedError    bool
+	}{
+		{
+			name: "metadata items in the user data atom",
+			content: bytes.Join([][]byte{
+				mp4Atom("ftyp", []byte("M4A \x00\x00\x00\x00")),
+				mp4Atom("moov",
+					mp4Mvhd(1000, 8000),
+					mp4Atom("udta", mp4Meta(
+						mp4Atom("\xa9nam", mp4Data(1, []byte("Airbag"))),
+						mp4Atom("trkn", mp4Data(0, []byte{0, 0, 0, 1, 0, 12, 0, 0})),
+						mp4Atom("disk", mp4Data(0, []byte{0, 0, 0, 1, 0, 0})),
+						mp4Atom("cpil", mp4Data(21, []byte{1})),
+						mp4Atom("gnre", mp4Data(0, []byte{0, 18})),
+						mp4Atom("----",
+							mp4Atom("mean", make([]byte, 4), []byte("com.apple.iTunes")),
+							mp4Atom("name", make([]byte, 4), []byte("MusicBrainz Track Id")),
+							mp4Data(1, []byte("0a1b"))),
+						mp4Atom("covr", mp4Data(mp4DataPNG, pngHeader)),
+						mp4Atom("xxxx", mp4Data(1, []byte("unknown"))),
+					)),
+				),
+				mp4Atom("mdat", make([]byte, 16000)),
+			}, nil),
+			expectedTags: map[string]string{
+				"title":                "Airbag",
+				"track":                "1/12",
+				"disc":                 "1",
+				"compilation":          "1",
+				"genre":                "Rock",
+				"musicbrainz track id": "0a1b",
+			},
+			expectedDuration: 8,
+			expectedBitRate:  16000,
+			expectedPicture:  "image/png",
+		},
+		{
+			name: "metadata in the movie atom of a QuickTime file",
+			content: mp4Atom("moov",
+				mp4Atom("meta", mp4Atom("hdlr", make([]byte, 25)), mp4Atom("ilst",
+					mp4Atom("\xa9ART", mp4Data(1, []byte("Radiohead\x00"))),
+				)),
+			),
+			expectedTags: map[string]string{"artist": "Radiohead"},
+		},
+		{
+			name: "64-bit atom size",
+			content: bytes.Join([][]byte{
+				{0, 0, 0, 1}, []byte("free"), binary.BigEndian.AppendUint64(nil, 24), make([]byte, 8),
+				mp4Atom("moov", mp4Mvhd(1000, 2000)),
+			}, nil),
+			expectedTags:     map[string]string{},
+			expectedDuration: 2,
+		},
+		{
+			name:          "atom larger than the file",
+			content:       append(binary.BigEndian.AppendUint32(nil, 1000), "moov"...),
+			expectedError: true,
+		},
+		{
+			name:          "64-bit atom size overflowing",
+			content:       bytes.Join([][]byte{{0, 0, 0, 1}, []byte("free"), {0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xF0}}, nil),
+			expectedError: true,
+		},
+		{
+			name:          "atom smaller than its header",
+			content:       append(binary.BigEndian.AppendUint32(nil, 4), "moov"...),
+			expectedError: true,
+		},
+		{
+			name:          "truncated 64-bit atom header",
+			content:       append([]byte{0, 0, 0, 1}, "moov\x00\x00"...),
+			expectedError: true,
+		},
+		{
+			name: "oversized items inside the movie atom skipped",
+			content: mp4Atom("moov",
+				mp4Mvhd(1000, 2000),
+				mp4Atom("udta", append(binary.BigEndian.AppendUint32(nil, 1000), "meta"...)),
+			),
+			expectedTags:     map[string]string{},
+			expectedDuration: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := newParsedFile()
+
+			err := parseMP4(bytes.NewReader(tt.content), int64(len(tt.content)), parsed)
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equalTags(parsed.tags, tt.expectedTags) {
+				t.Errorf("expected tags %v, got %v", tt.expectedTags, parsed.tags)
+			}
+			if parsed.duration != tt.expectedDuration {
+				t.Errorf("expected duration %v, got %v", tt.expectedDuration, parsed.duration)
+			}
+			if tt.expectedBitRate != 0 && parsed.bitRate != tt.expectedBitRate {
+				t.Errorf("expected bit rate %d, got %d", tt.expectedBitRate, parsed.bitRate)
+			}
+			if tt.expectedPicture != "" && (parsed.picture == nil || parsed.picture.MimeType != tt.expectedPicture) {
+				t.Errorf("expected a %s picture, got %+v", tt.expectedPicture, parsed.picture)
+			}
+		})
+	}
+}
+
+func FuzzParseMP4(f *testing.F) {
+	f.Add(bytes.Join([][]byte{
+		mp4Atom("ftyp", []byte("M4A \x00\x00\x00\x00")),
+		mp4Atom("moov", mp4Mvhd(1000, 8000), mp4Atom("udta", mp4Meta(
+			mp4Atom("\xa9nam", mp4Data(1, []byte("Airbag"))),
+			mp4Atom("trkn", mp4Data(0, []byte{0, 0, 0, 1, 0, 12, 0, 0})),
+		))),
+		mp4Atom("mdat", make([]byte, 16)),
+	}, nil))
+	f.Fuzz(func(t *testing.T, content []byte) {
+		_ = parseMP4(bytes.NewReader(content), int64(len(content)), newParsedFile())
+	})
+}
+
+func FuzzParseMP4Moov(f *testing.F) {
+	f.Add(bytes.Join([][]byte{
+		mp4Mvhd(1000, 8000),
+		mp4Meta(
+			mp4Atom("----", mp4Atom("name", make([]byte, 4), []byte("ISRC")), mp4Data(1, []byte("GBAYE"))),
+			mp4Atom("covr", mp4Data(mp4DataJPEG, []byte{0xFF, 0xD8})),
+			mp4Atom("gnre", mp4Data(0, []byte{0, 18})),
+		),
+	}, nil))
+	f.Fuzz(func(t *testing.T, moov []byte) {
+		parseMP4Moov(moov, newParsedFile())
+	})
+}
@@ -0,0 +1,165 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// mpegSyncSearchSize bounds how far after the tags the first MPEG frame is searched for.
+const mpegSyncSearchSize = 64 * 1024
+
+var (
+	// mpegBitRates lists the bit rates in kbps by MPEG version (1 or 2), layer and bit rate index.
+	mpegBitRates = map[int]map[int][]int{
+		1: {
+			1: {0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+			2: {0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+			3: {0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+		},
+		2: {
+			1: {0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+			2: {0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+			3: {0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		},
+	}
+
+	// mpegSampleRates lists the sample rates by version bits of the frame header.
+	mpegSampleRates = map[byte][]int{
+		0: {11025, 12000, 8000},  // MPEG 2.5
+		2: {22050, 24000, 16000}, // MPEG 2
+		3: {44100, 48000, 32000}, // MPEG 1
+	}
+)
+
+// mpegFrame holds the properties decoded from an MPEG audio frame header.
+type mpegFrame struct {
+	mpeg1           bool
+	layer           int
+	bitRate         int
+	sampleRate      int
+	samplesPerFrame int
+	size            int
+	mono            bool
+}
+
+// parseMPEGFile reads the ID3v1 tag and the audio properties of an MPEG audio stream starting at offset.
+func parseMPEGFile(r io.ReaderAt, offset int64, size int64, parsed *parsedFile) error {
+	end := size
+	if parseID3v1(r, size, parsed) {
+		end -= id3v1Size
+	}
+	if end-offset < 4 {
+		return nil
+	}
+
+	buf, err := readAt(r, offset, int(min(end-offset, mpegSyncSearchSize)))
+	if err != nil {
+		return err
+	}
+	for i := 0; i+4 <= len(buf); i++ {
+		frame, ok := parseMPEGFrameHeader(buf[i:])
+		if !ok {
+			continue
+		}
+		// Confirm the sync with the following frame when it is in the buffer
+		if next := i + frame.size; next+4 <= len(buf) {
+			if _, ok := parseMPEGFrameHeader(buf[next:]); !ok {
+				continue
+			}
+		}
+
+		audioSize := end - offset - int64(i)
+		frames, frameBytes := mpegVBRHeader(buf[i:], frame)
+		if frames > 0 {
+			parsed.duration = float64(frames) * float64(frame.samplesPerFrame) / float64(frame.sampleRate)
+			if frameBytes > 0 {
+				audioSize = frameBytes
+			}
+		} else {
+			parsed.duration = float64(audioSize) * 8 / float64(frame.bitRate)
+		}
+		parsed.bitRate = bitRateFor(audioSize, parsed.duration)
+		return nil
+	}
+	return nil
+}
+
+// parseMPEGFrameHeader decodes the 4 byte frame header at the start of b.
+func parseMPEGFrameHeader(b []byte) (mpegFrame, bool) {
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return mpegFrame{}, false
+	}
+	versionBits := (b[1] >> 3) & 0x03
+	layerBits := (b[1] >> 1) & 0x03
+	bitRateIndex := int(b[2] >> 4)
+	sampleRateIndex := int((b[2] >> 2) & 0x03)
+	padding := int((b[2] >> 1) & 0x01)
+	if versionBits == 1 || layerBits == 0 || bitRateIndex == 0 || bitRateIndex == 15 || sampleRateIndex == 3 {
+		return mpegFrame{}, false
+	}
+
+	frame := mpegFrame{
+		mpeg1:      versionBits == 3,
+		layer:      4 - int(layerBits),
+		sampleRate: mpegSampleRates[versionBits][sampleRateIndex],
+		mono:       b[3]>>6 == 3,
+	}
+	version := 2
+	if frame.mpeg1 {
+		version = 1
+	}
+	frame.bitRate = mpegBitRates[version][frame.layer][bitRateIndex] * 1000
+
+	switch {
+	case frame.layer == 1:
+		frame.samplesPerFrame = 384
+		frame.size = (12*frame.bitRate/frame.sampleRate + padding) * 4
+	case frame.layer == 3 && !frame.mpeg1:
+		frame.samplesPerFrame = 576
+		frame.size = 72*frame.bitRate/frame.sampleRate + padding
+	default:
+		frame.samplesPerFrame = 1152
+		frame.size = 144*frame.bitRate/frame.sampleRate + padding
+	}
+	return frame, frame.size > 4
+}
+
+// mpegVBRHeader reads the Xing, Info or VBRI header of the first frame in b.
+// Returns the number of frames and bytes of the stream, or zero when not present.
+func mpegVBRHeader(b []byte, frame mpegFrame) (int64, int64) {
+	if frame.layer != 3 {
+		return 0, 0
+	}
+
+	sideInfo := 17
+	switch {
+	case frame.mpeg1 && !frame.mono:
+		sideInfo = 32
+	case !frame.mpeg1 && frame.mono:
+		sideInfo = 9
+	}
+	if xing := 4 + sideInfo; xing+16 <= len(b) {
+		tag := b[xing : xing+4]
+		if bytes.Equal(tag, []byte("Xing")) || bytes.Equal(tag, []byte("Info")) {
+			flags := binary.BigEndian.Uint32(b[xing+4:])
+			var frames, size int64
+			pos := xing + 8
+			if flags&0x01 != 0 {
+				frames = int64(binary.BigEndian.Uint32(b[pos:]))
+				pos += 4
+			}
+			if flags&0x02 != 0 && pos+4 <= len(b) {
+				size = int64(binary.BigEndian.Uint32(b[pos:]))
+			}
+			return frames, size
+		}
+	}
+
+	if vbri := 4 + 32; vbri+18 <= len(b) && bytes.Equal(b[vbri:vbri+4], []byte("VBRI")) {
+		size := int64(binary.BigEndian.Uint32(b[vbri+10:]))
+		frames := int64(binary.BigEndian.Uint32(b[vbri+14:]))
+		return frames, size
+	}
+	return 0, 0
+}
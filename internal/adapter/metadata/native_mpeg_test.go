@@ -0,0 +1,181 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// mpegFrameBytes builds an MPEG audio frame of size bytes starting with header and followed by content.
+func mpegFrameBytes(header []byte, size int, content ...[]byte) []byte {
+	frame := make([]byte, size)
+	copy(frame, header)
+	copy(frame[4:], bytes.Join(content, nil))
+	return frame
+}
+
+var (
+	// MPEG 1 layer III, 128 kbps, 44100 Hz, stereo frames are 417 bytes long
+	mpeg1Header     = []byte{0xFF, 0xFB, 0x90, 0x00}
+	mpeg1MonoHeader = []byte{0xFF, 0xFB, 0x90, 0xC0}
+)
+
+func TestParseMPEGFile(t *testing.T) {
+	xing := append([]byte("Xing"), 0, 0, 0, 3)
+	xing = binary.BigEndian.AppendUint32(xing, 1000)
+	xing = binary.BigEndian.AppendUint32(xing, 417000)
+	vbri := append([]byte("VBRI"), make([]byte, 6)...)
+	vbri = binary.BigEndian.AppendUint32(vbri, 208500)
+	vbri = binary.BigEndian.AppendUint32(vbri, 500)
+	id3v1 := make([]byte, id3v1Size)
+	copy(id3v1, "TAGAirbag")
+	id3v1[127] = 0xFF
+
+	tests := []struct {
+		name             string
+		content          []byte
+		offset           int64
+		expectedTags     map[string]string
+		expectedDuration float64
+		expectedBitRate  int
+	}{
+		{
+			name: "constant bit rate after the tags",
+			content: bytes.Join([][]byte{
+				make([]byte, 10),
+				bytes.Repeat(mpegFrameBytes(mpeg1Header, 417), 3),
+			}, nil),
+			offset:           10,
+			expectedTags:     map[string]string{},
+			expectedDuration: float64(3*417) * 8 / 128000,
+			expectedBitRate:  128000,
+		},
+		{
+			name: "xing header",
+			content: bytes.Join([][]byte{
+				mpegFrameBytes(mpeg1Header, 417, make([]byte, 32), xing),
+				mpegFrameBytes(mpeg1Header, 417),
+			}, nil),
+			expectedTags:     map[string]string{},
+			expectedDuration: float64(1000) * 1152 / 44100,
+			expectedBitRate:  bitRateFor(417000, float64(1000)*1152/44100),
+		},
+		{
+			name: "vbri header",
+			content: bytes.Join([][]byte{
+				mpegFrameBytes(mpeg1MonoHeader, 417, make([]byte, 32), vbri),
+				mpegFrameBytes(mpeg1MonoHeader, 417),
+			}, nil),
+			expectedTags:     map[string]string{},
+			expectedDuration: float64(500) * 1152 / 44100,
+			expectedBitRate:  bitRateFor(208500, float64(500)*1152/44100),
+		},
+		{
+			name: "false sync skipped and id3v1 tag left out of the audio",
+			content: bytes.Join([][]byte{
+				mpeg1Header, make([]byte, 100),
+				bytes.Repeat(mpegFrameBytes(mpeg1Header, 417), 2),
+				id3v1,
+			}, nil),
+			expectedTags:     map[string]string{"title": "Airbag"},
+			expectedDuration: float64(2*417) * 8 / 128000,
+			expectedBitRate:  128000,
+		},
+		{
+			name:         "no frame",
+			content:      make([]byte, 1000),
+			expectedTags: map[string]string{},
+		},
+		{
+			name:         "stream shorter than a frame header",
+			content:      []byte{0xFF, 0xFB},
+			expectedTags: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := newParsedFile()
+
+			err := parseMPEGFile(bytes.NewReader(tt.content), tt.offset, int64(len(tt.content)), parsed)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equalTags(parsed.tags, tt.expectedTags) {
+				t.Errorf("expected tags %v, got %v", tt.expectedTags, parsed.tags)
+			}
+			if parsed.duration != tt.expectedDuration {
+				t.Errorf("expected duration %v, got %v", tt.expectedDuration, parsed.duration)
+			}
+			if parsed.bitRate != tt.expectedBitRate {
+				t.Errorf("expected bit rate %d, got %d", tt.expectedBitRate, parsed.bitRate)
+			}
+		})
+	}
+}
+
+func TestParseMPEGFrameHeader(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        []byte
+		expectedFrame mpegFrame
+		expectedOk    bool
+	}{
+		{
+			name:          "mpeg 1 layer III",
+			header:        mpeg1Header,
+			expectedFrame: mpegFrame{mpeg1: true, layer: 3, bitRate: 128000, sampleRate: 44100, samplesPerFrame: 1152, size: 417},
+			expectedOk:    true,
+		},
+		{
+			name:          "mpeg 2 layer III with padding",
+			header:        []byte{0xFF, 0xF3, 0x92, 0xC0},
+			expectedFrame: mpegFrame{layer: 3, bitRate: 80000, sampleRate: 22050, samplesPerFrame: 576, size: 262, mono: true},
+			expectedOk:    true,
+		},
+		{
+			name:          "mpeg 1 layer I",
+			header:        []byte{0xFF, 0xFF, 0x90, 0x00},
+			expectedFrame: mpegFrame{mpeg1: true, layer: 1, bitRate: 288000, sampleRate: 44100, samplesPerFrame: 384, size: 312},
+			expectedOk:    true,
+		},
+		{name: "reserved version", header: []byte{0xFF, 0xEB, 0x90, 0x00}},
+		{name: "reserved layer", header: []byte{0xFF, 0xF9, 0x90, 0x00}},
+		{name: "free bit rate", header: []byte{0xFF, 0xFB, 0x00, 0x00}},
+		{name: "bad bit rate", header: []byte{0xFF, 0xFB, 0xF0, 0x00}},
+		{name: "reserved sample rate", header: []byte{0xFF, 0xFB, 0x9C, 0x00}},
+		{name: "no sync", header: []byte{0xFF, 0x1B, 0x90, 0x00}},
+		{name: "truncated header", header: mpeg1Header[:3]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame, ok := parseMPEGFrameHeader(tt.header)
+
+			if ok != tt.expectedOk {
+				t.Fatalf("expected ok %v, got %v", tt.expectedOk, ok)
+			}
+			if frame != tt.expectedFrame {
+				t.Errorf("expected frame %+v, got %+v", tt.expectedFrame, frame)
+			}
+		})
+	}
+}
+
+func FuzzParseMPEGFile(f *testing.F) {
+	f.Add(bytes.Repeat(mpegFrameBytes(mpeg1Header, 417), 2))
+	f.Add(mpegFrameBytes(mpeg1Header, 417, make([]byte, 32), []byte("Xing\x00\x00\x00\x03\x00\x00\x03\xe8")))
+	f.Fuzz(func(t *testing.T, content []byte) {
+		_ = parseMPEGFile(bytes.NewReader(content), 0, int64(len(content)), newParsedFile())
+	})
+}
+
+func FuzzParseMPEGFrameHeader(f *testing.F) {
+	f.Add(mpeg1Header)
+	f.Fuzz(func(t *testing.T, header []byte) {
+		if frame, ok := parseMPEGFrameHeader(header); ok {
+			mpegVBRHeader(header, frame)
+		}
+	})
+}
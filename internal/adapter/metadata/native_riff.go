@@ -0,0 +1,106 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// riffChunkLimit bounds the size of the metadata chunks read in memory.
+const riffChunkLimit = 16 << 20
+
+// riffInfoKeys maps the RIFF INFO chunks to the tag keys reported by ffprobe.
+var riffInfoKeys = map[string]string{
+	"INAM": "title",
+	"IART": "artist",
+	"IPRD": "album",
+	"ICRD": "date",
+	"IGNR": "genre",
+	"ICMT": "comment",
+	"ITRK": "track",
+	"IPRT": "track",
+	"ICOP": "copyright",
+	"ISFT": "encoder",
+}
+
+// parseWAV reads the INFO list, the ID3 chunk and the audio properties of a RIFF WAVE file.
+func parseWAV(r io.ReaderAt, size int64, parsed *parsedFile) error {
+	var (
+		pos      int64 = 12
+		byteRate int64
+		dataSize int64
+	)
+
+	for pos+8 <= size {
+		header, err := readAt(r, pos, 8)
+		if err != nil {
+			return err
+		}
+		chunkID := string(header[0:4])
+		chunkSize := int64(binary.LittleEndian.Uint32(header[4:8]))
+		body := pos + 8
+		// Streamed files may carry a placeholder size for the data chunk
+		chunkSize = min(chunkSize, size-body)
+
+		switch chunkID {
+		case "fmt ":
+			if chunkSize >= 16 {
+				format, err := readAt(r, body, 16)
+				if err != nil {
+					return err
+				}
+				byteRate = int64(binary.LittleEndian.Uint32(format[8:12]))
+			}
+		case "data":
+			dataSize = chunkSize
+		case "LIST", "id3 ", "ID3 ":
+			if chunkSize > riffChunkLimit {
+				break
+			}
+			chunk, err := readAt(r, body, int(chunkSize))
+			if err != nil {
+				return err
+			}
+			if chunkID == "LIST" {
+				parseRIFFInfo(chunk, parsed)
+			} else if _, err := parseID3v2(bytes.NewReader(chunk), int64(len(chunk)), parsed); err != nil {
+				return err
+			}
+		}
+		// Chunks are word aligned
+		pos = body + chunkSize + chunkSize%2
+	}
+
+	if byteRate > 0 && dataSize > 0 {
+		parsed.duration = float64(dataSize) / float64(byteRate)
+		parsed.bitRate = int(byteRate * 8)
+	}
+	return nil
+}
+
+// parseRIFFInfo reads the tags of a LIST chunk of type INFO.
+func parseRIFFInfo(list []byte, parsed *parsedFile) {
+	if !bytes.HasPrefix(list, []byte("INFO")) {
+		return
+	}
+	list = list[4:]
+	for len(list) >= 8 {
+		id := string(list[0:4])
+		size := int(binary.LittleEndian.Uint32(list[4:8]))
+		if 8+size > len(list) {
+			return
+		}
+		if key, ok := riffInfoKeys[id]; ok {
+			value := list[8 : 8+size]
+			if i := bytes.IndexByte(value, 0); i >= 0 {
+				value = value[:i]
+			}
+			parsed.setTag(key, string(value))
+		}
+		next := 8 + size + size%2
+		if next > len(list) {
+			return
+		}
+		list = list[next:]
+	}
+}
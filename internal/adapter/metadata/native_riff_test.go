@@ -0,0 +1,159 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// riffChunk builds a chunk from its id and the concatenation of its content, padded to a word boundary.
+func riffChunk(id string, content ...[]byte) []byte {
+	data := bytes.Join(content, nil)
+	b := append([]byte(id), binary.LittleEndian.AppendUint32(nil, uint32(len(data)))...)
+	b = append(b, data...)
+	if len(data)%2 == 1 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// wavFile builds a RIFF WAVE file holding the chunks.
+func wavFile(chunks ...[]byte) []byte {
+	data := bytes.Join(chunks, nil)
+	b := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(4+len(data)))...)
+	b = append(b, "WAVE"...)
+	return append(b, data...)
+}
+
+// wavFormat builds the content of a PCM fmt chunk of 16 bit stereo audio at 44100 Hz.
+func wavFormat() []byte {
+	format := make([]byte, 16)
+	binary.LittleEndian.PutUint16(format[0:2], 1)
+	binary.LittleEndian.PutUint16(format[2:4], 2)
+	binary.LittleEndian.PutUint32(format[4:8], 44100)
+	binary.LittleEndian.PutUint32(format[8:12], 176400)
+	binary.LittleEndian.PutUint16(format[12:14], 4)
+	binary.LittleEndian.PutUint16(format[14:16], 16)
+	return format
+}
+
+func TestParseWAV(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          []byte
+		expectedTags     map[string]string
+		expectedDuration float64
+		expectedBitRate  int
+		expectedError    bool
+	}{
+		{
+			name: "info list with odd sized values",
+			content: wavFile(
+				riffChunk("fmt ", wavFormat()),
+				riffChunk("LIST", []byte("INFO"),
+					riffChunk("INAM", []byte("Airbag\x00")),
+					riffChunk("IART", []byte("Radiohead\x00")),
+					riffChunk("IXXX", []byte("unknown")),
+				),
+				riffChunk("data", make([]byte, 17640)),
+			),
+			expectedTags:     map[string]string{"title": "Airbag", "artist": "Radiohead"},
+			expectedDuration: 0.1,
+			expectedBitRate:  1411200,
+		},
+		{
+			name: "id3 chunk",
+			content: wavFile(
+				riffChunk("fmt ", wavFormat()),
+				riffChunk("data", make([]byte, 176400)),
+				riffChunk("id3 ", id3Tag(3, 0, id3v23Frame("TIT2", 0, textFrame("Airbag")))),
+			),
+			expectedTags:     map[string]string{"title": "Airbag"},
+			expectedDuration: 1,
+			expectedBitRate:  1411200,
+		},
+		{
+			name: "placeholder data size of a streamed file",
+			content: wavFile(
+				riffChunk("fmt ", wavFormat()),
+				append([]byte("data\xff\xff\xff\xff"), make([]byte, 17640)...),
+			),
+			expectedTags:     map[string]string{},
+			expectedDuration: 0.1,
+			expectedBitRate:  1411200,
+		},
+		{
+			name: "info value larger than the list",
+			content: wavFile(
+				riffChunk("LIST", []byte("INFO"), riffChunk("INAM", []byte("Airbag")), []byte("IART\xff\xff\x00\x00Radiohead")),
+			),
+			expectedTags: map[string]string{"title": "Airbag"},
+		},
+		{
+			name:         "list of another type",
+			content:      wavFile(riffChunk("LIST", []byte("adtl"), riffChunk("INAM", []byte("Airbag")))),
+			expectedTags: map[string]string{},
+		},
+		{
+			name:         "truncated fmt chunk",
+			content:      wavFile(riffChunk("fmt ", wavFormat()[:8])),
+			expectedTags: map[string]string{},
+		},
+		{
+			name:         "truncated chunk header",
+			content:      wavFile([]byte("LIST\x04")),
+			expectedTags: map[string]string{},
+		},
+		{
+			name:          "malformed id3 chunk",
+			content:       wavFile(riffChunk("id3 ", []byte("ID3\x03\x00\x00\x00\x00\x10\x00"))),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := newParsedFile()
+
+			err := parseWAV(bytes.NewReader(tt.content), int64(len(tt.content)), parsed)
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equalTags(parsed.tags, tt.expectedTags) {
+				t.Errorf("expected tags %v, got %v", tt.expectedTags, parsed.tags)
+			}
+			if parsed.duration != tt.expectedDuration {
+				t.Errorf("expected duration %v, got %v", tt.expectedDuration, parsed.duration)
+			}
+			if parsed.bitRate != tt.expectedBitRate {
+				t.Errorf("expected bit rate %d, got %d", tt.expectedBitRate, parsed.bitRate)
+			}
+		})
+	}
+}
+
+func FuzzParseWAV(f *testing.F) {
+	f.Add(wavFile(
+		riffChunk("fmt ", wavFormat()),
+		riffChunk("LIST", []byte("INFO"), riffChunk("INAM", []byte("Airbag"))),
+		riffChunk("data", make([]byte, 16)),
+		riffChunk("id3 ", id3Tag(3, 0, id3v23Frame("TIT2", 0, textFrame("Airbag")))),
+	))
+	f.Fuzz(func(t *testing.T, content []byte) {
+		_ = parseWAV(bytes.NewReader(content), int64(len(content)), newParsedFile())
+	})
+}
+
+func FuzzParseRIFFInfo(f *testing.F) {
+	f.Add(append([]byte("INFO"), riffChunk("INAM", []byte("Airbag\x00"))...))
+	f.Fuzz(func(t *testing.T, list []byte) {
+		parseRIFFInfo(list, newParsedFile())
+	})
+}
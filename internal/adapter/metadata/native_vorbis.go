@@ -0,0 +1,285 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// oggHeaderLimit bounds the size of the header packets read from Ogg streams, comments may embed pictures.
+	oggHeaderLimit = 16 << 20
+
+	// oggTailSize is how much of the end of an Ogg stream is searched for the last page.
+	oggTailSize = 64 * 1024
+
+	// opusSampleRate is the rate of Opus granule positions, whatever the input sample rate.
+	opusSampleRate = 48000
+
+	// FLAC metadata block types
+	flacStreamInfo    = 0
+	flacVorbisComment = 4
+	flacPicture       = 6
+)
+
+var errMalformedOgg = errors.New("malformed ogg stream")
+
+// parseFLAC reads the metadata blocks of the FLAC stream starting at offset.
+func parseFLAC(r io.ReaderAt, offset int64, size int64, parsed *parsedFile) error {
+	var (
+		sampleRate   int
+		totalSamples int64
+		pos          = offset + 4
+	)
+
+	for pos+4 <= size {
+		header, err := readAt(r, pos, 4)
+		if err != nil {
+			return err
+		}
+		last := header[0]&0x80 != 0
+		blockType := header[0] & 0x7F
+		length := int64(header[1])<<16 | int64(header[2])<<8 | int64(header[3])
+		pos += 4
+		if pos+length > size {
+			return fmt.Errorf("flac metadata block exceeds file size")
+		}
+
+		switch blockType {
+		case flacStreamInfo, flacVorbisComment, flacPicture:
+			block, err := readAt(r, pos, int(length))
+			if err != nil {
+				return err
+			}
+			switch blockType {
+			case flacStreamInfo:
+				if len(block) >= 18 {
+					sampleRate = int(block[10])<<12 | int(block[11])<<4 | int(block[12])>>4
+					totalSamples = int64(block[13]&0x0F)<<32 | int64(binary.BigEndian.Uint32(block[14:18]))
+				}
+			case flacVorbisComment:
+				parseVorbisComment(block, parsed)
+			case flacPicture:
+				parseFLACPicture(block, parsed)
+			}
+		}
+
+		pos += length
+		if last {
+			break
+		}
+	}
+
+	if sampleRate > 0 && totalSamples > 0 {
+		parsed.duration = float64(totalSamples) / float64(sampleRate)
+		parsed.bitRate = bitRateFor(size-pos, parsed.duration)
+	}
+	return nil
+}
+
+// parseOgg reads the header packets of the first logical stream of an Ogg Vorbis or Opus file.
+func parseOgg(r io.ReaderAt, size int64, parsed *parsedFile) error {
+	packets, serial, err := readOggHeaderPackets(r, 2)
+	if err != nil {
+		return err
+	}
+
+	var (
+		sampleRate int
+		preSkip    int64
+		comments   []byte
+	)
+	switch id := packets[0]; {
+	case bytes.HasPrefix(id, []byte("\x01vorbis")) && len(id) >= 16:
+		sampleRate = int(binary.LittleEndian.Uint32(id[12:16]))
+		if bytes.HasPrefix(packets[1], []byte("\x03vorbis")) {
+			comments = packets[1][7:]
+		}
+	case bytes.HasPrefix(id, []byte("OpusHead")) && len(id) >= 12:
+		sampleRate = opusSampleRate
+		preSkip = int64(binary.LittleEndian.Uint16(id[10:12]))
+		if bytes.HasPrefix(packets[1], []byte("OpusTags")) {
+			comments = packets[1][8:]
+		}
+	default:
+		// Other codecs such as FLAC in Ogg are not supported
+		return nil
+	}
+	parseVorbisComment(comments, parsed)
+
+	if granule := lastOggGranule(r, size, serial); granule > preSkip && sampleRate > 0 {
+		parsed.duration = float64(granule-preSkip) / float64(sampleRate)
+		parsed.bitRate = bitRateFor(size, parsed.duration)
+	}
+	return nil
+}
+
+// readOggHeaderPackets reassembles the first count packets of the first logical stream.
+// Returns the packets and the serial number of the stream.
+func readOggHeaderPackets(r io.ReaderAt, count int) ([][]byte, uint32, error) {
+	var (
+		packets [][]byte
+		current []byte
+		serial  uint32
+		pos     int64
+		read    int
+		first   = true
+	)
+
+	for len(packets) < count {
+		header, err := readAt(r, pos, 27)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !bytes.HasPrefix(header, []byte("OggS")) {
+			return nil, 0, errMalformedOgg
+		}
+		pageSerial := binary.LittleEndian.Uint32(header[14:18])
+		table, err := readAt(r, pos+27, int(header[26]))
+		if err != nil {
+			return nil, 0, err
+		}
+		dataSize := 0
+		for _, lacing := range table {
+			dataSize += int(lacing)
+		}
+		data, err := readAt(r, pos+27+int64(len(table)), dataSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		pos += 27 + int64(len(table)) + int64(dataSize)
+
+		if first {
+			serial, first = pageSerial, false
+		} else if pageSerial != serial {
+			// Page of another multiplexed stream
+			continue
+		}
+
+		offset := 0
+		for _, lacing := range table {
+			current = append(current, data[offset:offset+int(lacing)]...)
+			offset += int(lacing)
+			if read += int(lacing); read > oggHeaderLimit {
+				return nil, 0, fmt.Errorf("ogg header packets exceed %d bytes", oggHeaderLimit)
+			}
+			// A lacing value below 255 terminates the packet
+			if lacing < 255 {
+				packets = append(packets, current)
+				current = nil
+				if len(packets) == count {
+					break
+				}
+			}
+		}
+	}
+	return packets, serial, nil
+}
+
+// lastOggGranule returns the granule position of the last page of the stream with the given serial number.
+func lastOggGranule(r io.ReaderAt, size int64, serial uint32) int64 {
+	tailSize := min(size, oggTailSize)
+	tail, err := readAt(r, size-tailSize, int(tailSize))
+	if err != nil {
+		return 0
+	}
+	for i := bytes.LastIndex(tail, []byte("OggS")); i >= 0; i = bytes.LastIndex(tail[:i], []byte("OggS")) {
+		if i+27 > len(tail) || binary.LittleEndian.Uint32(tail[i+14:i+18]) != serial {
+			continue
+		}
+		// -1 marks pages on which no packet ends
+		if granule := int64(binary.LittleEndian.Uint64(tail[i+6 : i+14])); granule >= 0 {
+			return granule
+		}
+	}
+	return 0
+}
+
+// parseVorbisComment reads a Vorbis comment structure, values of repeated fields are joined.
+func parseVorbisComment(b []byte, parsed *parsedFile) {
+	if len(b) < 8 {
+		return
+	}
+	vendorSize := int(binary.LittleEndian.Uint32(b[0:4]))
+	if 4+vendorSize+4 > len(b) {
+		return
+	}
+	b = b[4+vendorSize:]
+	count := int(binary.LittleEndian.Uint32(b[0:4]))
+	b = b[4:]
+
+	var keys []string
+	values := make(map[string][]string)
+	for i := 0; i < count && len(b) >= 4; i++ {
+		size := int(binary.LittleEndian.Uint32(b[0:4]))
+		if 4+size > len(b) {
+			break
+		}
+		comment := string(b[4 : 4+size])
+		b = b[4+size:]
+
+		key, value, ok := strings.Cut(comment, "=")
+		if !ok {
+			continue
+		}
+		switch key = strings.ToLower(key); key {
+		case "metadata_block_picture":
+			if data, err := base64.StdEncoding.DecodeString(value); err == nil {
+				parseFLACPicture(data, parsed)
+			}
+		case "coverart":
+			if data, err := base64.StdEncoding.DecodeString(value); err == nil {
+				parsed.setPicture("", data, pictureTypeFrontCover)
+			}
+		default:
+			if _, seen := values[key]; !seen {
+				keys = append(keys, key)
+			}
+			values[key] = append(values[key], value)
+		}
+	}
+	for _, key := range keys {
+		parsed.setTag(key, strings.Join(values[key], "; "))
+	}
+}
+
+// parseFLACPicture reads a FLAC PICTURE block, also used base64 encoded in Vorbis comments.
+func parseFLACPicture(b []byte, parsed *parsedFile) {
+	field := func() ([]byte, bool) {
+		if len(b) < 4 {
+			return nil, false
+		}
+		size := int(binary.BigEndian.Uint32(b[0:4]))
+		if 4+size > len(b) {
+			return nil, false
+		}
+		value := b[4 : 4+size]
+		b = b[4+size:]
+		return value, true
+	}
+
+	if len(b) < 4 {
+		return
+	}
+	pictureType := int(binary.BigEndian.Uint32(b[0:4]))
+	b = b[4:]
+	mimeType, ok := field()
+	if !ok {
+		return
+	}
+	if _, ok := field(); !ok {
+		return
+	}
+	// Skip width, height, color depth and palette size
+	if len(b) < 16 {
+		return
+	}
+	b = b[16:]
+	if data, ok := field(); ok {
+		parsed.setPicture(string(mimeType), data, pictureType)
+	}
+}
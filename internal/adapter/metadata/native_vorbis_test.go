@@ -0,0 +1,351 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+// vorbisComment builds a Vorbis comment structure from KEY=value comments.
+func vorbisComment(comments ...string) []byte {
+	b := binary.LittleEndian.AppendUint32(nil, 6)
+	b = append(b, "vendor"...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(comments)))
+	for _, comment := range comments {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(comment)))
+		b = append(b, comment...)
+	}
+	return b
+}
+
+// flacBlock builds a FLAC metadata block header followed by its content.
+func flacBlock(blockType byte, last bool, content []byte) []byte {
+	if last {
+		blockType |= 0x80
+	}
+	return append([]byte{blockType, byte(len(content) >> 16), byte(len(content) >> 8), byte(len(content))}, content...)
+}
+
+// flacStreamInfoBlock builds the content of a STREAMINFO block with a sample rate and a sample count.
+func flacStreamInfoBlock(sampleRate int, totalSamples int64) []byte {
+	block := make([]byte, 34)
+	block[10] = byte(sampleRate >> 12)
+	block[11] = byte(sampleRate >> 4)
+	block[12] = byte(sampleRate<<4) | 0x02 // 2 channels
+	block[13] = 0xF0 | byte(totalSamples>>32&0x0F)
+	binary.BigEndian.PutUint32(block[14:18], uint32(totalSamples))
+	return block
+}
+
+// flacPictureBlock builds the content of a PICTURE block.
+func flacPictureBlock(pictureType uint32, mimeType string, data []byte) []byte {
+	b := binary.BigEndian.AppendUint32(nil, pictureType)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(mimeType)))
+	b = append(b, mimeType...)
+	b = binary.BigEndian.AppendUint32(b, 0) // description
+	b = append(b, make([]byte, 16)...)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(data)))
+	return append(b, data...)
+}
+
+// oggPage builds an Ogg page holding packets, the last one continuing on the next page when open is true.
+// An open packet must be a multiple of 255 bytes long.
+func oggPage(serial uint32, granule int64, open bool, packets ...[]byte) []byte {
+	var table, data []byte
+	for i, packet := range packets {
+		size := len(packet)
+		for ; size >= 255; size -= 255 {
+			table = append(table, 255)
+		}
+		if !open || i < len(packets)-1 {
+			table = append(table, byte(size))
+		}
+		data = append(data, packet...)
+	}
+	page := append([]byte("OggS"), 0, 0)
+	page = binary.LittleEndian.AppendUint64(page, uint64(granule))
+	page = binary.LittleEndian.AppendUint32(page, serial)
+	page = append(page, make([]byte, 8)...) // sequence number and checksum
+	page = append(page, byte(len(table)))
+	page = append(page, table...)
+	return append(page, data...)
+}
+
+func vorbisIDPacket(sampleRate uint32) []byte {
+	packet := append([]byte("\x01vorbis"), 0, 0, 0, 0, 2)
+	packet = binary.LittleEndian.AppendUint32(packet, sampleRate)
+	return append(packet, make([]byte, 14)...)
+}
+
+func TestParseFLAC(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          []byte
+		expectedTags     map[string]string
+		expectedDuration float64
+		expectedPicture  string
+		expectedError    bool
+	}{
+		{
+			name: "stream info, comments and picture",
+			content: bytes.Join([][]byte{
+				[]byte("fLaC"),
+				flacBlock(flacStreamInfo, false, flacStreamInfoBlock(44100, 441000)),
+				flacBlock(flacVorbisComment, false, vorbisComment("TITLE=Airbag", "ARTIST=Thom", "artist=Jonny", "no separator")),
+				flacBlock(flacPicture, true, flacPictureBlock(pictureTypeFrontCover, "image/png", pngHeader)),
+				make([]byte, 100),
+			}, nil),
+			expectedTags:     map[string]string{"title": "Airbag", "artist": "Thom; Jonny"},
+			expectedDuration: 10,
+			expectedPicture:  "image/png",
+		},
+		{
+			name: "block larger than the file",
+			content: bytes.Join([][]byte{
+				[]byte("fLaC"),
+				{flacVorbisComment | 0x80, 0x00, 0x10, 0x00},
+				vorbisComment("TITLE=Airbag"),
+			}, nil),
+			expectedError: true,
+		},
+		{
+			name:         "truncated block header",
+			content:      []byte("fLaC\x00\x00"),
+			expectedTags: map[string]string{},
+		},
+		{
+			name: "blocks after the last one ignored",
+			content: bytes.Join([][]byte{
+				[]byte("fLaC"),
+				flacBlock(flacStreamInfo, true, flacStreamInfoBlock(44100, 0)),
+				flacBlock(flacVorbisComment, true, vorbisComment("TITLE=Airbag")),
+			}, nil),
+			expectedTags: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := newParsedFile()
+
+			err := parseFLAC(bytes.NewReader(tt.content), 0, int64(len(tt.content)), parsed)
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equalTags(parsed.tags, tt.expectedTags) {
+				t.Errorf("expected tags %v, got %v", tt.expectedTags, parsed.tags)
+			}
+			if parsed.duration != tt.expectedDuration {
+				t.Errorf("expected duration %v, got %v", tt.expectedDuration, parsed.duration)
+			}
+			if tt.expectedPicture != "" && (parsed.picture == nil || parsed.picture.MimeType != tt.expectedPicture) {
+				t.Errorf("expected a %s picture, got %+v", tt.expectedPicture, parsed.picture)
+			}
+		})
+	}
+}
+
+func TestParseOgg(t *testing.T) {
+	comments := append([]byte("\x03vorbis"), vorbisComment("TITLE=Airbag")...)
+	longComments := append([]byte("\x03vorbis"), vorbisComment("TITLE=Airbag", "COMMENT="+string(bytes.Repeat([]byte("a"), 600)))...)
+	tests := []struct {
+		name             string
+		content          []byte
+		expectedTags     map[string]string
+		expectedDuration float64
+		expectedError    error
+	}{
+		{
+			name: "vorbis",
+			content: bytes.Join([][]byte{
+				oggPage(7, 0, false, vorbisIDPacket(44100)),
+				oggPage(7, 0, false, comments),
+				oggPage(7, 441000, false, make([]byte, 50)),
+			}, nil),
+			expectedTags:     map[string]string{"title": "Airbag"},
+			expectedDuration: 10,
+		},
+		{
+			name: "opus with pre-skip",
+			content: bytes.Join([][]byte{
+				oggPage(7, 0, false, append([]byte("OpusHead\x01\x02"), 0x80, 0x0C, 0, 0, 0, 0, 0, 0, 0)),
+				oggPage(7, 0, false, append([]byte("OpusTags"), vorbisComment("ARTIST=Radiohead")...)),
+				oggPage(7, 480000+3200, false, make([]byte, 50)),
+			}, nil),
+			expectedTags:     map[string]string{"artist": "Radiohead"},
+			expectedDuration: 10,
+		},
+		{
+			name: "comment packet spanning pages and pages of another stream",
+			content: bytes.Join([][]byte{
+				oggPage(7, 0, false, vorbisIDPacket(44100)),
+				oggPage(9, 0, false, []byte("other stream")),
+				oggPage(7, 0, true, longComments[:510]),
+				oggPage(7, 0, false, longComments[510:]),
+				oggPage(7, 441000, false, make([]byte, 50)),
+			}, nil),
+			expectedTags:     map[string]string{"title": "Airbag", "comment": string(bytes.Repeat([]byte("a"), 600))},
+			expectedDuration: 10,
+		},
+		{
+			name:          "not an ogg page",
+			content:       append([]byte("OggX"), make([]byte, 40)...),
+			expectedError: errMalformedOgg,
+		},
+		{
+			name:          "truncated page",
+			content:       oggPage(7, 0, false, vorbisIDPacket(44100))[:40],
+			expectedError: io.EOF,
+		},
+		{
+			name:          "header packets missing",
+			content:       oggPage(7, 0, false, vorbisIDPacket(44100)),
+			expectedError: io.EOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := newParsedFile()
+
+			err := parseOgg(bytes.NewReader(tt.content), int64(len(tt.content)), parsed)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equalTags(parsed.tags, tt.expectedTags) {
+				t.Errorf("expected tags %v, got %v", tt.expectedTags, parsed.tags)
+			}
+			if parsed.duration != tt.expectedDuration {
+				t.Errorf("expected duration %v, got %v", tt.expectedDuration, parsed.duration)
+			}
+		})
+	}
+}
+
+func TestParseVorbisComment(t *testing.T) {
+	picture := base64.StdEncoding.EncodeToString(flacPictureBlock(pictureTypeFrontCover, "image/png", pngHeader))
+	tests := []struct {
+		name            string
+		comment         []byte
+		expectedTags    map[string]string
+		expectedPicture bool
+	}{
+		{
+			name:            "tags and embedded picture",
+			comment:         vorbisComment("ALBUMARTIST=Radiohead", "TRACKNUMBER=1", "METADATA_BLOCK_PICTURE="+picture),
+			expectedTags:    map[string]string{"album_artist": "Radiohead", "track": "1"},
+			expectedPicture: true,
+		},
+		{
+			name:         "vendor larger than the comment",
+			comment:      binary.LittleEndian.AppendUint32(nil, 1000),
+			expectedTags: map[string]string{},
+		},
+		{
+			name:         "comment larger than the structure ends the comments",
+			comment:      append(vorbisComment("TITLE=Airbag", "ARTIST=Radiohead")[:30], 0xFF, 0xFF, 0xFF, 0x7F),
+			expectedTags: map[string]string{"title": "Airbag"},
+		},
+		{
+			name:         "count beyond the comments",
+			comment:      append(append(binary.LittleEndian.AppendUint32(nil, 0), 0xFF, 0xFF, 0xFF, 0xFF), vorbisComment("TITLE=Airbag")[14:]...),
+			expectedTags: map[string]string{"title": "Airbag"},
+		},
+		{
+			name:         "invalid picture ignored",
+			comment:      vorbisComment("METADATA_BLOCK_PICTURE=not base64", "COVERART="+base64.StdEncoding.EncodeToString([]byte{0, 0, 0})),
+			expectedTags: map[string]string{},
+			// COVERART holds the raw image, any data makes a picture
+			expectedPicture: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := newParsedFile()
+
+			parseVorbisComment(tt.comment, parsed)
+
+			if !equalTags(parsed.tags, tt.expectedTags) {
+				t.Errorf("expected tags %v, got %v", tt.expectedTags, parsed.tags)
+			}
+			if (parsed.picture != nil) != tt.expectedPicture {
+				t.Errorf("expected picture %v, got %+v", tt.expectedPicture, parsed.picture)
+			}
+		})
+	}
+}
+
+func TestParseFLACPicture(t *testing.T) {
+	valid := flacPictureBlock(pictureTypeFrontCover, "image/png", pngHeader)
+	tests := []struct {
+		name            string
+		block           []byte
+		expectedPicture bool
+	}{
+		{name: "front cover", block: valid, expectedPicture: true},
+		{name: "truncated picture data", block: valid[:len(valid)-1]},
+		{name: "mime type larger than the block", block: append(binary.BigEndian.AppendUint32(nil, 3), 0xFF, 0xFF, 0xFF, 0xFF)},
+		{name: "truncated dimensions", block: valid[:20]},
+		{name: "empty block", block: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := newParsedFile()
+
+			parseFLACPicture(tt.block, parsed)
+
+			if (parsed.picture != nil) != tt.expectedPicture {
+				t.Errorf("expected picture %v, got %+v", tt.expectedPicture, parsed.picture)
+			}
+		})
+	}
+}
+
+func FuzzParseFLAC(f *testing.F) {
+	f.Add(bytes.Join([][]byte{
+		[]byte("fLaC"),
+		flacBlock(flacStreamInfo, false, flacStreamInfoBlock(44100, 441000)),
+		flacBlock(flacVorbisComment, false, vorbisComment("TITLE=Airbag")),
+		flacBlock(flacPicture, true, flacPictureBlock(pictureTypeFrontCover, "image/png", pngHeader)),
+	}, nil))
+	f.Fuzz(func(t *testing.T, content []byte) {
+		_ = parseFLAC(bytes.NewReader(content), 0, int64(len(content)), newParsedFile())
+	})
+}
+
+func FuzzParseOgg(f *testing.F) {
+	f.Add(bytes.Join([][]byte{
+		oggPage(7, 0, false, vorbisIDPacket(44100)),
+		oggPage(7, 0, false, append([]byte("\x03vorbis"), vorbisComment("TITLE=Airbag")...)),
+		oggPage(7, 441000, false, make([]byte, 50)),
+	}, nil))
+	f.Fuzz(func(t *testing.T, content []byte) {
+		_ = parseOgg(bytes.NewReader(content), int64(len(content)), newParsedFile())
+	})
+}
+
+func FuzzParseVorbisComment(f *testing.F) {
+	f.Add(vorbisComment("TITLE=Airbag", "METADATA_BLOCK_PICTURE="+base64.StdEncoding.EncodeToString(flacPictureBlock(3, "image/png", pngHeader))))
+	f.Fuzz(func(t *testing.T, comment []byte) {
+		parseVorbisComment(comment, newParsedFile())
+	})
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"maps"
+	"music-streaming/internal/core/domain"
+	"slices"
+	"strings"
+	"sync"
+)
+
+/*
+* lyrics are matched to artists and titles by their display artist and title,
+* songs being kept by the media browsing repository
+ */
+
+type InMemoryLyricsRepository struct {
+	mediaBrowsingRepo *InMemoryMediaBrowsingRepository
+	lyrics            map[int][]domain.Lyrics
+	mu                sync.RWMutex
+}
+
+func NewInMemoryLyricsRepository(mediaBrowsingRepo *InMemoryMediaBrowsingRepository) *InMemoryLyricsRepository {
+	return &InMemoryLyricsRepository{
+		mediaBrowsingRepo: mediaBrowsingRepo,
+		lyrics:            make(map[int][]domain.Lyrics),
+	}
+}
+
+func (r *InMemoryLyricsRepository) GetLyricsBySongID(ctx context.Context, songID int) ([]domain.Lyrics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return cloneLyrics(r.lyrics[songID]), nil
+}
+
+func (r *InMemoryLyricsRepository) GetLyricsByArtistAndTitle(ctx context.Context, artist string, title string, musicFolderIDs []int) ([]domain.Lyrics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.mediaBrowsingRepo.mu.RLock()
+	defer r.mediaBrowsingRepo.mu.RUnlock()
+
+	var found []domain.Lyrics
+	for _, songID := range slices.Sorted(maps.Keys(r.lyrics)) {
+		if song, exists := r.mediaBrowsingRepo.songs[songID]; !exists || !inMusicFolders(song.MusicFolderId, musicFolderIDs) {
+			continue
+		}
+		for _, lyrics := range r.lyrics[songID] {
+			if strings.EqualFold(lyrics.DisplayTitle, title) && (artist == "" || strings.EqualFold(lyrics.DisplayArtist, artist)) {
+				found = append(found, lyrics)
+			}
+		}
+	}
+	return cloneLyrics(found), nil
+}
+
+func (r *InMemoryLyricsRepository) SaveLyrics(ctx context.Context, lyrics map[int][]domain.Lyrics) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for songID, songLyrics := range lyrics {
+		if len(songLyrics) == 0 {
+			delete(r.lyrics, songID)
+			continue
+		}
+		songLyrics = cloneLyrics(songLyrics)
+		for i := range songLyrics {
+			songLyrics[i].SongId = songID
+		}
+		r.lyrics[songID] = songLyrics
+	}
+	return nil
+}
+
+func cloneLyrics(lyrics []domain.Lyrics) []domain.Lyrics {
+	cloned := make([]domain.Lyrics, 0, len(lyrics))
+	for _, l := range lyrics {
+		l.Lines = slices.Clone(l.Lines)
+		cloned = append(cloned, l)
+	}
+	return cloned
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	sqlc "music-streaming/internal/adapter/sql/sqlc"
+	"music-streaming/internal/core/domain"
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SQLLyricsRepository struct {
+	queries *sqlc.Queries
+	db      *pgxpool.Pool
+}
+
+func NewSQLLyricsRepository(db *pgxpool.Pool) *SQLLyricsRepository {
+	return &SQLLyricsRepository{
+		queries: sqlc.New(db),
+		db:      db,
+	}
+}
+
+func (r *SQLLyricsRepository) GetLyricsBySongID(ctx context.Context, songID int) ([]domain.Lyrics, error) {
+	sqlLyrics, err := r.queries.GetLyricsBySong(ctx, int32(songID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lyrics: %w", err)
+	}
+	return toDomainLyricsList(sqlLyrics), nil
+}
+
+func (r *SQLLyricsRepository) GetLyricsByArtistAndTitle(ctx context.Context, artist string, title string, musicFolderIDs []int) ([]domain.Lyrics, error) {
+	sqlLyrics, err := r.queries.GetLyricsByArtistAndTitle(ctx, sqlc.GetLyricsByArtistAndTitleParams{
+		Title:          title,
+		Artist:         artist,
+		MusicFolderIds: toInt32s(musicFolderIDs),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lyrics: %w", err)
+	}
+	return toDomainLyricsList(sqlLyrics), nil
+}
+
+func (r *SQLLyricsRepository) SaveLyrics(ctx context.Context, lyrics map[int][]domain.Lyrics) error {
+	if len(lyrics) == 0 {
+		return nil
+	}
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	queries := r.queries.WithTx(tx)
+	songIDs := make([]int32, 0, len(lyrics))
+	for songID := range lyrics {
+		songIDs = append(songIDs, int32(songID))
+	}
+	slices.Sort(songIDs)
+	if err := queries.DeleteLyrics(ctx, songIDs); err != nil {
+		return fmt.Errorf("failed to delete lyrics: %w", err)
+	}
+
+	for _, songID := range songIDs {
+		for _, songLyrics := range lyrics[int(songID)] {
+			starts := make([]int32, 0, len(songLyrics.Lines))
+			values := make([]string, 0, len(songLyrics.Lines))
+			for _, line := range songLyrics.Lines {
+				starts = append(starts, int32(line.Start))
+				values = append(values, line.Value)
+			}
+			if err := queries.CreateLyrics(ctx, sqlc.CreateLyricsParams{
+				SongID:        songID,
+				Lang:          songLyrics.Lang,
+				Synced:        songLyrics.Synced,
+				DisplayArtist: songLyrics.DisplayArtist,
+				DisplayTitle:  songLyrics.DisplayTitle,
+				OffsetMs:      int32(songLyrics.Offset),
+				LineStarts:    starts,
+				LineValues:    values,
+			}); err != nil {
+				return fmt.Errorf("failed to create lyrics: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit lyrics: %w", err)
+	}
+	return nil
+}
+
+func toDomainLyricsList(sqlLyrics []sqlc.Lyric) []domain.Lyrics {
+	lyrics := make([]domain.Lyrics, 0, len(sqlLyrics))
+	for _, sqlLyric := range sqlLyrics {
+		lyrics = append(lyrics, toDomainLyrics(sqlLyric))
+	}
+	return lyrics
+}
+
+func toDomainLyrics(sqlLyrics sqlc.Lyric) domain.Lyrics {
+	lyrics := domain.Lyrics{
+		SongId:        int(sqlLyrics.SongID),
+		Lang:          sqlLyrics.Lang,
+		Synced:        sqlLyrics.Synced,
+		DisplayArtist: sqlLyrics.DisplayArtist,
+		DisplayTitle:  sqlLyrics.DisplayTitle,
+		Offset:        int(sqlLyrics.OffsetMs),
+		Lines:         make([]domain.LyricsLine, 0, len(sqlLyrics.LineValues)),
+	}
+	for i, value := range sqlLyrics.LineValues {
+		line := domain.LyricsLine{Value: value}
+		if i < len(sqlLyrics.LineStarts) {
+			line.Start = int(sqlLyrics.LineStarts[i])
+		}
+		lyrics.Lines = append(lyrics.Lines, line)
+	}
+	return lyrics
+}
@@ -0,0 +1,262 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"music-streaming/internal/core/domain"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+* stars, ratings and play counts are kept per username and item,
+* artists, albums and songs being kept by the media browsing repository
+ */
+
+type InMemoryMediaAnnotationRepository struct {
+	mediaBrowsingRepo *InMemoryMediaBrowsingRepository
+	starred           map[annotationKey]time.Time
+	ratings           map[annotationKey]int
+	playCounts        map[annotationKey]int
+	lastPlayed        map[annotationKey]time.Time
+	plays             []play
+	mu                sync.RWMutex
+}
+
+// play is an entry of the play history of a user.
+type play struct {
+	username string
+	songID   int
+	played   time.Time
+}
+
+// annotationKey identifies the item a user starred or rated.
+type annotationKey struct {
+	username string
+	itemType domain.AnnotationItemType
+	id       int
+}
+
+func NewInMemoryMediaAnnotationRepository(mediaBrowsingRepo *InMemoryMediaBrowsingRepository) *InMemoryMediaAnnotationRepository {
+	return &InMemoryMediaAnnotationRepository{
+		mediaBrowsingRepo: mediaBrowsingRepo,
+		starred:           make(map[annotationKey]time.Time),
+		ratings:           make(map[annotationKey]int),
+		playCounts:        make(map[annotationKey]int),
+		lastPlayed:        make(map[annotationKey]time.Time),
+	}
+}
+
+func (r *InMemoryMediaAnnotationRepository) StarItems(ctx context.Context, username string, itemType domain.AnnotationItemType, ids []int, starred time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range ids {
+		key := annotationKey{username: username, itemType: itemType, id: id}
+		if _, exists := r.starred[key]; !exists {
+			r.starred[key] = starred
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryMediaAnnotationRepository) UnstarItems(ctx context.Context, username string, itemType domain.AnnotationItemType, ids []int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range ids {
+		delete(r.starred, annotationKey{username: username, itemType: itemType, id: id})
+	}
+	return nil
+}
+
+func (r *InMemoryMediaAnnotationRepository) RateItem(ctx context.Context, username string, itemType domain.AnnotationItemType, id int, rating int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := annotationKey{username: username, itemType: itemType, id: id}
+	if rating == 0 {
+		delete(r.ratings, key)
+		return nil
+	}
+	r.ratings[key] = rating
+	return nil
+}
+
+func (r *InMemoryMediaAnnotationRepository) GetAnnotations(ctx context.Context, username string, itemType domain.AnnotationItemType, ids []int) (map[int]domain.Annotation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	annotations := make(map[int]domain.Annotation)
+	for _, id := range ids {
+		key := annotationKey{username: username, itemType: itemType, id: id}
+		starred, isStarred := r.starred[key]
+		rating, isRated := r.ratings[key]
+		played, isPlayed := r.lastPlayed[key]
+		if !isStarred && !isRated && !isPlayed {
+			continue
+		}
+		annotation := domain.Annotation{ItemId: id, Rating: rating, PlayCount: r.playCounts[key]}
+		if isStarred {
+			annotation.Starred = starred.Format(time.RFC3339)
+		}
+		if isPlayed {
+			annotation.Played = played.Format(time.RFC3339)
+		}
+		annotations[id] = annotation
+	}
+	return annotations, nil
+}
+
+func (r *InMemoryMediaAnnotationRepository) GetStarredArtists(ctx context.Context, username string, musicFolderIDs []int) ([]domain.Artist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.mediaBrowsingRepo.mu.RLock()
+	defer r.mediaBrowsingRepo.mu.RUnlock()
+
+	artists := make([]domain.Artist, 0)
+	for _, id := range r.starredIDs(username, domain.AnnotationItemArtist) {
+		if artist, exists := r.mediaBrowsingRepo.artists[id]; exists && inMusicFolders(artist.MusicFolderId, musicFolderIDs) {
+			artists = append(artists, artist)
+		}
+	}
+	return artists, nil
+}
+
+func (r *InMemoryMediaAnnotationRepository) GetStarredAlbums(ctx context.Context, username string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.mediaBrowsingRepo.mu.RLock()
+	defer r.mediaBrowsingRepo.mu.RUnlock()
+
+	albums := make([]domain.Album, 0)
+	for _, id := range r.starredIDs(username, domain.AnnotationItemAlbum) {
+		if album, exists := r.mediaBrowsingRepo.albums[id]; exists && inMusicFolders(album.MusicFolderId, musicFolderIDs) {
+			albums = append(albums, album)
+		}
+	}
+	if offset >= len(albums) {
+		return []domain.Album{}, nil
+	}
+	return albums[offset:min(offset+limit, len(albums))], nil
+}
+
+func (r *InMemoryMediaAnnotationRepository) GetStarredSongs(ctx context.Context, username string, musicFolderIDs []int) ([]domain.Song, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.mediaBrowsingRepo.mu.RLock()
+	defer r.mediaBrowsingRepo.mu.RUnlock()
+
+	songs := make([]domain.Song, 0)
+	for _, id := range r.starredIDs(username, domain.AnnotationItemSong) {
+		if song, exists := r.mediaBrowsingRepo.songs[id]; exists && inMusicFolders(song.MusicFolderId, musicFolderIDs) {
+			songs = append(songs, song)
+		}
+	}
+	return songs, nil
+}
+
+func (r *InMemoryMediaAnnotationRepository) GetHighestRatedAlbums(ctx context.Context, username string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.mediaBrowsingRepo.mu.RLock()
+	defer r.mediaBrowsingRepo.mu.RUnlock()
+
+	albums := make([]domain.Album, 0)
+	for key := range r.ratings {
+		if key.username != username || key.itemType != domain.AnnotationItemAlbum {
+			continue
+		}
+		if album, exists := r.mediaBrowsingRepo.albums[key.id]; exists && inMusicFolders(album.MusicFolderId, musicFolderIDs) {
+			albums = append(albums, album)
+		}
+	}
+	slices.SortFunc(albums, func(a, b domain.Album) int {
+		return cmp.Or(
+			cmp.Compare(r.ratings[annotationKey{username: username, itemType: domain.AnnotationItemAlbum, id: b.Id}],
+				r.ratings[annotationKey{username: username, itemType: domain.AnnotationItemAlbum, id: a.Id}]),
+			strings.Compare(strings.ToLower(a.SortName), strings.ToLower(b.SortName)),
+			cmp.Compare(a.Id, b.Id),
+		)
+	})
+	if offset >= len(albums) {
+		return []domain.Album{}, nil
+	}
+	return albums[offset:min(offset+limit, len(albums))], nil
+}
+
+func (r *InMemoryMediaAnnotationRepository) RecordPlay(ctx context.Context, username string, songID int, albumID int, played time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.plays = append(r.plays, play{username: username, songID: songID, played: played})
+	keys := []annotationKey{{username: username, itemType: domain.AnnotationItemSong, id: songID}}
+	if albumID != 0 {
+		keys = append(keys, annotationKey{username: username, itemType: domain.AnnotationItemAlbum, id: albumID})
+	}
+	for _, key := range keys {
+		r.playCounts[key]++
+		if last, exists := r.lastPlayed[key]; !exists || played.After(last) {
+			r.lastPlayed[key] = played
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryMediaAnnotationRepository) GetMostPlayedAlbums(ctx context.Context, username string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error) {
+	return r.playedAlbums(username, musicFolderIDs, limit, offset, func(a, b annotationKey) int {
+		return cmp.Compare(r.playCounts[b], r.playCounts[a])
+	})
+}
+
+func (r *InMemoryMediaAnnotationRepository) GetRecentlyPlayedAlbums(ctx context.Context, username string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error) {
+	return r.playedAlbums(username, musicFolderIDs, limit, offset, func(a, b annotationKey) int {
+		return r.lastPlayed[b].Compare(r.lastPlayed[a])
+	})
+}
+
+// playedAlbums returns a page of the albums in musicFolderIDs a user played, sorted by compare then by ID.
+func (r *InMemoryMediaAnnotationRepository) playedAlbums(username string, musicFolderIDs []int, limit int, offset int, compare func(a, b annotationKey) int) ([]domain.Album, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.mediaBrowsingRepo.mu.RLock()
+	defer r.mediaBrowsingRepo.mu.RUnlock()
+
+	keys := make([]annotationKey, 0)
+	for key := range r.lastPlayed {
+		if key.username != username || key.itemType != domain.AnnotationItemAlbum {
+			continue
+		}
+		if album, exists := r.mediaBrowsingRepo.albums[key.id]; exists && inMusicFolders(album.MusicFolderId, musicFolderIDs) {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b annotationKey) int {
+		return cmp.Or(compare(a, b), cmp.Compare(a.id, b.id))
+	})
+	albums := make([]domain.Album, 0)
+	for _, key := range keys[min(offset, len(keys)):min(offset+limit, len(keys))] {
+		albums = append(albums, r.mediaBrowsingRepo.albums[key.id])
+	}
+	return albums, nil
+}
+
+// starredIDs returns the IDs of the items of a type a user starred, the last starred first.
+func (r *InMemoryMediaAnnotationRepository) starredIDs(username string, itemType domain.AnnotationItemType) []int {
+	keys := make([]annotationKey, 0)
+	for key := range r.starred {
+		if key.username == username && key.itemType == itemType {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b annotationKey) int {
+		return cmp.Or(r.starred[b].Compare(r.starred[a]), cmp.Compare(a.id, b.id))
+	})
+	ids := make([]int, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, key.id)
+	}
+	return ids
+}
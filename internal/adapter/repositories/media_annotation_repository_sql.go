@@ -0,0 +1,260 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	sqlc "music-streaming/internal/adapter/sql/sqlc"
+	"music-streaming/internal/core/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SQLMediaAnnotationRepository struct {
+	queries *sqlc.Queries
+	db      *pgxpool.Pool
+}
+
+func NewSQLMediaAnnotationRepository(db *pgxpool.Pool) *SQLMediaAnnotationRepository {
+	return &SQLMediaAnnotationRepository{
+		queries: sqlc.New(db),
+		db:      db,
+	}
+}
+
+func (r *SQLMediaAnnotationRepository) StarItems(ctx context.Context, username string, itemType domain.AnnotationItemType, ids []int, starred time.Time) error {
+	var (
+		itemIDs   = toInt32s(ids)
+		timestamp = pgtype.Timestamp{Time: starred, Valid: true}
+		err       error
+	)
+	switch itemType {
+	case domain.AnnotationItemArtist:
+		err = r.queries.StarArtists(ctx, sqlc.StarArtistsParams{Username: username, ArtistIds: itemIDs, Starred: timestamp})
+	case domain.AnnotationItemAlbum:
+		err = r.queries.StarAlbums(ctx, sqlc.StarAlbumsParams{Username: username, AlbumIds: itemIDs, Starred: timestamp})
+	case domain.AnnotationItemSong:
+		err = r.queries.StarSongs(ctx, sqlc.StarSongsParams{Username: username, SongIds: itemIDs, Starred: timestamp})
+	default:
+		return fmt.Errorf("unsupported annotation item type %q", itemType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to star %ss: %w", itemType, err)
+	}
+	return nil
+}
+
+func (r *SQLMediaAnnotationRepository) UnstarItems(ctx context.Context, username string, itemType domain.AnnotationItemType, ids []int) error {
+	var (
+		itemIDs = toInt32s(ids)
+		err     error
+	)
+	switch itemType {
+	case domain.AnnotationItemArtist:
+		err = r.queries.UnstarArtists(ctx, sqlc.UnstarArtistsParams{Username: username, ArtistIds: itemIDs})
+	case domain.AnnotationItemAlbum:
+		err = r.queries.UnstarAlbums(ctx, sqlc.UnstarAlbumsParams{Username: username, AlbumIds: itemIDs})
+	case domain.AnnotationItemSong:
+		err = r.queries.UnstarSongs(ctx, sqlc.UnstarSongsParams{Username: username, SongIds: itemIDs})
+	default:
+		return fmt.Errorf("unsupported annotation item type %q", itemType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unstar %ss: %w", itemType, err)
+	}
+	return nil
+}
+
+func (r *SQLMediaAnnotationRepository) RateItem(ctx context.Context, username string, itemType domain.AnnotationItemType, id int, rating int) error {
+	var err error
+	switch itemType {
+	case domain.AnnotationItemArtist:
+		err = r.queries.RateArtist(ctx, sqlc.RateArtistParams{Username: username, ArtistID: int32(id), Rating: int32(rating)})
+	case domain.AnnotationItemAlbum:
+		err = r.queries.RateAlbum(ctx, sqlc.RateAlbumParams{Username: username, AlbumID: int32(id), Rating: int32(rating)})
+	case domain.AnnotationItemSong:
+		err = r.queries.RateSong(ctx, sqlc.RateSongParams{Username: username, SongID: int32(id), Rating: int32(rating)})
+	default:
+		return fmt.Errorf("unsupported annotation item type %q", itemType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to rate %s: %w", itemType, err)
+	}
+	return nil
+}
+
+func (r *SQLMediaAnnotationRepository) GetAnnotations(ctx context.Context, username string, itemType domain.AnnotationItemType, ids []int) (map[int]domain.Annotation, error) {
+	itemIDs := toInt32s(ids)
+	annotations := make(map[int]domain.Annotation)
+	switch itemType {
+	case domain.AnnotationItemArtist:
+		rows, err := r.queries.GetArtistAnnotations(ctx, sqlc.GetArtistAnnotationsParams{Username: username, ArtistIds: itemIDs})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get artist annotations: %w", err)
+		}
+		for _, row := range rows {
+			annotations[int(row.ArtistID)] = toDomainAnnotation(row.ArtistID, row.Starred, row.Rating)
+		}
+	case domain.AnnotationItemAlbum:
+		rows, err := r.queries.GetAlbumAnnotations(ctx, sqlc.GetAlbumAnnotationsParams{Username: username, AlbumIds: itemIDs})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get album annotations: %w", err)
+		}
+		for _, row := range rows {
+			annotations[int(row.AlbumID)] = withPlays(toDomainAnnotation(row.AlbumID, row.Starred, row.Rating), row.PlayCount, row.Played)
+		}
+	case domain.AnnotationItemSong:
+		rows, err := r.queries.GetSongAnnotations(ctx, sqlc.GetSongAnnotationsParams{Username: username, SongIds: itemIDs})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get song annotations: %w", err)
+		}
+		for _, row := range rows {
+			annotations[int(row.SongID)] = withPlays(toDomainAnnotation(row.SongID, row.Starred, row.Rating), row.PlayCount, row.Played)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported annotation item type %q", itemType)
+	}
+	return annotations, nil
+}
+
+func (r *SQLMediaAnnotationRepository) GetStarredArtists(ctx context.Context, username string, musicFolderIDs []int) ([]domain.Artist, error) {
+	sqlArtists, err := r.queries.GetStarredArtists(ctx, sqlc.GetStarredArtistsParams{Username: username, MusicFolderIds: toInt32s(musicFolderIDs)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get starred artists: %w", err)
+	}
+
+	artists := make([]domain.Artist, 0, len(sqlArtists))
+	for _, sqlArtist := range sqlArtists {
+		artists = append(artists, toDomainArtist(sqlArtist))
+	}
+	return artists, nil
+}
+
+func (r *SQLMediaAnnotationRepository) GetStarredAlbums(ctx context.Context, username string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error) {
+	sqlAlbums, err := r.queries.GetStarredAlbums(ctx, sqlc.GetStarredAlbumsParams{
+		Username:       username,
+		MusicFolderIds: toInt32s(musicFolderIDs),
+		Limit:          int32(limit),
+		Offset:         int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get starred albums: %w", err)
+	}
+
+	albums := make([]domain.Album, 0, len(sqlAlbums))
+	for _, sqlAlbum := range sqlAlbums {
+		albums = append(albums, toDomainAlbum(sqlAlbum))
+	}
+	return albums, nil
+}
+
+func (r *SQLMediaAnnotationRepository) GetStarredSongs(ctx context.Context, username string, musicFolderIDs []int) ([]domain.Song, error) {
+	sqlSongs, err := r.queries.GetStarredSongs(ctx, sqlc.GetStarredSongsParams{Username: username, MusicFolderIds: toInt32s(musicFolderIDs)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get starred songs: %w", err)
+	}
+
+	return songsWithGenres(ctx, r.queries, sqlSongs)
+}
+
+func (r *SQLMediaAnnotationRepository) GetHighestRatedAlbums(ctx context.Context, username string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error) {
+	sqlAlbums, err := r.queries.GetHighestRatedAlbums(ctx, sqlc.GetHighestRatedAlbumsParams{
+		Username:       username,
+		MusicFolderIds: toInt32s(musicFolderIDs),
+		Limit:          int32(limit),
+		Offset:         int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get highest rated albums: %w", err)
+	}
+
+	albums := make([]domain.Album, 0, len(sqlAlbums))
+	for _, sqlAlbum := range sqlAlbums {
+		albums = append(albums, toDomainAlbum(sqlAlbum))
+	}
+	return albums, nil
+}
+
+func (r *SQLMediaAnnotationRepository) RecordPlay(ctx context.Context, username string, songID int, albumID int, played time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	queries := r.queries.WithTx(tx)
+	timestamp := pgtype.Timestamp{Time: played, Valid: true}
+	if err := queries.CreatePlay(ctx, sqlc.CreatePlayParams{Username: username, SongID: int32(songID), Played: timestamp}); err != nil {
+		return fmt.Errorf("failed to create play: %w", err)
+	}
+	if err := queries.RecordSongPlay(ctx, sqlc.RecordSongPlayParams{Username: username, SongID: int32(songID), Played: timestamp}); err != nil {
+		return fmt.Errorf("failed to record song play: %w", err)
+	}
+	if albumID != 0 {
+		if err := queries.RecordAlbumPlay(ctx, sqlc.RecordAlbumPlayParams{Username: username, AlbumID: int32(albumID), Played: timestamp}); err != nil {
+			return fmt.Errorf("failed to record album play: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit play: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLMediaAnnotationRepository) GetMostPlayedAlbums(ctx context.Context, username string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error) {
+	sqlAlbums, err := r.queries.GetMostPlayedAlbums(ctx, sqlc.GetMostPlayedAlbumsParams{
+		Username:       username,
+		MusicFolderIds: toInt32s(musicFolderIDs),
+		Limit:          int32(limit),
+		Offset:         int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get most played albums: %w", err)
+	}
+
+	albums := make([]domain.Album, 0, len(sqlAlbums))
+	for _, sqlAlbum := range sqlAlbums {
+		albums = append(albums, toDomainAlbum(sqlAlbum))
+	}
+	return albums, nil
+}
+
+func (r *SQLMediaAnnotationRepository) GetRecentlyPlayedAlbums(ctx context.Context, username string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error) {
+	sqlAlbums, err := r.queries.GetRecentlyPlayedAlbums(ctx, sqlc.GetRecentlyPlayedAlbumsParams{
+		Username:       username,
+		MusicFolderIds: toInt32s(musicFolderIDs),
+		Limit:          int32(limit),
+		Offset:         int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recently played albums: %w", err)
+	}
+
+	albums := make([]domain.Album, 0, len(sqlAlbums))
+	for _, sqlAlbum := range sqlAlbums {
+		albums = append(albums, toDomainAlbum(sqlAlbum))
+	}
+	return albums, nil
+}
+
+func toDomainAnnotation(itemID int32, starred pgtype.Timestamp, rating int32) domain.Annotation {
+	annotation := domain.Annotation{
+		ItemId: int(itemID),
+		Rating: int(rating),
+	}
+	if starred.Valid {
+		annotation.Starred = starred.Time.Format(time.RFC3339)
+	}
+	return annotation
+}
+
+// withPlays sets the play count and last play of an album or song annotation
+func withPlays(annotation domain.Annotation, playCount int32, played pgtype.Timestamp) domain.Annotation {
+	annotation.PlayCount = int(playCount)
+	if played.Valid {
+		annotation.Played = played.Time.Format(time.RFC3339)
+	}
+	return annotation
+}
@@ -1,10 +1,22 @@
 package repositories
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"math/rand/v2"
 	"music-streaming/internal/core/domain"
 	"music-streaming/internal/core/ports"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 /*
@@ -12,19 +24,30 @@ import (
  */
 
 type InMemoryMediaBrowsingRepository struct {
-	artists map[int]domain.Artist
-	albums  map[int]domain.Album
-	songs   map[int]domain.Song
-	cover   map[string]domain.Cover
-	mu      sync.RWMutex
+	musicFolders    []domain.MusicFolder
+	directories     map[int]domain.Directory
+	artists         map[int]domain.Artist
+	albums          map[int]domain.Album
+	songs           map[int]domain.Song
+	cover           map[string]domain.Cover
+	nextDirectoryID int
+	nextArtistID    int
+	nextAlbumID     int
+	nextSongID      int
+	mu              sync.RWMutex
 }
 
 func NewInMemoryMediaBrowsingRepository() *InMemoryMediaBrowsingRepository {
 	return &InMemoryMediaBrowsingRepository{
-		artists: make(map[int]domain.Artist),
-		albums:  make(map[int]domain.Album),
-		songs:   make(map[int]domain.Song),
-		cover:   make(map[string]domain.Cover),
+		directories:     make(map[int]domain.Directory),
+		artists:         make(map[int]domain.Artist),
+		albums:          make(map[int]domain.Album),
+		songs:           make(map[int]domain.Song),
+		cover:           make(map[string]domain.Cover),
+		nextDirectoryID: 1,
+		nextArtistID:    1,
+		nextAlbumID:     1,
+		nextSongID:      1,
 	}
 }
 
@@ -39,6 +62,22 @@ func (r *InMemoryMediaBrowsingRepository) GetArtistByID(ctx context.Context, id
 	return artist, nil
 }
 
+func (r *InMemoryMediaBrowsingRepository) GetArtists(ctx context.Context, musicFolderIDs []int) ([]domain.Artist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	artists := make([]domain.Artist, 0)
+	for _, artist := range r.artists {
+		if inMusicFolders(artist.MusicFolderId, musicFolderIDs) {
+			artists = append(artists, artist)
+		}
+	}
+	slices.SortFunc(artists, func(a, b domain.Artist) int {
+		return cmp.Or(strings.Compare(strings.ToLower(a.SortName), strings.ToLower(b.SortName)), cmp.Compare(a.Id, b.Id))
+	})
+	return artists, nil
+}
+
 func (r *InMemoryMediaBrowsingRepository) GetAlbumByID(ctx context.Context, id int) (domain.Album, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -50,6 +89,99 @@ func (r *InMemoryMediaBrowsingRepository) GetAlbumByID(ctx context.Context, id i
 	return album, nil
 }
 
+func (r *InMemoryMediaBrowsingRepository) GetAlbumsByArtistID(ctx context.Context, artistID int) ([]domain.Album, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	albums := make([]domain.Album, 0)
+	for _, album := range r.albums {
+		if album.ArtistId == artistID {
+			albums = append(albums, album)
+		}
+	}
+	slices.SortFunc(albums, func(a, b domain.Album) int {
+		return cmp.Or(
+			cmp.Compare(a.Year, b.Year),
+			strings.Compare(strings.ToLower(a.SortName), strings.ToLower(b.SortName)),
+			cmp.Compare(a.Id, b.Id),
+		)
+	})
+	return albums, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) GetAlbumList(ctx context.Context, query domain.AlbumListQuery, musicFolderIDs []int) ([]domain.Album, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	albums := make([]domain.Album, 0)
+	for _, album := range r.albums {
+		if !inMusicFolders(album.MusicFolderId, musicFolderIDs) {
+			continue
+		}
+		if query.Type == domain.AlbumListByYear && (album.Year < min(query.FromYear, query.ToYear) || album.Year > max(query.FromYear, query.ToYear)) {
+			continue
+		}
+		if query.Type == domain.AlbumListByGenre && !r.albumHasGenre(album.Id, query.Genre) {
+			continue
+		}
+		albums = append(albums, album)
+	}
+
+	byName := func(a, b domain.Album) int {
+		return cmp.Or(strings.Compare(strings.ToLower(a.SortName), strings.ToLower(b.SortName)), cmp.Compare(a.Id, b.Id))
+	}
+	switch query.Type {
+	case domain.AlbumListRandom:
+		rand.Shuffle(len(albums), func(i, j int) { albums[i], albums[j] = albums[j], albums[i] })
+		return albums[:min(query.Size, len(albums))], nil
+	case domain.AlbumListNewest:
+		slices.SortFunc(albums, func(a, b domain.Album) int {
+			return cmp.Or(strings.Compare(b.Created, a.Created), cmp.Compare(b.Id, a.Id))
+		})
+	case domain.AlbumListAlphabeticalByName, domain.AlbumListByGenre:
+		slices.SortFunc(albums, byName)
+	case domain.AlbumListAlphabeticalByArtist:
+		slices.SortFunc(albums, func(a, b domain.Album) int {
+			return cmp.Or(
+				strings.Compare(strings.ToLower(r.albumArtistSortName(a)), strings.ToLower(r.albumArtistSortName(b))),
+				cmp.Compare(a.Year, b.Year),
+				byName(a, b),
+			)
+		})
+	case domain.AlbumListByYear:
+		slices.SortFunc(albums, func(a, b domain.Album) int {
+			if query.FromYear > query.ToYear {
+				return cmp.Or(cmp.Compare(b.Year, a.Year), byName(a, b))
+			}
+			return cmp.Or(cmp.Compare(a.Year, b.Year), byName(a, b))
+		})
+	default:
+		return nil, fmt.Errorf("unsupported album list type %q", query.Type)
+	}
+	if query.Offset >= len(albums) {
+		return []domain.Album{}, nil
+	}
+	return albums[query.Offset:min(query.Offset+query.Size, len(albums))], nil
+}
+
+// albumHasGenre reports whether a song of the album is tagged with genre.
+func (r *InMemoryMediaBrowsingRepository) albumHasGenre(albumID int, genre string) bool {
+	for _, song := range r.songs {
+		if song.AlbumId == albumID && slices.Contains(song.Genres, genre) {
+			return true
+		}
+	}
+	return false
+}
+
+// albumArtistSortName returns the sort name of the album artist, or the artist name of the album when unknown.
+func (r *InMemoryMediaBrowsingRepository) albumArtistSortName(album domain.Album) string {
+	if artist, exists := r.artists[album.ArtistId]; exists {
+		return artist.SortName
+	}
+	return album.Artist
+}
+
 func (r *InMemoryMediaBrowsingRepository) GetSongByID(ctx context.Context, id int) (domain.Song, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -61,6 +193,261 @@ func (r *InMemoryMediaBrowsingRepository) GetSongByID(ctx context.Context, id in
 	return song, nil
 }
 
+func (r *InMemoryMediaBrowsingRepository) GetSongsByAlbumID(ctx context.Context, albumID int) ([]domain.Song, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	songs := make([]domain.Song, 0)
+	for _, song := range r.songs {
+		if song.AlbumId == albumID {
+			songs = append(songs, song)
+		}
+	}
+	slices.SortFunc(songs, func(a, b domain.Song) int {
+		return cmp.Or(
+			cmp.Compare(a.DiscNumber, b.DiscNumber),
+			cmp.Compare(a.Track, b.Track),
+			strings.Compare(a.Title, b.Title),
+			cmp.Compare(a.Id, b.Id),
+		)
+	})
+	return songs, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) GetMusicFolders(ctx context.Context) ([]domain.MusicFolder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.musicFolders), nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) SaveMusicFolders(ctx context.Context, paths []string) ([]domain.MusicFolder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	folders := make([]domain.MusicFolder, 0, len(paths))
+	for _, path := range paths {
+		i := slices.IndexFunc(r.musicFolders, func(folder domain.MusicFolder) bool {
+			return folder.Path == path
+		})
+		if i < 0 {
+			r.musicFolders = append(r.musicFolders, domain.MusicFolder{
+				Id:   len(r.musicFolders) + 1,
+				Name: filepath.Base(path),
+				Path: path,
+			})
+			i = len(r.musicFolders) - 1
+		}
+		folders = append(folders, r.musicFolders[i])
+	}
+	return folders, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) GetDirectory(ctx context.Context, id int) (domain.Directory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	directory, exists := r.directories[id]
+	if !exists {
+		return domain.Directory{}, &ports.NotFoundError{Message: "directory not found"}
+	}
+	return directory, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) GetDirectoryByPath(ctx context.Context, path string) (domain.Directory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, directory := range r.directories {
+		if directory.Path == path {
+			return directory, nil
+		}
+	}
+	return domain.Directory{}, &ports.NotFoundError{Message: "directory not found"}
+}
+
+func (r *InMemoryMediaBrowsingRepository) GetSubdirectories(ctx context.Context, parentIDs []int) ([]domain.Directory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	directories := make([]domain.Directory, 0)
+	for _, directory := range r.directories {
+		if directory.ParentId > 0 && slices.Contains(parentIDs, directory.ParentId) {
+			directories = append(directories, directory)
+		}
+	}
+	slices.SortFunc(directories, func(a, b domain.Directory) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), cmp.Compare(a.Id, b.Id))
+	})
+	return directories, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) GetSongsByDirectory(ctx context.Context, directoryID int) ([]domain.Song, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	songs := make([]domain.Song, 0)
+	for _, song := range r.songs {
+		if song.DirectoryId == directoryID {
+			songs = append(songs, song)
+		}
+	}
+	slices.SortFunc(songs, func(a, b domain.Song) int {
+		return cmp.Or(
+			cmp.Compare(a.DiscNumber, b.DiscNumber),
+			cmp.Compare(a.Track, b.Track),
+			strings.Compare(a.Title, b.Title),
+			cmp.Compare(a.Id, b.Id),
+		)
+	})
+	return songs, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) GetGenres(ctx context.Context, musicFolderIDs []int) ([]domain.Genre, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]*domain.Genre)
+	albums := make(map[string]map[int]bool)
+	for _, song := range r.songs {
+		if !inMusicFolders(song.MusicFolderId, musicFolderIDs) {
+			continue
+		}
+		for _, name := range song.Genres {
+			genre, ok := counts[name]
+			if !ok {
+				genre = &domain.Genre{Name: name}
+				counts[name] = genre
+				albums[name] = make(map[int]bool)
+			}
+			genre.SongCount++
+			if song.AlbumId > 0 && !albums[name][song.AlbumId] {
+				albums[name][song.AlbumId] = true
+				genre.AlbumCount++
+			}
+		}
+	}
+
+	genres := make([]domain.Genre, 0, len(counts))
+	for _, genre := range counts {
+		genres = append(genres, *genre)
+	}
+	slices.SortFunc(genres, func(a, b domain.Genre) int {
+		return cmp.Or(
+			strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+	return genres, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) GetSongsByGenre(ctx context.Context, genre string, musicFolderIDs []int, limit int, offset int) ([]domain.Song, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	songs := make([]domain.Song, 0)
+	for _, song := range r.songs {
+		if slices.Contains(song.Genres, genre) && inMusicFolders(song.MusicFolderId, musicFolderIDs) {
+			songs = append(songs, song)
+		}
+	}
+	slices.SortFunc(songs, func(a, b domain.Song) int {
+		return cmp.Or(
+			cmp.Compare(a.AlbumId, b.AlbumId),
+			cmp.Compare(a.DiscNumber, b.DiscNumber),
+			cmp.Compare(a.Track, b.Track),
+			cmp.Compare(a.Id, b.Id),
+		)
+	})
+	if offset >= len(songs) {
+		return []domain.Song{}, nil
+	}
+	return songs[offset:min(offset+limit, len(songs))], nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) SearchArtists(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int) ([]domain.Artist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	artists := make([]domain.Artist, 0)
+	for _, artist := range r.artists {
+		if inMusicFolders(artist.MusicFolderId, musicFolderIDs) && matchesSearch(query, artist.Name) {
+			artists = append(artists, artist)
+		}
+	}
+	slices.SortFunc(artists, func(a, b domain.Artist) int {
+		return cmp.Or(strings.Compare(strings.ToLower(a.SortName), strings.ToLower(b.SortName)), cmp.Compare(a.Id, b.Id))
+	})
+	if offset >= len(artists) {
+		return []domain.Artist{}, nil
+	}
+	return artists[offset:min(offset+limit, len(artists))], nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) SearchAlbums(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	albums := make([]domain.Album, 0)
+	for _, album := range r.albums {
+		if inMusicFolders(album.MusicFolderId, musicFolderIDs) && matchesSearch(query, album.Name, album.Artist) {
+			albums = append(albums, album)
+		}
+	}
+	slices.SortFunc(albums, func(a, b domain.Album) int {
+		return cmp.Or(strings.Compare(strings.ToLower(a.SortName), strings.ToLower(b.SortName)), cmp.Compare(a.Id, b.Id))
+	})
+	if offset >= len(albums) {
+		return []domain.Album{}, nil
+	}
+	return albums[offset:min(offset+limit, len(albums))], nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) SearchSongs(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int) ([]domain.Song, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	songs := make([]domain.Song, 0)
+	for _, song := range r.songs {
+		if inMusicFolders(song.MusicFolderId, musicFolderIDs) && matchesSearch(query, song.Title, song.Artist, song.Album) {
+			songs = append(songs, song)
+		}
+	}
+	slices.SortFunc(songs, func(a, b domain.Song) int {
+		return cmp.Or(strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)), cmp.Compare(a.Id, b.Id))
+	})
+	if offset >= len(songs) {
+		return []domain.Song{}, nil
+	}
+	return songs[offset:min(offset+limit, len(songs))], nil
+}
+
+// matchesSearch reports whether every word of query starts a word of one of texts, ignoring case and diacritics.
+// An empty query matches everything.
+func matchesSearch(query string, texts ...string) bool {
+	var words []string
+	for _, text := range texts {
+		words = append(words, searchWords(text)...)
+	}
+	for _, queryWord := range searchWords(query) {
+		if !slices.ContainsFunc(words, func(word string) bool { return strings.HasPrefix(word, queryWord) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// searchWords splits text into lowercase words without diacritics.
+func searchWords(text string) []string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		folded = text
+	}
+	return strings.FieldsFunc(strings.ToLower(folded), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
 func (r *InMemoryMediaBrowsingRepository) GetCoverByID(ctx context.Context, id string) (domain.Cover, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -77,7 +464,8 @@ func (r *InMemoryMediaBrowsingRepository) CreateArtist(ctx context.Context, arti
 	defer r.mu.Unlock()
 
 	// assign a new ID
-	artist.Id = len(r.artists) + 1
+	artist.Id = r.nextArtistID
+	r.nextArtistID++
 	r.artists[artist.Id] = artist
 	return artist, nil
 }
@@ -87,7 +475,8 @@ func (r *InMemoryMediaBrowsingRepository) CreateAlbum(ctx context.Context, album
 	defer r.mu.Unlock()
 
 	// assign a new ID
-	album.Id = len(r.albums) + 1
+	album.Id = r.nextAlbumID
+	r.nextAlbumID++
 	r.albums[album.Id] = album
 	return album, nil
 }
@@ -96,12 +485,40 @@ func (r *InMemoryMediaBrowsingRepository) CreateSong(ctx context.Context, song d
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	for _, existing := range r.songs {
+		if existing.Path == song.Path && existing.StartOffset == song.StartOffset {
+			return domain.Song{}, &ports.FailedOperationError{Description: "song path already indexed"}
+		}
+	}
+
 	// assign a new ID
-	song.Id = len(r.songs) + 1
+	song.Id = r.nextSongID
+	r.nextSongID++
 	r.songs[song.Id] = song
 	return song, nil
 }
 
+func (r *InMemoryMediaBrowsingRepository) CreateDirectory(ctx context.Context, directory domain.Directory) (domain.Directory, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.directories {
+		if existing.Path == directory.Path {
+			return domain.Directory{}, &ports.FailedOperationError{Description: "directory path already indexed"}
+		}
+	}
+
+	// assign a new ID
+	directory.Id = r.nextDirectoryID
+	r.nextDirectoryID++
+	r.directories[directory.Id] = directory
+	if parent, exists := r.directories[directory.ParentId]; exists {
+		parent.Updated = directory.Updated
+		r.directories[parent.Id] = parent
+	}
+	return directory, nil
+}
+
 func (r *InMemoryMediaBrowsingRepository) CreateCover(ctx context.Context, cover domain.Cover) (domain.Cover, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -109,3 +526,273 @@ func (r *InMemoryMediaBrowsingRepository) CreateCover(ctx context.Context, cover
 	r.cover[cover.Id] = cover
 	return cover, nil
 }
+
+func (r *InMemoryMediaBrowsingRepository) GetArtistByName(ctx context.Context, musicFolderID int, name string) (domain.Artist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		found domain.Artist
+		ok    bool
+	)
+	for _, artist := range r.artists {
+		if artist.Name != name || artist.MusicFolderId != musicFolderID {
+			continue
+		}
+		if !ok || compareArtistsByName(artist, found) < 0 {
+			found, ok = artist, true
+		}
+	}
+	if !ok {
+		return domain.Artist{}, &ports.NotFoundError{Message: "artist not found"}
+	}
+	return found, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) GetArtistByMusicBrainzID(ctx context.Context, musicFolderID int, musicBrainzID string) (domain.Artist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		found domain.Artist
+		ok    bool
+	)
+	for _, artist := range r.artists {
+		if artist.MusicBrainzId == musicBrainzID && artist.MusicFolderId == musicFolderID && (!ok || artist.Id < found.Id) {
+			found, ok = artist, true
+		}
+	}
+	if !ok {
+		return domain.Artist{}, &ports.NotFoundError{Message: "artist not found"}
+	}
+	return found, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) GetAlbumByRelease(ctx context.Context, artistID int, name string, year int) (domain.Album, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, album := range r.albums {
+		if album.ArtistId == artistID && album.Name == name && album.Year == year {
+			return album, nil
+		}
+	}
+	return domain.Album{}, &ports.NotFoundError{Message: "album not found"}
+}
+
+func (r *InMemoryMediaBrowsingRepository) GetSongFingerprints(ctx context.Context) ([]domain.FileFingerprint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fingerprints := make([]domain.FileFingerprint, 0, len(r.songs))
+	for _, song := range r.songs {
+		fingerprints = append(fingerprints, domain.FileFingerprint{
+			SongId:      song.Id,
+			AlbumId:     song.AlbumId,
+			Path:        song.Path,
+			Size:        song.Size,
+			ModTime:     song.ModTime,
+			StartOffset: song.StartOffset,
+			CuePath:     song.CuePath,
+			LyricsPath:  song.LyricsPath,
+		})
+	}
+	return fingerprints, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) UpdateArtist(ctx context.Context, artist domain.Artist) (domain.Artist, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.artists[artist.Id]; !exists {
+		return domain.Artist{}, &ports.NotFoundError{Message: "artist not found"}
+	}
+	r.artists[artist.Id] = artist
+	return artist, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) UpdateAlbum(ctx context.Context, album domain.Album) (domain.Album, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.albums[album.Id]; !exists {
+		return domain.Album{}, &ports.NotFoundError{Message: "album not found"}
+	}
+	r.albums[album.Id] = album
+	return album, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) UpdateSong(ctx context.Context, song domain.Song) (domain.Song, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.songs[song.Id]; !exists {
+		return domain.Song{}, &ports.NotFoundError{Message: "song not found"}
+	}
+	r.songs[song.Id] = song
+	return song, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) SaveSongs(ctx context.Context, songs []domain.Song) ([]domain.Song, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// validate the whole batch first so that nothing is saved on failure
+	// songs split from a CUE sheet share their path and are told apart by their start offset
+	type songKey struct {
+		path        string
+		startOffset int
+	}
+	keys := make(map[songKey]int, len(r.songs))
+	for _, existing := range r.songs {
+		keys[songKey{existing.Path, existing.StartOffset}] = existing.Id
+	}
+	for _, song := range songs {
+		if song.Id > 0 {
+			if _, exists := r.songs[song.Id]; !exists {
+				return nil, &ports.NotFoundError{Message: "song not found"}
+			}
+		}
+		key := songKey{song.Path, song.StartOffset}
+		if id, exists := keys[key]; exists && id != song.Id {
+			return nil, &ports.FailedOperationError{Description: "song path already indexed"}
+		}
+		keys[key] = song.Id
+	}
+
+	saved := make([]domain.Song, 0, len(songs))
+	for _, song := range songs {
+		if song.Id <= 0 {
+			// assign a new ID
+			song.Id = r.nextSongID
+			r.nextSongID++
+		}
+		r.songs[song.Id] = song
+		saved = append(saved, song)
+	}
+	return saved, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) DeleteSongs(ctx context.Context, ids []int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for _, id := range ids {
+		if _, exists := r.songs[id]; exists {
+			delete(r.songs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (r *InMemoryMediaBrowsingRepository) DeleteOrphans(ctx context.Context) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usedAlbums := make(map[int]bool)
+	for _, song := range r.songs {
+		usedAlbums[song.AlbumId] = true
+	}
+	removed := 0
+	for id := range r.albums {
+		if !usedAlbums[id] {
+			delete(r.albums, id)
+			removed++
+		}
+	}
+
+	usedArtists := make(map[int]bool)
+	for _, album := range r.albums {
+		usedArtists[album.ArtistId] = true
+	}
+	for id := range r.artists {
+		if !usedArtists[id] {
+			delete(r.artists, id)
+			removed++
+		}
+	}
+
+	// Deepest directories first, their parents may become orphans
+	for {
+		usedDirectories := make(map[int]bool)
+		for _, song := range r.songs {
+			usedDirectories[song.DirectoryId] = true
+		}
+		for _, directory := range r.directories {
+			usedDirectories[directory.ParentId] = true
+		}
+		var orphans []domain.Directory
+		for id, directory := range r.directories {
+			if !usedDirectories[id] {
+				orphans = append(orphans, directory)
+			}
+		}
+		if len(orphans) == 0 {
+			return removed, nil
+		}
+		for _, orphan := range orphans {
+			delete(r.directories, orphan.Id)
+			removed++
+			if parent, exists := r.directories[orphan.ParentId]; exists {
+				parent.Updated = time.Now().UnixMilli()
+				r.directories[parent.Id] = parent
+			}
+		}
+	}
+}
+
+func (r *InMemoryMediaBrowsingRepository) UpdateCatalogStats(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, album := range r.albums {
+		album.SongCount = 0
+		album.Duration = 0
+		album.DirectoryId = 0
+		for _, song := range r.songs {
+			if song.AlbumId == id {
+				album.SongCount++
+				album.Duration += song.Duration
+				if album.DirectoryId == 0 || song.DirectoryId < album.DirectoryId {
+					album.DirectoryId = song.DirectoryId
+				}
+			}
+		}
+		r.albums[id] = album
+	}
+
+	for id, artist := range r.artists {
+		artist.AlbumCount = 0
+		artist.DirectoryId = 0
+		for _, album := range r.albums {
+			if album.ArtistId == id {
+				artist.AlbumCount++
+				parentID := r.directories[album.DirectoryId].ParentId
+				if parentID != 0 && (artist.DirectoryId == 0 || parentID < artist.DirectoryId) {
+					artist.DirectoryId = parentID
+				}
+			}
+		}
+		r.artists[id] = artist
+	}
+	return nil
+}
+
+// compareArtistsByName orders artists sharing a name as the SQL repository does,
+// artists without a MusicBrainz ID first then by ID.
+func compareArtistsByName(a domain.Artist, b domain.Artist) int {
+	if (a.MusicBrainzId == "") != (b.MusicBrainzId == "") {
+		if a.MusicBrainzId == "" {
+			return -1
+		}
+		return 1
+	}
+	return a.Id - b.Id
+}
+
+// inMusicFolders reports whether musicFolderID is one of musicFolderIDs, nil standing for every music folder.
+func inMusicFolders(musicFolderID int, musicFolderIDs []int) bool {
+	return musicFolderIDs == nil || slices.Contains(musicFolderIDs, musicFolderID)
+}
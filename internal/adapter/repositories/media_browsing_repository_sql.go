@@ -6,18 +6,22 @@ import (
 	sqlc "music-streaming/internal/adapter/sql/sqlc"
 	"music-streaming/internal/core/domain"
 	"music-streaming/internal/core/ports"
+	"path/filepath"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type SQLMediaBrowsingRepository struct {
 	queries *sqlc.Queries
-	db      *pgx.Conn
+	db      *pgxpool.Pool
 }
 
-func NewSQLMediaBrowsingRepository(db *pgx.Conn) *SQLMediaBrowsingRepository {
+func NewSQLMediaBrowsingRepository(db *pgxpool.Pool) *SQLMediaBrowsingRepository {
 	return &SQLMediaBrowsingRepository{
 		queries: sqlc.New(db),
 		db:      db,
@@ -36,6 +40,19 @@ func (r *SQLMediaBrowsingRepository) GetArtistByID(ctx context.Context, id int)
 	return toDomainArtist(sqlArtist), nil
 }
 
+func (r *SQLMediaBrowsingRepository) GetArtists(ctx context.Context, musicFolderIDs []int) ([]domain.Artist, error) {
+	sqlArtists, err := r.queries.GetArtists(ctx, toInt32s(musicFolderIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artists: %w", err)
+	}
+
+	artists := make([]domain.Artist, 0, len(sqlArtists))
+	for _, sqlArtist := range sqlArtists {
+		artists = append(artists, toDomainArtist(sqlArtist))
+	}
+	return artists, nil
+}
+
 func (r *SQLMediaBrowsingRepository) GetAlbumByID(ctx context.Context, id int) (domain.Album, error) {
 	sqlAlbum, err := r.queries.GetAlbum(ctx, int32(id))
 	if err != nil {
@@ -48,6 +65,61 @@ func (r *SQLMediaBrowsingRepository) GetAlbumByID(ctx context.Context, id int) (
 	return toDomainAlbum(sqlAlbum), nil
 }
 
+func (r *SQLMediaBrowsingRepository) GetAlbumsByArtistID(ctx context.Context, artistID int) ([]domain.Album, error) {
+	sqlAlbums, err := r.queries.GetAlbums(ctx, toInt4(artistID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get albums: %w", err)
+	}
+
+	albums := make([]domain.Album, 0, len(sqlAlbums))
+	for _, sqlAlbum := range sqlAlbums {
+		albums = append(albums, toDomainAlbum(sqlAlbum))
+	}
+	return albums, nil
+}
+
+func (r *SQLMediaBrowsingRepository) GetAlbumList(ctx context.Context, query domain.AlbumListQuery, musicFolderIDs []int) ([]domain.Album, error) {
+	var (
+		folderIDs = toInt32s(musicFolderIDs)
+		limit     = int32(query.Size)
+		offset    = int32(query.Offset)
+		sqlAlbums []sqlc.Album
+		err       error
+	)
+	switch query.Type {
+	case domain.AlbumListRandom:
+		sqlAlbums, err = r.queries.GetRandomAlbums(ctx, sqlc.GetRandomAlbumsParams{MusicFolderIds: folderIDs, Limit: limit})
+	case domain.AlbumListNewest:
+		sqlAlbums, err = r.queries.GetNewestAlbums(ctx, sqlc.GetNewestAlbumsParams{MusicFolderIds: folderIDs, Limit: limit, Offset: offset})
+	case domain.AlbumListAlphabeticalByName:
+		sqlAlbums, err = r.queries.GetAlbumsByName(ctx, sqlc.GetAlbumsByNameParams{MusicFolderIds: folderIDs, Limit: limit, Offset: offset})
+	case domain.AlbumListAlphabeticalByArtist:
+		sqlAlbums, err = r.queries.GetAlbumsByArtistName(ctx, sqlc.GetAlbumsByArtistNameParams{MusicFolderIds: folderIDs, Limit: limit, Offset: offset})
+	case domain.AlbumListByYear:
+		sqlAlbums, err = r.queries.GetAlbumsByYear(ctx, sqlc.GetAlbumsByYearParams{
+			MinYear:        int32(min(query.FromYear, query.ToYear)),
+			MaxYear:        int32(max(query.FromYear, query.ToYear)),
+			MusicFolderIds: folderIDs,
+			Descending:     query.FromYear > query.ToYear,
+			Limit:          limit,
+			Offset:         offset,
+		})
+	case domain.AlbumListByGenre:
+		sqlAlbums, err = r.queries.GetAlbumsByGenre(ctx, sqlc.GetAlbumsByGenreParams{Name: query.Genre, MusicFolderIds: folderIDs, Limit: limit, Offset: offset})
+	default:
+		return nil, fmt.Errorf("unsupported album list type %q", query.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get album list: %w", err)
+	}
+
+	albums := make([]domain.Album, 0, len(sqlAlbums))
+	for _, sqlAlbum := range sqlAlbums {
+		albums = append(albums, toDomainAlbum(sqlAlbum))
+	}
+	return albums, nil
+}
+
 func (r *SQLMediaBrowsingRepository) GetSongByID(ctx context.Context, id int) (domain.Song, error) {
 	sqlSong, err := r.queries.GetSong(ctx, int32(id))
 	if err != nil {
@@ -57,7 +129,234 @@ func (r *SQLMediaBrowsingRepository) GetSongByID(ctx context.Context, id int) (d
 		return domain.Song{}, fmt.Errorf("failed to get song: %w", err)
 	}
 
-	return toDomainSong(sqlSong), nil
+	songs, err := r.withGenres(ctx, []sqlc.Song{sqlSong})
+	if err != nil {
+		return domain.Song{}, err
+	}
+	return songs[0], nil
+}
+
+func (r *SQLMediaBrowsingRepository) GetSongsByAlbumID(ctx context.Context, albumID int) ([]domain.Song, error) {
+	sqlSongs, err := r.queries.GetSongs(ctx, toInt4(albumID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get songs: %w", err)
+	}
+
+	return r.withGenres(ctx, sqlSongs)
+}
+
+func (r *SQLMediaBrowsingRepository) GetMusicFolders(ctx context.Context) ([]domain.MusicFolder, error) {
+	sqlFolders, err := r.queries.GetMusicFolders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get music folders: %w", err)
+	}
+
+	folders := make([]domain.MusicFolder, 0, len(sqlFolders))
+	for _, sqlFolder := range sqlFolders {
+		folders = append(folders, toDomainMusicFolder(sqlFolder))
+	}
+	return folders, nil
+}
+
+func (r *SQLMediaBrowsingRepository) SaveMusicFolders(ctx context.Context, paths []string) ([]domain.MusicFolder, error) {
+	folders := make([]domain.MusicFolder, 0, len(paths))
+	for _, path := range paths {
+		sqlFolder, err := r.queries.CreateMusicFolder(ctx, sqlc.CreateMusicFolderParams{
+			Path: path,
+			Name: filepath.Base(path),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save music folder: %w", err)
+		}
+		folders = append(folders, toDomainMusicFolder(sqlFolder))
+	}
+	return folders, nil
+}
+
+func (r *SQLMediaBrowsingRepository) GetDirectory(ctx context.Context, id int) (domain.Directory, error) {
+	sqlDirectory, err := r.queries.GetDirectory(ctx, int32(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Directory{}, &ports.NotFoundError{Message: "directory not found"}
+		}
+		return domain.Directory{}, fmt.Errorf("failed to get directory: %w", err)
+	}
+
+	return toDomainDirectory(sqlDirectory), nil
+}
+
+func (r *SQLMediaBrowsingRepository) GetDirectoryByPath(ctx context.Context, path string) (domain.Directory, error) {
+	sqlDirectory, err := r.queries.GetDirectoryByPath(ctx, path)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Directory{}, &ports.NotFoundError{Message: "directory not found"}
+		}
+		return domain.Directory{}, fmt.Errorf("failed to get directory: %w", err)
+	}
+
+	return toDomainDirectory(sqlDirectory), nil
+}
+
+func (r *SQLMediaBrowsingRepository) GetSubdirectories(ctx context.Context, parentIDs []int) ([]domain.Directory, error) {
+	sqlDirectories, err := r.queries.GetSubdirectories(ctx, toInt32s(parentIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subdirectories: %w", err)
+	}
+
+	directories := make([]domain.Directory, 0, len(sqlDirectories))
+	for _, sqlDirectory := range sqlDirectories {
+		directories = append(directories, toDomainDirectory(sqlDirectory))
+	}
+	return directories, nil
+}
+
+func (r *SQLMediaBrowsingRepository) GetSongsByDirectory(ctx context.Context, directoryID int) ([]domain.Song, error) {
+	sqlSongs, err := r.queries.GetSongsByDirectory(ctx, int32(directoryID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get songs by directory: %w", err)
+	}
+
+	return r.withGenres(ctx, sqlSongs)
+}
+
+func (r *SQLMediaBrowsingRepository) GetGenres(ctx context.Context, musicFolderIDs []int) ([]domain.Genre, error) {
+	genres := []domain.Genre{}
+	if musicFolderIDs == nil {
+		sqlGenres, err := r.queries.GetGenres(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get genres: %w", err)
+		}
+		for _, sqlGenre := range sqlGenres {
+			genres = append(genres, domain.Genre{
+				Name:       sqlGenre.Name,
+				SongCount:  int(sqlGenre.SongCount),
+				AlbumCount: int(sqlGenre.AlbumCount),
+			})
+		}
+		return genres, nil
+	}
+
+	// Stored counts cover every music folder, count the songs and albums of musicFolderIDs instead
+	rows, err := r.queries.GetGenresByMusicFolders(ctx, toInt32s(musicFolderIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get genres: %w", err)
+	}
+	for _, row := range rows {
+		genres = append(genres, domain.Genre{
+			Name:       row.Name,
+			SongCount:  int(row.SongCount),
+			AlbumCount: int(row.AlbumCount),
+		})
+	}
+	return genres, nil
+}
+
+func (r *SQLMediaBrowsingRepository) GetSongsByGenre(ctx context.Context, genre string, musicFolderIDs []int, limit int, offset int) ([]domain.Song, error) {
+	sqlSongs, err := r.queries.GetSongsByGenre(ctx, sqlc.GetSongsByGenreParams{
+		Name:           genre,
+		MusicFolderIds: toInt32s(musicFolderIDs),
+		Limit:          int32(limit),
+		Offset:         int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get songs by genre: %w", err)
+	}
+
+	return r.withGenres(ctx, sqlSongs)
+}
+
+func (r *SQLMediaBrowsingRepository) SearchArtists(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int) ([]domain.Artist, error) {
+	sqlArtists, err := r.queries.SearchArtists(ctx, sqlc.SearchArtistsParams{
+		Query:          query,
+		Terms:          prefixTSQuery(query),
+		MusicFolderIds: toInt32s(musicFolderIDs),
+		Limit:          int32(limit),
+		Offset:         int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search artists: %w", err)
+	}
+
+	artists := make([]domain.Artist, 0, len(sqlArtists))
+	for _, sqlArtist := range sqlArtists {
+		artists = append(artists, toDomainArtist(sqlArtist))
+	}
+	return artists, nil
+}
+
+func (r *SQLMediaBrowsingRepository) SearchAlbums(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error) {
+	sqlAlbums, err := r.queries.SearchAlbums(ctx, sqlc.SearchAlbumsParams{
+		Query:          query,
+		Terms:          prefixTSQuery(query),
+		MusicFolderIds: toInt32s(musicFolderIDs),
+		Limit:          int32(limit),
+		Offset:         int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search albums: %w", err)
+	}
+
+	albums := make([]domain.Album, 0, len(sqlAlbums))
+	for _, sqlAlbum := range sqlAlbums {
+		albums = append(albums, toDomainAlbum(sqlAlbum))
+	}
+	return albums, nil
+}
+
+func (r *SQLMediaBrowsingRepository) SearchSongs(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int) ([]domain.Song, error) {
+	sqlSongs, err := r.queries.SearchSongs(ctx, sqlc.SearchSongsParams{
+		Query:          query,
+		Terms:          prefixTSQuery(query),
+		MusicFolderIds: toInt32s(musicFolderIDs),
+		Limit:          int32(limit),
+		Offset:         int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search songs: %w", err)
+	}
+
+	return r.withGenres(ctx, sqlSongs)
+}
+
+// prefixTSQuery builds a tsquery matching the start of every word of query, such as "bjo:* & hom:*".
+// Words are split on anything but letters and digits, leaving no tsquery operator in them.
+func prefixTSQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// withGenres converts songs to domain songs with their genres.
+func (r *SQLMediaBrowsingRepository) withGenres(ctx context.Context, sqlSongs []sqlc.Song) ([]domain.Song, error) {
+	return songsWithGenres(ctx, r.queries, sqlSongs)
+}
+
+// songsWithGenres converts songs to domain songs with their genres.
+func songsWithGenres(ctx context.Context, queries *sqlc.Queries, sqlSongs []sqlc.Song) ([]domain.Song, error) {
+	songIDs := make([]int32, 0, len(sqlSongs))
+	for _, sqlSong := range sqlSongs {
+		songIDs = append(songIDs, sqlSong.SongID)
+	}
+	rows, err := queries.GetSongGenres(ctx, songIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get song genres: %w", err)
+	}
+	genres := make(map[int32][]string)
+	for _, row := range rows {
+		genres[row.SongID] = append(genres[row.SongID], row.Name)
+	}
+
+	songs := make([]domain.Song, 0, len(sqlSongs))
+	for _, sqlSong := range sqlSongs {
+		song := toDomainSong(sqlSong)
+		song.Genres = genres[sqlSong.SongID]
+		songs = append(songs, song)
+	}
+	return songs, nil
 }
 
 func (r *SQLMediaBrowsingRepository) GetCoverByID(ctx context.Context, id string) (domain.Cover, error) {
@@ -76,19 +375,13 @@ func (r *SQLMediaBrowsingRepository) GetCoverByID(ctx context.Context, id string
 }
 
 func (r *SQLMediaBrowsingRepository) CreateArtist(ctx context.Context, artist domain.Artist) (domain.Artist, error) {
-	var coverArt pgtype.Text
-	if artist.CoverArt != "" {
-		coverArt = pgtype.Text{String: artist.CoverArt, Valid: true}
-	}
-	var albumCount pgtype.Int4
-	if artist.AlbumCount > 0 {
-		albumCount = pgtype.Int4{Int32: int32(artist.AlbumCount), Valid: true}
-	}
-
 	sqlArtist, err := r.queries.CreateArtist(ctx, sqlc.CreateArtistParams{
-		Name:       artist.Name,
-		CoverArt:   coverArt,
-		AlbumCount: albumCount,
+		Name:          artist.Name,
+		CoverArt:      toText(artist.CoverArt),
+		AlbumCount:    toInt4(artist.AlbumCount),
+		SortName:      artist.SortName,
+		MusicbrainzID: artist.MusicBrainzId,
+		MusicFolderID: int32(artist.MusicFolderId),
 	})
 	if err != nil {
 		return domain.Artist{}, fmt.Errorf("failed to create artist: %w", err)
@@ -98,43 +391,19 @@ func (r *SQLMediaBrowsingRepository) CreateArtist(ctx context.Context, artist do
 }
 
 func (r *SQLMediaBrowsingRepository) CreateAlbum(ctx context.Context, album domain.Album) (domain.Album, error) {
-	var artistID pgtype.Int4
-	if album.ArtistId > 0 {
-		artistID = pgtype.Int4{Int32: int32(album.ArtistId), Valid: true}
-	}
-	var coverArt pgtype.Text
-	if album.CoverArt != "" {
-		coverArt = pgtype.Text{String: album.CoverArt, Valid: true}
-	}
-	var songCount pgtype.Int4
-	if album.SongCount > 0 {
-		songCount = pgtype.Int4{Int32: int32(album.SongCount), Valid: true}
-	}
-	var duration pgtype.Int4
-	if album.Duration > 0 {
-		duration = pgtype.Int4{Int32: int32(album.Duration), Valid: true}
-	}
-	var artist pgtype.Text
-	if album.Artist != "" {
-		artist = pgtype.Text{String: album.Artist, Valid: true}
-	}
-
-	var created pgtype.Timestamp
-	if album.Created != "" {
-		t, err := time.Parse(time.RFC3339, album.Created)
-		if err == nil {
-			created = pgtype.Timestamp{Time: t, Valid: true}
-		}
-	}
-
 	sqlAlbum, err := r.queries.CreateAlbum(ctx, sqlc.CreateAlbumParams{
-		ArtistID:  artistID,
-		Name:      album.Name,
-		CoverArt:  coverArt,
-		SongCount: songCount,
-		Created:   created,
-		Duration:  duration,
-		Artist:    artist,
+		ArtistID:      toInt4(album.ArtistId),
+		Name:          album.Name,
+		CoverArt:      toText(album.CoverArt),
+		SongCount:     toInt4(album.SongCount),
+		Created:       toTimestamp(album.Created),
+		Duration:      toInt4(album.Duration),
+		Artist:        toText(album.Artist),
+		Year:          int32(album.Year),
+		Compilation:   album.Compilation,
+		SortName:      album.SortName,
+		MusicbrainzID: album.MusicBrainzId,
+		MusicFolderID: int32(album.MusicFolderId),
 	})
 	if err != nil {
 		return domain.Album{}, fmt.Errorf("failed to create album: %w", err)
@@ -144,75 +413,41 @@ func (r *SQLMediaBrowsingRepository) CreateAlbum(ctx context.Context, album doma
 }
 
 func (r *SQLMediaBrowsingRepository) CreateSong(ctx context.Context, song domain.Song) (domain.Song, error) {
-	var albumID pgtype.Int4
-	if song.AlbumId > 0 {
-		albumID = pgtype.Int4{Int32: int32(song.AlbumId), Valid: true}
-	}
-	var album pgtype.Text
-	if song.Album != "" {
-		album = pgtype.Text{String: song.Album, Valid: true}
-	}
-	var artist pgtype.Text
-	if song.Artist != "" {
-		artist = pgtype.Text{String: song.Artist, Valid: true}
-	}
-	isDir := pgtype.Bool{Bool: song.IsDir, Valid: true}
-	var coverArt pgtype.Text
-	if song.CoverArt != "" {
-		coverArt = pgtype.Text{String: song.CoverArt, Valid: true}
-	}
-	var created pgtype.Timestamp
-	if song.Created != "" {
-		t, err := time.Parse(time.RFC3339, song.Created)
-		if err == nil {
-			created = pgtype.Timestamp{Time: t, Valid: true}
-		}
+	return createSong(ctx, r.queries, song)
+}
+
+func (r *SQLMediaBrowsingRepository) CreateDirectory(ctx context.Context, directory domain.Directory) (domain.Directory, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return domain.Directory{}, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	var duration pgtype.Int4
-	if song.Duration > 0 {
-		duration = pgtype.Int4{Int32: int32(song.Duration), Valid: true}
-	}
-	var bitRate pgtype.Int4
-	if song.BitRate > 0 {
-		bitRate = pgtype.Int4{Int32: int32(song.BitRate), Valid: true}
-	}
-	var size pgtype.Int4
-	if song.Size > 0 {
-		// Note: pgtype.Int4 can only handle up to int32, but domain uses int64
-		// This might cause issues with very large files
-		size = pgtype.Int4{Int32: int32(song.Size), Valid: true}
-	}
-	var suffix pgtype.Text
-	if song.Suffix != "" {
-		suffix = pgtype.Text{String: song.Suffix, Valid: true}
-	}
-	var contentType pgtype.Text
-	if song.ContentType != "" {
-		contentType = pgtype.Text{String: song.ContentType, Valid: true}
-	}
-	isVideo := pgtype.Bool{Bool: song.IsVideo, Valid: true}
-
-	sqlSong, err := r.queries.CreateSong(ctx, sqlc.CreateSongParams{
-		AlbumID:     albumID,
-		Title:       song.Title,
-		Album:       album,
-		Artist:      artist,
-		IsDir:       isDir,
-		CoverArt:    coverArt,
-		Created:     created,
-		Duration:    duration,
-		BitRate:     bitRate,
-		Size:        size,
-		Suffix:      suffix,
-		ContentType: contentType,
-		IsVideo:     isVideo,
-		Path:        song.Path,
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	queries := r.queries.WithTx(tx)
+	sqlDirectory, err := queries.CreateDirectory(ctx, sqlc.CreateDirectoryParams{
+		ParentID:      toInt4(directory.ParentId),
+		MusicFolderID: int32(directory.MusicFolderId),
+		Path:          directory.Path,
+		Name:          directory.Name,
+		Created:       toTimestamp(directory.Created),
+		Updated:       directory.Updated,
 	})
 	if err != nil {
-		return domain.Song{}, fmt.Errorf("failed to create song: %w", err)
+		return domain.Directory{}, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if directory.ParentId > 0 {
+		if err := queries.TouchDirectories(ctx, sqlc.TouchDirectoriesParams{
+			Updated:      directory.Updated,
+			DirectoryIds: []int32{int32(directory.ParentId)},
+		}); err != nil {
+			return domain.Directory{}, fmt.Errorf("failed to update parent directory: %w", err)
+		}
 	}
 
-	return toDomainSong(sqlSong), nil
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Directory{}, fmt.Errorf("failed to commit directory: %w", err)
+	}
+	return toDomainDirectory(sqlDirectory), nil
 }
 
 func (r *SQLMediaBrowsingRepository) CreateCover(ctx context.Context, cover domain.Cover) (domain.Cover, error) {
@@ -230,12 +465,419 @@ func (r *SQLMediaBrowsingRepository) CreateCover(ctx context.Context, cover doma
 	}, nil
 }
 
+func (r *SQLMediaBrowsingRepository) GetArtistByName(ctx context.Context, musicFolderID int, name string) (domain.Artist, error) {
+	sqlArtist, err := r.queries.GetArtistByName(ctx, sqlc.GetArtistByNameParams{
+		Name:          name,
+		MusicFolderID: int32(musicFolderID),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Artist{}, &ports.NotFoundError{Message: "artist not found"}
+		}
+		return domain.Artist{}, fmt.Errorf("failed to get artist: %w", err)
+	}
+
+	return toDomainArtist(sqlArtist), nil
+}
+
+func (r *SQLMediaBrowsingRepository) GetArtistByMusicBrainzID(ctx context.Context, musicFolderID int, musicBrainzID string) (domain.Artist, error) {
+	sqlArtist, err := r.queries.GetArtistByMusicBrainzID(ctx, sqlc.GetArtistByMusicBrainzIDParams{
+		MusicbrainzID: musicBrainzID,
+		MusicFolderID: int32(musicFolderID),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Artist{}, &ports.NotFoundError{Message: "artist not found"}
+		}
+		return domain.Artist{}, fmt.Errorf("failed to get artist: %w", err)
+	}
+
+	return toDomainArtist(sqlArtist), nil
+}
+
+func (r *SQLMediaBrowsingRepository) GetAlbumByRelease(ctx context.Context, artistID int, name string, year int) (domain.Album, error) {
+	sqlAlbum, err := r.queries.GetAlbumByRelease(ctx, sqlc.GetAlbumByReleaseParams{
+		ArtistID: toInt4(artistID),
+		Name:     name,
+		Year:     int32(year),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Album{}, &ports.NotFoundError{Message: "album not found"}
+		}
+		return domain.Album{}, fmt.Errorf("failed to get album: %w", err)
+	}
+
+	return toDomainAlbum(sqlAlbum), nil
+}
+
+func (r *SQLMediaBrowsingRepository) GetSongFingerprints(ctx context.Context) ([]domain.FileFingerprint, error) {
+	rows, err := r.queries.GetSongFingerprints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get song fingerprints: %w", err)
+	}
+
+	fingerprints := make([]domain.FileFingerprint, 0, len(rows))
+	for _, row := range rows {
+		fingerprints = append(fingerprints, domain.FileFingerprint{
+			SongId:      int(row.SongID),
+			AlbumId:     int(row.AlbumID.Int32),
+			Path:        row.Path,
+			Size:        row.Size.Int64,
+			ModTime:     row.ModTime,
+			StartOffset: int(row.StartOffset),
+			CuePath:     row.CuePath,
+			LyricsPath:  row.LyricsPath,
+		})
+	}
+	return fingerprints, nil
+}
+
+func (r *SQLMediaBrowsingRepository) UpdateArtist(ctx context.Context, artist domain.Artist) (domain.Artist, error) {
+	sqlArtist, err := r.queries.UpdateArtist(ctx, sqlc.UpdateArtistParams{
+		ArtistID:      int32(artist.Id),
+		Name:          artist.Name,
+		CoverArt:      toText(artist.CoverArt),
+		AlbumCount:    toInt4(artist.AlbumCount),
+		SortName:      artist.SortName,
+		MusicbrainzID: artist.MusicBrainzId,
+		MusicFolderID: int32(artist.MusicFolderId),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Artist{}, &ports.NotFoundError{Message: "artist not found"}
+		}
+		return domain.Artist{}, fmt.Errorf("failed to update artist: %w", err)
+	}
+
+	return toDomainArtist(sqlArtist), nil
+}
+
+func (r *SQLMediaBrowsingRepository) UpdateAlbum(ctx context.Context, album domain.Album) (domain.Album, error) {
+	sqlAlbum, err := r.queries.UpdateAlbum(ctx, sqlc.UpdateAlbumParams{
+		AlbumID:       int32(album.Id),
+		ArtistID:      toInt4(album.ArtistId),
+		Name:          album.Name,
+		CoverArt:      toText(album.CoverArt),
+		SongCount:     toInt4(album.SongCount),
+		Created:       toTimestamp(album.Created),
+		Duration:      toInt4(album.Duration),
+		Artist:        toText(album.Artist),
+		Year:          int32(album.Year),
+		Compilation:   album.Compilation,
+		SortName:      album.SortName,
+		MusicbrainzID: album.MusicBrainzId,
+		MusicFolderID: int32(album.MusicFolderId),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Album{}, &ports.NotFoundError{Message: "album not found"}
+		}
+		return domain.Album{}, fmt.Errorf("failed to update album: %w", err)
+	}
+
+	return toDomainAlbum(sqlAlbum), nil
+}
+
+func (r *SQLMediaBrowsingRepository) UpdateSong(ctx context.Context, song domain.Song) (domain.Song, error) {
+	return updateSong(ctx, r.queries, song)
+}
+
+func (r *SQLMediaBrowsingRepository) SaveSongs(ctx context.Context, songs []domain.Song) ([]domain.Song, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	queries := r.queries.WithTx(tx)
+	saved := make([]domain.Song, 0, len(songs))
+	for _, song := range songs {
+		if song.Id > 0 {
+			song, err = updateSong(ctx, queries, song)
+		} else {
+			song, err = createSong(ctx, queries, song)
+		}
+		if err != nil {
+			return nil, err
+		}
+		saved = append(saved, song)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit songs: %w", err)
+	}
+	return saved, nil
+}
+
+func (r *SQLMediaBrowsingRepository) DeleteSongs(ctx context.Context, ids []int) (int, error) {
+	songIDs := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		songIDs = append(songIDs, int32(id))
+	}
+
+	deleted, err := r.queries.DeleteSongs(ctx, songIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete songs: %w", err)
+	}
+	return int(deleted), nil
+}
+
+func (r *SQLMediaBrowsingRepository) DeleteOrphans(ctx context.Context) (int, error) {
+	albums, err := r.queries.DeleteOrphanAlbums(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete orphan albums: %w", err)
+	}
+	artists, err := r.queries.DeleteOrphanArtists(ctx)
+	if err != nil {
+		return int(albums), fmt.Errorf("failed to delete orphan artists: %w", err)
+	}
+	genres, err := r.queries.DeleteOrphanGenres(ctx)
+	if err != nil {
+		return int(albums + artists), fmt.Errorf("failed to delete orphan genres: %w", err)
+	}
+	directories, err := r.deleteOrphanDirectories(ctx)
+	return int(albums+artists+genres) + directories, err
+}
+
+// deleteOrphanDirectories removes directories without songs below them, deepest first, and marks the
+// parents of removed directories as updated. Returns the number of removed directories.
+func (r *SQLMediaBrowsingRepository) deleteOrphanDirectories(ctx context.Context) (int, error) {
+	removed := 0
+	for {
+		parents, err := r.queries.DeleteOrphanDirectories(ctx)
+		if err != nil {
+			return removed, fmt.Errorf("failed to delete orphan directories: %w", err)
+		}
+		if len(parents) == 0 {
+			return removed, nil
+		}
+		removed += len(parents)
+
+		parentIDs := make([]int32, 0, len(parents))
+		for _, parent := range parents {
+			if parent.Valid {
+				parentIDs = append(parentIDs, parent.Int32)
+			}
+		}
+		if err := r.queries.TouchDirectories(ctx, sqlc.TouchDirectoriesParams{
+			Updated:      time.Now().UnixMilli(),
+			DirectoryIds: parentIDs,
+		}); err != nil {
+			return removed, fmt.Errorf("failed to update parent directories: %w", err)
+		}
+	}
+}
+
+func (r *SQLMediaBrowsingRepository) UpdateCatalogStats(ctx context.Context) error {
+	if err := r.queries.UpdateAlbumStats(ctx); err != nil {
+		return fmt.Errorf("failed to update album stats: %w", err)
+	}
+	if err := r.queries.UpdateArtistStats(ctx); err != nil {
+		return fmt.Errorf("failed to update artist stats: %w", err)
+	}
+
+	// Album genres are replaced at once so genre counts never see them missing
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	queries := r.queries.WithTx(tx)
+	if err := queries.DeleteAlbumGenres(ctx); err != nil {
+		return fmt.Errorf("failed to delete album genres: %w", err)
+	}
+	if err := queries.CreateAlbumGenres(ctx); err != nil {
+		return fmt.Errorf("failed to create album genres: %w", err)
+	}
+	if err := queries.UpdateGenreStats(ctx); err != nil {
+		return fmt.Errorf("failed to update genre stats: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit genre stats: %w", err)
+	}
+	return nil
+}
+
 // Helper functions to convert between SQL and domain models
 
+func createSong(ctx context.Context, queries *sqlc.Queries, song domain.Song) (domain.Song, error) {
+	sqlSong, err := queries.CreateSong(ctx, sqlc.CreateSongParams{
+		AlbumID:       toInt4(song.AlbumId),
+		Title:         song.Title,
+		Album:         toText(song.Album),
+		Artist:        toText(song.Artist),
+		IsDir:         pgtype.Bool{Bool: song.IsDir, Valid: true},
+		CoverArt:      toText(song.CoverArt),
+		Created:       toTimestamp(song.Created),
+		Duration:      toInt4(song.Duration),
+		BitRate:       toInt4(song.BitRate),
+		Size:          toInt8(song.Size),
+		Suffix:        toText(song.Suffix),
+		ContentType:   toText(song.ContentType),
+		IsVideo:       pgtype.Bool{Bool: song.IsVideo, Valid: true},
+		Path:          song.Path,
+		ModTime:       song.ModTime,
+		Track:         int32(song.Track),
+		DiscNumber:    int32(song.DiscNumber),
+		Year:          int32(song.Year),
+		TrackGain:     song.ReplayGain.TrackGain,
+		TrackPeak:     song.ReplayGain.TrackPeak,
+		AlbumGain:     song.ReplayGain.AlbumGain,
+		AlbumPeak:     song.ReplayGain.AlbumPeak,
+		StartOffset:   int32(song.StartOffset),
+		EndOffset:     int32(song.EndOffset),
+		CuePath:       song.CuePath,
+		LyricsPath:    song.LyricsPath,
+		SortName:      song.SortName,
+		MusicbrainzID: song.MusicBrainzId,
+		MusicFolderID: int32(song.MusicFolderId),
+		DirectoryID:   int32(song.DirectoryId),
+	})
+	if err != nil {
+		return domain.Song{}, fmt.Errorf("failed to create song: %w", err)
+	}
+
+	saved := toDomainSong(sqlSong)
+	saved.Genres = song.Genres
+	return saved, saveSongGenres(ctx, queries, saved)
+}
+
+func updateSong(ctx context.Context, queries *sqlc.Queries, song domain.Song) (domain.Song, error) {
+	sqlSong, err := queries.UpdateSong(ctx, sqlc.UpdateSongParams{
+		SongID:        int32(song.Id),
+		AlbumID:       toInt4(song.AlbumId),
+		Title:         song.Title,
+		Album:         toText(song.Album),
+		Artist:        toText(song.Artist),
+		IsDir:         pgtype.Bool{Bool: song.IsDir, Valid: true},
+		CoverArt:      toText(song.CoverArt),
+		Created:       toTimestamp(song.Created),
+		Duration:      toInt4(song.Duration),
+		BitRate:       toInt4(song.BitRate),
+		Size:          toInt8(song.Size),
+		Suffix:        toText(song.Suffix),
+		ContentType:   toText(song.ContentType),
+		IsVideo:       pgtype.Bool{Bool: song.IsVideo, Valid: true},
+		Path:          song.Path,
+		ModTime:       song.ModTime,
+		Track:         int32(song.Track),
+		DiscNumber:    int32(song.DiscNumber),
+		Year:          int32(song.Year),
+		TrackGain:     song.ReplayGain.TrackGain,
+		TrackPeak:     song.ReplayGain.TrackPeak,
+		AlbumGain:     song.ReplayGain.AlbumGain,
+		AlbumPeak:     song.ReplayGain.AlbumPeak,
+		StartOffset:   int32(song.StartOffset),
+		EndOffset:     int32(song.EndOffset),
+		CuePath:       song.CuePath,
+		LyricsPath:    song.LyricsPath,
+		SortName:      song.SortName,
+		MusicbrainzID: song.MusicBrainzId,
+		MusicFolderID: int32(song.MusicFolderId),
+		DirectoryID:   int32(song.DirectoryId),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Song{}, &ports.NotFoundError{Message: "song not found"}
+		}
+		return domain.Song{}, fmt.Errorf("failed to update song: %w", err)
+	}
+
+	saved := toDomainSong(sqlSong)
+	saved.Genres = song.Genres
+	if err := queries.DeleteSongGenres(ctx, sqlSong.SongID); err != nil {
+		return domain.Song{}, fmt.Errorf("failed to delete song genres: %w", err)
+	}
+	return saved, saveSongGenres(ctx, queries, saved)
+}
+
+// saveSongGenres links a saved song to its genres, creating the genres not known yet.
+func saveSongGenres(ctx context.Context, queries *sqlc.Queries, song domain.Song) error {
+	for i, name := range song.Genres {
+		genre, err := queries.CreateGenre(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to create genre: %w", err)
+		}
+		if err := queries.CreateSongGenre(ctx, sqlc.CreateSongGenreParams{
+			SongID:   int32(song.Id),
+			GenreID:  genre.GenreID,
+			Position: int32(i),
+		}); err != nil {
+			return fmt.Errorf("failed to create song genre: %w", err)
+		}
+	}
+	return nil
+}
+
+func toText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func toInt4(n int) pgtype.Int4 {
+	return pgtype.Int4{Int32: int32(n), Valid: n > 0}
+}
+
+func toInt8(n int64) pgtype.Int8 {
+	return pgtype.Int8{Int64: n, Valid: n > 0}
+}
+
+func toTimestamp(s string) pgtype.Timestamp {
+	if s == "" {
+		return pgtype.Timestamp{}
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return pgtype.Timestamp{}
+	}
+	return pgtype.Timestamp{Time: t, Valid: true}
+}
+
+func toDomainMusicFolder(sqlFolder sqlc.MusicFolder) domain.MusicFolder {
+	return domain.MusicFolder{
+		Id:   int(sqlFolder.MusicFolderID),
+		Name: sqlFolder.Name,
+		Path: sqlFolder.Path,
+	}
+}
+
+func toDomainDirectory(sqlDirectory sqlc.Directory) domain.Directory {
+	directory := domain.Directory{
+		Id:            int(sqlDirectory.DirectoryID),
+		MusicFolderId: int(sqlDirectory.MusicFolderID),
+		Path:          sqlDirectory.Path,
+		Name:          sqlDirectory.Name,
+		Updated:       sqlDirectory.Updated,
+	}
+	if sqlDirectory.ParentID.Valid {
+		directory.ParentId = int(sqlDirectory.ParentID.Int32)
+	}
+	if sqlDirectory.Created.Valid {
+		directory.Created = sqlDirectory.Created.Time.Format(time.RFC3339)
+	}
+	return directory
+}
+
+// toInt32s converts IDs to query parameters, nil staying nil.
+func toInt32s(ids []int) []int32 {
+	if ids == nil {
+		return nil
+	}
+	converted := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		converted = append(converted, int32(id))
+	}
+	return converted
+}
+
 func toDomainArtist(sqlArtist sqlc.Artist) domain.Artist {
 	artist := domain.Artist{
-		Id:   int(sqlArtist.ArtistID),
-		Name: sqlArtist.Name,
+		Id:            int(sqlArtist.ArtistID),
+		Name:          sqlArtist.Name,
+		SortName:      sqlArtist.SortName,
+		MusicBrainzId: sqlArtist.MusicbrainzID,
+		MusicFolderId: int(sqlArtist.MusicFolderID),
 	}
 	if sqlArtist.CoverArt.Valid {
 		artist.CoverArt = sqlArtist.CoverArt.String
@@ -243,13 +885,21 @@ func toDomainArtist(sqlArtist sqlc.Artist) domain.Artist {
 	if sqlArtist.AlbumCount.Valid {
 		artist.AlbumCount = int(sqlArtist.AlbumCount.Int32)
 	}
+	if sqlArtist.DirectoryID.Valid {
+		artist.DirectoryId = int(sqlArtist.DirectoryID.Int32)
+	}
 	return artist
 }
 
 func toDomainAlbum(sqlAlbum sqlc.Album) domain.Album {
 	album := domain.Album{
-		Id:   int(sqlAlbum.AlbumID),
-		Name: sqlAlbum.Name,
+		Id:            int(sqlAlbum.AlbumID),
+		Name:          sqlAlbum.Name,
+		Year:          int(sqlAlbum.Year),
+		Compilation:   sqlAlbum.Compilation,
+		SortName:      sqlAlbum.SortName,
+		MusicBrainzId: sqlAlbum.MusicbrainzID,
+		MusicFolderId: int(sqlAlbum.MusicFolderID),
 	}
 	if sqlAlbum.ArtistID.Valid {
 		album.ArtistId = int(sqlAlbum.ArtistID.Int32)
@@ -257,6 +907,9 @@ func toDomainAlbum(sqlAlbum sqlc.Album) domain.Album {
 	if sqlAlbum.CoverArt.Valid {
 		album.CoverArt = sqlAlbum.CoverArt.String
 	}
+	if sqlAlbum.DirectoryID.Valid {
+		album.DirectoryId = int(sqlAlbum.DirectoryID.Int32)
+	}
 	if sqlAlbum.SongCount.Valid {
 		album.SongCount = int(sqlAlbum.SongCount.Int32)
 	}
@@ -274,9 +927,27 @@ func toDomainAlbum(sqlAlbum sqlc.Album) domain.Album {
 
 func toDomainSong(sqlSong sqlc.Song) domain.Song {
 	song := domain.Song{
-		Id:    int(sqlSong.SongID),
-		Title: sqlSong.Title,
-		Path:  sqlSong.Path,
+		Id:            int(sqlSong.SongID),
+		Title:         sqlSong.Title,
+		Path:          sqlSong.Path,
+		ModTime:       sqlSong.ModTime,
+		Track:         int(sqlSong.Track),
+		DiscNumber:    int(sqlSong.DiscNumber),
+		Year:          int(sqlSong.Year),
+		StartOffset:   int(sqlSong.StartOffset),
+		EndOffset:     int(sqlSong.EndOffset),
+		CuePath:       sqlSong.CuePath,
+		LyricsPath:    sqlSong.LyricsPath,
+		SortName:      sqlSong.SortName,
+		MusicBrainzId: sqlSong.MusicbrainzID,
+		MusicFolderId: int(sqlSong.MusicFolderID),
+		DirectoryId:   int(sqlSong.DirectoryID),
+		ReplayGain: domain.ReplayGain{
+			TrackGain: sqlSong.TrackGain,
+			TrackPeak: sqlSong.TrackPeak,
+			AlbumGain: sqlSong.AlbumGain,
+			AlbumPeak: sqlSong.AlbumPeak,
+		},
 	}
 	if sqlSong.AlbumID.Valid {
 		song.AlbumId = int(sqlSong.AlbumID.Int32)
@@ -303,7 +974,7 @@ func toDomainSong(sqlSong sqlc.Song) domain.Song {
 		song.BitRate = int(sqlSong.BitRate.Int32)
 	}
 	if sqlSong.Size.Valid {
-		song.Size = int64(sqlSong.Size.Int32)
+		song.Size = sqlSong.Size.Int64
 	}
 	if sqlSong.Suffix.Valid {
 		song.Suffix = sqlSong.Suffix.String
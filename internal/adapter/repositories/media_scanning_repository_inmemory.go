@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"slices"
+	"sync"
+)
+
+/*
+* scan runs have their id created by the repository (auto-increment)
+ */
+
+type InMemoryMediaScanningRepository struct {
+	runs      map[int]domain.ScanRun
+	nextRunID int
+	mu        sync.RWMutex
+}
+
+func NewInMemoryMediaScanningRepository() *InMemoryMediaScanningRepository {
+	return &InMemoryMediaScanningRepository{
+		runs:      make(map[int]domain.ScanRun),
+		nextRunID: 1,
+	}
+}
+
+func (r *InMemoryMediaScanningRepository) CreateScanRun(ctx context.Context, run domain.ScanRun) (domain.ScanRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run.Id = r.nextRunID
+	r.nextRunID++
+	r.runs[run.Id] = run
+	return run, nil
+}
+
+func (r *InMemoryMediaScanningRepository) FinishScanRun(ctx context.Context, run domain.ScanRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.runs[run.Id]; !exists {
+		return &ports.NotFoundError{Message: "scan run not found"}
+	}
+	run.Errors = slices.Clone(run.Errors)
+	r.runs[run.Id] = run
+	return nil
+}
+
+func (r *InMemoryMediaScanningRepository) GetScanRuns(ctx context.Context, count int, offset int) ([]domain.ScanRun, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	runs := make([]domain.ScanRun, 0, len(r.runs))
+	for _, run := range r.runs {
+		run.Errors = nil
+		runs = append(runs, run)
+	}
+	// Most recent first, IDs increase with the start time
+	slices.SortFunc(runs, func(a, b domain.ScanRun) int {
+		return b.Id - a.Id
+	})
+
+	if offset >= len(runs) {
+		return []domain.ScanRun{}, nil
+	}
+	return runs[offset:min(offset+count, len(runs))], nil
+}
+
+func (r *InMemoryMediaScanningRepository) GetScanRun(ctx context.Context, id int) (domain.ScanRun, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	run, exists := r.runs[id]
+	if !exists {
+		return domain.ScanRun{}, &ports.NotFoundError{Message: "scan run not found"}
+	}
+	run.Errors = slices.Clone(run.Errors)
+	return run, nil
+}
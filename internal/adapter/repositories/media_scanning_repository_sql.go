@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	sqlc "music-streaming/internal/adapter/sql/sqlc"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SQLMediaScanningRepository struct {
+	queries *sqlc.Queries
+	db      *pgxpool.Pool
+}
+
+func NewSQLMediaScanningRepository(db *pgxpool.Pool) *SQLMediaScanningRepository {
+	return &SQLMediaScanningRepository{
+		queries: sqlc.New(db),
+		db:      db,
+	}
+}
+
+func (r *SQLMediaScanningRepository) CreateScanRun(ctx context.Context, run domain.ScanRun) (domain.ScanRun, error) {
+	sqlRun, err := r.queries.CreateScanRun(ctx, sqlc.CreateScanRunParams{
+		TriggerType: string(run.Trigger),
+		Partial:     run.Partial,
+		Started:     toTimestamp(run.Started),
+	})
+	if err != nil {
+		return domain.ScanRun{}, fmt.Errorf("failed to create scan run: %w", err)
+	}
+
+	return toDomainScanRun(sqlRun), nil
+}
+
+func (r *SQLMediaScanningRepository) FinishScanRun(ctx context.Context, run domain.ScanRun) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	queries := r.queries.WithTx(tx)
+	updated, err := queries.FinishScanRun(ctx, sqlc.FinishScanRunParams{
+		ScanRunID: int32(run.Id),
+		Cancelled: run.Cancelled,
+		Finished:  toTimestamp(run.Finished),
+		Added:     int32(run.Added),
+		Updated:   int32(run.Updated),
+		Removed:   int32(run.Removed),
+		Failed:    int32(run.Failed),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to finish scan run: %w", err)
+	}
+	if updated == 0 {
+		return &ports.NotFoundError{Message: "scan run not found"}
+	}
+
+	for _, scanError := range run.Errors {
+		if err := queries.CreateScanError(ctx, sqlc.CreateScanErrorParams{
+			ScanRunID: int32(run.Id),
+			Path:      scanError.Path,
+			Message:   scanError.Message,
+		}); err != nil {
+			return fmt.Errorf("failed to create scan error: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit scan run: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLMediaScanningRepository) GetScanRuns(ctx context.Context, count int, offset int) ([]domain.ScanRun, error) {
+	sqlRuns, err := r.queries.GetScanRuns(ctx, sqlc.GetScanRunsParams{
+		Limit:  int32(count),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scan runs: %w", err)
+	}
+
+	runs := make([]domain.ScanRun, 0, len(sqlRuns))
+	for _, sqlRun := range sqlRuns {
+		runs = append(runs, toDomainScanRun(sqlRun))
+	}
+	return runs, nil
+}
+
+func (r *SQLMediaScanningRepository) GetScanRun(ctx context.Context, id int) (domain.ScanRun, error) {
+	sqlRun, err := r.queries.GetScanRun(ctx, int32(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ScanRun{}, &ports.NotFoundError{Message: "scan run not found"}
+		}
+		return domain.ScanRun{}, fmt.Errorf("failed to get scan run: %w", err)
+	}
+
+	sqlErrors, err := r.queries.GetScanErrors(ctx, int32(id))
+	if err != nil {
+		return domain.ScanRun{}, fmt.Errorf("failed to get scan errors: %w", err)
+	}
+
+	run := toDomainScanRun(sqlRun)
+	run.Errors = make([]domain.ScanError, 0, len(sqlErrors))
+	for _, sqlError := range sqlErrors {
+		run.Errors = append(run.Errors, domain.ScanError{
+			Path:    sqlError.Path,
+			Message: sqlError.Message,
+		})
+	}
+	return run, nil
+}
+
+func toDomainScanRun(sqlRun sqlc.ScanRun) domain.ScanRun {
+	run := domain.ScanRun{
+		Id:        int(sqlRun.ScanRunID),
+		Trigger:   domain.ScanTrigger(sqlRun.TriggerType),
+		Partial:   sqlRun.Partial,
+		Cancelled: sqlRun.Cancelled,
+		Added:     int(sqlRun.Added),
+		Updated:   int(sqlRun.Updated),
+		Removed:   int(sqlRun.Removed),
+		Failed:    int(sqlRun.Failed),
+	}
+	if sqlRun.Started.Valid {
+		run.Started = sqlRun.Started.Time.Format(time.RFC3339)
+	}
+	if sqlRun.Finished.Valid {
+		run.Finished = sqlRun.Finished.Time.Format(time.RFC3339)
+	}
+	return run
+}
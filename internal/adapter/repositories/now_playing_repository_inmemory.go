@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"context"
+	"music-streaming/internal/core/domain"
+	"slices"
+	"sync"
+)
+
+/*
+* the songs playing now are only kept in memory, by username and player
+ */
+
+type InMemoryNowPlayingRepository struct {
+	entries map[nowPlayingKey]domain.NowPlaying
+	mu      sync.RWMutex
+}
+
+// nowPlayingKey identifies the player of a user.
+type nowPlayingKey struct {
+	username   string
+	playerName string
+}
+
+func NewInMemoryNowPlayingRepository() *InMemoryNowPlayingRepository {
+	return &InMemoryNowPlayingRepository{
+		entries: make(map[nowPlayingKey]domain.NowPlaying),
+	}
+}
+
+func (r *InMemoryNowPlayingRepository) SetNowPlaying(ctx context.Context, nowPlaying domain.NowPlaying) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[nowPlayingKey{username: nowPlaying.Username, playerName: nowPlaying.PlayerName}] = nowPlaying
+	return nil
+}
+
+func (r *InMemoryNowPlayingRepository) GetNowPlaying(ctx context.Context) ([]domain.NowPlaying, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]domain.NowPlaying, 0, len(r.entries))
+	for _, entry := range r.entries {
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b domain.NowPlaying) int {
+		return b.Started.Compare(a.Started)
+	})
+	return entries, nil
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"slices"
+	"strings"
+	"sync"
+)
+
+/*
+* playlists have their id created by the repository (auto-increment),
+* their songs being kept by the media browsing repository
+ */
+
+type InMemoryPlaylistRepository struct {
+	mediaBrowsingRepo *InMemoryMediaBrowsingRepository
+	playlists         map[int]domain.Playlist
+	nextPlaylistID    int
+	mu                sync.RWMutex
+}
+
+func NewInMemoryPlaylistRepository(mediaBrowsingRepo *InMemoryMediaBrowsingRepository) *InMemoryPlaylistRepository {
+	return &InMemoryPlaylistRepository{
+		mediaBrowsingRepo: mediaBrowsingRepo,
+		playlists:         make(map[int]domain.Playlist),
+		nextPlaylistID:    1,
+	}
+}
+
+func (r *InMemoryPlaylistRepository) GetPlaylists(ctx context.Context, username string, musicFolderIDs []int) ([]domain.Playlist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.mediaBrowsingRepo.mu.RLock()
+	defer r.mediaBrowsingRepo.mu.RUnlock()
+
+	playlists := make([]domain.Playlist, 0)
+	for _, playlist := range r.playlists {
+		if playlist.Owner != username && !playlist.Public {
+			continue
+		}
+		for _, id := range playlist.SongIds {
+			if song, exists := r.mediaBrowsingRepo.songs[id]; exists && inMusicFolders(song.MusicFolderId, musicFolderIDs) {
+				playlist.SongCount++
+				playlist.Duration += song.Duration
+			}
+		}
+		playlist.SongIds = nil
+		playlists = append(playlists, playlist)
+	}
+	slices.SortFunc(playlists, func(a, b domain.Playlist) int {
+		return cmp.Or(strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), a.Id-b.Id)
+	})
+	return playlists, nil
+}
+
+func (r *InMemoryPlaylistRepository) GetPlaylist(ctx context.Context, id int) (domain.Playlist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.mediaBrowsingRepo.mu.RLock()
+	defer r.mediaBrowsingRepo.mu.RUnlock()
+
+	playlist, exists := r.playlists[id]
+	if !exists {
+		return domain.Playlist{}, &ports.NotFoundError{Message: "playlist not found"}
+	}
+	// songs removed from the library leave the playlist, as the foreign key of the SQL repository does
+	songIDs := make([]int, 0, len(playlist.SongIds))
+	playlist.Songs = make([]domain.Song, 0, len(playlist.SongIds))
+	for _, songID := range playlist.SongIds {
+		if song, exists := r.mediaBrowsingRepo.songs[songID]; exists {
+			songIDs = append(songIDs, songID)
+			playlist.Songs = append(playlist.Songs, song)
+			playlist.Duration += song.Duration
+		}
+	}
+	playlist.SongIds = songIDs
+	playlist.SongCount = len(songIDs)
+	return playlist, nil
+}
+
+func (r *InMemoryPlaylistRepository) GetImportedPlaylists(ctx context.Context) ([]domain.Playlist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var playlists []domain.Playlist
+	for _, playlist := range r.playlists {
+		if playlist.IsImported() {
+			playlist.SongIds = nil
+			playlists = append(playlists, playlist)
+		}
+	}
+	slices.SortFunc(playlists, func(a, b domain.Playlist) int {
+		return a.Id - b.Id
+	})
+	return playlists, nil
+}
+
+func (r *InMemoryPlaylistRepository) CreatePlaylist(ctx context.Context, playlist domain.Playlist) (domain.Playlist, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkPath(playlist); err != nil {
+		return domain.Playlist{}, err
+	}
+	playlist.Id = r.nextPlaylistID
+	r.nextPlaylistID++
+	playlist.SongIds = slices.Clone(playlist.SongIds)
+	r.playlists[playlist.Id] = playlist
+	return playlist, nil
+}
+
+func (r *InMemoryPlaylistRepository) UpdatePlaylist(ctx context.Context, playlist domain.Playlist) (domain.Playlist, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.playlists[playlist.Id]
+	if !exists {
+		return domain.Playlist{}, &ports.NotFoundError{Message: "playlist not found"}
+	}
+	if err := r.checkPath(playlist); err != nil {
+		return domain.Playlist{}, err
+	}
+	playlist.Created = existing.Created
+	playlist.SongIds = slices.Clone(playlist.SongIds)
+	r.playlists[playlist.Id] = playlist
+	return playlist, nil
+}
+
+func (r *InMemoryPlaylistRepository) DeletePlaylist(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.playlists[id]; !exists {
+		return &ports.NotFoundError{Message: "playlist not found"}
+	}
+	delete(r.playlists, id)
+	return nil
+}
+
+// checkPath mirrors the unique constraint on the playlist file path of the SQL repository.
+func (r *InMemoryPlaylistRepository) checkPath(playlist domain.Playlist) error {
+	if !playlist.IsImported() {
+		return nil
+	}
+	for id, existing := range r.playlists {
+		if id != playlist.Id && existing.Path == playlist.Path {
+			return &ports.FailedOperationError{Description: "playlist file already imported"}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	sqlc "music-streaming/internal/adapter/sql/sqlc"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SQLPlaylistRepository struct {
+	queries *sqlc.Queries
+	db      *pgxpool.Pool
+}
+
+func NewSQLPlaylistRepository(db *pgxpool.Pool) *SQLPlaylistRepository {
+	return &SQLPlaylistRepository{
+		queries: sqlc.New(db),
+		db:      db,
+	}
+}
+
+func (r *SQLPlaylistRepository) GetPlaylists(ctx context.Context, username string, musicFolderIDs []int) ([]domain.Playlist, error) {
+	rows, err := r.queries.GetPlaylists(ctx, sqlc.GetPlaylistsParams{Username: username, MusicFolderIds: toInt32s(musicFolderIDs)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playlists: %w", err)
+	}
+
+	playlists := make([]domain.Playlist, 0, len(rows))
+	for _, row := range rows {
+		playlist := toDomainPlaylist(sqlc.Playlist{
+			PlaylistID: row.PlaylistID,
+			Name:       row.Name,
+			Comment:    row.Comment,
+			Owner:      row.Owner,
+			Public:     row.Public,
+			Created:    row.Created,
+			Changed:    row.Changed,
+			Path:       row.Path,
+			ModTime:    row.ModTime,
+		})
+		playlist.SongCount = int(row.SongCount)
+		playlist.Duration = int(row.TotalDuration)
+		playlists = append(playlists, playlist)
+	}
+	return playlists, nil
+}
+
+func (r *SQLPlaylistRepository) GetPlaylist(ctx context.Context, id int) (domain.Playlist, error) {
+	sqlPlaylist, err := r.queries.GetPlaylistByID(ctx, int32(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Playlist{}, &ports.NotFoundError{Message: "playlist not found"}
+		}
+		return domain.Playlist{}, fmt.Errorf("failed to get playlist: %w", err)
+	}
+	sqlSongs, err := r.queries.GetPlaylistSongs(ctx, sqlPlaylist.PlaylistID)
+	if err != nil {
+		return domain.Playlist{}, fmt.Errorf("failed to get playlist songs: %w", err)
+	}
+	songs, err := songsWithGenres(ctx, r.queries, sqlSongs)
+	if err != nil {
+		return domain.Playlist{}, err
+	}
+
+	playlist := toDomainPlaylist(sqlPlaylist)
+	playlist.Songs = songs
+	playlist.SongIds = make([]int, 0, len(songs))
+	for _, song := range songs {
+		playlist.SongIds = append(playlist.SongIds, song.Id)
+		playlist.Duration += song.Duration
+	}
+	playlist.SongCount = len(songs)
+	return playlist, nil
+}
+
+func (r *SQLPlaylistRepository) GetImportedPlaylists(ctx context.Context) ([]domain.Playlist, error) {
+	sqlPlaylists, err := r.queries.GetImportedPlaylists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get imported playlists: %w", err)
+	}
+
+	playlists := make([]domain.Playlist, 0, len(sqlPlaylists))
+	for _, sqlPlaylist := range sqlPlaylists {
+		playlists = append(playlists, toDomainPlaylist(sqlPlaylist))
+	}
+	return playlists, nil
+}
+
+func (r *SQLPlaylistRepository) CreatePlaylist(ctx context.Context, playlist domain.Playlist) (domain.Playlist, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return domain.Playlist{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	queries := r.queries.WithTx(tx)
+	sqlPlaylist, err := queries.CreatePlaylist(ctx, sqlc.CreatePlaylistParams{
+		Name:    playlist.Name,
+		Comment: playlist.Comment,
+		Owner:   playlist.Owner,
+		Public:  playlist.Public,
+		Created: toTimestamp(playlist.Created),
+		Changed: toTimestamp(playlist.Changed),
+		Path:    toText(playlist.Path),
+		ModTime: playlist.ModTime,
+	})
+	if err != nil {
+		return domain.Playlist{}, fmt.Errorf("failed to create playlist: %w", err)
+	}
+	if err := createPlaylistSongs(ctx, queries, sqlPlaylist.PlaylistID, playlist.SongIds); err != nil {
+		return domain.Playlist{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Playlist{}, fmt.Errorf("failed to commit playlist: %w", err)
+	}
+	created := toDomainPlaylist(sqlPlaylist)
+	created.SongIds = playlist.SongIds
+	return created, nil
+}
+
+func (r *SQLPlaylistRepository) UpdatePlaylist(ctx context.Context, playlist domain.Playlist) (domain.Playlist, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return domain.Playlist{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // nolint:errcheck
+
+	queries := r.queries.WithTx(tx)
+	sqlPlaylist, err := queries.UpdatePlaylist(ctx, sqlc.UpdatePlaylistParams{
+		PlaylistID: int32(playlist.Id),
+		Name:       playlist.Name,
+		Comment:    playlist.Comment,
+		Owner:      playlist.Owner,
+		Public:     playlist.Public,
+		Changed:    toTimestamp(playlist.Changed),
+		Path:       toText(playlist.Path),
+		ModTime:    playlist.ModTime,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Playlist{}, &ports.NotFoundError{Message: "playlist not found"}
+		}
+		return domain.Playlist{}, fmt.Errorf("failed to update playlist: %w", err)
+	}
+	if err := queries.DeletePlaylistSongs(ctx, sqlPlaylist.PlaylistID); err != nil {
+		return domain.Playlist{}, fmt.Errorf("failed to delete playlist songs: %w", err)
+	}
+	if err := createPlaylistSongs(ctx, queries, sqlPlaylist.PlaylistID, playlist.SongIds); err != nil {
+		return domain.Playlist{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Playlist{}, fmt.Errorf("failed to commit playlist: %w", err)
+	}
+	updated := toDomainPlaylist(sqlPlaylist)
+	updated.SongIds = playlist.SongIds
+	return updated, nil
+}
+
+func (r *SQLPlaylistRepository) DeletePlaylist(ctx context.Context, id int) error {
+	deleted, err := r.queries.DeletePlaylist(ctx, int32(id))
+	if err != nil {
+		return fmt.Errorf("failed to delete playlist: %w", err)
+	}
+	if deleted == 0 {
+		return &ports.NotFoundError{Message: "playlist not found"}
+	}
+	return nil
+}
+
+// createPlaylistSongs writes the songs of a playlist, positions following the order of songIDs.
+func createPlaylistSongs(ctx context.Context, queries *sqlc.Queries, playlistID int32, songIDs []int) error {
+	for position, songID := range songIDs {
+		if err := queries.CreatePlaylistSong(ctx, sqlc.CreatePlaylistSongParams{
+			PlaylistID: playlistID,
+			Position:   int32(position),
+			SongID:     int32(songID),
+		}); err != nil {
+			return fmt.Errorf("failed to create playlist song: %w", err)
+		}
+	}
+	return nil
+}
+
+func toDomainPlaylist(sqlPlaylist sqlc.Playlist) domain.Playlist {
+	playlist := domain.Playlist{
+		Id:      int(sqlPlaylist.PlaylistID),
+		Name:    sqlPlaylist.Name,
+		Comment: sqlPlaylist.Comment,
+		Owner:   sqlPlaylist.Owner,
+		Public:  sqlPlaylist.Public,
+		ModTime: sqlPlaylist.ModTime,
+	}
+	if sqlPlaylist.Created.Valid {
+		playlist.Created = sqlPlaylist.Created.Time.Format(time.RFC3339)
+	}
+	if sqlPlaylist.Changed.Valid {
+		playlist.Changed = sqlPlaylist.Changed.Time.Format(time.RFC3339)
+	}
+	if sqlPlaylist.Path.Valid {
+		playlist.Path = sqlPlaylist.Path.String
+	}
+	return playlist
+}
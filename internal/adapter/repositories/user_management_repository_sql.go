@@ -12,16 +12,17 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
 
 type SQLUserManagementRepository struct {
 	queries *sqlc.Queries
-	db      *pgx.Conn
+	db      *pgxpool.Pool
 	redis   *redis.Client
 }
 
-func NewSQLUserManagementRepository(db *pgx.Conn, redisClient *redis.Client) *SQLUserManagementRepository {
+func NewSQLUserManagementRepository(db *pgxpool.Pool, redisClient *redis.Client) *SQLUserManagementRepository {
 	return &SQLUserManagementRepository{
 		queries: sqlc.New(db),
 		db:      db,
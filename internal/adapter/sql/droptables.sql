@@ -1,5 +1,19 @@
+DROP TABLE IF EXISTS Plays;
+DROP TABLE IF EXISTS SongAnnotations;
+DROP TABLE IF EXISTS AlbumAnnotations;
+DROP TABLE IF EXISTS ArtistAnnotations;
+DROP TABLE IF EXISTS AlbumGenres;
+DROP TABLE IF EXISTS SongGenres;
+DROP TABLE IF EXISTS Genres;
+DROP TABLE IF EXISTS Lyrics;
+DROP TABLE IF EXISTS PlaylistSongs;
+DROP TABLE IF EXISTS Playlists;
+DROP TABLE IF EXISTS ScanErrors;
+DROP TABLE IF EXISTS ScanRuns;
 DROP TABLE IF EXISTS Users;
 DROP TABLE IF EXISTS Covers;
 DROP TABLE IF EXISTS Songs;
 DROP TABLE IF EXISTS Albums;
-DROP TABLE IF EXISTS Artists;
\ No newline at end of file
+DROP TABLE IF EXISTS Artists;
+DROP TABLE IF EXISTS Directories;
+DROP TABLE IF EXISTS MusicFolders;
\ No newline at end of file
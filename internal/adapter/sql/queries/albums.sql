@@ -1,6 +1,6 @@
 -- name: CreateAlbum :one
-INSERT INTO Albums (artist_id, name, cover_art, song_count, created, duration, artist)
-VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
+INSERT INTO Albums (artist_id, name, cover_art, song_count, created, duration, artist, year, compilation, sort_name, musicbrainz_id, music_folder_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING *;
 
 -- name: GetAlbum :one
 SELECT * FROM Albums
@@ -8,4 +8,87 @@ WHERE album_id = $1 LIMIT 1;
 
 -- name: GetAlbums :many
 SELECT * FROM Albums
-WHERE artist_id = $1;
\ No newline at end of file
+WHERE artist_id = $1
+ORDER BY year, lower(sort_name), album_id;
+
+-- name: GetRandomAlbums :many
+SELECT * FROM Albums
+WHERE (@music_folder_ids::int[] IS NULL OR music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY random()
+LIMIT @lim;
+
+-- name: GetNewestAlbums :many
+SELECT * FROM Albums
+WHERE (@music_folder_ids::int[] IS NULL OR music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY created DESC, album_id DESC
+LIMIT @lim OFFSET @off;
+
+-- name: GetAlbumsByName :many
+SELECT * FROM Albums
+WHERE (@music_folder_ids::int[] IS NULL OR music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY lower(sort_name), album_id
+LIMIT @lim OFFSET @off;
+
+-- name: GetAlbumsByArtistName :many
+SELECT Albums.* FROM Albums
+LEFT JOIN Artists ON Artists.artist_id = Albums.artist_id
+WHERE (@music_folder_ids::int[] IS NULL OR Albums.music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY lower(COALESCE(Artists.sort_name, Albums.artist, '')), Albums.year, lower(Albums.sort_name), Albums.album_id
+LIMIT @lim OFFSET @off;
+
+-- name: GetAlbumsByYear :many
+SELECT * FROM Albums
+WHERE year BETWEEN @min_year::int AND @max_year::int
+AND (@music_folder_ids::int[] IS NULL OR music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY CASE WHEN @descending::boolean THEN -year ELSE year END, lower(sort_name), album_id
+LIMIT @lim OFFSET @off;
+
+-- name: GetAlbumsByGenre :many
+SELECT Albums.* FROM Albums
+JOIN AlbumGenres ON AlbumGenres.album_id = Albums.album_id
+JOIN Genres ON Genres.genre_id = AlbumGenres.genre_id
+WHERE Genres.name = @name
+AND (@music_folder_ids::int[] IS NULL OR Albums.music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY lower(Albums.sort_name), Albums.album_id
+LIMIT @lim OFFSET @off;
+
+-- name: SearchAlbums :many
+SELECT * FROM Albums
+WHERE (@query::text = ''
+    OR search_vector @@ to_tsquery('simple', immutable_unaccent(@terms::text))
+    OR lower(immutable_unaccent(name)) % lower(immutable_unaccent(@query::text)))
+AND (@music_folder_ids::int[] IS NULL OR music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY ts_rank(search_vector, to_tsquery('simple', immutable_unaccent(@terms::text))) DESC,
+    similarity(lower(immutable_unaccent(name)), lower(immutable_unaccent(@query::text))) DESC,
+    lower(sort_name), album_id
+LIMIT @lim OFFSET @off;
+
+-- name: GetAlbumByRelease :one
+SELECT * FROM Albums
+WHERE artist_id = $1 AND name = $2 AND year = $3 LIMIT 1;
+
+-- name: UpdateAlbum :one
+UPDATE Albums SET
+    artist_id = $2,
+    name = $3,
+    cover_art = $4,
+    song_count = $5,
+    created = $6,
+    duration = $7,
+    artist = $8,
+    year = $9,
+    compilation = $10,
+    sort_name = $11,
+    musicbrainz_id = $12,
+    music_folder_id = $13
+WHERE album_id = $1 RETURNING *;
+
+-- name: UpdateAlbumStats :exec
+UPDATE Albums SET
+    song_count = (SELECT COUNT(*) FROM Songs WHERE Songs.album_id = Albums.album_id),
+    duration = (SELECT COALESCE(SUM(Songs.duration), 0) FROM Songs WHERE Songs.album_id = Albums.album_id),
+    directory_id = (SELECT MIN(Songs.directory_id) FROM Songs WHERE Songs.album_id = Albums.album_id);
+
+-- name: DeleteOrphanAlbums :execrows
+DELETE FROM Albums
+WHERE NOT EXISTS (SELECT 1 FROM Songs WHERE Songs.album_id = Albums.album_id);
@@ -0,0 +1,117 @@
+-- name: StarArtists :exec
+INSERT INTO ArtistAnnotations (username, artist_id, starred)
+SELECT @username::text, unnest(@artist_ids::int[]), @starred::timestamp
+ON CONFLICT (username, artist_id) DO UPDATE SET starred = COALESCE(ArtistAnnotations.starred, EXCLUDED.starred);
+
+-- name: UnstarArtists :exec
+UPDATE ArtistAnnotations SET starred = NULL
+WHERE username = @username AND artist_id = ANY(@artist_ids::int[]);
+
+-- name: RateArtist :exec
+INSERT INTO ArtistAnnotations (username, artist_id, rating)
+VALUES ($1, $2, $3)
+ON CONFLICT (username, artist_id) DO UPDATE SET rating = EXCLUDED.rating;
+
+-- name: GetArtistAnnotations :many
+SELECT * FROM ArtistAnnotations
+WHERE username = @username AND artist_id = ANY(@artist_ids::int[]);
+
+-- name: StarAlbums :exec
+INSERT INTO AlbumAnnotations (username, album_id, starred)
+SELECT @username::text, unnest(@album_ids::int[]), @starred::timestamp
+ON CONFLICT (username, album_id) DO UPDATE SET starred = COALESCE(AlbumAnnotations.starred, EXCLUDED.starred);
+
+-- name: UnstarAlbums :exec
+UPDATE AlbumAnnotations SET starred = NULL
+WHERE username = @username AND album_id = ANY(@album_ids::int[]);
+
+-- name: RateAlbum :exec
+INSERT INTO AlbumAnnotations (username, album_id, rating)
+VALUES ($1, $2, $3)
+ON CONFLICT (username, album_id) DO UPDATE SET rating = EXCLUDED.rating;
+
+-- name: GetAlbumAnnotations :many
+SELECT * FROM AlbumAnnotations
+WHERE username = @username AND album_id = ANY(@album_ids::int[]);
+
+-- name: StarSongs :exec
+INSERT INTO SongAnnotations (username, song_id, starred)
+SELECT @username::text, unnest(@song_ids::int[]), @starred::timestamp
+ON CONFLICT (username, song_id) DO UPDATE SET starred = COALESCE(SongAnnotations.starred, EXCLUDED.starred);
+
+-- name: UnstarSongs :exec
+UPDATE SongAnnotations SET starred = NULL
+WHERE username = @username AND song_id = ANY(@song_ids::int[]);
+
+-- name: RateSong :exec
+INSERT INTO SongAnnotations (username, song_id, rating)
+VALUES ($1, $2, $3)
+ON CONFLICT (username, song_id) DO UPDATE SET rating = EXCLUDED.rating;
+
+-- name: GetSongAnnotations :many
+SELECT * FROM SongAnnotations
+WHERE username = @username AND song_id = ANY(@song_ids::int[]);
+
+-- name: GetStarredArtists :many
+SELECT Artists.* FROM Artists
+JOIN ArtistAnnotations ON ArtistAnnotations.artist_id = Artists.artist_id
+WHERE ArtistAnnotations.username = @username AND ArtistAnnotations.starred IS NOT NULL
+AND (@music_folder_ids::int[] IS NULL OR Artists.music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY ArtistAnnotations.starred DESC, Artists.artist_id;
+
+-- name: GetStarredAlbums :many
+SELECT Albums.* FROM Albums
+JOIN AlbumAnnotations ON AlbumAnnotations.album_id = Albums.album_id
+WHERE AlbumAnnotations.username = @username AND AlbumAnnotations.starred IS NOT NULL
+AND (@music_folder_ids::int[] IS NULL OR Albums.music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY AlbumAnnotations.starred DESC, Albums.album_id
+LIMIT @lim OFFSET @off;
+
+-- name: GetStarredSongs :many
+SELECT Songs.* FROM Songs
+JOIN SongAnnotations ON SongAnnotations.song_id = Songs.song_id
+WHERE SongAnnotations.username = @username AND SongAnnotations.starred IS NOT NULL
+AND (@music_folder_ids::int[] IS NULL OR Songs.music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY SongAnnotations.starred DESC, Songs.song_id;
+
+-- name: GetHighestRatedAlbums :many
+SELECT Albums.* FROM Albums
+JOIN AlbumAnnotations ON AlbumAnnotations.album_id = Albums.album_id
+WHERE AlbumAnnotations.username = @username AND AlbumAnnotations.rating > 0
+AND (@music_folder_ids::int[] IS NULL OR Albums.music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY AlbumAnnotations.rating DESC, lower(Albums.sort_name), Albums.album_id
+LIMIT @lim OFFSET @off;
+
+-- name: CreatePlay :exec
+INSERT INTO Plays (username, song_id, played)
+VALUES ($1, $2, $3);
+
+-- name: RecordSongPlay :exec
+INSERT INTO SongAnnotations (username, song_id, play_count, played)
+VALUES (@username::text, @song_id::int, 1, @played::timestamp)
+ON CONFLICT (username, song_id) DO UPDATE SET
+    play_count = SongAnnotations.play_count + 1,
+    played = GREATEST(SongAnnotations.played, EXCLUDED.played);
+
+-- name: RecordAlbumPlay :exec
+INSERT INTO AlbumAnnotations (username, album_id, play_count, played)
+VALUES (@username::text, @album_id::int, 1, @played::timestamp)
+ON CONFLICT (username, album_id) DO UPDATE SET
+    play_count = AlbumAnnotations.play_count + 1,
+    played = GREATEST(AlbumAnnotations.played, EXCLUDED.played);
+
+-- name: GetMostPlayedAlbums :many
+SELECT Albums.* FROM Albums
+JOIN AlbumAnnotations ON AlbumAnnotations.album_id = Albums.album_id
+WHERE AlbumAnnotations.username = @username AND AlbumAnnotations.play_count > 0
+AND (@music_folder_ids::int[] IS NULL OR Albums.music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY AlbumAnnotations.play_count DESC, lower(Albums.sort_name), Albums.album_id
+LIMIT @lim OFFSET @off;
+
+-- name: GetRecentlyPlayedAlbums :many
+SELECT Albums.* FROM Albums
+JOIN AlbumAnnotations ON AlbumAnnotations.album_id = Albums.album_id
+WHERE AlbumAnnotations.username = @username AND AlbumAnnotations.played IS NOT NULL
+AND (@music_folder_ids::int[] IS NULL OR Albums.music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY AlbumAnnotations.played DESC, Albums.album_id
+LIMIT @lim OFFSET @off;
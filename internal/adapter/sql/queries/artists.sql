@@ -1,10 +1,56 @@
 -- name: CreateArtist :one
-INSERT INTO Artists (name, cover_art, album_count)
-VALUES ($1, $2, $3) RETURNING *;
+INSERT INTO Artists (name, cover_art, album_count, sort_name, musicbrainz_id, music_folder_id)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
 
 -- name: GetArtist :one
 SELECT * FROM Artists
 WHERE artist_id = $1 LIMIT 1;
 
 -- name: GetArtists :many
-SELECT * FROM Artists;
\ No newline at end of file
+SELECT * FROM Artists
+WHERE (@music_folder_ids::int[] IS NULL OR music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY lower(sort_name), artist_id;
+
+-- name: SearchArtists :many
+SELECT * FROM Artists
+WHERE (@query::text = ''
+    OR search_vector @@ to_tsquery('simple', immutable_unaccent(@terms::text))
+    OR lower(immutable_unaccent(name)) % lower(immutable_unaccent(@query::text)))
+AND (@music_folder_ids::int[] IS NULL OR music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY ts_rank(search_vector, to_tsquery('simple', immutable_unaccent(@terms::text))) DESC,
+    similarity(lower(immutable_unaccent(name)), lower(immutable_unaccent(@query::text))) DESC,
+    lower(sort_name), artist_id
+LIMIT @lim OFFSET @off;
+
+-- name: GetArtistByName :one
+SELECT * FROM Artists
+WHERE name = $1 AND music_folder_id = $2
+ORDER BY musicbrainz_id <> '', artist_id LIMIT 1;
+
+-- name: GetArtistByMusicBrainzID :one
+SELECT * FROM Artists
+WHERE musicbrainz_id = $1 AND music_folder_id = $2
+ORDER BY artist_id LIMIT 1;
+
+-- name: UpdateArtist :one
+UPDATE Artists SET
+    name = $2,
+    cover_art = $3,
+    album_count = $4,
+    sort_name = $5,
+    musicbrainz_id = $6,
+    music_folder_id = $7
+WHERE artist_id = $1 RETURNING *;
+
+-- name: UpdateArtistStats :exec
+UPDATE Artists SET
+    album_count = (SELECT COUNT(*) FROM Albums WHERE Albums.artist_id = Artists.artist_id),
+    directory_id = (
+        SELECT MIN(Directories.parent_id) FROM Albums
+        JOIN Directories ON Directories.directory_id = Albums.directory_id
+        WHERE Albums.artist_id = Artists.artist_id
+    );
+
+-- name: DeleteOrphanArtists :execrows
+DELETE FROM Artists
+WHERE NOT EXISTS (SELECT 1 FROM Albums WHERE Albums.artist_id = Artists.artist_id);
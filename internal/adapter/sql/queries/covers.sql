@@ -1,7 +1,9 @@
 -- name: CreateCover :one
 INSERT INTO Covers (cover_id, path)
-VALUES ($1, $2) RETURNING *;
+VALUES ($1, $2)
+ON CONFLICT (cover_id) DO UPDATE SET path = EXCLUDED.path
+RETURNING *;
 
 -- name: GetCover :one
 SELECT * FROM Covers
-WHERE cover_id = $1 LIMIT 1;
\ No newline at end of file
+WHERE cover_id = $1 LIMIT 1;
@@ -0,0 +1,27 @@
+-- name: CreateDirectory :one
+INSERT INTO Directories (parent_id, music_folder_id, path, name, created, updated)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetDirectory :one
+SELECT * FROM Directories
+WHERE directory_id = $1 LIMIT 1;
+
+-- name: GetDirectoryByPath :one
+SELECT * FROM Directories
+WHERE path = $1 LIMIT 1;
+
+-- name: GetSubdirectories :many
+SELECT * FROM Directories
+WHERE parent_id = ANY(@parent_ids::int[])
+ORDER BY name, directory_id;
+
+-- name: TouchDirectories :exec
+UPDATE Directories SET updated = @updated
+WHERE directory_id = ANY(@directory_ids::int[]);
+
+-- name: DeleteOrphanDirectories :many
+DELETE FROM Directories
+WHERE NOT EXISTS (SELECT 1 FROM Songs WHERE Songs.directory_id = Directories.directory_id)
+AND NOT EXISTS (SELECT 1 FROM Directories AS Children WHERE Children.parent_id = Directories.directory_id)
+RETURNING parent_id;
@@ -0,0 +1,58 @@
+-- name: CreateGenre :one
+INSERT INTO Genres (name)
+VALUES ($1)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING *;
+
+-- name: GetGenres :many
+SELECT * FROM Genres
+ORDER BY lower(name), name;
+
+-- name: CreateSongGenre :exec
+INSERT INTO SongGenres (song_id, genre_id, position)
+VALUES ($1, $2, $3);
+
+-- name: DeleteSongGenres :exec
+DELETE FROM SongGenres
+WHERE song_id = $1;
+
+-- name: GetSongGenres :many
+SELECT SongGenres.song_id, Genres.name FROM SongGenres
+JOIN Genres ON Genres.genre_id = SongGenres.genre_id
+WHERE SongGenres.song_id = ANY(@song_ids::int[])
+ORDER BY SongGenres.song_id, SongGenres.position;
+
+-- name: GetGenresByMusicFolders :many
+SELECT Genres.name, COUNT(DISTINCT Songs.song_id) AS song_count, COUNT(DISTINCT Songs.album_id) AS album_count FROM Genres
+JOIN SongGenres ON SongGenres.genre_id = Genres.genre_id
+JOIN Songs ON Songs.song_id = SongGenres.song_id
+WHERE Songs.music_folder_id = ANY(@music_folder_ids::int[])
+GROUP BY Genres.genre_id, Genres.name
+ORDER BY lower(Genres.name), Genres.name;
+
+-- name: GetSongsByGenre :many
+SELECT Songs.* FROM Songs
+JOIN SongGenres ON SongGenres.song_id = Songs.song_id
+JOIN Genres ON Genres.genre_id = SongGenres.genre_id
+WHERE Genres.name = @name
+AND (@music_folder_ids::int[] IS NULL OR Songs.music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY Songs.album_id, Songs.disc_number, Songs.track, Songs.song_id
+LIMIT @lim OFFSET @off;
+
+-- name: DeleteAlbumGenres :exec
+DELETE FROM AlbumGenres;
+
+-- name: CreateAlbumGenres :exec
+INSERT INTO AlbumGenres (album_id, genre_id)
+SELECT DISTINCT Songs.album_id, SongGenres.genre_id FROM SongGenres
+JOIN Songs ON Songs.song_id = SongGenres.song_id
+WHERE Songs.album_id IS NOT NULL;
+
+-- name: UpdateGenreStats :exec
+UPDATE Genres SET
+    song_count = (SELECT COUNT(*) FROM SongGenres WHERE SongGenres.genre_id = Genres.genre_id),
+    album_count = (SELECT COUNT(*) FROM AlbumGenres WHERE AlbumGenres.genre_id = Genres.genre_id);
+
+-- name: DeleteOrphanGenres :execrows
+DELETE FROM Genres
+WHERE NOT EXISTS (SELECT 1 FROM SongGenres WHERE SongGenres.genre_id = Genres.genre_id);
@@ -0,0 +1,20 @@
+-- name: CreateLyrics :exec
+INSERT INTO Lyrics (song_id, lang, synced, display_artist, display_title, offset_ms, line_starts, line_values)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: DeleteLyrics :exec
+DELETE FROM Lyrics
+WHERE song_id = ANY(@song_ids::int[]);
+
+-- name: GetLyricsBySong :many
+SELECT * FROM Lyrics
+WHERE song_id = $1
+ORDER BY lyrics_id;
+
+-- name: GetLyricsByArtistAndTitle :many
+SELECT Lyrics.* FROM Lyrics
+JOIN Songs ON Songs.song_id = Lyrics.song_id
+WHERE lower(Songs.title) = lower(@title::text)
+    AND (@artist::text = '' OR lower(Songs.artist) = lower(@artist::text))
+    AND (@music_folder_ids::int[] IS NULL OR Songs.music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY Lyrics.song_id, Lyrics.lyrics_id;
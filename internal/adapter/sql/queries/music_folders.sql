@@ -0,0 +1,9 @@
+-- name: CreateMusicFolder :one
+INSERT INTO MusicFolders (path, name)
+VALUES ($1, $2)
+ON CONFLICT (path) DO UPDATE SET path = EXCLUDED.path
+RETURNING *;
+
+-- name: GetMusicFolders :many
+SELECT * FROM MusicFolders
+ORDER BY music_folder_id;
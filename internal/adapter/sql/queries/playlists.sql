@@ -0,0 +1,53 @@
+-- name: CreatePlaylist :one
+INSERT INTO Playlists (name, comment, owner, public, created, changed, path, mod_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *;
+
+-- name: UpdatePlaylist :one
+UPDATE Playlists SET
+    name = $2,
+    comment = $3,
+    owner = $4,
+    public = $5,
+    changed = $6,
+    path = $7,
+    mod_time = $8
+WHERE playlist_id = $1 RETURNING *;
+
+-- name: DeletePlaylist :execrows
+DELETE FROM Playlists
+WHERE playlist_id = $1;
+
+-- name: GetImportedPlaylists :many
+SELECT * FROM Playlists
+WHERE path IS NOT NULL
+ORDER BY playlist_id;
+
+-- name: CreatePlaylistSong :exec
+INSERT INTO PlaylistSongs (playlist_id, position, song_id)
+VALUES ($1, $2, $3);
+
+-- name: DeletePlaylistSongs :exec
+DELETE FROM PlaylistSongs
+WHERE playlist_id = $1;
+
+-- name: GetPlaylists :many
+SELECT Playlists.*,
+    COUNT(Songs.song_id) AS song_count,
+    COALESCE(SUM(Songs.duration), 0)::int AS total_duration
+FROM Playlists
+LEFT JOIN PlaylistSongs ON PlaylistSongs.playlist_id = Playlists.playlist_id
+LEFT JOIN Songs ON Songs.song_id = PlaylistSongs.song_id
+    AND (@music_folder_ids::int[] IS NULL OR Songs.music_folder_id = ANY(@music_folder_ids::int[]))
+WHERE Playlists.owner = @username OR Playlists.public
+GROUP BY Playlists.playlist_id
+ORDER BY lower(Playlists.name), Playlists.playlist_id;
+
+-- name: GetPlaylistByID :one
+SELECT * FROM Playlists
+WHERE playlist_id = $1;
+
+-- name: GetPlaylistSongs :many
+SELECT Songs.* FROM PlaylistSongs
+JOIN Songs ON Songs.song_id = PlaylistSongs.song_id
+WHERE PlaylistSongs.playlist_id = $1
+ORDER BY PlaylistSongs.position;
@@ -0,0 +1,31 @@
+-- name: CreateScanRun :one
+INSERT INTO ScanRuns (trigger_type, partial, started)
+VALUES ($1, $2, $3) RETURNING *;
+
+-- name: FinishScanRun :execrows
+UPDATE ScanRuns SET
+    cancelled = $2,
+    finished = $3,
+    added = $4,
+    updated = $5,
+    removed = $6,
+    failed = $7
+WHERE scan_run_id = $1;
+
+-- name: GetScanRun :one
+SELECT * FROM ScanRuns
+WHERE scan_run_id = $1 LIMIT 1;
+
+-- name: GetScanRuns :many
+SELECT * FROM ScanRuns
+ORDER BY started DESC, scan_run_id DESC
+LIMIT $1 OFFSET $2;
+
+-- name: CreateScanError :exec
+INSERT INTO ScanErrors (scan_run_id, path, message)
+VALUES ($1, $2, $3);
+
+-- name: GetScanErrors :many
+SELECT * FROM ScanErrors
+WHERE scan_run_id = $1
+ORDER BY scan_error_id;
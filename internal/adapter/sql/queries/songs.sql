@@ -1,6 +1,6 @@
 -- name: CreateSong :one
-INSERT INTO Songs (album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING *;
+INSERT INTO Songs (album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path, mod_time, track, disc_number, year, track_gain, track_peak, album_gain, album_peak, start_offset, end_offset, cue_path, lyrics_path, sort_name, musicbrainz_id, music_folder_id, directory_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30) RETURNING *;
 
 -- name: GetSong :one
 SELECT * FROM Songs
@@ -8,4 +8,62 @@ WHERE song_id = $1 LIMIT 1;
 
 -- name: GetSongs :many
 SELECT * FROM Songs
-WHERE album_id = $1;
\ No newline at end of file
+WHERE album_id = $1
+ORDER BY disc_number, track, title, song_id;
+
+-- name: GetSongsByDirectory :many
+SELECT * FROM Songs
+WHERE directory_id = $1
+ORDER BY disc_number, track, title, song_id;
+
+-- name: SearchSongs :many
+SELECT * FROM Songs
+WHERE (@query::text = ''
+    OR search_vector @@ to_tsquery('simple', immutable_unaccent(@terms::text))
+    OR lower(immutable_unaccent(title)) % lower(immutable_unaccent(@query::text)))
+AND (@music_folder_ids::int[] IS NULL OR music_folder_id = ANY(@music_folder_ids::int[]))
+ORDER BY ts_rank(search_vector, to_tsquery('simple', immutable_unaccent(@terms::text))) DESC,
+    similarity(lower(immutable_unaccent(title)), lower(immutable_unaccent(@query::text))) DESC,
+    lower(title), song_id
+LIMIT @lim OFFSET @off;
+
+-- name: UpdateSong :one
+UPDATE Songs SET
+    album_id = $2,
+    title = $3,
+    album = $4,
+    artist = $5,
+    is_dir = $6,
+    cover_art = $7,
+    created = $8,
+    duration = $9,
+    bit_rate = $10,
+    size = $11,
+    suffix = $12,
+    content_type = $13,
+    is_video = $14,
+    path = $15,
+    mod_time = $16,
+    track = $17,
+    disc_number = $18,
+    year = $19,
+    track_gain = $20,
+    track_peak = $21,
+    album_gain = $22,
+    album_peak = $23,
+    start_offset = $24,
+    end_offset = $25,
+    cue_path = $26,
+    lyrics_path = $27,
+    sort_name = $28,
+    musicbrainz_id = $29,
+    music_folder_id = $30,
+    directory_id = $31
+WHERE song_id = $1 RETURNING *;
+
+-- name: DeleteSongs :execrows
+DELETE FROM Songs
+WHERE song_id = ANY(@song_ids::int[]);
+
+-- name: GetSongFingerprints :many
+SELECT song_id, album_id, path, size, mod_time, start_offset, cue_path, lyrics_path FROM Songs;
@@ -12,18 +12,23 @@ import (
 )
 
 const createAlbum = `-- name: CreateAlbum :one
-INSERT INTO Albums (artist_id, name, cover_art, song_count, created, duration, artist)
-VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING album_id, artist_id, name, cover_art, song_count, created, duration, artist
+INSERT INTO Albums (artist_id, name, cover_art, song_count, created, duration, artist, year, compilation, sort_name, musicbrainz_id, music_folder_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING album_id, artist_id, name, cover_art, song_count, created, duration, artist, year, compilation, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector
 `
 
 type CreateAlbumParams struct {
-	ArtistID  pgtype.Int4
-	Name      string
-	CoverArt  pgtype.Text
-	SongCount pgtype.Int4
-	Created   pgtype.Timestamp
-	Duration  pgtype.Int4
-	Artist    pgtype.Text
+	ArtistID      pgtype.Int4
+	Name          string
+	CoverArt      pgtype.Text
+	SongCount     pgtype.Int4
+	Created       pgtype.Timestamp
+	Duration      pgtype.Int4
+	Artist        pgtype.Text
+	Year          int32
+	Compilation   bool
+	SortName      string
+	MusicbrainzID string
+	MusicFolderID int32
 }
 
 func (q *Queries) CreateAlbum(ctx context.Context, arg CreateAlbumParams) (Album, error) {
@@ -35,6 +40,11 @@ func (q *Queries) CreateAlbum(ctx context.Context, arg CreateAlbumParams) (Album
 		arg.Created,
 		arg.Duration,
 		arg.Artist,
+		arg.Year,
+		arg.Compilation,
+		arg.SortName,
+		arg.MusicbrainzID,
+		arg.MusicFolderID,
 	)
 	var i Album
 	err := row.Scan(
@@ -46,12 +56,32 @@ func (q *Queries) CreateAlbum(ctx context.Context, arg CreateAlbumParams) (Album
 		&i.Created,
 		&i.Duration,
 		&i.Artist,
+		&i.Year,
+		&i.Compilation,
+		&i.SortName,
+		&i.MusicbrainzID,
+		&i.MusicFolderID,
+		&i.DirectoryID,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
+const deleteOrphanAlbums = `-- name: DeleteOrphanAlbums :execrows
+DELETE FROM Albums
+WHERE NOT EXISTS (SELECT 1 FROM Songs WHERE Songs.album_id = Albums.album_id)
+`
+
+func (q *Queries) DeleteOrphanAlbums(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOrphanAlbums)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getAlbum = `-- name: GetAlbum :one
-SELECT album_id, artist_id, name, cover_art, song_count, created, duration, artist FROM Albums
+SELECT album_id, artist_id, name, cover_art, song_count, created, duration, artist, year, compilation, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Albums
 WHERE album_id = $1 LIMIT 1
 `
 
@@ -67,13 +97,55 @@ func (q *Queries) GetAlbum(ctx context.Context, albumID int32) (Album, error) {
 		&i.Created,
 		&i.Duration,
 		&i.Artist,
+		&i.Year,
+		&i.Compilation,
+		&i.SortName,
+		&i.MusicbrainzID,
+		&i.MusicFolderID,
+		&i.DirectoryID,
+		&i.SearchVector,
+	)
+	return i, err
+}
+
+const getAlbumByRelease = `-- name: GetAlbumByRelease :one
+SELECT album_id, artist_id, name, cover_art, song_count, created, duration, artist, year, compilation, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Albums
+WHERE artist_id = $1 AND name = $2 AND year = $3 LIMIT 1
+`
+
+type GetAlbumByReleaseParams struct {
+	ArtistID pgtype.Int4
+	Name     string
+	Year     int32
+}
+
+func (q *Queries) GetAlbumByRelease(ctx context.Context, arg GetAlbumByReleaseParams) (Album, error) {
+	row := q.db.QueryRow(ctx, getAlbumByRelease, arg.ArtistID, arg.Name, arg.Year)
+	var i Album
+	err := row.Scan(
+		&i.AlbumID,
+		&i.ArtistID,
+		&i.Name,
+		&i.CoverArt,
+		&i.SongCount,
+		&i.Created,
+		&i.Duration,
+		&i.Artist,
+		&i.Year,
+		&i.Compilation,
+		&i.SortName,
+		&i.MusicbrainzID,
+		&i.MusicFolderID,
+		&i.DirectoryID,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
 const getAlbums = `-- name: GetAlbums :many
-SELECT album_id, artist_id, name, cover_art, song_count, created, duration, artist FROM Albums
+SELECT album_id, artist_id, name, cover_art, song_count, created, duration, artist, year, compilation, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Albums
 WHERE artist_id = $1
+ORDER BY year, lower(sort_name), album_id
 `
 
 func (q *Queries) GetAlbums(ctx context.Context, artistID pgtype.Int4) ([]Album, error) {
@@ -94,6 +166,121 @@ func (q *Queries) GetAlbums(ctx context.Context, artistID pgtype.Int4) ([]Album,
 			&i.Created,
 			&i.Duration,
 			&i.Artist,
+			&i.Year,
+			&i.Compilation,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAlbumsByArtistName = `-- name: GetAlbumsByArtistName :many
+SELECT Albums.album_id, Albums.artist_id, Albums.name, Albums.cover_art, Albums.song_count, Albums.created, Albums.duration, Albums.artist, Albums.year, Albums.compilation, Albums.sort_name, Albums.musicbrainz_id, Albums.music_folder_id, Albums.directory_id, Albums.search_vector FROM Albums
+LEFT JOIN Artists ON Artists.artist_id = Albums.artist_id
+WHERE ($1::int[] IS NULL OR Albums.music_folder_id = ANY($1::int[]))
+ORDER BY lower(COALESCE(Artists.sort_name, Albums.artist, '')), Albums.year, lower(Albums.sort_name), Albums.album_id
+LIMIT $2 OFFSET $3
+`
+
+type GetAlbumsByArtistNameParams struct {
+	MusicFolderIds []int32
+	Limit          int32
+	Offset         int32
+}
+
+func (q *Queries) GetAlbumsByArtistName(ctx context.Context, arg GetAlbumsByArtistNameParams) ([]Album, error) {
+	rows, err := q.db.Query(ctx, getAlbumsByArtistName, arg.MusicFolderIds, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Album
+	for rows.Next() {
+		var i Album
+		if err := rows.Scan(
+			&i.AlbumID,
+			&i.ArtistID,
+			&i.Name,
+			&i.CoverArt,
+			&i.SongCount,
+			&i.Created,
+			&i.Duration,
+			&i.Artist,
+			&i.Year,
+			&i.Compilation,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAlbumsByGenre = `-- name: GetAlbumsByGenre :many
+SELECT Albums.album_id, Albums.artist_id, Albums.name, Albums.cover_art, Albums.song_count, Albums.created, Albums.duration, Albums.artist, Albums.year, Albums.compilation, Albums.sort_name, Albums.musicbrainz_id, Albums.music_folder_id, Albums.directory_id, Albums.search_vector FROM Albums
+JOIN AlbumGenres ON AlbumGenres.album_id = Albums.album_id
+JOIN Genres ON Genres.genre_id = AlbumGenres.genre_id
+WHERE Genres.name = $1
+AND ($2::int[] IS NULL OR Albums.music_folder_id = ANY($2::int[]))
+ORDER BY lower(Albums.sort_name), Albums.album_id
+LIMIT $3 OFFSET $4
+`
+
+type GetAlbumsByGenreParams struct {
+	Name           string
+	MusicFolderIds []int32
+	Limit          int32
+	Offset         int32
+}
+
+func (q *Queries) GetAlbumsByGenre(ctx context.Context, arg GetAlbumsByGenreParams) ([]Album, error) {
+	rows, err := q.db.Query(ctx, getAlbumsByGenre,
+		arg.Name,
+		arg.MusicFolderIds,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Album
+	for rows.Next() {
+		var i Album
+		if err := rows.Scan(
+			&i.AlbumID,
+			&i.ArtistID,
+			&i.Name,
+			&i.CoverArt,
+			&i.SongCount,
+			&i.Created,
+			&i.Duration,
+			&i.Artist,
+			&i.Year,
+			&i.Compilation,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
 		); err != nil {
 			return nil, err
 		}
@@ -104,3 +291,353 @@ func (q *Queries) GetAlbums(ctx context.Context, artistID pgtype.Int4) ([]Album,
 	}
 	return items, nil
 }
+
+const getAlbumsByName = `-- name: GetAlbumsByName :many
+SELECT album_id, artist_id, name, cover_art, song_count, created, duration, artist, year, compilation, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Albums
+WHERE ($1::int[] IS NULL OR music_folder_id = ANY($1::int[]))
+ORDER BY lower(sort_name), album_id
+LIMIT $2 OFFSET $3
+`
+
+type GetAlbumsByNameParams struct {
+	MusicFolderIds []int32
+	Limit          int32
+	Offset         int32
+}
+
+func (q *Queries) GetAlbumsByName(ctx context.Context, arg GetAlbumsByNameParams) ([]Album, error) {
+	rows, err := q.db.Query(ctx, getAlbumsByName, arg.MusicFolderIds, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Album
+	for rows.Next() {
+		var i Album
+		if err := rows.Scan(
+			&i.AlbumID,
+			&i.ArtistID,
+			&i.Name,
+			&i.CoverArt,
+			&i.SongCount,
+			&i.Created,
+			&i.Duration,
+			&i.Artist,
+			&i.Year,
+			&i.Compilation,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAlbumsByYear = `-- name: GetAlbumsByYear :many
+SELECT album_id, artist_id, name, cover_art, song_count, created, duration, artist, year, compilation, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Albums
+WHERE year BETWEEN $1::int AND $2::int
+AND ($3::int[] IS NULL OR music_folder_id = ANY($3::int[]))
+ORDER BY CASE WHEN $4::boolean THEN -year ELSE year END, lower(sort_name), album_id
+LIMIT $5 OFFSET $6
+`
+
+type GetAlbumsByYearParams struct {
+	MinYear        int32
+	MaxYear        int32
+	MusicFolderIds []int32
+	Descending     bool
+	Limit          int32
+	Offset         int32
+}
+
+func (q *Queries) GetAlbumsByYear(ctx context.Context, arg GetAlbumsByYearParams) ([]Album, error) {
+	rows, err := q.db.Query(ctx, getAlbumsByYear,
+		arg.MinYear,
+		arg.MaxYear,
+		arg.MusicFolderIds,
+		arg.Descending,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Album
+	for rows.Next() {
+		var i Album
+		if err := rows.Scan(
+			&i.AlbumID,
+			&i.ArtistID,
+			&i.Name,
+			&i.CoverArt,
+			&i.SongCount,
+			&i.Created,
+			&i.Duration,
+			&i.Artist,
+			&i.Year,
+			&i.Compilation,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNewestAlbums = `-- name: GetNewestAlbums :many
+SELECT album_id, artist_id, name, cover_art, song_count, created, duration, artist, year, compilation, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Albums
+WHERE ($1::int[] IS NULL OR music_folder_id = ANY($1::int[]))
+ORDER BY created DESC, album_id DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetNewestAlbumsParams struct {
+	MusicFolderIds []int32
+	Limit          int32
+	Offset         int32
+}
+
+func (q *Queries) GetNewestAlbums(ctx context.Context, arg GetNewestAlbumsParams) ([]Album, error) {
+	rows, err := q.db.Query(ctx, getNewestAlbums, arg.MusicFolderIds, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Album
+	for rows.Next() {
+		var i Album
+		if err := rows.Scan(
+			&i.AlbumID,
+			&i.ArtistID,
+			&i.Name,
+			&i.CoverArt,
+			&i.SongCount,
+			&i.Created,
+			&i.Duration,
+			&i.Artist,
+			&i.Year,
+			&i.Compilation,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRandomAlbums = `-- name: GetRandomAlbums :many
+SELECT album_id, artist_id, name, cover_art, song_count, created, duration, artist, year, compilation, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Albums
+WHERE ($1::int[] IS NULL OR music_folder_id = ANY($1::int[]))
+ORDER BY random()
+LIMIT $2
+`
+
+type GetRandomAlbumsParams struct {
+	MusicFolderIds []int32
+	Limit          int32
+}
+
+func (q *Queries) GetRandomAlbums(ctx context.Context, arg GetRandomAlbumsParams) ([]Album, error) {
+	rows, err := q.db.Query(ctx, getRandomAlbums, arg.MusicFolderIds, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Album
+	for rows.Next() {
+		var i Album
+		if err := rows.Scan(
+			&i.AlbumID,
+			&i.ArtistID,
+			&i.Name,
+			&i.CoverArt,
+			&i.SongCount,
+			&i.Created,
+			&i.Duration,
+			&i.Artist,
+			&i.Year,
+			&i.Compilation,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchAlbums = `-- name: SearchAlbums :many
+SELECT album_id, artist_id, name, cover_art, song_count, created, duration, artist, year, compilation, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Albums
+WHERE ($1::text = ''
+    OR search_vector @@ to_tsquery('simple', immutable_unaccent($2::text))
+    OR lower(immutable_unaccent(name)) % lower(immutable_unaccent($1::text)))
+AND ($3::int[] IS NULL OR music_folder_id = ANY($3::int[]))
+ORDER BY ts_rank(search_vector, to_tsquery('simple', immutable_unaccent($2::text))) DESC,
+    similarity(lower(immutable_unaccent(name)), lower(immutable_unaccent($1::text))) DESC,
+    lower(sort_name), album_id
+LIMIT $4 OFFSET $5
+`
+
+type SearchAlbumsParams struct {
+	Query          string
+	Terms          string
+	MusicFolderIds []int32
+	Limit          int32
+	Offset         int32
+}
+
+func (q *Queries) SearchAlbums(ctx context.Context, arg SearchAlbumsParams) ([]Album, error) {
+	rows, err := q.db.Query(ctx, searchAlbums,
+		arg.Query,
+		arg.Terms,
+		arg.MusicFolderIds,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Album
+	for rows.Next() {
+		var i Album
+		if err := rows.Scan(
+			&i.AlbumID,
+			&i.ArtistID,
+			&i.Name,
+			&i.CoverArt,
+			&i.SongCount,
+			&i.Created,
+			&i.Duration,
+			&i.Artist,
+			&i.Year,
+			&i.Compilation,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateAlbum = `-- name: UpdateAlbum :one
+UPDATE Albums SET
+    artist_id = $2,
+    name = $3,
+    cover_art = $4,
+    song_count = $5,
+    created = $6,
+    duration = $7,
+    artist = $8,
+    year = $9,
+    compilation = $10,
+    sort_name = $11,
+    musicbrainz_id = $12,
+    music_folder_id = $13
+WHERE album_id = $1 RETURNING album_id, artist_id, name, cover_art, song_count, created, duration, artist, year, compilation, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector
+`
+
+type UpdateAlbumParams struct {
+	AlbumID       int32
+	ArtistID      pgtype.Int4
+	Name          string
+	CoverArt      pgtype.Text
+	SongCount     pgtype.Int4
+	Created       pgtype.Timestamp
+	Duration      pgtype.Int4
+	Artist        pgtype.Text
+	Year          int32
+	Compilation   bool
+	SortName      string
+	MusicbrainzID string
+	MusicFolderID int32
+}
+
+func (q *Queries) UpdateAlbum(ctx context.Context, arg UpdateAlbumParams) (Album, error) {
+	row := q.db.QueryRow(ctx, updateAlbum,
+		arg.AlbumID,
+		arg.ArtistID,
+		arg.Name,
+		arg.CoverArt,
+		arg.SongCount,
+		arg.Created,
+		arg.Duration,
+		arg.Artist,
+		arg.Year,
+		arg.Compilation,
+		arg.SortName,
+		arg.MusicbrainzID,
+		arg.MusicFolderID,
+	)
+	var i Album
+	err := row.Scan(
+		&i.AlbumID,
+		&i.ArtistID,
+		&i.Name,
+		&i.CoverArt,
+		&i.SongCount,
+		&i.Created,
+		&i.Duration,
+		&i.Artist,
+		&i.Year,
+		&i.Compilation,
+		&i.SortName,
+		&i.MusicbrainzID,
+		&i.MusicFolderID,
+		&i.DirectoryID,
+		&i.SearchVector,
+	)
+	return i, err
+}
+
+const updateAlbumStats = `-- name: UpdateAlbumStats :exec
+UPDATE Albums SET
+    song_count = (SELECT COUNT(*) FROM Songs WHERE Songs.album_id = Albums.album_id),
+    duration = (SELECT COALESCE(SUM(Songs.duration), 0) FROM Songs WHERE Songs.album_id = Albums.album_id),
+    directory_id = (SELECT MIN(Songs.directory_id) FROM Songs WHERE Songs.album_id = Albums.album_id)
+`
+
+func (q *Queries) UpdateAlbumStats(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, updateAlbumStats)
+	return err
+}
@@ -12,30 +12,58 @@ import (
 )
 
 const createArtist = `-- name: CreateArtist :one
-INSERT INTO Artists (name, cover_art, album_count)
-VALUES ($1, $2, $3) RETURNING artist_id, name, cover_art, album_count
+INSERT INTO Artists (name, cover_art, album_count, sort_name, musicbrainz_id, music_folder_id)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING artist_id, name, cover_art, album_count, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector
 `
 
 type CreateArtistParams struct {
-	Name       string
-	CoverArt   pgtype.Text
-	AlbumCount pgtype.Int4
+	Name          string
+	CoverArt      pgtype.Text
+	AlbumCount    pgtype.Int4
+	SortName      string
+	MusicbrainzID string
+	MusicFolderID int32
 }
 
 func (q *Queries) CreateArtist(ctx context.Context, arg CreateArtistParams) (Artist, error) {
-	row := q.db.QueryRow(ctx, createArtist, arg.Name, arg.CoverArt, arg.AlbumCount)
+	row := q.db.QueryRow(ctx, createArtist,
+		arg.Name,
+		arg.CoverArt,
+		arg.AlbumCount,
+		arg.SortName,
+		arg.MusicbrainzID,
+		arg.MusicFolderID,
+	)
 	var i Artist
 	err := row.Scan(
 		&i.ArtistID,
 		&i.Name,
 		&i.CoverArt,
 		&i.AlbumCount,
+		&i.SortName,
+		&i.MusicbrainzID,
+		&i.MusicFolderID,
+		&i.DirectoryID,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
+const deleteOrphanArtists = `-- name: DeleteOrphanArtists :execrows
+DELETE FROM Artists
+WHERE NOT EXISTS (SELECT 1 FROM Albums WHERE Albums.artist_id = Artists.artist_id)
+`
+
+func (q *Queries) DeleteOrphanArtists(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOrphanArtists)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getArtist = `-- name: GetArtist :one
-SELECT artist_id, name, cover_art, album_count FROM Artists
+SELECT artist_id, name, cover_art, album_count, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Artists
 WHERE artist_id = $1 LIMIT 1
 `
 
@@ -47,16 +75,135 @@ func (q *Queries) GetArtist(ctx context.Context, artistID int32) (Artist, error)
 		&i.Name,
 		&i.CoverArt,
 		&i.AlbumCount,
+		&i.SortName,
+		&i.MusicbrainzID,
+		&i.MusicFolderID,
+		&i.DirectoryID,
+		&i.SearchVector,
+	)
+	return i, err
+}
+
+const getArtistByMusicBrainzID = `-- name: GetArtistByMusicBrainzID :one
+SELECT artist_id, name, cover_art, album_count, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Artists
+WHERE musicbrainz_id = $1 AND music_folder_id = $2
+ORDER BY artist_id LIMIT 1
+`
+
+type GetArtistByMusicBrainzIDParams struct {
+	MusicbrainzID string
+	MusicFolderID int32
+}
+
+func (q *Queries) GetArtistByMusicBrainzID(ctx context.Context, arg GetArtistByMusicBrainzIDParams) (Artist, error) {
+	row := q.db.QueryRow(ctx, getArtistByMusicBrainzID, arg.MusicbrainzID, arg.MusicFolderID)
+	var i Artist
+	err := row.Scan(
+		&i.ArtistID,
+		&i.Name,
+		&i.CoverArt,
+		&i.AlbumCount,
+		&i.SortName,
+		&i.MusicbrainzID,
+		&i.MusicFolderID,
+		&i.DirectoryID,
+		&i.SearchVector,
+	)
+	return i, err
+}
+
+const getArtistByName = `-- name: GetArtistByName :one
+SELECT artist_id, name, cover_art, album_count, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Artists
+WHERE name = $1 AND music_folder_id = $2
+ORDER BY musicbrainz_id <> '', artist_id LIMIT 1
+`
+
+type GetArtistByNameParams struct {
+	Name          string
+	MusicFolderID int32
+}
+
+func (q *Queries) GetArtistByName(ctx context.Context, arg GetArtistByNameParams) (Artist, error) {
+	row := q.db.QueryRow(ctx, getArtistByName, arg.Name, arg.MusicFolderID)
+	var i Artist
+	err := row.Scan(
+		&i.ArtistID,
+		&i.Name,
+		&i.CoverArt,
+		&i.AlbumCount,
+		&i.SortName,
+		&i.MusicbrainzID,
+		&i.MusicFolderID,
+		&i.DirectoryID,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
 const getArtists = `-- name: GetArtists :many
-SELECT artist_id, name, cover_art, album_count FROM Artists
+SELECT artist_id, name, cover_art, album_count, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Artists
+WHERE ($1::int[] IS NULL OR music_folder_id = ANY($1::int[]))
+ORDER BY lower(sort_name), artist_id
+`
+
+func (q *Queries) GetArtists(ctx context.Context, musicFolderIds []int32) ([]Artist, error) {
+	rows, err := q.db.Query(ctx, getArtists, musicFolderIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Artist
+	for rows.Next() {
+		var i Artist
+		if err := rows.Scan(
+			&i.ArtistID,
+			&i.Name,
+			&i.CoverArt,
+			&i.AlbumCount,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchArtists = `-- name: SearchArtists :many
+SELECT artist_id, name, cover_art, album_count, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Artists
+WHERE ($1::text = ''
+    OR search_vector @@ to_tsquery('simple', immutable_unaccent($2::text))
+    OR lower(immutable_unaccent(name)) % lower(immutable_unaccent($1::text)))
+AND ($3::int[] IS NULL OR music_folder_id = ANY($3::int[]))
+ORDER BY ts_rank(search_vector, to_tsquery('simple', immutable_unaccent($2::text))) DESC,
+    similarity(lower(immutable_unaccent(name)), lower(immutable_unaccent($1::text))) DESC,
+    lower(sort_name), artist_id
+LIMIT $4 OFFSET $5
 `
 
-func (q *Queries) GetArtists(ctx context.Context) ([]Artist, error) {
-	rows, err := q.db.Query(ctx, getArtists)
+type SearchArtistsParams struct {
+	Query          string
+	Terms          string
+	MusicFolderIds []int32
+	Limit          int32
+	Offset         int32
+}
+
+func (q *Queries) SearchArtists(ctx context.Context, arg SearchArtistsParams) ([]Artist, error) {
+	rows, err := q.db.Query(ctx, searchArtists,
+		arg.Query,
+		arg.Terms,
+		arg.MusicFolderIds,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +216,11 @@ func (q *Queries) GetArtists(ctx context.Context) ([]Artist, error) {
 			&i.Name,
 			&i.CoverArt,
 			&i.AlbumCount,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
 		); err != nil {
 			return nil, err
 		}
@@ -79,3 +231,64 @@ func (q *Queries) GetArtists(ctx context.Context) ([]Artist, error) {
 	}
 	return items, nil
 }
+
+const updateArtist = `-- name: UpdateArtist :one
+UPDATE Artists SET
+    name = $2,
+    cover_art = $3,
+    album_count = $4,
+    sort_name = $5,
+    musicbrainz_id = $6,
+    music_folder_id = $7
+WHERE artist_id = $1 RETURNING artist_id, name, cover_art, album_count, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector
+`
+
+type UpdateArtistParams struct {
+	ArtistID      int32
+	Name          string
+	CoverArt      pgtype.Text
+	AlbumCount    pgtype.Int4
+	SortName      string
+	MusicbrainzID string
+	MusicFolderID int32
+}
+
+func (q *Queries) UpdateArtist(ctx context.Context, arg UpdateArtistParams) (Artist, error) {
+	row := q.db.QueryRow(ctx, updateArtist,
+		arg.ArtistID,
+		arg.Name,
+		arg.CoverArt,
+		arg.AlbumCount,
+		arg.SortName,
+		arg.MusicbrainzID,
+		arg.MusicFolderID,
+	)
+	var i Artist
+	err := row.Scan(
+		&i.ArtistID,
+		&i.Name,
+		&i.CoverArt,
+		&i.AlbumCount,
+		&i.SortName,
+		&i.MusicbrainzID,
+		&i.MusicFolderID,
+		&i.DirectoryID,
+		&i.SearchVector,
+	)
+	return i, err
+}
+
+const updateArtistStats = `-- name: UpdateArtistStats :exec
+UPDATE Artists SET
+    album_count = (SELECT COUNT(*) FROM Albums WHERE Albums.artist_id = Artists.artist_id),
+    directory_id = (
+        SELECT MIN(Directories.parent_id) FROM Albums
+        JOIN Directories ON Directories.directory_id = Albums.directory_id
+        WHERE Albums.artist_id = Artists.artist_id
+    )
+`
+
+func (q *Queries) UpdateArtistStats(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, updateArtistStats)
+	return err
+}
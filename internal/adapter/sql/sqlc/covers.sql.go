@@ -11,7 +11,9 @@ import (
 
 const createCover = `-- name: CreateCover :one
 INSERT INTO Covers (cover_id, path)
-VALUES ($1, $2) RETURNING cover_id, path
+VALUES ($1, $2)
+ON CONFLICT (cover_id) DO UPDATE SET path = EXCLUDED.path
+RETURNING cover_id, path
 `
 
 type CreateCoverParams struct {
@@ -9,21 +9,54 @@ import (
 )
 
 type Album struct {
+	AlbumID       int32
+	ArtistID      pgtype.Int4
+	Name          string
+	CoverArt      pgtype.Text
+	SongCount     pgtype.Int4
+	Created       pgtype.Timestamp
+	Duration      pgtype.Int4
+	Artist        pgtype.Text
+	Year          int32
+	Compilation   bool
+	SortName      string
+	MusicbrainzID string
+	MusicFolderID int32
+	DirectoryID   pgtype.Int4
+	SearchVector  interface{}
+}
+
+type AlbumAnnotation struct {
+	Username  string
 	AlbumID   int32
-	ArtistID  pgtype.Int4
-	Name      string
-	CoverArt  pgtype.Text
-	SongCount pgtype.Int4
-	Created   pgtype.Timestamp
-	Duration  pgtype.Int4
-	Artist    pgtype.Text
+	Starred   pgtype.Timestamp
+	Rating    int32
+	PlayCount int32
+	Played    pgtype.Timestamp
+}
+
+type AlbumGenre struct {
+	AlbumID int32
+	GenreID int32
 }
 
 type Artist struct {
-	ArtistID   int32
-	Name       string
-	CoverArt   pgtype.Text
-	AlbumCount pgtype.Int4
+	ArtistID      int32
+	Name          string
+	CoverArt      pgtype.Text
+	AlbumCount    pgtype.Int4
+	SortName      string
+	MusicbrainzID string
+	MusicFolderID int32
+	DirectoryID   pgtype.Int4
+	SearchVector  interface{}
+}
+
+type ArtistAnnotation struct {
+	Username string
+	ArtistID int32
+	Starred  pgtype.Timestamp
+	Rating   int32
 }
 
 type Cover struct {
@@ -31,22 +64,134 @@ type Cover struct {
 	Path    string
 }
 
-type Song struct {
-	SongID      int32
-	AlbumID     pgtype.Int4
-	Title       string
-	Album       pgtype.Text
-	Artist      pgtype.Text
-	IsDir       pgtype.Bool
-	CoverArt    pgtype.Text
-	Created     pgtype.Timestamp
-	Duration    pgtype.Int4
-	BitRate     pgtype.Int4
-	Size        pgtype.Int4
-	Suffix      pgtype.Text
-	ContentType pgtype.Text
-	IsVideo     pgtype.Bool
+type Directory struct {
+	DirectoryID   int32
+	ParentID      pgtype.Int4
+	MusicFolderID int32
+	Path          string
+	Name          string
+	Created       pgtype.Timestamp
+	Updated       int64
+}
+
+type Genre struct {
+	GenreID    int32
+	Name       string
+	SongCount  int32
+	AlbumCount int32
+}
+
+type Lyric struct {
+	LyricsID      int32
+	SongID        int32
+	Lang          string
+	Synced        bool
+	DisplayArtist string
+	DisplayTitle  string
+	OffsetMs      int32
+	LineStarts    []int32
+	LineValues    []string
+}
+
+type MusicFolder struct {
+	MusicFolderID int32
+	Path          string
+	Name          string
+}
+
+type Play struct {
+	PlayID   int32
+	Username string
+	SongID   int32
+	Played   pgtype.Timestamp
+}
+
+type Playlist struct {
+	PlaylistID int32
+	Name       string
+	Comment    string
+	Owner      string
+	Public     bool
+	Created    pgtype.Timestamp
+	Changed    pgtype.Timestamp
+	Path       pgtype.Text
+	ModTime    int64
+}
+
+type PlaylistSong struct {
+	PlaylistID int32
+	Position   int32
+	SongID     int32
+}
+
+type ScanError struct {
+	ScanErrorID int32
+	ScanRunID   int32
 	Path        string
+	Message     string
+}
+
+type ScanRun struct {
+	ScanRunID   int32
+	TriggerType string
+	Partial     bool
+	Cancelled   bool
+	Started     pgtype.Timestamp
+	Finished    pgtype.Timestamp
+	Added       int32
+	Updated     int32
+	Removed     int32
+	Failed      int32
+}
+
+type Song struct {
+	SongID        int32
+	AlbumID       pgtype.Int4
+	Title         string
+	Album         pgtype.Text
+	Artist        pgtype.Text
+	IsDir         pgtype.Bool
+	CoverArt      pgtype.Text
+	Created       pgtype.Timestamp
+	Duration      pgtype.Int4
+	BitRate       pgtype.Int4
+	Size          pgtype.Int8
+	Suffix        pgtype.Text
+	ContentType   pgtype.Text
+	IsVideo       pgtype.Bool
+	Path          string
+	ModTime       int64
+	Track         int32
+	DiscNumber    int32
+	Year          int32
+	TrackGain     float64
+	TrackPeak     float64
+	AlbumGain     float64
+	AlbumPeak     float64
+	StartOffset   int32
+	EndOffset     int32
+	CuePath       string
+	LyricsPath    string
+	SortName      string
+	MusicbrainzID string
+	MusicFolderID int32
+	DirectoryID   int32
+	SearchVector  interface{}
+}
+
+type SongAnnotation struct {
+	Username  string
+	SongID    int32
+	Starred   pgtype.Timestamp
+	Rating    int32
+	PlayCount int32
+	Played    pgtype.Timestamp
+}
+
+type SongGenre struct {
+	SongID   int32
+	GenreID  int32
+	Position int32
 }
 
 type User struct {
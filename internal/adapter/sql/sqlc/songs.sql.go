@@ -12,25 +12,41 @@ import (
 )
 
 const createSong = `-- name: CreateSong :one
-INSERT INTO Songs (album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING song_id, album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path
+INSERT INTO Songs (album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path, mod_time, track, disc_number, year, track_gain, track_peak, album_gain, album_peak, start_offset, end_offset, cue_path, lyrics_path, sort_name, musicbrainz_id, music_folder_id, directory_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30) RETURNING song_id, album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path, mod_time, track, disc_number, year, track_gain, track_peak, album_gain, album_peak, start_offset, end_offset, cue_path, lyrics_path, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector
 `
 
 type CreateSongParams struct {
-	AlbumID     pgtype.Int4
-	Title       string
-	Album       pgtype.Text
-	Artist      pgtype.Text
-	IsDir       pgtype.Bool
-	CoverArt    pgtype.Text
-	Created     pgtype.Timestamp
-	Duration    pgtype.Int4
-	BitRate     pgtype.Int4
-	Size        pgtype.Int4
-	Suffix      pgtype.Text
-	ContentType pgtype.Text
-	IsVideo     pgtype.Bool
-	Path        string
+	AlbumID       pgtype.Int4
+	Title         string
+	Album         pgtype.Text
+	Artist        pgtype.Text
+	IsDir         pgtype.Bool
+	CoverArt      pgtype.Text
+	Created       pgtype.Timestamp
+	Duration      pgtype.Int4
+	BitRate       pgtype.Int4
+	Size          pgtype.Int8
+	Suffix        pgtype.Text
+	ContentType   pgtype.Text
+	IsVideo       pgtype.Bool
+	Path          string
+	ModTime       int64
+	Track         int32
+	DiscNumber    int32
+	Year          int32
+	TrackGain     float64
+	TrackPeak     float64
+	AlbumGain     float64
+	AlbumPeak     float64
+	StartOffset   int32
+	EndOffset     int32
+	CuePath       string
+	LyricsPath    string
+	SortName      string
+	MusicbrainzID string
+	MusicFolderID int32
+	DirectoryID   int32
 }
 
 func (q *Queries) CreateSong(ctx context.Context, arg CreateSongParams) (Song, error) {
@@ -49,6 +65,22 @@ func (q *Queries) CreateSong(ctx context.Context, arg CreateSongParams) (Song, e
 		arg.ContentType,
 		arg.IsVideo,
 		arg.Path,
+		arg.ModTime,
+		arg.Track,
+		arg.DiscNumber,
+		arg.Year,
+		arg.TrackGain,
+		arg.TrackPeak,
+		arg.AlbumGain,
+		arg.AlbumPeak,
+		arg.StartOffset,
+		arg.EndOffset,
+		arg.CuePath,
+		arg.LyricsPath,
+		arg.SortName,
+		arg.MusicbrainzID,
+		arg.MusicFolderID,
+		arg.DirectoryID,
 	)
 	var i Song
 	err := row.Scan(
@@ -67,12 +99,42 @@ func (q *Queries) CreateSong(ctx context.Context, arg CreateSongParams) (Song, e
 		&i.ContentType,
 		&i.IsVideo,
 		&i.Path,
+		&i.ModTime,
+		&i.Track,
+		&i.DiscNumber,
+		&i.Year,
+		&i.TrackGain,
+		&i.TrackPeak,
+		&i.AlbumGain,
+		&i.AlbumPeak,
+		&i.StartOffset,
+		&i.EndOffset,
+		&i.CuePath,
+		&i.LyricsPath,
+		&i.SortName,
+		&i.MusicbrainzID,
+		&i.MusicFolderID,
+		&i.DirectoryID,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
+const deleteSongs = `-- name: DeleteSongs :execrows
+DELETE FROM Songs
+WHERE song_id = ANY($1::int[])
+`
+
+func (q *Queries) DeleteSongs(ctx context.Context, songIds []int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteSongs, songIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getSong = `-- name: GetSong :one
-SELECT song_id, album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path FROM Songs
+SELECT song_id, album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path, mod_time, track, disc_number, year, track_gain, track_peak, album_gain, album_peak, start_offset, end_offset, cue_path, lyrics_path, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Songs
 WHERE song_id = $1 LIMIT 1
 `
 
@@ -95,13 +157,75 @@ func (q *Queries) GetSong(ctx context.Context, songID int32) (Song, error) {
 		&i.ContentType,
 		&i.IsVideo,
 		&i.Path,
+		&i.ModTime,
+		&i.Track,
+		&i.DiscNumber,
+		&i.Year,
+		&i.TrackGain,
+		&i.TrackPeak,
+		&i.AlbumGain,
+		&i.AlbumPeak,
+		&i.StartOffset,
+		&i.EndOffset,
+		&i.CuePath,
+		&i.LyricsPath,
+		&i.SortName,
+		&i.MusicbrainzID,
+		&i.MusicFolderID,
+		&i.DirectoryID,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
+const getSongFingerprints = `-- name: GetSongFingerprints :many
+SELECT song_id, album_id, path, size, mod_time, start_offset, cue_path, lyrics_path FROM Songs
+`
+
+type GetSongFingerprintsRow struct {
+	SongID      int32
+	AlbumID     pgtype.Int4
+	Path        string
+	Size        pgtype.Int8
+	ModTime     int64
+	StartOffset int32
+	CuePath     string
+	LyricsPath  string
+}
+
+func (q *Queries) GetSongFingerprints(ctx context.Context) ([]GetSongFingerprintsRow, error) {
+	rows, err := q.db.Query(ctx, getSongFingerprints)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSongFingerprintsRow
+	for rows.Next() {
+		var i GetSongFingerprintsRow
+		if err := rows.Scan(
+			&i.SongID,
+			&i.AlbumID,
+			&i.Path,
+			&i.Size,
+			&i.ModTime,
+			&i.StartOffset,
+			&i.CuePath,
+			&i.LyricsPath,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getSongs = `-- name: GetSongs :many
-SELECT song_id, album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path FROM Songs
+SELECT song_id, album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path, mod_time, track, disc_number, year, track_gain, track_peak, album_gain, album_peak, start_offset, end_offset, cue_path, lyrics_path, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Songs
 WHERE album_id = $1
+ORDER BY disc_number, track, title, song_id
 `
 
 func (q *Queries) GetSongs(ctx context.Context, albumID pgtype.Int4) ([]Song, error) {
@@ -129,6 +253,161 @@ func (q *Queries) GetSongs(ctx context.Context, albumID pgtype.Int4) ([]Song, er
 			&i.ContentType,
 			&i.IsVideo,
 			&i.Path,
+			&i.ModTime,
+			&i.Track,
+			&i.DiscNumber,
+			&i.Year,
+			&i.TrackGain,
+			&i.TrackPeak,
+			&i.AlbumGain,
+			&i.AlbumPeak,
+			&i.StartOffset,
+			&i.EndOffset,
+			&i.CuePath,
+			&i.LyricsPath,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSongsByDirectory = `-- name: GetSongsByDirectory :many
+SELECT song_id, album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path, mod_time, track, disc_number, year, track_gain, track_peak, album_gain, album_peak, start_offset, end_offset, cue_path, lyrics_path, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Songs
+WHERE directory_id = $1
+ORDER BY disc_number, track, title, song_id
+`
+
+func (q *Queries) GetSongsByDirectory(ctx context.Context, directoryID int32) ([]Song, error) {
+	rows, err := q.db.Query(ctx, getSongsByDirectory, directoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Song
+	for rows.Next() {
+		var i Song
+		if err := rows.Scan(
+			&i.SongID,
+			&i.AlbumID,
+			&i.Title,
+			&i.Album,
+			&i.Artist,
+			&i.IsDir,
+			&i.CoverArt,
+			&i.Created,
+			&i.Duration,
+			&i.BitRate,
+			&i.Size,
+			&i.Suffix,
+			&i.ContentType,
+			&i.IsVideo,
+			&i.Path,
+			&i.ModTime,
+			&i.Track,
+			&i.DiscNumber,
+			&i.Year,
+			&i.TrackGain,
+			&i.TrackPeak,
+			&i.AlbumGain,
+			&i.AlbumPeak,
+			&i.StartOffset,
+			&i.EndOffset,
+			&i.CuePath,
+			&i.LyricsPath,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchSongs = `-- name: SearchSongs :many
+SELECT song_id, album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path, mod_time, track, disc_number, year, track_gain, track_peak, album_gain, album_peak, start_offset, end_offset, cue_path, lyrics_path, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector FROM Songs
+WHERE ($1::text = ''
+    OR search_vector @@ to_tsquery('simple', immutable_unaccent($2::text))
+    OR lower(immutable_unaccent(title)) % lower(immutable_unaccent($1::text)))
+AND ($3::int[] IS NULL OR music_folder_id = ANY($3::int[]))
+ORDER BY ts_rank(search_vector, to_tsquery('simple', immutable_unaccent($2::text))) DESC,
+    similarity(lower(immutable_unaccent(title)), lower(immutable_unaccent($1::text))) DESC,
+    lower(title), song_id
+LIMIT $4 OFFSET $5
+`
+
+type SearchSongsParams struct {
+	Query          string
+	Terms          string
+	MusicFolderIds []int32
+	Limit          int32
+	Offset         int32
+}
+
+func (q *Queries) SearchSongs(ctx context.Context, arg SearchSongsParams) ([]Song, error) {
+	rows, err := q.db.Query(ctx, searchSongs,
+		arg.Query,
+		arg.Terms,
+		arg.MusicFolderIds,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Song
+	for rows.Next() {
+		var i Song
+		if err := rows.Scan(
+			&i.SongID,
+			&i.AlbumID,
+			&i.Title,
+			&i.Album,
+			&i.Artist,
+			&i.IsDir,
+			&i.CoverArt,
+			&i.Created,
+			&i.Duration,
+			&i.BitRate,
+			&i.Size,
+			&i.Suffix,
+			&i.ContentType,
+			&i.IsVideo,
+			&i.Path,
+			&i.ModTime,
+			&i.Track,
+			&i.DiscNumber,
+			&i.Year,
+			&i.TrackGain,
+			&i.TrackPeak,
+			&i.AlbumGain,
+			&i.AlbumPeak,
+			&i.StartOffset,
+			&i.EndOffset,
+			&i.CuePath,
+			&i.LyricsPath,
+			&i.SortName,
+			&i.MusicbrainzID,
+			&i.MusicFolderID,
+			&i.DirectoryID,
+			&i.SearchVector,
 		); err != nil {
 			return nil, err
 		}
@@ -139,3 +418,144 @@ func (q *Queries) GetSongs(ctx context.Context, albumID pgtype.Int4) ([]Song, er
 	}
 	return items, nil
 }
+
+const updateSong = `-- name: UpdateSong :one
+UPDATE Songs SET
+    album_id = $2,
+    title = $3,
+    album = $4,
+    artist = $5,
+    is_dir = $6,
+    cover_art = $7,
+    created = $8,
+    duration = $9,
+    bit_rate = $10,
+    size = $11,
+    suffix = $12,
+    content_type = $13,
+    is_video = $14,
+    path = $15,
+    mod_time = $16,
+    track = $17,
+    disc_number = $18,
+    year = $19,
+    track_gain = $20,
+    track_peak = $21,
+    album_gain = $22,
+    album_peak = $23,
+    start_offset = $24,
+    end_offset = $25,
+    cue_path = $26,
+    lyrics_path = $27,
+    sort_name = $28,
+    musicbrainz_id = $29,
+    music_folder_id = $30,
+    directory_id = $31
+WHERE song_id = $1 RETURNING song_id, album_id, title, album, artist, is_dir, cover_art, created, duration, bit_rate, size, suffix, content_type, is_video, path, mod_time, track, disc_number, year, track_gain, track_peak, album_gain, album_peak, start_offset, end_offset, cue_path, lyrics_path, sort_name, musicbrainz_id, music_folder_id, directory_id, search_vector
+`
+
+type UpdateSongParams struct {
+	SongID        int32
+	AlbumID       pgtype.Int4
+	Title         string
+	Album         pgtype.Text
+	Artist        pgtype.Text
+	IsDir         pgtype.Bool
+	CoverArt      pgtype.Text
+	Created       pgtype.Timestamp
+	Duration      pgtype.Int4
+	BitRate       pgtype.Int4
+	Size          pgtype.Int8
+	Suffix        pgtype.Text
+	ContentType   pgtype.Text
+	IsVideo       pgtype.Bool
+	Path          string
+	ModTime       int64
+	Track         int32
+	DiscNumber    int32
+	Year          int32
+	TrackGain     float64
+	TrackPeak     float64
+	AlbumGain     float64
+	AlbumPeak     float64
+	StartOffset   int32
+	EndOffset     int32
+	CuePath       string
+	LyricsPath    string
+	SortName      string
+	MusicbrainzID string
+	MusicFolderID int32
+	DirectoryID   int32
+}
+
+func (q *Queries) UpdateSong(ctx context.Context, arg UpdateSongParams) (Song, error) {
+	row := q.db.QueryRow(ctx, updateSong,
+		arg.SongID,
+		arg.AlbumID,
+		arg.Title,
+		arg.Album,
+		arg.Artist,
+		arg.IsDir,
+		arg.CoverArt,
+		arg.Created,
+		arg.Duration,
+		arg.BitRate,
+		arg.Size,
+		arg.Suffix,
+		arg.ContentType,
+		arg.IsVideo,
+		arg.Path,
+		arg.ModTime,
+		arg.Track,
+		arg.DiscNumber,
+		arg.Year,
+		arg.TrackGain,
+		arg.TrackPeak,
+		arg.AlbumGain,
+		arg.AlbumPeak,
+		arg.StartOffset,
+		arg.EndOffset,
+		arg.CuePath,
+		arg.LyricsPath,
+		arg.SortName,
+		arg.MusicbrainzID,
+		arg.MusicFolderID,
+		arg.DirectoryID,
+	)
+	var i Song
+	err := row.Scan(
+		&i.SongID,
+		&i.AlbumID,
+		&i.Title,
+		&i.Album,
+		&i.Artist,
+		&i.IsDir,
+		&i.CoverArt,
+		&i.Created,
+		&i.Duration,
+		&i.BitRate,
+		&i.Size,
+		&i.Suffix,
+		&i.ContentType,
+		&i.IsVideo,
+		&i.Path,
+		&i.ModTime,
+		&i.Track,
+		&i.DiscNumber,
+		&i.Year,
+		&i.TrackGain,
+		&i.TrackPeak,
+		&i.AlbumGain,
+		&i.AlbumPeak,
+		&i.StartOffset,
+		&i.EndOffset,
+		&i.CuePath,
+		&i.LyricsPath,
+		&i.SortName,
+		&i.MusicbrainzID,
+		&i.MusicFolderID,
+		&i.DirectoryID,
+		&i.SearchVector,
+	)
+	return i, err
+}
@@ -1,3 +1,11 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent is only stable as its dictionary may change, search columns and indexes need an immutable version
+CREATE OR REPLACE FUNCTION immutable_unaccent(TEXT) RETURNS TEXT
+AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$
+LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
 CREATE TABLE IF NOT EXISTS Users (
     username VARCHAR(30),
     password VARCHAR(50) NOT NULL,
@@ -32,14 +40,81 @@ CREATE TABLE IF NOT EXISTS Covers(
     PRIMARY KEY(cover_id)
 );
 
+CREATE TABLE IF NOT EXISTS MusicFolders (
+    music_folder_id SERIAL,
+    path TEXT NOT NULL,
+    name TEXT NOT NULL,
+    PRIMARY KEY(music_folder_id),
+    UNIQUE (path)
+);
+
+CREATE TABLE IF NOT EXISTS Directories (
+    directory_id SERIAL,
+    parent_id INTEGER,
+    music_folder_id INTEGER NOT NULL,
+    path TEXT NOT NULL,
+    name TEXT NOT NULL,
+    created TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY(directory_id),
+    UNIQUE (path),
+    FOREIGN KEY (parent_id) REFERENCES Directories(directory_id),
+    FOREIGN KEY (music_folder_id) REFERENCES MusicFolders(music_folder_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_directories_parent_id
+ON Directories(parent_id);
+
+-- Artists, albums and songs indexed before music folders and directories existed cannot be assigned to them,
+-- upgrading stops until the library is emptied and indexed again by a scan
+DO $$
+BEGIN
+    IF to_regclass('songs') IS NOT NULL AND (
+        SELECT count(*) FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'songs' AND column_name IN ('music_folder_id', 'directory_id')
+    ) < 2 THEN
+        IF EXISTS (SELECT 1 FROM Artists) OR EXISTS (SELECT 1 FROM Albums) OR EXISTS (SELECT 1 FROM Songs) THEN
+            RAISE EXCEPTION 'the library was indexed before music folders and directories existed'
+                USING HINT = 'Empty it with TRUNCATE Songs, Albums, Artists CASCADE, apply the schema again and start a scan';
+        END IF;
+    END IF;
+END $$;
+
 CREATE TABLE IF NOT EXISTS Artists (
     artist_id SERIAL,
     name TEXT NOT NULL,
     cover_art TEXT,
     album_count INTEGER,
-    PRIMARY KEY(artist_id)
+    sort_name TEXT NOT NULL DEFAULT '',
+    musicbrainz_id TEXT NOT NULL DEFAULT '',
+    music_folder_id INTEGER NOT NULL,
+    directory_id INTEGER,
+    search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', immutable_unaccent(name))) STORED,
+    PRIMARY KEY(artist_id),
+    FOREIGN KEY (music_folder_id) REFERENCES MusicFolders(music_folder_id),
+    FOREIGN KEY (directory_id) REFERENCES Directories(directory_id) ON DELETE SET NULL
 );
 
+-- Columns added since the table was created, for databases created by earlier versions
+ALTER TABLE Artists
+    ADD COLUMN IF NOT EXISTS sort_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS musicbrainz_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS music_folder_id INTEGER NOT NULL REFERENCES MusicFolders(music_folder_id),
+    ADD COLUMN IF NOT EXISTS directory_id INTEGER REFERENCES Directories(directory_id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', immutable_unaccent(name))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_artists_name
+ON Artists(name);
+
+CREATE INDEX IF NOT EXISTS idx_artists_search_vector
+ON Artists USING GIN (search_vector);
+
+CREATE INDEX IF NOT EXISTS idx_artists_name_trgm
+ON Artists USING GIN (lower(immutable_unaccent(name)) gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_artists_musicbrainz_id
+ON Artists(musicbrainz_id);
+
 CREATE TABLE IF NOT EXISTS Albums (
     album_id SERIAL,
     artist_id INTEGER,
@@ -49,10 +124,40 @@ CREATE TABLE IF NOT EXISTS Albums (
     created TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     duration INTEGER,
     artist TEXT,
+    year INTEGER NOT NULL DEFAULT 0,
+    compilation BOOLEAN NOT NULL DEFAULT FALSE,
+    sort_name TEXT NOT NULL DEFAULT '',
+    musicbrainz_id TEXT NOT NULL DEFAULT '',
+    music_folder_id INTEGER NOT NULL,
+    directory_id INTEGER,
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', immutable_unaccent(name)), 'A') ||
+        setweight(to_tsvector('simple', immutable_unaccent(coalesce(artist, ''))), 'B')
+    ) STORED,
     PRIMARY KEY(album_id),
-    FOREIGN KEY (artist_id) REFERENCES Artists(artist_id)
+    FOREIGN KEY (artist_id) REFERENCES Artists(artist_id),
+    FOREIGN KEY (music_folder_id) REFERENCES MusicFolders(music_folder_id),
+    FOREIGN KEY (directory_id) REFERENCES Directories(directory_id) ON DELETE SET NULL
 );
 
+ALTER TABLE Albums
+    ADD COLUMN IF NOT EXISTS year INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS compilation BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS sort_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS musicbrainz_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS music_folder_id INTEGER NOT NULL REFERENCES MusicFolders(music_folder_id),
+    ADD COLUMN IF NOT EXISTS directory_id INTEGER REFERENCES Directories(directory_id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', immutable_unaccent(name)), 'A') ||
+        setweight(to_tsvector('simple', immutable_unaccent(coalesce(artist, ''))), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_albums_search_vector
+ON Albums USING GIN (search_vector);
+
+CREATE INDEX IF NOT EXISTS idx_albums_name_trgm
+ON Albums USING GIN (lower(immutable_unaccent(name)) gin_trgm_ops);
+
 CREATE TABLE IF NOT EXISTS Songs (
     song_id SERIAL,
     album_id INTEGER,
@@ -64,11 +169,226 @@ CREATE TABLE IF NOT EXISTS Songs (
     created TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     duration INTEGER,
     bit_rate INTEGER,
-    size INTEGER,
+    size BIGINT,
     suffix TEXT,
     content_type TEXT,
     is_video BOOLEAN,
     path TEXT NOT NULL,
+    mod_time BIGINT NOT NULL DEFAULT 0,
+    track INTEGER NOT NULL DEFAULT 0,
+    disc_number INTEGER NOT NULL DEFAULT 0,
+    year INTEGER NOT NULL DEFAULT 0,
+    track_gain DOUBLE PRECISION NOT NULL DEFAULT 0,
+    track_peak DOUBLE PRECISION NOT NULL DEFAULT 0,
+    album_gain DOUBLE PRECISION NOT NULL DEFAULT 0,
+    album_peak DOUBLE PRECISION NOT NULL DEFAULT 0,
+    start_offset INTEGER NOT NULL DEFAULT 0,
+    end_offset INTEGER NOT NULL DEFAULT 0,
+    cue_path TEXT NOT NULL DEFAULT '',
+    lyrics_path TEXT NOT NULL DEFAULT '',
+    sort_name TEXT NOT NULL DEFAULT '',
+    musicbrainz_id TEXT NOT NULL DEFAULT '',
+    music_folder_id INTEGER NOT NULL,
+    directory_id INTEGER NOT NULL,
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', immutable_unaccent(title)), 'A') ||
+        setweight(to_tsvector('simple', immutable_unaccent(coalesce(artist, ''))), 'B') ||
+        setweight(to_tsvector('simple', immutable_unaccent(coalesce(album, ''))), 'C')
+    ) STORED,
     PRIMARY KEY(song_id),
-    FOREIGN KEY (album_id) REFERENCES Albums(album_id)
-);
\ No newline at end of file
+    UNIQUE (path, start_offset),
+    FOREIGN KEY (album_id) REFERENCES Albums(album_id),
+    FOREIGN KEY (music_folder_id) REFERENCES MusicFolders(music_folder_id),
+    FOREIGN KEY (directory_id) REFERENCES Directories(directory_id)
+);
+
+ALTER TABLE Songs
+    ALTER COLUMN size TYPE BIGINT,
+    ADD COLUMN IF NOT EXISTS mod_time BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS track INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS disc_number INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS year INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS track_gain DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS track_peak DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS album_gain DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS album_peak DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS start_offset INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS end_offset INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS cue_path TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS lyrics_path TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS sort_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS musicbrainz_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS music_folder_id INTEGER NOT NULL REFERENCES MusicFolders(music_folder_id),
+    ADD COLUMN IF NOT EXISTS directory_id INTEGER NOT NULL REFERENCES Directories(directory_id),
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', immutable_unaccent(title)), 'A') ||
+        setweight(to_tsvector('simple', immutable_unaccent(coalesce(artist, ''))), 'B') ||
+        setweight(to_tsvector('simple', immutable_unaccent(coalesce(album, ''))), 'C')
+    ) STORED,
+    -- Songs were unique by path before files could hold several tracks
+    DROP CONSTRAINT IF EXISTS songs_path_key;
+
+DROP INDEX IF EXISTS songs_path_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS songs_path_start_offset_key
+ON Songs(path, start_offset);
+
+CREATE INDEX IF NOT EXISTS idx_songs_directory_id
+ON Songs(directory_id);
+
+CREATE INDEX IF NOT EXISTS idx_songs_search_vector
+ON Songs USING GIN (search_vector);
+
+CREATE INDEX IF NOT EXISTS idx_songs_title_trgm
+ON Songs USING GIN (lower(immutable_unaccent(title)) gin_trgm_ops);
+
+CREATE TABLE IF NOT EXISTS Genres (
+    genre_id SERIAL,
+    name TEXT NOT NULL,
+    song_count INTEGER NOT NULL DEFAULT 0,
+    album_count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY(genre_id),
+    UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS SongGenres (
+    song_id INTEGER NOT NULL,
+    genre_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    PRIMARY KEY(song_id, genre_id),
+    FOREIGN KEY (song_id) REFERENCES Songs(song_id) ON DELETE CASCADE,
+    FOREIGN KEY (genre_id) REFERENCES Genres(genre_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_songgenres_genre_id
+ON SongGenres(genre_id);
+
+CREATE TABLE IF NOT EXISTS AlbumGenres (
+    album_id INTEGER NOT NULL,
+    genre_id INTEGER NOT NULL,
+    PRIMARY KEY(album_id, genre_id),
+    FOREIGN KEY (album_id) REFERENCES Albums(album_id) ON DELETE CASCADE,
+    FOREIGN KEY (genre_id) REFERENCES Genres(genre_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS Lyrics (
+    lyrics_id SERIAL,
+    song_id INTEGER NOT NULL,
+    lang TEXT NOT NULL,
+    synced BOOLEAN NOT NULL DEFAULT FALSE,
+    display_artist TEXT NOT NULL DEFAULT '',
+    display_title TEXT NOT NULL DEFAULT '',
+    offset_ms INTEGER NOT NULL DEFAULT 0,
+    line_starts INTEGER[] NOT NULL,
+    line_values TEXT[] NOT NULL,
+    PRIMARY KEY(lyrics_id),
+    FOREIGN KEY (song_id) REFERENCES Songs(song_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_lyrics_song_id
+ON Lyrics(song_id);
+
+CREATE TABLE IF NOT EXISTS Playlists (
+    playlist_id SERIAL,
+    name TEXT NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    owner VARCHAR(30) NOT NULL,
+    public BOOLEAN NOT NULL DEFAULT FALSE,
+    created TIMESTAMP NOT NULL,
+    changed TIMESTAMP NOT NULL,
+    path TEXT,
+    mod_time BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY(playlist_id),
+    UNIQUE (path),
+    FOREIGN KEY (owner) REFERENCES Users(username) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS PlaylistSongs (
+    playlist_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    song_id INTEGER NOT NULL,
+    PRIMARY KEY(playlist_id, position),
+    FOREIGN KEY (playlist_id) REFERENCES Playlists(playlist_id) ON DELETE CASCADE,
+    FOREIGN KEY (song_id) REFERENCES Songs(song_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS ArtistAnnotations (
+    username VARCHAR(30) NOT NULL,
+    artist_id INTEGER NOT NULL,
+    starred TIMESTAMP,
+    rating INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY(username, artist_id),
+    FOREIGN KEY (username) REFERENCES Users(username) ON DELETE CASCADE,
+    FOREIGN KEY (artist_id) REFERENCES Artists(artist_id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS AlbumAnnotations (
+    username VARCHAR(30) NOT NULL,
+    album_id INTEGER NOT NULL,
+    starred TIMESTAMP,
+    rating INTEGER NOT NULL DEFAULT 0,
+    play_count INTEGER NOT NULL DEFAULT 0,
+    played TIMESTAMP,
+    PRIMARY KEY(username, album_id),
+    FOREIGN KEY (username) REFERENCES Users(username) ON DELETE CASCADE,
+    FOREIGN KEY (album_id) REFERENCES Albums(album_id) ON DELETE CASCADE
+);
+
+ALTER TABLE AlbumAnnotations
+    ADD COLUMN IF NOT EXISTS play_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS played TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS SongAnnotations (
+    username VARCHAR(30) NOT NULL,
+    song_id INTEGER NOT NULL,
+    starred TIMESTAMP,
+    rating INTEGER NOT NULL DEFAULT 0,
+    play_count INTEGER NOT NULL DEFAULT 0,
+    played TIMESTAMP,
+    PRIMARY KEY(username, song_id),
+    FOREIGN KEY (username) REFERENCES Users(username) ON DELETE CASCADE,
+    FOREIGN KEY (song_id) REFERENCES Songs(song_id) ON DELETE CASCADE
+);
+
+ALTER TABLE SongAnnotations
+    ADD COLUMN IF NOT EXISTS play_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS played TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS Plays (
+    play_id SERIAL,
+    username VARCHAR(30) NOT NULL,
+    song_id INTEGER NOT NULL,
+    played TIMESTAMP NOT NULL,
+    PRIMARY KEY(play_id),
+    FOREIGN KEY (username) REFERENCES Users(username) ON DELETE CASCADE,
+    FOREIGN KEY (song_id) REFERENCES Songs(song_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_plays_username_played
+ON Plays(username, played);
+
+CREATE TABLE IF NOT EXISTS ScanRuns (
+    scan_run_id SERIAL,
+    trigger_type TEXT NOT NULL,
+    partial BOOLEAN NOT NULL DEFAULT FALSE,
+    cancelled BOOLEAN NOT NULL DEFAULT FALSE,
+    started TIMESTAMP NOT NULL,
+    finished TIMESTAMP,
+    added INTEGER NOT NULL DEFAULT 0,
+    updated INTEGER NOT NULL DEFAULT 0,
+    removed INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY(scan_run_id)
+);
+
+CREATE TABLE IF NOT EXISTS ScanErrors (
+    scan_error_id SERIAL,
+    scan_run_id INTEGER NOT NULL,
+    path TEXT NOT NULL,
+    message TEXT NOT NULL,
+    PRIMARY KEY(scan_error_id),
+    FOREIGN KEY (scan_run_id) REFERENCES ScanRuns(scan_run_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_scanerrors_scan_run_id
+ON ScanErrors(scan_run_id);
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -12,7 +13,20 @@ const (
 )
 
 type Config struct {
-	MusicDirectories []string `mapstructure:"music-directories"`
+	MusicDirectories    []string      `mapstructure:"music-directories"`
+	Watch               bool          `mapstructure:"watch"`
+	WatchDebounce       time.Duration `mapstructure:"watch-debounce"`
+	ScanWorkers         int           `mapstructure:"scan-workers"`
+	ScanBatchSize       int           `mapstructure:"scan-batch-size"`
+	CoverArtPriority    []string      `mapstructure:"cover-art-priority"`
+	CoverCacheDirectory string        `mapstructure:"cover-cache-directory"`
+	MetadataExtractor   string        `mapstructure:"metadata-extractor"`
+	ScanSchedule        string        `mapstructure:"scan-schedule"`
+	LoudnessAnalysis    bool          `mapstructure:"loudness-analysis"`
+	PlaylistOwner       string        `mapstructure:"playlist-owner"`
+	IgnoredArticles     []string      `mapstructure:"ignored-articles"`
+	ScanExcludes        []string      `mapstructure:"scan-excludes"`
+	GenreSeparators     []string      `mapstructure:"genre-separators"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UnknownLyricsLanguage is the language of lyrics whose language is not known, as written by taggers.
+const UnknownLyricsLanguage = "xxx"
+
+// Lyrics are the lyrics of a song in one language, read from an LRC file next to the song's file or from its tags.
+// Synced lyrics have the start of every line. Offset, in milliseconds, makes lines appear sooner when positive.
+type Lyrics struct {
+	SongId        int
+	Lang          string
+	Synced        bool
+	DisplayArtist string
+	DisplayTitle  string
+	Offset        int
+	Lines         []LyricsLine
+}
+
+// LyricsLine is a line of lyrics. Start is in milliseconds and only meaningful for synced lyrics.
+type LyricsLine struct {
+	Start int
+	Value string
+}
+
+// Validate checks if the Lyrics have valid field values
+func (l *Lyrics) Validate() error {
+	if l.Lang == "" {
+		return errors.New("lyrics language is required")
+	}
+	if len(l.Lines) == 0 {
+		return errors.New("lyrics must have at least one line")
+	}
+	for i, line := range l.Lines {
+		if line.Start < 0 {
+			return fmt.Errorf("line %d starts before the song, at %d", i+1, line.Start)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// MaxRating is the best rating users give artists, albums and songs, ratings going from 1 to MaxRating.
+const MaxRating = 5
+
+// AnnotationItemType identifies the kind of item users star and rate
+type AnnotationItemType string
+
+const (
+	AnnotationItemArtist AnnotationItemType = "artist"
+	AnnotationItemAlbum  AnnotationItemType = "album"
+	AnnotationItemSong   AnnotationItemType = "song"
+)
+
+// Annotation holds the star and rating a user gave an artist, album or song, and how often the user played an album or song.
+type Annotation struct {
+	ItemId    int
+	Starred   string // time the item was starred, empty when it is not starred
+	Rating    int    // from 1 to MaxRating, 0 when the item is not rated
+	PlayCount int    // number of plays recorded for the item
+	Played    string // time of the last play recorded for the item, empty when never played
+}
+
+// Starred lists the artists, albums and songs a user starred, the last starred first.
+type Starred struct {
+	Artists []Artist
+	Albums  []Album
+	Songs   []Song
+}
+
+// NowPlaying is the song a user is playing on a player, either notified through scrobble or streamed.
+type NowPlaying struct {
+	Song       Song
+	Username   string
+	PlayerName string // client application the song is played with
+	Started    time.Time
+}
@@ -6,12 +6,72 @@ import (
 	"strings"
 )
 
-// Artist represents a music artist in the domain
+// VariousArtists is the name of the artist compilations are grouped under.
+const VariousArtists = "Various Artists"
+
+// MusicFolder represents one of the configured music directories.
+// Its ID is kept across restarts and configuration changes, users can be restricted to some music folders.
+type MusicFolder struct {
+	Id   int
+	Name string
+	Path string
+}
+
+// Directory represents a folder of a music folder holding songs, for clients browsing by file structure.
+// The directory of a music folder itself has no parent.
+type Directory struct {
+	Id            int
+	ParentId      int // 0 for the directory of a music folder
+	MusicFolderId int
+	Path          string
+	Name          string
+	Created       string
+	Updated       int64  // milliseconds since the epoch of the creation of the directory or the last change to its subdirectories
+	Children      []Song // only set when the directory is retrieved with its content, subdirectories first with IsDir set
+}
+
+// Indexes lists the top-level directories of music folders under the letter they are sorted by,
+// along with the songs right in the music folders.
+type Indexes struct {
+	LastModified    int64    // milliseconds since the epoch of the last change to the listed directories
+	IgnoredArticles []string // leading articles ignored to sort and index directories
+	Index           []Index
+	Songs           []Song
+}
+
+// Index groups the directories whose sort name starts with the same letter, "#" for other characters.
+type Index struct {
+	Name        string
+	Directories []Directory
+}
+
+// Artist represents a music artist in the domain.
+// Artists sharing a name are told apart by their MusicBrainz ID when their files are tagged with one.
+// An artist with files in several music folders has one Artist per music folder.
 type Artist struct {
-	Id         int
-	Name       string
-	CoverArt   string
-	AlbumCount int
+	Id            int
+	Name          string
+	CoverArt      string
+	AlbumCount    int
+	SortName      string  // name the artist is sorted by, such as "Beatles, The"
+	MusicBrainzId string  // MusicBrainz artist ID, empty when unknown
+	MusicFolderId int     // music folder the artist's files are in
+	DirectoryId   int     // directory holding the artist's album directories, the first one when there are several
+	Starred       string  // time the requesting user starred the artist, empty when not starred
+	UserRating    int     // rating from 1 to 5 the requesting user gave the artist, 0 when not rated
+	Albums        []Album // only set when the artist is retrieved with its albums
+}
+
+// ArtistIndexes lists artists under the letter they are sorted by.
+type ArtistIndexes struct {
+	IgnoredArticles []string // leading articles ignored to sort and index artists without sort name
+	Index           []ArtistIndex
+}
+
+// ArtistIndex groups the artists whose sort name starts with the same letter, "#" for other characters.
+type ArtistIndex struct {
+	Name    string
+	Artists []Artist
 }
 
 // Validate checks if the Artist has valid field values
@@ -25,16 +85,75 @@ func (a *Artist) Validate() error {
 	return nil
 }
 
-// Album represents a music album in the domain
+// Album represents a music album in the domain.
+// Albums are identified by their album artist, name and release year.
+// The artist of a compilation is VariousArtists unless its files name an album artist.
 type Album struct {
-	Id        int
-	ArtistId  int
-	Name      string
-	CoverArt  string
-	SongCount int
-	Created   string
-	Duration  int
-	Artist    string
+	Id            int
+	ArtistId      int
+	Name          string
+	CoverArt      string
+	SongCount     int
+	Created       string
+	Duration      int
+	Artist        string
+	Year          int
+	Compilation   bool
+	SortName      string // name the album is sorted by
+	MusicBrainzId string // MusicBrainz release ID, empty when unknown
+	MusicFolderId int    // music folder of the album artist
+	DirectoryId   int    // directory holding the album's songs, the first one when they are spread over several
+	Starred       string // time the requesting user starred the album, empty when not starred
+	UserRating    int    // rating from 1 to 5 the requesting user gave the album, 0 when not rated
+	PlayCount     int    // number of plays of the album's songs the requesting user recorded
+	Played        string // time the requesting user last played a song of the album, empty when never played
+	Songs         []Song // only set when the album is retrieved with its songs
+}
+
+// AlbumListType is the order albums are listed in by getAlbumList and getAlbumList2.
+type AlbumListType string
+
+const (
+	AlbumListRandom               AlbumListType = "random"
+	AlbumListNewest               AlbumListType = "newest"
+	AlbumListHighest              AlbumListType = "highest"
+	AlbumListFrequent             AlbumListType = "frequent"
+	AlbumListRecent               AlbumListType = "recent"
+	AlbumListAlphabeticalByName   AlbumListType = "alphabeticalByName"
+	AlbumListAlphabeticalByArtist AlbumListType = "alphabeticalByArtist"
+	AlbumListStarred              AlbumListType = "starred"
+	AlbumListByYear               AlbumListType = "byYear"
+	AlbumListByGenre              AlbumListType = "byGenre"
+)
+
+// AlbumListQuery selects a page of albums of an album list.
+type AlbumListQuery struct {
+	Type          AlbumListType
+	Size          int
+	Offset        int    // ignored by random lists
+	FromYear      int    // first year of byYear lists, the list is in reverse order when after ToYear
+	ToYear        int    // last year of byYear lists
+	Genre         string // genre of byGenre lists
+	MusicFolderId int    // 0 for every music folder the user can access
+}
+
+// SearchQuery selects pages of the artists, albums and songs matching a query.
+type SearchQuery struct {
+	Query         string // words matched as prefixes ignoring case and diacritics, empty to match everything
+	ArtistCount   int
+	ArtistOffset  int
+	AlbumCount    int
+	AlbumOffset   int
+	SongCount     int
+	SongOffset    int
+	MusicFolderId int // 0 for every music folder the user can access
+}
+
+// SearchResult holds the artists, albums and songs matching a search query, best matches first.
+type SearchResult struct {
+	Artists []Artist
+	Albums  []Album
+	Songs   []Song
 }
 
 // Validate checks if the Album has valid field values
@@ -48,26 +167,72 @@ func (a *Album) Validate() error {
 	if a.Duration < 0 {
 		return fmt.Errorf("duration must be non-negative, got %d", a.Duration)
 	}
+	if a.Year < 0 {
+		return fmt.Errorf("year must be non-negative, got %d", a.Year)
+	}
 	return nil
 }
 
 // Song represents a music track in the domain
 type Song struct {
-	Id          int
-	AlbumId     int
-	Title       string
-	Album       string
-	Artist      string
-	IsDir       bool
-	CoverArt    string
-	Created     string
-	Duration    int
-	BitRate     int
-	Size        int64
-	Suffix      string
-	ContentType string
-	IsVideo     bool
-	Path        string
+	Id            int
+	AlbumId       int
+	Title         string
+	Album         string
+	Artist        string
+	IsDir         bool
+	CoverArt      string
+	Created       string
+	Duration      int
+	BitRate       int
+	Size          int64
+	Suffix        string
+	ContentType   string
+	IsVideo       bool
+	Path          string
+	ModTime       int64
+	Track         int
+	DiscNumber    int
+	Year          int
+	ReplayGain    ReplayGain
+	StartOffset   int      // milliseconds into the file where a song split from a CUE sheet starts
+	EndOffset     int      // milliseconds into the file where a song split from a CUE sheet ends, 0 for the end of the file
+	CuePath       string   // CUE sheet the song was split from
+	LyricsPath    string   // LRC file next to the song's file its lyrics were read from
+	SortName      string   // title the song is sorted by
+	MusicBrainzId string   // MusicBrainz recording ID, empty when unknown
+	Genres        []string // genres the song is tagged with, in tag order
+	MusicFolderId int      // music folder the song's file is in
+	DirectoryId   int      // directory the song's file is in
+	Starred       string   // time the requesting user starred the song, empty when not starred
+	UserRating    int      // rating from 1 to 5 the requesting user gave the song, 0 when not rated
+	PlayCount     int      // number of plays of the song the requesting user recorded
+	Played        string   // time the requesting user last played the song, empty when never played
+}
+
+// IsSlice reports whether the song covers only part of its file and must be cut out of it when served
+func (s *Song) IsSlice() bool {
+	return s.StartOffset > 0 || s.EndOffset > 0
+}
+
+// ReplayGain holds the loudness normalization values of a song.
+// Gains are in dB relative to the ReplayGain 2.0 reference of -18 LUFS, peaks are linear sample amplitudes.
+// A zero peak means the track or album values are unknown.
+type ReplayGain struct {
+	TrackGain float64
+	TrackPeak float64
+	AlbumGain float64
+	AlbumPeak float64
+}
+
+// HasTrack reports whether the track gain and peak are known
+func (r ReplayGain) HasTrack() bool {
+	return r.TrackPeak > 0
+}
+
+// HasAlbum reports whether the album gain and peak are known
+func (r ReplayGain) HasAlbum() bool {
+	return r.AlbumPeak > 0
 }
 
 // Validate checks if the Song has valid field values
@@ -84,12 +249,26 @@ func (s *Song) Validate() error {
 	if s.Size < 0 {
 		return fmt.Errorf("size must be non-negative, got %d", s.Size)
 	}
+	if s.StartOffset < 0 || (s.EndOffset != 0 && s.EndOffset <= s.StartOffset) {
+		return fmt.Errorf("invalid offsets %d to %d", s.StartOffset, s.EndOffset)
+	}
+	if s.ReplayGain.TrackPeak < 0 || s.ReplayGain.AlbumPeak < 0 {
+		return fmt.Errorf("replay gain peaks must be non-negative, got %g and %g", s.ReplayGain.TrackPeak, s.ReplayGain.AlbumPeak)
+	}
 	if !s.IsDir && strings.TrimSpace(s.Path) == "" {
 		return errors.New("path is required for non-directory songs")
 	}
 	return nil
 }
 
+// Genre represents a music genre, with the number of songs and albums tagged with it.
+// An album is tagged with the genres of its songs.
+type Genre struct {
+	Name       string
+	SongCount  int
+	AlbumCount int
+}
+
 // Cover represents album or artist cover art
 type Cover struct {
 	Id   string
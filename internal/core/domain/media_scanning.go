@@ -1,29 +1,106 @@
 package domain
 
-// ScanStatus represents the current status of a media library scan
+// ScanStatus represents the current status of a media library scan.
+// NextScheduledScan is empty when scans are not scheduled.
 type ScanStatus struct {
-	Scanning bool
-	Count    int
-}
-
-// FFProbeFormat represents the format information from ffprobe
-// JSON tags are kept here as they are used for parsing external tool output
-type FFProbeFormat struct {
-	Filename       string `json:"filename"`
-	NbStreams      int    `json:"nb_streams"`
-	NbPrograms     int    `json:"nb_programs"`
-	NbStreamGroups int    `json:"nb_stream_groups"`
-	FormatName     string `json:"format_name"`
-	FormatLongName string `json:"format_long_name"`
-	StartTime      string `json:"start_time"`
-	Duration       string `json:"duration"`
-	Size           string `json:"size"`
-	BitRate        string `json:"bit_rate"`
-	ProbeScore     int    `json:"probe_score"`
-}
-
-// FFProbeInfo represents the full information from ffprobe
-// JSON tags are kept here as they are used for parsing external tool output
-type FFProbeInfo struct {
-	Format *FFProbeFormat `json:"format"`
+	Scanning          bool
+	Count             int
+	NextScheduledScan string
+}
+
+// ScanTrigger identifies what started a media library scan
+type ScanTrigger string
+
+const (
+	ScanTriggerManual    ScanTrigger = "manual"
+	ScanTriggerScheduled ScanTrigger = "scheduled"
+	ScanTriggerWatcher   ScanTrigger = "watcher"
+)
+
+// ScanPhase identifies the step a media library scan is at
+type ScanPhase string
+
+const (
+	ScanPhaseIdle      ScanPhase = "idle"
+	ScanPhaseIndexing  ScanPhase = "indexing"
+	ScanPhaseRemoving  ScanPhase = "removing"
+	ScanPhasePlaylists ScanPhase = "playlists"
+	ScanPhaseLoudness  ScanPhase = "loudness"
+	ScanPhaseFinishing ScanPhase = "finishing"
+)
+
+// ScanProgress is a snapshot of the progress of the running media library scan.
+// Seen counts the supported files found so far, Queued those that are new or modified and
+// Indexed those already probed and indexed. Directory is the directory being walked.
+// ETA is the estimated number of seconds left to index the files, -1 when it cannot be estimated yet.
+type ScanProgress struct {
+	Scanning  bool
+	Phase     ScanPhase
+	Trigger   ScanTrigger
+	Started   string
+	Directory string
+	Seen      int
+	Queued    int
+	Indexed   int
+	Failed    int
+	ETA       int
+}
+
+// ScanRun is the persisted report of a media library scan.
+// Finished is empty while the scan is running, or if the server stopped before it could end.
+// Errors may hold fewer entries than Failed, only the first failures of a run are kept.
+type ScanRun struct {
+	Id        int
+	Trigger   ScanTrigger
+	Partial   bool
+	Cancelled bool
+	Started   string
+	Finished  string
+	Added     int
+	Updated   int
+	Removed   int
+	Failed    int
+	Errors    []ScanError
+}
+
+// ScanError records why a file could not be indexed during a scan.
+// Path is empty for failures not related to a single file.
+type ScanError struct {
+	Path    string
+	Message string
+}
+
+// FileFingerprint identifies the state of an indexed media file on disk.
+// It is used to skip unchanged files during incremental rescans.
+// A file split by a CUE sheet has one fingerprint per song, told apart by their start offset.
+type FileFingerprint struct {
+	SongId      int
+	AlbumId     int
+	Path        string
+	Size        int64
+	ModTime     int64
+	StartOffset int
+	CuePath     string
+	LyricsPath  string
+}
+
+// MediaMetadata holds the tags and audio properties read from a media file.
+// Tag keys are lower case, e.g. "title", "artist", "album_artist" or "track".
+type MediaMetadata struct {
+	Tags       map[string]string
+	Duration   float64 // seconds
+	BitRate    int     // bits per second
+	HasPicture bool
+}
+
+// Loudness holds the EBU R128 loudness measurement of a media file
+type Loudness struct {
+	Integrated float64 // LUFS
+	TruePeak   float64 // linear sample amplitude
+}
+
+// Picture holds artwork embedded in a media file
+type Picture struct {
+	MimeType string
+	Data     []byte
 }
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Playlist represents an ordered list of songs owned by a user.
+// Playlists imported from a playlist file of the music directories have the path and modification time of the file.
+type Playlist struct {
+	Id        int
+	Name      string
+	Comment   string
+	Owner     string
+	Public    bool
+	Created   string
+	Changed   string
+	Path      string
+	ModTime   int64
+	SongIds   []int  // in playlist order, a song may appear more than once
+	SongCount int    // number of songs the requesting user may access
+	Duration  int    // total duration in seconds of the songs the requesting user may access
+	Songs     []Song // only set when the playlist is retrieved with its songs, in playlist order
+}
+
+// PlaylistUpdate holds the changes updatePlaylist makes to a playlist, nil fields being left unchanged.
+// Songs at SongIndexesToRemove are removed first, then SongMoves are applied in order to the songs left,
+// and SongIdsToAdd are appended last.
+type PlaylistUpdate struct {
+	Name                *string
+	Comment             *string
+	Public              *bool
+	SongIdsToAdd        []int
+	SongIndexesToRemove []int
+	SongMoves           []SongMove
+}
+
+// SongMove moves the song at index From of a playlist to index To, shifting the songs in between.
+type SongMove struct {
+	From int
+	To   int
+}
+
+// IsImported reports whether the playlist is kept in sync with a playlist file
+func (p *Playlist) IsImported() bool {
+	return p.Path != ""
+}
+
+// Validate checks if the Playlist has valid field values
+func (p *Playlist) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("playlist name is required")
+	}
+	if strings.TrimSpace(p.Owner) == "" {
+		return errors.New("playlist owner is required")
+	}
+	for _, id := range p.SongIds {
+		if id <= 0 {
+			return fmt.Errorf("invalid song id %d", id)
+		}
+	}
+	return nil
+}
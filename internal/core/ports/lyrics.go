@@ -0,0 +1,33 @@
+package ports
+
+import (
+	"context"
+	"music-streaming/internal/core/domain"
+)
+
+// LyricsPort defines the interface for retrieving song lyrics.
+type LyricsPort interface {
+	// GetLyrics retrieves the lyrics of the first song with the given title and artist, ignoring case.
+	// The artist may be empty to match any artist. Returns a NotFoundError when no such song has lyrics.
+	GetLyrics(ctx context.Context, artist string, title string) (domain.Lyrics, error)
+
+	// GetLyricsBySongID retrieves every lyrics of a song, synced ones first.
+	// Returns a NotFoundError when the song does not exist, and no lyrics when it has none.
+	GetLyricsBySongID(ctx context.Context, songID int) ([]domain.Lyrics, error)
+}
+
+// LyricsRepository defines the interface for lyrics data persistence.
+type LyricsRepository interface {
+	// GetLyricsBySongID retrieves the lyrics of a song from the data store, in the order they were saved.
+	GetLyricsBySongID(ctx context.Context, songID int) ([]domain.Lyrics, error)
+
+	// GetLyricsByArtistAndTitle retrieves the lyrics of the songs in musicFolderIDs with the given title and artist,
+	// ignoring case, grouped by song. The artist may be empty to match any artist, and a nil musicFolderIDs
+	// matches the songs of every music folder.
+	GetLyricsByArtistAndTitle(ctx context.Context, artist string, title string, musicFolderIDs []int) ([]domain.Lyrics, error)
+
+	// SaveLyrics replaces the lyrics of the songs whose IDs are keys of lyrics in a single transaction.
+	// Songs mapped to no lyrics have theirs removed.
+	// Used by media scanning service to store the lyrics found while indexing.
+	SaveLyrics(ctx context.Context, lyrics map[int][]domain.Lyrics) error
+}
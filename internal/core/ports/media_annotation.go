@@ -0,0 +1,82 @@
+package ports
+
+import (
+	"context"
+	"music-streaming/internal/core/domain"
+	"time"
+)
+
+// MediaAnnotationPort defines the interface for the stars and ratings users give artists, albums and songs,
+// and for the songs they play. Stars, ratings and plays belong to the requesting user.
+type MediaAnnotationPort interface {
+	// Star stars songs, albums and artists by their IDs. Items already starred keep the time they were starred at.
+	Star(ctx context.Context, songIDs []int, albumIDs []int, artistIDs []int) error
+
+	// Unstar removes the star of songs, albums and artists by their IDs.
+	Unstar(ctx context.Context, songIDs []int, albumIDs []int, artistIDs []int) error
+
+	// SetRating rates an artist, album or song from 1 to domain.MaxRating, 0 removing its rating.
+	SetRating(ctx context.Context, itemType domain.AnnotationItemType, id int, rating int) error
+
+	// GetStarred retrieves the starred artists, albums and songs, the last starred first.
+	// When musicFolderID is not 0, only items of that music folder are retrieved.
+	GetStarred(ctx context.Context, musicFolderID int) (domain.Starred, error)
+
+	// Scrobble records plays of songs at the given times, or now when times is empty, whether or not the user
+	// enabled scrobbling to external services. When submission is false, the songs are only noted as playing now.
+	Scrobble(ctx context.Context, songIDs []int, times []time.Time, submission bool) error
+
+	// GetNowPlaying retrieves the songs users are playing, the last started first.
+	GetNowPlaying(ctx context.Context) ([]domain.NowPlaying, error)
+}
+
+// MediaAnnotationRepository defines the interface for the persistence of stars, ratings and plays, kept per username.
+type MediaAnnotationRepository interface {
+	// StarItems stars items of a type for a user at starred. Items already starred keep the time they were starred at.
+	StarItems(ctx context.Context, username string, itemType domain.AnnotationItemType, ids []int, starred time.Time) error
+
+	// UnstarItems removes the star a user gave items of a type.
+	UnstarItems(ctx context.Context, username string, itemType domain.AnnotationItemType, ids []int) error
+
+	// RateItem sets the rating a user gives an item, 0 removing the rating.
+	RateItem(ctx context.Context, username string, itemType domain.AnnotationItemType, id int, rating int) error
+
+	// GetAnnotations retrieves the stars, ratings and plays of a user on items of a type, by item ID.
+	// Items the user neither starred, rated nor played are left out.
+	GetAnnotations(ctx context.Context, username string, itemType domain.AnnotationItemType, ids []int) (map[int]domain.Annotation, error)
+
+	// GetStarredArtists retrieves the artists in musicFolderIDs a user starred, the last starred first.
+	// A nil musicFolderIDs retrieves the artists of every music folder.
+	GetStarredArtists(ctx context.Context, username string, musicFolderIDs []int) ([]domain.Artist, error)
+
+	// GetStarredAlbums retrieves up to limit albums in musicFolderIDs a user starred, the last starred first,
+	// skipping the first offset albums. A nil musicFolderIDs retrieves the albums of every music folder.
+	GetStarredAlbums(ctx context.Context, username string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error)
+
+	// GetStarredSongs retrieves the songs in musicFolderIDs a user starred, as GetStarredArtists does.
+	GetStarredSongs(ctx context.Context, username string, musicFolderIDs []int) ([]domain.Song, error)
+
+	// GetHighestRatedAlbums retrieves up to limit albums in musicFolderIDs a user rated, best rated first,
+	// skipping the first offset albums. A nil musicFolderIDs retrieves the albums of every music folder.
+	GetHighestRatedAlbums(ctx context.Context, username string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error)
+
+	// RecordPlay adds a play of a song at played to the play history of a user, and counts it for the song and its album.
+	// An albumID of 0 only counts the play for the song.
+	RecordPlay(ctx context.Context, username string, songID int, albumID int, played time.Time) error
+
+	// GetMostPlayedAlbums retrieves the albums in musicFolderIDs a user played, most played first, as GetHighestRatedAlbums does.
+	GetMostPlayedAlbums(ctx context.Context, username string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error)
+
+	// GetRecentlyPlayedAlbums retrieves the albums in musicFolderIDs a user played, last played first, as GetHighestRatedAlbums does.
+	GetRecentlyPlayedAlbums(ctx context.Context, username string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error)
+}
+
+// NowPlayingRepository defines the interface for the live registry of the songs users are playing.
+// Each player of a user has a single entry, which is lost on restart.
+type NowPlayingRepository interface {
+	// SetNowPlaying records the song a user plays on a player, replacing what the player played before.
+	SetNowPlaying(ctx context.Context, nowPlaying domain.NowPlaying) error
+
+	// GetNowPlaying retrieves the last song of every player, the last started first.
+	GetNowPlaying(ctx context.Context) ([]domain.NowPlaying, error)
+}
@@ -7,13 +7,7 @@ import (
 
 /*
 TODO: Future Subsonic API endpoints to implement:
-- GetMusicFolders
-- GetIndexes
-- GetMusicDirectory
-- GetGenres
-- GetArtists
 - GetRandomSong
-- GetStarred
 - GetFiles
 
 LastFM Integration:
@@ -23,11 +17,28 @@ LastFM Integration:
 
 // MediaBrowsingPort defines the interface for browsing media catalog operations.
 // It provides methods to retrieve artists, albums, songs, and cover art.
+// Content outside the music folders the requesting user may access is reported as not found.
 type MediaBrowsingPort interface {
-	// GetArtist retrieves an artist by their unique ID.
+	// GetMusicFolders retrieves the configured music folders the requesting user may access.
+	GetMusicFolders(ctx context.Context) ([]domain.MusicFolder, error)
+
+	// GetIndexes retrieves the top-level directories of the music folders grouped by letter, along with the
+	// songs right in the music folders. When musicFolderID is not 0, only that music folder is indexed.
+	// When ifModifiedSince is not 0 and nothing changed since that time, in milliseconds since the epoch,
+	// the indexes are returned empty.
+	GetIndexes(ctx context.Context, musicFolderID int, ifModifiedSince int64) (domain.Indexes, error)
+
+	// GetMusicDirectory retrieves a directory by its unique ID, with its subdirectories and songs as children.
+	GetMusicDirectory(ctx context.Context, id int) (domain.Directory, error)
+
+	// GetArtists retrieves the artists grouped by the letter they are sorted by.
+	// When musicFolderID is not 0, only artists of that music folder are retrieved.
+	GetArtists(ctx context.Context, musicFolderID int) (domain.ArtistIndexes, error)
+
+	// GetArtist retrieves an artist by their unique ID, with their albums ordered by year.
 	GetArtist(ctx context.Context, id int) (domain.Artist, error)
 
-	// GetAlbum retrieves an album by its unique ID.
+	// GetAlbum retrieves an album by its unique ID, with its songs ordered by disc and track number.
 	GetAlbum(ctx context.Context, id int) (domain.Album, error)
 
 	// GetSong retrieves a song by its unique ID.
@@ -35,6 +46,22 @@ type MediaBrowsingPort interface {
 
 	// GetCover retrieves cover art metadata by its unique ID.
 	GetCover(ctx context.Context, id string) (domain.Cover, error)
+
+	// GetGenres retrieves every genre with its song and album counts, ordered by name.
+	GetGenres(ctx context.Context) ([]domain.Genre, error)
+
+	// GetSongsByGenre retrieves up to count songs tagged with genre, skipping the first offset songs.
+	// When musicFolderID is not 0, only songs of that music folder are retrieved.
+	GetSongsByGenre(ctx context.Context, genre string, count int, offset int, musicFolderID int) ([]domain.Song, error)
+
+	// GetAlbumList retrieves a page of albums listed in the order of the query type.
+	// Highest rated and starred lists are those of the requesting user.
+	// Frequently and recently played lists are empty until plays are recorded.
+	GetAlbumList(ctx context.Context, query domain.AlbumListQuery) ([]domain.Album, error)
+
+	// Search retrieves pages of the artists, albums and songs matching the query, best matches first.
+	// A count of 0 skips the search of that kind of item.
+	Search(ctx context.Context, query domain.SearchQuery) (domain.SearchResult, error)
 }
 
 // MediaBrowsingRepository defines the interface for media catalog data persistence.
@@ -43,15 +70,69 @@ type MediaBrowsingRepository interface {
 	// GetArtistByID retrieves an artist from the data store by ID.
 	GetArtistByID(ctx context.Context, id int) (domain.Artist, error)
 
+	// GetArtists retrieves the artists in musicFolderIDs from the data store, ordered by sort name.
+	// A nil musicFolderIDs retrieves the artists of every music folder.
+	GetArtists(ctx context.Context, musicFolderIDs []int) ([]domain.Artist, error)
+
 	// GetAlbumByID retrieves an album from the data store by ID.
 	GetAlbumByID(ctx context.Context, id int) (domain.Album, error)
 
+	// GetAlbumsByArtistID retrieves the albums of an artist from the data store, ordered by year and sort name.
+	GetAlbumsByArtistID(ctx context.Context, artistID int) ([]domain.Album, error)
+
+	// GetAlbumList retrieves a page of the albums in musicFolderIDs listed in the order of the query type,
+	// one of random, newest, alphabeticalByName, alphabeticalByArtist, byYear or byGenre.
+	// A nil musicFolderIDs retrieves albums of every music folder; the music folder of the query is ignored.
+	GetAlbumList(ctx context.Context, query domain.AlbumListQuery, musicFolderIDs []int) ([]domain.Album, error)
+
 	// GetSongByID retrieves a song from the data store by ID.
 	GetSongByID(ctx context.Context, id int) (domain.Song, error)
 
+	// GetSongsByAlbumID retrieves the songs of an album from the data store, ordered by disc and track number.
+	GetSongsByAlbumID(ctx context.Context, albumID int) ([]domain.Song, error)
+
 	// GetCoverByID retrieves cover art metadata from the data store by ID.
 	GetCoverByID(ctx context.Context, id string) (domain.Cover, error)
 
+	// GetMusicFolders retrieves every music folder from the data store, ordered by ID.
+	GetMusicFolders(ctx context.Context) ([]domain.MusicFolder, error)
+
+	// SaveMusicFolders retrieves the music folders of the directories at paths, in the same order,
+	// creating those not stored yet so that a directory keeps its ID.
+	SaveMusicFolders(ctx context.Context, paths []string) ([]domain.MusicFolder, error)
+
+	// GetDirectory retrieves a directory from the data store by ID.
+	GetDirectory(ctx context.Context, id int) (domain.Directory, error)
+
+	// GetDirectoryByPath retrieves a directory from the data store by its exact path.
+	GetDirectoryByPath(ctx context.Context, path string) (domain.Directory, error)
+
+	// GetSubdirectories retrieves the directories whose parent is one of parentIDs, ordered by name.
+	GetSubdirectories(ctx context.Context, parentIDs []int) ([]domain.Directory, error)
+
+	// GetSongsByDirectory retrieves the songs of a directory from the data store, ordered by disc and track number.
+	GetSongsByDirectory(ctx context.Context, directoryID int) ([]domain.Song, error)
+
+	// GetGenres retrieves the genres of the songs in musicFolderIDs from the data store with their song
+	// and album counts, ordered by name. A nil musicFolderIDs retrieves every genre.
+	GetGenres(ctx context.Context, musicFolderIDs []int) ([]domain.Genre, error)
+
+	// GetSongsByGenre retrieves up to limit songs in musicFolderIDs tagged with the exact genre name,
+	// skipping the first offset songs. A nil musicFolderIDs retrieves songs of every music folder.
+	// Songs are ordered by album, then disc and track number.
+	GetSongsByGenre(ctx context.Context, genre string, musicFolderIDs []int, limit int, offset int) ([]domain.Song, error)
+
+	// SearchArtists retrieves up to limit artists in musicFolderIDs whose name matches query, skipping the first offset.
+	// Words of the query match the start of words ignoring case and diacritics, an empty query matches every artist.
+	// A nil musicFolderIDs searches every music folder.
+	SearchArtists(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int) ([]domain.Artist, error)
+
+	// SearchAlbums retrieves up to limit albums in musicFolderIDs whose name or artist matches query, as SearchArtists does.
+	SearchAlbums(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error)
+
+	// SearchSongs retrieves up to limit songs in musicFolderIDs whose title, artist or album matches query, as SearchArtists does.
+	SearchSongs(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int) ([]domain.Song, error)
+
 	// CreateArtist persists a new artist to the data store.
 	// Used by media scanning service during library indexing.
 	CreateArtist(ctx context.Context, artist domain.Artist) (domain.Artist, error)
@@ -64,7 +145,56 @@ type MediaBrowsingRepository interface {
 	// Used by media scanning service during library indexing.
 	CreateSong(ctx context.Context, song domain.Song) (domain.Song, error)
 
+	// CreateDirectory persists a new directory to the data store and marks its parent as updated.
+	// Used by media scanning service during library indexing.
+	CreateDirectory(ctx context.Context, directory domain.Directory) (domain.Directory, error)
+
 	// CreateCover persists new cover art metadata to the data store.
+	// Existing cover art with the same ID is replaced.
 	// Used by media scanning service during library indexing.
 	CreateCover(ctx context.Context, cover domain.Cover) (domain.Cover, error)
+
+	// GetArtistByName retrieves an artist of a music folder from the data store by exact name,
+	// preferring an artist without a MusicBrainz ID when several share the name.
+	// Used by media scanning service to reuse artists across scans.
+	GetArtistByName(ctx context.Context, musicFolderID int, name string) (domain.Artist, error)
+
+	// GetArtistByMusicBrainzID retrieves the artist of a music folder with the given MusicBrainz artist ID.
+	// Used by media scanning service to reuse artists across scans.
+	GetArtistByMusicBrainzID(ctx context.Context, musicFolderID int, musicBrainzID string) (domain.Artist, error)
+
+	// GetAlbumByRelease retrieves the album of the given album artist with the exact name and release year.
+	// Used by media scanning service to reuse albums across scans.
+	GetAlbumByRelease(ctx context.Context, artistID int, name string, year int) (domain.Album, error)
+
+	// GetSongFingerprints retrieves the path, size and modification time of every indexed song.
+	// Used by media scanning service to detect changed and deleted files.
+	GetSongFingerprints(ctx context.Context) ([]domain.FileFingerprint, error)
+
+	// UpdateArtist persists changes to an existing artist.
+	UpdateArtist(ctx context.Context, artist domain.Artist) (domain.Artist, error)
+
+	// UpdateAlbum persists changes to an existing album.
+	UpdateAlbum(ctx context.Context, album domain.Album) (domain.Album, error)
+
+	// UpdateSong persists changes to an existing song.
+	UpdateSong(ctx context.Context, song domain.Song) (domain.Song, error)
+
+	// SaveSongs creates the songs without an ID and updates the others in a single transaction,
+	// together with their genres. Either every song is saved or none is. Returns the saved songs in the same order.
+	// Used by media scanning service to write indexed songs in batches.
+	SaveSongs(ctx context.Context, songs []domain.Song) ([]domain.Song, error)
+
+	// DeleteSongs removes the songs with the given IDs from the data store.
+	// Returns the number of removed songs.
+	DeleteSongs(ctx context.Context, ids []int) (int, error)
+
+	// DeleteOrphans removes albums without songs, artists without albums, genres without songs and
+	// directories without songs below them, marking the parents of removed directories as updated.
+	// Returns the number of removed albums, artists, genres and directories.
+	DeleteOrphans(ctx context.Context) (int, error)
+
+	// UpdateCatalogStats recomputes album song counts and durations, artist album counts,
+	// album genres and genre song and album counts.
+	UpdateCatalogStats(ctx context.Context) error
 }
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"io"
 	"music-streaming/internal/core/domain"
 )
 
@@ -22,4 +23,15 @@ type MediaRetrievalPort interface {
 	// GetCover retrieves cover art metadata for display.
 	// Requires cover art role permission.
 	GetCover(ctx context.Context, id string) (domain.Cover, error)
+
+	// WriteSongSlice writes the part of its file covered by a song split from a CUE sheet to w,
+	// transcoded to the format of the song's suffix. The song is one returned by DownloadSong or StreamSong.
+	WriteSongSlice(ctx context.Context, song domain.Song, w io.Writer) error
+}
+
+// MediaTranscoder defines the interface for converting media files to other formats.
+type MediaTranscoder interface {
+	// TranscodeSlice encodes the audio of the media file at path between start and end, in milliseconds,
+	// as format and writes it to w. An end of 0 means the end of the file.
+	TranscodeSlice(ctx context.Context, path string, start int, end int, format string, w io.Writer) error
 }
@@ -6,21 +6,70 @@ import (
 )
 
 // MediaScanningPort defines the interface for media library scanning operations.
-// It provides methods to scan media directories, extract metadata, and track scan status.
+// It provides methods to scan media directories and track scan status.
 type MediaScanningPort interface {
-	// FFProbeProcessFile extracts media metadata from a file using ffprobe.
-	// Returns structured metadata information about the media file.
-	FFProbeProcessFile(path string) (*domain.FFProbeInfo, error)
-
 	// StartScan initiates a media library scan.
 	// Requires admin role permission. Returns the current scan status.
 	StartScan(ctx context.Context) (domain.ScanStatus, error)
 
+	// StopScan cancels the running media library scan, if any.
+	// Songs indexed before cancellation are kept. Requires admin role permission.
+	// Returns the current scan status.
+	StopScan(ctx context.Context) (domain.ScanStatus, error)
+
 	// GetScanStatus retrieves the current status of the media library scan.
 	// Returns information about whether a scan is in progress and file count.
 	GetScanStatus(ctx context.Context) (domain.ScanStatus, error)
 
+	// WatchScanProgress subscribes to the progress of media library scans. Requires admin role permission.
+	// The current progress is sent first, then every change, bursts of changes being coalesced.
+	// Slow receivers only get the latest progress. The channel is closed once ctx is done.
+	WatchScanProgress(ctx context.Context) (<-chan domain.ScanProgress, error)
+
+	// GetScanRuns retrieves the reports of past and running scans, most recent first.
+	// Errors are not included. Requires admin role permission.
+	GetScanRuns(ctx context.Context, size int, offset int) ([]domain.ScanRun, error)
+
+	// GetScanRun retrieves the report of a scan along with its per-file errors.
+	// Requires admin role permission.
+	GetScanRun(ctx context.Context, id int) (domain.ScanRun, error)
+
 	// Scan performs the actual media library scanning operation.
 	// This is typically called as a background goroutine by StartScan.
-	Scan()
+	// The trigger is recorded in the scan report. Cancelling ctx stops the scan.
+	Scan(ctx context.Context, trigger domain.ScanTrigger)
+}
+
+// MediaScanningRepository defines the interface for persisting the reports of media library scans.
+type MediaScanningRepository interface {
+	// CreateScanRun records the start of a scan and returns it with its ID.
+	CreateScanRun(ctx context.Context, run domain.ScanRun) (domain.ScanRun, error)
+
+	// FinishScanRun records the end of a scan, its counts and its errors.
+	FinishScanRun(ctx context.Context, run domain.ScanRun) error
+
+	// GetScanRuns returns at most count scan runs after skipping offset, most recent first, without their errors.
+	GetScanRuns(ctx context.Context, count int, offset int) ([]domain.ScanRun, error)
+
+	// GetScanRun returns the scan run with the given ID along with its errors.
+	GetScanRun(ctx context.Context, id int) (domain.ScanRun, error)
+}
+
+// MetadataExtractor defines the interface for reading metadata from media files.
+// Implementations either wrap an external tool or parse the tag formats natively.
+type MetadataExtractor interface {
+	// Extract reads the tags and audio properties of the media file at path.
+	Extract(ctx context.Context, path string) (domain.MediaMetadata, error)
+
+	// ExtractPicture reads the artwork embedded in the media file at path.
+	// Returns a NotFoundError when the file has no embedded picture.
+	ExtractPicture(ctx context.Context, path string) (domain.Picture, error)
+}
+
+// LoudnessAnalyzer defines the interface for measuring the loudness of media files.
+// Used by media scanning service to compute ReplayGain values missing from the tags.
+type LoudnessAnalyzer interface {
+	// Analyze measures the integrated loudness and true peak of the audio in the media file at path, following EBU R128.
+	// Only the audio between start and end, in milliseconds, is measured. An end of 0 means the end of the file.
+	Analyze(ctx context.Context, path string, start int, end int) (domain.Loudness, error)
 }
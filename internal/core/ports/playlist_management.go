@@ -0,0 +1,54 @@
+package ports
+
+import (
+	"context"
+	"music-streaming/internal/core/domain"
+)
+
+// PlaylistPort defines the interface for managing the playlists of users.
+// Users see their own playlists and the public playlists of other users.
+// Only the owner, with the playlist role, and admins may change a playlist.
+type PlaylistPort interface {
+	// GetPlaylists retrieves the playlists the user may see, without their songs.
+	// An empty username retrieves the playlists of the requesting user, only admins may retrieve those of another user.
+	GetPlaylists(ctx context.Context, username string) ([]domain.Playlist, error)
+
+	// GetPlaylist retrieves a playlist with its songs, leaving out songs outside the music folders of the requesting user.
+	GetPlaylist(ctx context.Context, id int) (domain.Playlist, error)
+
+	// CreatePlaylist creates a private playlist of the requesting user with songs in the given order.
+	CreatePlaylist(ctx context.Context, name string, songIDs []int) (domain.Playlist, error)
+
+	// ReplacePlaylistSongs replaces the songs of a playlist, which is how clients reorder a playlist.
+	ReplacePlaylistSongs(ctx context.Context, id int, songIDs []int) (domain.Playlist, error)
+
+	// UpdatePlaylist changes the name, comment or visibility of a playlist, and removes, reorders and adds songs.
+	// Song indexes are positions among the songs GetPlaylist retrieves, moves counting the songs left after removal.
+	UpdatePlaylist(ctx context.Context, id int, update domain.PlaylistUpdate) error
+
+	// DeletePlaylist removes a playlist.
+	DeletePlaylist(ctx context.Context, id int) error
+}
+
+// PlaylistRepository defines the interface for playlist data persistence.
+type PlaylistRepository interface {
+	// GetPlaylists retrieves the playlists owned by username and the public playlists, ordered by name, without their songs.
+	// SongCount and Duration only count songs in musicFolderIDs, a nil musicFolderIDs counting every song.
+	GetPlaylists(ctx context.Context, username string, musicFolderIDs []int) ([]domain.Playlist, error)
+
+	// GetPlaylist retrieves a playlist along with its song IDs and songs, in playlist order.
+	GetPlaylist(ctx context.Context, id int) (domain.Playlist, error)
+
+	// GetImportedPlaylists retrieves the playlists imported from playlist files, without their songs.
+	// Used by media scanning service to sync playlists with their files.
+	GetImportedPlaylists(ctx context.Context) ([]domain.Playlist, error)
+
+	// CreatePlaylist persists a new playlist along with its songs.
+	CreatePlaylist(ctx context.Context, playlist domain.Playlist) (domain.Playlist, error)
+
+	// UpdatePlaylist persists changes to an existing playlist, replacing its songs.
+	UpdatePlaylist(ctx context.Context, playlist domain.Playlist) (domain.Playlist, error)
+
+	// DeletePlaylist removes a playlist along with its songs from the data store.
+	DeletePlaylist(ctx context.Context, id int) error
+}
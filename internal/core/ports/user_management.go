@@ -11,6 +11,12 @@ type ContextKey int
 const (
 	// KeyRequestingUserID is the context key for storing the requesting user.
 	KeyRequestingUserID ContextKey = iota
+
+	// KeyScanTrigger is the context key for storing what starts a media scan, manual when not set.
+	KeyScanTrigger
+
+	// KeyPlayerName is the context key for storing the name of the client application making the request.
+	KeyPlayerName
 )
 
 // UserManagementPort defines the interface for user management operations.
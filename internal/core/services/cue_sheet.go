@@ -0,0 +1,274 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// cueFramesPerSecond is the number of frames per second of CUE sheet timestamps.
+const cueFramesPerSecond = 75
+
+// cueSheet is a parsed CUE sheet, describing the tracks of one or more audio files.
+type cueSheet struct {
+	title     string
+	performer string
+	remarks   map[string]string // REM comments such as DATE or GENRE, keyed by lower case name
+	files     []cueFile
+}
+
+// cueFile lists the tracks of an audio file referenced by a CUE sheet.
+type cueFile struct {
+	name   string
+	tracks []cueTrack
+}
+
+// cueTrack is a track of a CUE sheet. start is the position of its INDEX 01 in the file, in milliseconds.
+type cueTrack struct {
+	number    int
+	title     string
+	performer string
+	remarks   map[string]string
+	start     int
+}
+
+// cueSheetRef points to the tracks of an audio file in a CUE sheet found next to it.
+type cueSheetRef struct {
+	path    string
+	modTime time.Time
+	sheet   *cueSheet
+	file    *cueFile
+}
+
+// parseCueSheet parses the content of a CUE sheet.
+func parseCueSheet(data []byte) (*cueSheet, error) {
+	sheet := &cueSheet{remarks: make(map[string]string)}
+	var (
+		file  *cueFile
+		track *cueTrack
+	)
+	scanner := bufio.NewScanner(strings.NewReader(decodeText(data)))
+	for line := 1; scanner.Scan(); line++ {
+		command, args := cueCommand(scanner.Text())
+		switch command {
+		case "FILE":
+			sheet.files = append(sheet.files, cueFile{name: cueFileName(args)})
+			file = &sheet.files[len(sheet.files)-1]
+			track = nil
+		case "TRACK":
+			if file == nil {
+				return nil, fmt.Errorf("line %d: track outside of a file", line)
+			}
+			fields := strings.Fields(args)
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("line %d: missing track number", line)
+			}
+			number, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid track number %q", line, fields[0])
+			}
+			file.tracks = append(file.tracks, cueTrack{number: number, remarks: make(map[string]string), start: -1})
+			track = &file.tracks[len(file.tracks)-1]
+		case "INDEX":
+			fields := strings.Fields(args)
+			if track == nil || len(fields) != 2 || fields[0] != "01" {
+				continue
+			}
+			start, err := parseCueTime(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			track.start = start
+		case "TITLE", "PERFORMER":
+			value := cueValue(args)
+			switch {
+			case track != nil && command == "TITLE":
+				track.title = value
+			case track != nil:
+				track.performer = value
+			case command == "TITLE":
+				sheet.title = value
+			default:
+				sheet.performer = value
+			}
+		case "REM":
+			name, value, ok := strings.Cut(strings.TrimSpace(args), " ")
+			if !ok {
+				continue
+			}
+			if track != nil {
+				track.remarks[strings.ToLower(name)] = cueValue(value)
+			} else {
+				sheet.remarks[strings.ToLower(name)] = cueValue(value)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, file := range sheet.files {
+		for i, track := range file.tracks {
+			if track.start < 0 {
+				return nil, fmt.Errorf("track %d of %s has no start index", track.number, file.name)
+			}
+			if i > 0 && track.start <= file.tracks[i-1].start {
+				return nil, fmt.Errorf("track %d of %s starts before the previous track", track.number, file.name)
+			}
+		}
+	}
+	return sheet, nil
+}
+
+// decodeText decodes the content of a text file written by a music tool.
+// Such files are usually UTF-8, possibly with a byte order mark, older ones being read as Latin-1.
+func decodeText(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// cueCommand splits a CUE sheet line into its upper case command and its arguments.
+func cueCommand(line string) (string, string) {
+	command, args, _ := strings.Cut(strings.TrimSpace(line), " ")
+	return strings.ToUpper(command), strings.TrimSpace(args)
+}
+
+// cueValue returns a command argument without its surrounding quotes.
+func cueValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// cueFileName returns the file name of a FILE command, dropping the file type that follows it.
+func cueFileName(args string) string {
+	if strings.HasPrefix(args, `"`) {
+		if end := strings.Index(args[1:], `"`); end >= 0 {
+			return args[1 : end+1]
+		}
+	}
+	if i := strings.LastIndex(args, " "); i > 0 {
+		return args[:i]
+	}
+	return args
+}
+
+// parseCueTime converts a CUE sheet timestamp, minutes:seconds:frames, to milliseconds.
+func parseCueTime(value string) (int, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid timestamp %q", value)
+	}
+	var numbers [3]int
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return 0, fmt.Errorf("invalid timestamp %q", value)
+		}
+		numbers[i] = number
+	}
+	if numbers[1] >= 60 || numbers[2] >= cueFramesPerSecond {
+		return 0, fmt.Errorf("invalid timestamp %q", value)
+	}
+	return (numbers[0]*60+numbers[1])*1000 + numbers[2]*1000/cueFramesPerSecond, nil
+}
+
+// cueSheetIndex finds the CUE sheets describing the audio files of a directory.
+// Sheets are parsed once per directory and remembered for the rest of the walk.
+type cueSheetIndex struct {
+	dirs map[string]map[string]cueSheetRef
+	fail func(path string, err error)
+}
+
+func newCueSheetIndex(fail func(path string, err error)) *cueSheetIndex {
+	return &cueSheetIndex{
+		dirs: make(map[string]map[string]cueSheetRef),
+		fail: fail,
+	}
+}
+
+// lookup returns the CUE sheet tracks of the audio file at path.
+// The FILE entries of a sheet are matched against the file name ignoring case, then ignoring the extension
+// since rips are often converted after the sheet was written.
+func (x *cueSheetIndex) lookup(path string) (cueSheetRef, bool) {
+	dir := filepath.Dir(path)
+	refs, ok := x.dirs[dir]
+	if !ok {
+		refs = x.load(dir)
+		x.dirs[dir] = refs
+	}
+
+	name := strings.ToLower(filepath.Base(path))
+	if ref, ok := refs[name]; ok {
+		return ref, true
+	}
+	ref, ok := refs[strings.TrimSuffix(name, filepath.Ext(name))]
+	return ref, ok
+}
+
+// load parses the CUE sheets of dir and indexes their tracks by file name, with and without extension.
+func (x *cueSheetIndex) load(dir string) map[string]cueSheetRef {
+	refs := make(map[string]cueSheetRef)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return refs
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || fileSuffix(entry.Name()) != "cue" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		sheet, modTime, err := readCueSheet(path)
+		if err != nil {
+			x.fail(path, err)
+			continue
+		}
+		for i := range sheet.files {
+			file := &sheet.files[i]
+			if len(file.tracks) == 0 {
+				continue
+			}
+			ref := cueSheetRef{path: path, modTime: modTime, sheet: sheet, file: file}
+			// Sheets written on Windows separate folders with backslashes
+			name := strings.ToLower(filepath.Base(strings.ReplaceAll(file.name, `\`, "/")))
+			refs[name] = ref
+			if stem := strings.TrimSuffix(name, filepath.Ext(name)); stem != name {
+				if _, exists := refs[stem]; !exists {
+					refs[stem] = ref
+				}
+			}
+		}
+	}
+	return refs
+}
+
+func readCueSheet(path string) (*cueSheet, time.Time, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	sheet, err := parseCueSheet(data)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid cue sheet: %w", err)
+	}
+	return sheet, stat.ModTime(), nil
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"maps"
+	"music-streaming/internal/core/domain"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testCueSheet = `REM GENRE Classical
+REM DATE 1998
+PERFORMER "Orchestra"
+TITLE "Symphonies"
+FILE "Symphonies.wav" WAVE
+  TRACK 01 AUDIO
+    TITLE "Allegro"
+    INDEX 01 00:00:00
+  TRACK 02 AUDIO
+    TITLE "Adagio"
+    PERFORMER "Soloist"
+    REM REPLAYGAIN_TRACK_GAIN -3.20 dB
+    REM REPLAYGAIN_TRACK_PEAK 0.850000
+    INDEX 00 03:34:70
+    INDEX 01 03:35:00
+  TRACK 03 AUDIO
+    INDEX 01 07:10:37
+`
+
+func TestParseCueSheet(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           []byte
+		expectedTracks []cueTrack
+		expectedTitle  string
+		expectedError  bool
+	}{
+		{
+			name: "tracks with start offsets",
+			data: []byte(testCueSheet),
+			expectedTracks: []cueTrack{
+				{number: 1, title: "Allegro", start: 0},
+				{number: 2, title: "Adagio", performer: "Soloist", start: 215000},
+				{number: 3, start: 430493},
+			},
+			expectedTitle: "Symphonies",
+		},
+		{
+			name:           "byte order mark and latin-1 titles",
+			data:           []byte("\xef\xbb\xbfTITLE \"Caf\xe9\"\nFILE \"a.flac\" WAVE\n  TRACK 01 AUDIO\n    INDEX 01 00:00:00\n"),
+			expectedTracks: []cueTrack{{number: 1, start: 0}},
+			expectedTitle:  "Café",
+		},
+		{
+			name:          "track without start index",
+			data:          []byte("FILE \"a.flac\" WAVE\n  TRACK 01 AUDIO\n    INDEX 00 00:00:00\n"),
+			expectedError: true,
+		},
+		{
+			name:          "track outside of a file",
+			data:          []byte("TRACK 01 AUDIO\n  INDEX 01 00:00:00\n"),
+			expectedError: true,
+		},
+		{
+			name:          "invalid timestamp",
+			data:          []byte("FILE \"a.flac\" WAVE\n  TRACK 01 AUDIO\n    INDEX 01 00:75:00\n"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheet, err := parseCueSheet(tt.data)
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sheet.title != tt.expectedTitle {
+				t.Errorf("expected title %q, got %q", tt.expectedTitle, sheet.title)
+			}
+			if len(sheet.files) != 1 || len(sheet.files[0].tracks) != len(tt.expectedTracks) {
+				t.Fatalf("expected one file with %d tracks, got %+v", len(tt.expectedTracks), sheet.files)
+			}
+			for i, expected := range tt.expectedTracks {
+				track := sheet.files[0].tracks[i]
+				if track.number != expected.number || track.title != expected.title ||
+					track.performer != expected.performer || track.start != expected.start {
+					t.Errorf("expected track %+v, got %+v", expected, track)
+				}
+			}
+		})
+	}
+}
+
+func TestCueSheetIndex_Lookup(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Orchestra - Symphonies.cue"), []byte(testCueSheet), 0o600); err != nil {
+		t.Fatalf("failed to create cue sheet: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.cue"), []byte("TRACK 01 AUDIO\n"), 0o600); err != nil {
+		t.Fatalf("failed to create cue sheet: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		expectedFound bool
+	}{
+		{
+			name:          "sheet matched after conversion to another format",
+			path:          filepath.Join(dir, "Symphonies.flac"),
+			expectedFound: true,
+		},
+		{
+			name:          "sheet matched ignoring case",
+			path:          filepath.Join(dir, "SYMPHONIES.WAV"),
+			expectedFound: true,
+		},
+		{
+			name: "file without sheet",
+			path: filepath.Join(dir, "other.flac"),
+		},
+	}
+
+	var failed []string
+	cues := newCueSheetIndex(func(path string, err error) {
+		failed = append(failed, path)
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, found := cues.lookup(tt.path)
+
+			if found != tt.expectedFound {
+				t.Fatalf("expected found %v, got %v", tt.expectedFound, found)
+			}
+			if found && (len(ref.file.tracks) != 3 || filepath.Base(ref.path) != "Orchestra - Symphonies.cue") {
+				t.Errorf("unexpected cue sheet %s with %d tracks", ref.path, len(ref.file.tracks))
+			}
+		})
+	}
+	if len(failed) != 1 || filepath.Base(failed[0]) != "broken.cue" {
+		t.Errorf("expected the broken sheet to be reported once, got %v", failed)
+	}
+}
+
+func TestSplitCueTracks(t *testing.T) {
+	sheet, err := parseCueSheet([]byte(testCueSheet))
+	if err != nil {
+		t.Fatalf("failed to parse cue sheet: %v", err)
+	}
+	file := scannedFile{
+		path:    "/music/Symphonies.flac",
+		modTime: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		metadata: domain.MediaMetadata{
+			Duration: 600.5,
+			BitRate:  900000,
+			Tags:     map[string]string{"title": "Symphonies", "replaygain_track_gain": "-5 dB", "replaygain_track_peak": "1"},
+		},
+		cue: &cueSheetRef{path: "/music/Symphonies.cue", sheet: sheet, file: &sheet.files[0]},
+	}
+
+	split := splitCueTracks(file)
+
+	expected := []struct {
+		startOffset int
+		endOffset   int
+		duration    float64
+		tags        map[string]string
+	}{
+		{
+			startOffset: 0,
+			endOffset:   215000,
+			duration:    215,
+			tags: map[string]string{
+				"title": "Allegro", "artist": "Orchestra", "album_artist": "Orchestra", "album": "Symphonies",
+				"track": "1/3", "genre": "Classical", "date": "1998",
+			},
+		},
+		{
+			startOffset: 215000,
+			endOffset:   430493,
+			duration:    215.493,
+			tags: map[string]string{
+				"title": "Adagio", "artist": "Soloist", "album_artist": "Orchestra", "album": "Symphonies",
+				"track": "2/3", "genre": "Classical", "date": "1998",
+				"replaygain_track_gain": "-3.20 dB", "replaygain_track_peak": "0.850000",
+			},
+		},
+		{
+			startOffset: 430493,
+			endOffset:   600500,
+			duration:    170.007,
+			tags: map[string]string{
+				"title": "Track 3", "artist": "Orchestra", "album_artist": "Orchestra", "album": "Symphonies",
+				"track": "3/3", "genre": "Classical", "date": "1998",
+			},
+		},
+	}
+	if len(split) != len(expected) {
+		t.Fatalf("expected %d tracks, got %d", len(expected), len(split))
+	}
+	for i, track := range split {
+		if track.startOffset != expected[i].startOffset || track.endOffset != expected[i].endOffset {
+			t.Errorf("track %d: expected offsets %d to %d, got %d to %d", i+1, expected[i].startOffset, expected[i].endOffset, track.startOffset, track.endOffset)
+		}
+		if diff := track.metadata.Duration - expected[i].duration; diff > 0.001 || diff < -0.001 {
+			t.Errorf("track %d: expected duration %v, got %v", i+1, expected[i].duration, track.metadata.Duration)
+		}
+		if !maps.Equal(track.metadata.Tags, expected[i].tags) {
+			t.Errorf("track %d: expected tags %v, got %v", i+1, expected[i].tags, track.metadata.Tags)
+		}
+		song := songFromFile(track)
+		if song.CuePath != "/music/Symphonies.cue" || !song.IsSlice() {
+			t.Errorf("track %d: expected a slice of the cue sheet, got %+v", i+1, song)
+		}
+	}
+}
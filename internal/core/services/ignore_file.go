@@ -0,0 +1,198 @@
+package services
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ignoreFileName is the name of the files listing the paths of a folder excluded from scans.
+const ignoreFileName = ".msignore"
+
+// defaultScanExcludes lists the exclude patterns always applied before the configured ones,
+// for the metadata folders NAS and macOS file sharing leave next to music files.
+var defaultScanExcludes = []string{"@eaDir/", ".AppleDouble/"}
+
+// ignoreRule is a gitignore-style pattern of an ignore file or of the configured excludes.
+type ignoreRule struct {
+	base     string   // directory the pattern is relative to
+	segments []string // pattern split on "/"
+	anchored bool     // matched against the path below base, otherwise against the base name at any depth
+	dirOnly  bool     // only matches directories, written with a trailing "/"
+	negate   bool     // re-includes the paths it matches, written with a leading "!"
+}
+
+// parseIgnoreRules parses gitignore-style patterns relative to base, one per line.
+// Blank lines and lines starting with # are skipped, a leading backslash escapes # and !.
+func parseIgnoreRules(base string, content string) []ignoreRule {
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreRule(base, scanner.Text()); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+func parseIgnoreRule(base string, line string) (ignoreRule, bool) {
+	pattern := strings.TrimSpace(line)
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return ignoreRule{}, false
+	}
+	rule := ignoreRule{base: base}
+	if negated, ok := strings.CutPrefix(pattern, "!"); ok {
+		rule.negate = true
+		pattern = negated
+	}
+	pattern = strings.TrimPrefix(pattern, `\`)
+	if trimmed, ok := strings.CutSuffix(pattern, "/"); ok {
+		rule.dirOnly = true
+		pattern = trimmed
+	}
+	// A slash anywhere but at the end ties the pattern to base
+	rule.anchored = strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return ignoreRule{}, false
+	}
+	rule.segments = strings.Split(pattern, "/")
+	return rule, true
+}
+
+// matches reports whether the rule matches the file or directory name, a path below its base.
+func (r ignoreRule) matches(name string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	rel, err := filepath.Rel(r.base, name)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	segments := strings.Split(filepath.ToSlash(rel), "/")
+	if !r.anchored {
+		matched, _ := path.Match(r.segments[0], segments[len(segments)-1])
+		return matched
+	}
+	return matchIgnoreSegments(r.segments, segments)
+}
+
+// matchIgnoreSegments matches the segments of a path against those of a pattern, "**" matching any number
+// of folders and a trailing "**" everything inside the folder before it.
+func matchIgnoreSegments(pattern []string, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			pattern = pattern[1:]
+			if len(pattern) == 0 {
+				return len(segments) > 0
+			}
+			for i := range segments {
+				if matchIgnoreSegments(pattern, segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// scanIgnore decides which paths below a music directory are excluded from scans, from the default and
+// configured excludes, relative to the music directory, then the ignore files of the folders above them.
+// As with gitignore, the last matching pattern wins and ignore files of deeper folders take precedence.
+// Ignore files are read once per folder and remembered for the rest of the walk.
+type scanIgnore struct {
+	root     string
+	excludes []ignoreRule
+	dirs     map[string][]ignoreRule
+	fail     func(path string, err error)
+}
+
+func newScanIgnore(root string, excludes []string, fail func(path string, err error)) *scanIgnore {
+	ignore := &scanIgnore{
+		root: root,
+		dirs: make(map[string][]ignoreRule),
+		fail: fail,
+	}
+	for _, pattern := range excludes {
+		if rule, ok := parseIgnoreRule(root, pattern); ok {
+			ignore.excludes = append(ignore.excludes, rule)
+		}
+	}
+	return ignore
+}
+
+// ignored reports whether the file or directory at path is excluded. The folders above path are not checked,
+// walks skip the content of excluded directories.
+func (x *scanIgnore) ignored(path string, isDir bool) bool {
+	if path == x.root || !isBelowAny(path, []string{x.root}) {
+		return false
+	}
+	ignored := applyIgnoreRules(x.excludes, false, path, isDir)
+	for _, dir := range x.ancestors(filepath.Dir(path)) {
+		ignored = applyIgnoreRules(x.load(dir), ignored, path, isDir)
+	}
+	return ignored
+}
+
+// ignoredTree reports whether dir or one of the folders between the music directory and dir is excluded,
+// for walks starting below the music directory.
+func (x *scanIgnore) ignoredTree(dir string) bool {
+	for _, ancestor := range x.ancestors(dir) {
+		if x.ignored(ancestor, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// ancestors returns the folders from the music directory down to dir.
+func (x *scanIgnore) ancestors(dir string) []string {
+	var dirs []string
+	for isBelowAny(dir, []string{x.root}) {
+		dirs = append(dirs, dir)
+		if dir == x.root {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	slices.Reverse(dirs)
+	return dirs
+}
+
+// load reads the ignore file of dir, if any.
+func (x *scanIgnore) load(dir string) []ignoreRule {
+	if rules, ok := x.dirs[dir]; ok {
+		return rules
+	}
+	path := filepath.Join(dir, ignoreFileName)
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		x.fail(path, err)
+	}
+	rules := parseIgnoreRules(dir, decodeText(data))
+	x.dirs[dir] = rules
+	return rules
+}
+
+// applyIgnoreRules returns whether path is ignored once rules are applied, starting from ignored.
+func applyIgnoreRules(rules []ignoreRule, ignored bool, path string, isDir bool) bool {
+	for _, rule := range rules {
+		if rule.matches(path, isDir) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestScanIgnore(t *testing.T) {
+	root := t.TempDir()
+	ignoreFiles := map[string]string{
+		ignoreFileName:                          "# sample packs\nSamples/\n*.wav\n!keep.wav\n/Live/bootlegs\n",
+		filepath.Join("Artist", ignoreFileName): "Stems/**\n!*.wav\n\\#1 Hits/\n",
+	}
+	for name, content := range ignoreFiles {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatalf("failed to create folder: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create ignore file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		path            string
+		isDir           bool
+		expectedIgnored bool
+	}{
+		{
+			name:            "directory pattern at any depth",
+			path:            "Artist/Album/Samples",
+			isDir:           true,
+			expectedIgnored: true,
+		},
+		{
+			name: "directory pattern does not match files",
+			path: "Artist/Samples",
+		},
+		{
+			name:            "file pattern",
+			path:            "Album/01 Intro.wav",
+			expectedIgnored: true,
+		},
+		{
+			name: "negated pattern",
+			path: "Album/keep.wav",
+		},
+		{
+			name:            "pattern anchored to its folder",
+			path:            "Live/bootlegs",
+			isDir:           true,
+			expectedIgnored: true,
+		},
+		{
+			name:  "anchored pattern below another folder",
+			path:  "Artist/Live/bootlegs",
+			isDir: true,
+		},
+		{
+			name:            "everything inside a folder",
+			path:            "Artist/Stems/bass.flac",
+			expectedIgnored: true,
+		},
+		{
+			name: "deeper ignore file re-includes",
+			path: "Artist/Album/02 Song.wav",
+		},
+		{
+			name:            "escaped hash",
+			path:            "Artist/#1 Hits",
+			isDir:           true,
+			expectedIgnored: true,
+		},
+		{
+			name:            "default exclude",
+			path:            "Artist/Album/@eaDir",
+			isDir:           true,
+			expectedIgnored: true,
+		},
+		{
+			name:            "configured exclude",
+			path:            "Artist/Album/cover.tmp",
+			expectedIgnored: true,
+		},
+		{
+			name: "music directory",
+			path: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ignore := newScanIgnore(root, slices.Concat(defaultScanExcludes, []string{"*.tmp"}), func(path string, err error) {
+				t.Errorf("unexpected failure for %s: %v", path, err)
+			})
+
+			ignored := ignore.ignored(filepath.Join(root, filepath.FromSlash(tt.path)), tt.isDir)
+
+			if ignored != tt.expectedIgnored {
+				t.Errorf("expected ignored %v, got %v", tt.expectedIgnored, ignored)
+			}
+		})
+	}
+}
+
+func TestScanIgnore_IgnoredTree(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ignoreFileName), []byte("Samples/\n"), 0o600); err != nil {
+		t.Fatalf("failed to create ignore file: %v", err)
+	}
+	ignore := newScanIgnore(root, nil, func(path string, err error) {
+		t.Errorf("unexpected failure for %s: %v", path, err)
+	})
+
+	if !ignore.ignoredTree(filepath.Join(root, "Samples", "Drums", "Kicks")) {
+		t.Errorf("expected folder below an excluded folder to be ignored")
+	}
+	if ignore.ignoredTree(filepath.Join(root, "Artist", "Album")) {
+		t.Errorf("expected folder to be scanned")
+	}
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"music-streaming/internal/core/domain"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// lyricsSidecarSuffixes lists the suffixes of the LRC files read next to audio files, in order of preference.
+	lyricsSidecarSuffixes = []string{".lrc", ".LRC", ".Lrc"}
+
+	// lyricsTagNames lists the tags holding lyrics, possibly followed by a language as in "lyrics-eng".
+	lyricsTagNames = []string{"lyrics", "unsyncedlyrics", "unsynced lyrics", "syncedlyrics"}
+
+	// lrcTimestamp matches the time tags of LRC lines, [mm:ss], [mm:ss.xx] or [mm:ss.xxx].
+	lrcTimestamp = regexp.MustCompile(`^\[(\d+):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+
+	// lrcMetadata matches the ID tags of LRC files, such as [ar:Artist] or [offset:+250].
+	lrcMetadata = regexp.MustCompile(`^\[([A-Za-z#]+):(.*)\]$`)
+
+	// lrcWordTimestamp matches the word time tags of enhanced LRC, <mm:ss.xx>.
+	lrcWordTimestamp = regexp.MustCompile(`<\d+:\d{1,2}(?:[.:]\d{1,3})?>`)
+)
+
+// parseLyrics parses lyrics in the LRC format. Lines with time tags make the lyrics synced, the other lines
+// then being ignored, and text without any time tag is read as plain lyrics.
+// The language is UnknownLyricsLanguage unless set by a [la:] tag.
+func parseLyrics(content string) domain.Lyrics {
+	lyrics := domain.Lyrics{Lang: domain.UnknownLyricsLanguage}
+	var plain []domain.LyricsLine
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if match := lrcMetadata.FindStringSubmatch(line); match != nil {
+			value := strings.TrimSpace(match[2])
+			switch strings.ToLower(match[1]) {
+			case "ar":
+				lyrics.DisplayArtist = value
+			case "ti":
+				lyrics.DisplayTitle = value
+			case "la", "lang":
+				if value != "" {
+					lyrics.Lang = strings.ToLower(value)
+				}
+			case "offset":
+				if offset, err := strconv.Atoi(strings.TrimPrefix(value, "+")); err == nil {
+					lyrics.Offset = offset
+				}
+			}
+			continue
+		}
+
+		// A line may be sung several times, each time being tagged at its start
+		var starts []int
+		for {
+			match := lrcTimestamp.FindStringSubmatch(line)
+			if match == nil {
+				break
+			}
+			starts = append(starts, lrcMilliseconds(match[1], match[2], match[3]))
+			line = strings.TrimSpace(line[len(match[0]):])
+		}
+		value := strings.TrimSpace(lrcWordTimestamp.ReplaceAllString(line, ""))
+		if len(starts) == 0 {
+			plain = append(plain, domain.LyricsLine{Value: value})
+			continue
+		}
+		lyrics.Synced = true
+		for _, start := range starts {
+			lyrics.Lines = append(lyrics.Lines, domain.LyricsLine{Start: start, Value: value})
+		}
+	}
+
+	if lyrics.Synced {
+		slices.SortStableFunc(lyrics.Lines, func(a, b domain.LyricsLine) int {
+			return a.Start - b.Start
+		})
+		return lyrics
+	}
+	// Blank lines separate verses, only those around the lyrics are dropped
+	first := slices.IndexFunc(plain, func(line domain.LyricsLine) bool { return line.Value != "" })
+	if first < 0 {
+		return lyrics
+	}
+	last := len(plain) - 1
+	for plain[last].Value == "" {
+		last--
+	}
+	lyrics.Lines = plain[first : last+1]
+	return lyrics
+}
+
+// lrcMilliseconds converts the minutes, seconds and fraction of an LRC time tag to milliseconds.
+// The fraction is in hundredths of a second when it has two digits, as written by most tools.
+func lrcMilliseconds(minutes string, seconds string, fraction string) int {
+	m, _ := strconv.Atoi(minutes)
+	s, _ := strconv.Atoi(seconds)
+	ms := 0
+	if fraction != "" {
+		ms, _ = strconv.Atoi(fraction)
+		for range 3 - len(fraction) {
+			ms *= 10
+		}
+	}
+	return (m*60+s)*1000 + ms
+}
+
+// lyricsFromTags returns the lyrics found in the tags of a file, synced lyrics first.
+// Tags named with a language suffix, as ffprobe names the USLT frames of MP3 files, set the language of the lyrics.
+func lyricsFromTags(metadata domain.MediaMetadata) []domain.Lyrics {
+	var found []domain.Lyrics
+	for _, key := range slices.Sorted(maps.Keys(metadata.Tags)) {
+		name, lang, _ := strings.Cut(strings.ToLower(key), "-")
+		if !slices.Contains(lyricsTagNames, name) {
+			continue
+		}
+		lyrics := parseLyrics(metadata.Tags[key])
+		if len(lyrics.Lines) == 0 {
+			continue
+		}
+		if len(lang) == 3 && lyrics.Lang == domain.UnknownLyricsLanguage {
+			lyrics.Lang = lang
+		}
+		if !slices.ContainsFunc(found, func(l domain.Lyrics) bool { return sameLyrics(l, lyrics) }) {
+			found = append(found, lyrics)
+		}
+	}
+	sortLyrics(found)
+	return found
+}
+
+// sameLyrics reports whether two lyrics have the same lines, as when a tagger writes them to several tags.
+func sameLyrics(a domain.Lyrics, b domain.Lyrics) bool {
+	return a.Synced == b.Synced && a.Lang == b.Lang && slices.Equal(a.Lines, b.Lines)
+}
+
+// sortLyrics moves synced lyrics before unsynced ones, keeping their order otherwise.
+func sortLyrics(lyrics []domain.Lyrics) {
+	slices.SortStableFunc(lyrics, func(a, b domain.Lyrics) int {
+		switch {
+		case a.Synced == b.Synced:
+			return 0
+		case a.Synced:
+			return -1
+		default:
+			return 1
+		}
+	})
+}
+
+// findLyricsSidecar returns the path and modification time of the LRC file named after the audio file at path.
+func findLyricsSidecar(path string) (string, time.Time, bool) {
+	stem := strings.TrimSuffix(path, filepath.Ext(path))
+	for _, suffix := range lyricsSidecarSuffixes {
+		stat, err := os.Stat(stem + suffix)
+		if err == nil && !stat.IsDir() {
+			return stem + suffix, stat.ModTime(), true
+		}
+	}
+	return "", time.Time{}, false
+}
+
+func readLyricsFile(path string) (domain.Lyrics, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.Lyrics{}, err
+	}
+	lyrics := parseLyrics(decodeText(data))
+	if err := lyrics.Validate(); err != nil {
+		return domain.Lyrics{}, fmt.Errorf("invalid lyrics: %w", err)
+	}
+	return lyrics, nil
+}
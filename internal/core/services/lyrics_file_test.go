@@ -0,0 +1,163 @@
+package services
+
+import (
+	"music-streaming/internal/core/domain"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseLyrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		expectedLyrics domain.Lyrics
+	}{
+		{
+			name:    "synced lyrics with id tags",
+			content: "[ar:Artist]\n[ti:Song]\n[la:ENG]\n[offset:+250]\n[by:someone]\n[00:12.50]First line\n[00:17.2]Second line\n[01:02.345]\n",
+			expectedLyrics: domain.Lyrics{
+				Lang: "eng", Synced: true, DisplayArtist: "Artist", DisplayTitle: "Song", Offset: 250,
+				Lines: []domain.LyricsLine{{Start: 12500, Value: "First line"}, {Start: 17200, Value: "Second line"}, {Start: 62345}},
+			},
+		},
+		{
+			name:    "repeated lines and word time tags",
+			content: "[00:30.00][00:10.00]Chorus <00:10.50>line\nuntagged text\n[00:20.00]Verse\n",
+			expectedLyrics: domain.Lyrics{
+				Lang: domain.UnknownLyricsLanguage, Synced: true,
+				Lines: []domain.LyricsLine{{Start: 10000, Value: "Chorus line"}, {Start: 20000, Value: "Verse"}, {Start: 30000, Value: "Chorus line"}},
+			},
+		},
+		{
+			name:    "plain text keeps verses apart",
+			content: "\n[Chorus]\nFirst verse\r\n\r\nSecond verse\n\n",
+			expectedLyrics: domain.Lyrics{
+				Lang:  domain.UnknownLyricsLanguage,
+				Lines: []domain.LyricsLine{{Value: "[Chorus]"}, {Value: "First verse"}, {}, {Value: "Second verse"}},
+			},
+		},
+		{
+			name:           "empty lyrics",
+			content:        "[ti:Instrumental]\n\n",
+			expectedLyrics: domain.Lyrics{Lang: domain.UnknownLyricsLanguage, DisplayTitle: "Instrumental"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lyrics := parseLyrics(tt.content)
+
+			if lyrics.Lang != tt.expectedLyrics.Lang || lyrics.Synced != tt.expectedLyrics.Synced ||
+				lyrics.DisplayArtist != tt.expectedLyrics.DisplayArtist || lyrics.DisplayTitle != tt.expectedLyrics.DisplayTitle ||
+				lyrics.Offset != tt.expectedLyrics.Offset {
+				t.Errorf("expected lyrics %+v, got %+v", tt.expectedLyrics, lyrics)
+			}
+			if !slices.Equal(lyrics.Lines, tt.expectedLyrics.Lines) {
+				t.Errorf("expected lines %+v, got %+v", tt.expectedLyrics.Lines, lyrics.Lines)
+			}
+		})
+	}
+}
+
+func TestLyricsFromTags(t *testing.T) {
+	tests := []struct {
+		name          string
+		tags          map[string]string
+		expectedLangs []string
+		expectedSync  []bool
+	}{
+		{
+			name: "mp3 frames named after their language",
+			tags: map[string]string{
+				"title":              "Song",
+				"lyrics-eng":         "Hello\nWorld",
+				"syncedlyrics-deu":   "[00:01.00]Hallo\n[00:02.00]Welt",
+				"lyrics-xxx":         "Hello\nWorld",
+				"unsyncedlyrics-123": "",
+			},
+			expectedLangs: []string{"deu", "eng", "xxx"},
+			expectedSync:  []bool{true, false, false},
+		},
+		{
+			name:          "vorbis comment written twice",
+			tags:          map[string]string{"LYRICS": "Line", "UNSYNCEDLYRICS": "Line"},
+			expectedLangs: []string{domain.UnknownLyricsLanguage},
+			expectedSync:  []bool{false},
+		},
+		{
+			name: "no lyrics",
+			tags: map[string]string{"title": "Song", "comment": "Ripped"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := lyricsFromTags(domain.MediaMetadata{Tags: tt.tags})
+
+			var (
+				langs []string
+				sync  []bool
+			)
+			for _, lyrics := range found {
+				langs = append(langs, lyrics.Lang)
+				sync = append(sync, lyrics.Synced)
+			}
+			if !slices.Equal(langs, tt.expectedLangs) || !slices.Equal(sync, tt.expectedSync) {
+				t.Errorf("expected languages %v synced %v, got %v synced %v", tt.expectedLangs, tt.expectedSync, langs, sync)
+			}
+		})
+	}
+}
+
+func TestFindLyricsSidecar(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"01 Song.mp3", "01 Song.lrc", "02 Other.flac", "02 Other.LRC", "03 None.ogg"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("[00:01.00]Line"), 0o600); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		expectedPath  string
+		expectedFound bool
+	}{
+		{
+			name:          "lower case suffix",
+			path:          filepath.Join(dir, "01 Song.mp3"),
+			expectedPath:  filepath.Join(dir, "01 Song.lrc"),
+			expectedFound: true,
+		},
+		{
+			name:          "upper case suffix",
+			path:          filepath.Join(dir, "02 Other.flac"),
+			expectedPath:  filepath.Join(dir, "02 Other.LRC"),
+			expectedFound: true,
+		},
+		{
+			name: "file without lyrics",
+			path: filepath.Join(dir, "03 None.ogg"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, modTime, found := findLyricsSidecar(tt.path)
+
+			if found != tt.expectedFound {
+				t.Fatalf("expected found %v, got %v", tt.expectedFound, found)
+			}
+			// Case insensitive file systems find either suffix
+			if found && !strings.EqualFold(path, tt.expectedPath) {
+				t.Errorf("expected path %q, got %q", tt.expectedPath, path)
+			}
+			if found && modTime.IsZero() {
+				t.Errorf("expected modification time of %s", path)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+)
+
+type LyricsService struct {
+	mediaBrowsingRepo ports.MediaBrowsingRepository
+	lyricsRepo        ports.LyricsRepository
+	logger            *slog.Logger
+}
+
+func NewLyricsService(mediaBrowsingRepo ports.MediaBrowsingRepository, lyricsRepo ports.LyricsRepository, logger *slog.Logger) *LyricsService {
+	return &LyricsService{
+		mediaBrowsingRepo: mediaBrowsingRepo,
+		lyricsRepo:        lyricsRepo,
+		logger:            logger,
+	}
+}
+
+func (s *LyricsService) GetLyrics(ctx context.Context, artist string, title string) (domain.Lyrics, error) {
+	s.logger.Info("Getting lyrics", slog.String("artist", artist), slog.String("title", title))
+	if title == "" {
+		s.logger.Warn("Missing title for lyrics")
+		return domain.Lyrics{}, &ports.MissingOrInvalidParameterError{ParameterName: "title"}
+	}
+
+	lyrics, err := s.lyricsRepo.GetLyricsByArtistAndTitle(ctx, artist, title, allowedMusicFolders(ctx))
+	if err != nil {
+		s.logger.Error("Failed to get lyrics", slog.String("artist", artist), slog.String("title", title), slog.String("error", err.Error()))
+		return domain.Lyrics{}, err
+	}
+	if len(lyrics) == 0 {
+		s.logger.Info("No lyrics found", slog.String("artist", artist), slog.String("title", title))
+		return domain.Lyrics{}, &ports.NotFoundError{Message: "lyrics not found"}
+	}
+
+	// Lyrics are grouped by song, only those of the first song are considered
+	songLyrics := make([]domain.Lyrics, 0, len(lyrics))
+	for _, l := range lyrics {
+		if l.SongId == lyrics[0].SongId {
+			songLyrics = append(songLyrics, l)
+		}
+	}
+	sortLyrics(songLyrics)
+	s.logger.Info("Successfully retrieved lyrics", slog.Int("songId", songLyrics[0].SongId), slog.String("lang", songLyrics[0].Lang))
+	return songLyrics[0], nil
+}
+
+func (s *LyricsService) GetLyricsBySongID(ctx context.Context, songID int) ([]domain.Lyrics, error) {
+	s.logger.Info("Getting song lyrics", slog.Int("songId", songID))
+	song, err := s.mediaBrowsingRepo.GetSongByID(ctx, songID)
+	if err != nil {
+		s.logger.Error("Failed to get song", slog.Int("songId", songID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if !canAccessMusicFolder(ctx, song.MusicFolderId) {
+		s.logger.Warn("Song outside the allowed music folders", slog.Int("songId", songID))
+		return nil, &ports.NotFoundError{Message: "song not found"}
+	}
+
+	lyrics, err := s.lyricsRepo.GetLyricsBySongID(ctx, songID)
+	if err != nil {
+		s.logger.Error("Failed to get song lyrics", slog.Int("songId", songID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	sortLyrics(lyrics)
+	s.logger.Info("Successfully retrieved song lyrics", slog.Int("songId", songID), slog.Int("count", len(lyrics)))
+	return lyrics, nil
+}
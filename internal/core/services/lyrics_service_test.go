@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"music-streaming/internal/core/services/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLyricsService_GetLyrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *domain.User
+		artist         string
+		title          string
+		setupMock      func(*mocks.MockLyricsRepository)
+		expectedLyrics domain.Lyrics
+		expectedError  error
+	}{
+		{
+			name:   "synced lyrics of the first song preferred",
+			artist: "artist",
+			title:  "song",
+			setupMock: func(m *mocks.MockLyricsRepository) {
+				m.EXPECT().GetLyricsByArtistAndTitle(mock.Anything, "artist", "song", []int(nil)).Return([]domain.Lyrics{
+					{SongId: 1, Lang: "eng", DisplayTitle: "Song", Lines: []domain.LyricsLine{{Value: "Plain"}}},
+					{SongId: 1, Lang: "eng", Synced: true, DisplayTitle: "Song", Lines: []domain.LyricsLine{{Start: 1000, Value: "Synced"}}},
+					{SongId: 2, Lang: "eng", Synced: true, DisplayTitle: "Song (Live)", Lines: []domain.LyricsLine{{Start: 0, Value: "Live"}}},
+				}, nil)
+			},
+			expectedLyrics: domain.Lyrics{SongId: 1, Lang: "eng", Synced: true, DisplayTitle: "Song", Lines: []domain.LyricsLine{{Start: 1000, Value: "Synced"}}},
+		},
+		{
+			name:  "no lyrics",
+			title: "song",
+			setupMock: func(m *mocks.MockLyricsRepository) {
+				m.EXPECT().GetLyricsByArtistAndTitle(mock.Anything, "", "song", []int(nil)).Return(nil, nil)
+			},
+			expectedError: &ports.NotFoundError{Message: "lyrics not found"},
+		},
+		{
+			name:  "songs outside the allowed music folders left out",
+			user:  &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			title: "song",
+			setupMock: func(m *mocks.MockLyricsRepository) {
+				m.EXPECT().GetLyricsByArtistAndTitle(mock.Anything, "", "song", []int{2}).Return(nil, nil)
+			},
+			expectedError: &ports.NotFoundError{Message: "lyrics not found"},
+		},
+		{
+			name:          "missing title",
+			artist:        "artist",
+			setupMock:     func(m *mocks.MockLyricsRepository) {},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "title"},
+		},
+		{
+			name:  "repository error",
+			title: "song",
+			setupMock: func(m *mocks.MockLyricsRepository) {
+				m.EXPECT().GetLyricsByArtistAndTitle(mock.Anything, "", "song", []int(nil)).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lyricsRepo := mocks.NewMockLyricsRepository(t)
+			tt.setupMock(lyricsRepo)
+			service := NewLyricsService(mocks.NewMockMediaBrowsingRepository(t), lyricsRepo, slog.Default())
+
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+			result, err := service.GetLyrics(ctx, tt.artist, tt.title)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.SongId != tt.expectedLyrics.SongId || result.Synced != tt.expectedLyrics.Synced ||
+				len(result.Lines) != len(tt.expectedLyrics.Lines) || result.Lines[0] != tt.expectedLyrics.Lines[0] {
+				t.Errorf("expected lyrics %+v, got %+v", tt.expectedLyrics, result)
+			}
+		})
+	}
+}
+
+func TestLyricsService_GetLyricsBySongID(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             int
+		setupMock      func(*mocks.MockMediaBrowsingRepository, *mocks.MockLyricsRepository)
+		expectedSynced []bool
+		expectedError  error
+	}{
+		{
+			name: "synced lyrics first",
+			id:   1,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository, l *mocks.MockLyricsRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 1).Return(domain.Song{Id: 1, Title: "Song"}, nil)
+				l.EXPECT().GetLyricsBySongID(mock.Anything, 1).Return([]domain.Lyrics{
+					{SongId: 1, Lang: "xxx"},
+					{SongId: 1, Lang: "eng", Synced: true},
+				}, nil)
+			},
+			expectedSynced: []bool{true, false},
+		},
+		{
+			name: "song without lyrics",
+			id:   2,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository, l *mocks.MockLyricsRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 2).Return(domain.Song{Id: 2, Title: "Instrumental"}, nil)
+				l.EXPECT().GetLyricsBySongID(mock.Anything, 2).Return([]domain.Lyrics{}, nil)
+			},
+			expectedSynced: []bool{},
+		},
+		{
+			name: "song not found",
+			id:   999,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository, l *mocks.MockLyricsRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 999).Return(domain.Song{}, &ports.NotFoundError{Message: "song not found"})
+			},
+			expectedError: &ports.NotFoundError{Message: "song not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			lyricsRepo := mocks.NewMockLyricsRepository(t)
+			tt.setupMock(repo, lyricsRepo)
+			service := NewLyricsService(repo, lyricsRepo, slog.Default())
+
+			result, err := service.GetLyricsBySongID(context.Background(), tt.id)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result) != len(tt.expectedSynced) {
+				t.Fatalf("expected %d lyrics, got %d", len(tt.expectedSynced), len(result))
+			}
+			for i, synced := range tt.expectedSynced {
+				if result[i].Synced != synced {
+					t.Errorf("expected lyrics %d synced %v, got %+v", i, synced, result[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"time"
+)
+
+// nowPlayingGracePeriod is how long a song stays playing now past its duration, covering pauses and buffering.
+const nowPlayingGracePeriod = time.Minute
+
+type MediaAnnotationService struct {
+	mediaBrowsingRepo ports.MediaBrowsingRepository
+	annotationRepo    ports.MediaAnnotationRepository
+	nowPlayingRepo    ports.NowPlayingRepository
+	logger            *slog.Logger
+}
+
+func NewMediaAnnotationService(mediaBrowsingRepo ports.MediaBrowsingRepository, annotationRepo ports.MediaAnnotationRepository, nowPlayingRepo ports.NowPlayingRepository, logger *slog.Logger) *MediaAnnotationService {
+	return &MediaAnnotationService{
+		mediaBrowsingRepo: mediaBrowsingRepo,
+		annotationRepo:    annotationRepo,
+		nowPlayingRepo:    nowPlayingRepo,
+		logger:            logger,
+	}
+}
+
+// annotatedItems holds the IDs of the items of a type a request stars or unstars.
+type annotatedItems struct {
+	itemType domain.AnnotationItemType
+	ids      []int
+}
+
+func (s *MediaAnnotationService) Star(ctx context.Context, songIDs []int, albumIDs []int, artistIDs []int) error {
+	username := requestingUsername(ctx)
+	s.logger.Info("Starring items", slog.String("username", username), slog.Int("songs", len(songIDs)), slog.Int("albums", len(albumIDs)), slog.Int("artists", len(artistIDs)))
+	if username == "" {
+		return &ports.NotAuthorizedError{Action: "star"}
+	}
+	if len(songIDs) == 0 && len(albumIDs) == 0 && len(artistIDs) == 0 {
+		return &ports.MissingOrInvalidParameterError{ParameterName: "id, albumId or artistId"}
+	}
+
+	annotated := []annotatedItems{
+		{domain.AnnotationItemSong, songIDs},
+		{domain.AnnotationItemAlbum, albumIDs},
+		{domain.AnnotationItemArtist, artistIDs},
+	}
+	// Every item is checked before any is starred, so that a request naming a missing item stars nothing
+	for _, items := range annotated {
+		for _, id := range items.ids {
+			if err := s.checkItem(ctx, items.itemType, id); err != nil {
+				return err
+			}
+		}
+	}
+
+	starred := time.Now()
+	for _, items := range annotated {
+		if len(items.ids) == 0 {
+			continue
+		}
+		if err := s.annotationRepo.StarItems(ctx, username, items.itemType, items.ids, starred); err != nil {
+			s.logger.Error("Failed to star items", slog.String("username", username), slog.String("type", string(items.itemType)), slog.String("error", err.Error()))
+			return err
+		}
+	}
+	s.logger.Info("Successfully starred items", slog.String("username", username))
+	return nil
+}
+
+func (s *MediaAnnotationService) Unstar(ctx context.Context, songIDs []int, albumIDs []int, artistIDs []int) error {
+	username := requestingUsername(ctx)
+	s.logger.Info("Unstarring items", slog.String("username", username), slog.Int("songs", len(songIDs)), slog.Int("albums", len(albumIDs)), slog.Int("artists", len(artistIDs)))
+	if username == "" {
+		return &ports.NotAuthorizedError{Action: "unstar"}
+	}
+	if len(songIDs) == 0 && len(albumIDs) == 0 && len(artistIDs) == 0 {
+		return &ports.MissingOrInvalidParameterError{ParameterName: "id, albumId or artistId"}
+	}
+
+	for _, items := range []annotatedItems{
+		{domain.AnnotationItemSong, songIDs},
+		{domain.AnnotationItemAlbum, albumIDs},
+		{domain.AnnotationItemArtist, artistIDs},
+	} {
+		if len(items.ids) == 0 {
+			continue
+		}
+		if err := s.annotationRepo.UnstarItems(ctx, username, items.itemType, items.ids); err != nil {
+			s.logger.Error("Failed to unstar items", slog.String("username", username), slog.String("type", string(items.itemType)), slog.String("error", err.Error()))
+			return err
+		}
+	}
+	s.logger.Info("Successfully unstarred items", slog.String("username", username))
+	return nil
+}
+
+func (s *MediaAnnotationService) SetRating(ctx context.Context, itemType domain.AnnotationItemType, id int, rating int) error {
+	username := requestingUsername(ctx)
+	s.logger.Info("Setting rating", slog.String("username", username), slog.String("type", string(itemType)), slog.Int("id", id), slog.Int("rating", rating))
+	if username == "" {
+		return &ports.NotAuthorizedError{Action: "set rating"}
+	}
+	if rating < 0 || rating > domain.MaxRating {
+		return &ports.MissingOrInvalidParameterError{ParameterName: "rating"}
+	}
+	if err := s.checkItem(ctx, itemType, id); err != nil {
+		return err
+	}
+
+	if err := s.annotationRepo.RateItem(ctx, username, itemType, id, rating); err != nil {
+		s.logger.Error("Failed to set rating", slog.String("username", username), slog.String("type", string(itemType)), slog.Int("id", id), slog.String("error", err.Error()))
+		return err
+	}
+	s.logger.Info("Successfully set rating", slog.String("username", username), slog.String("type", string(itemType)), slog.Int("id", id))
+	return nil
+}
+
+func (s *MediaAnnotationService) GetStarred(ctx context.Context, musicFolderID int) (domain.Starred, error) {
+	username := requestingUsername(ctx)
+	s.logger.Info("Getting starred items", slog.String("username", username), slog.Int("musicFolderId", musicFolderID))
+	if username == "" {
+		return domain.Starred{}, &ports.NotAuthorizedError{Action: "get starred"}
+	}
+	musicFolderIDs := allowedMusicFolders(ctx)
+	if musicFolderID != 0 {
+		if !canAccessMusicFolder(ctx, musicFolderID) {
+			s.logger.Warn("Music folder not allowed", slog.Int("musicFolderId", musicFolderID))
+			return domain.Starred{}, &ports.NotFoundError{Message: "music folder not found"}
+		}
+		musicFolderIDs = []int{musicFolderID}
+	}
+
+	artists, err := s.annotationRepo.GetStarredArtists(ctx, username, musicFolderIDs)
+	if err != nil {
+		s.logger.Error("Failed to get starred artists", slog.String("username", username), slog.String("error", err.Error()))
+		return domain.Starred{}, err
+	}
+	// Starred items are not paged
+	albums, err := s.annotationRepo.GetStarredAlbums(ctx, username, musicFolderIDs, math.MaxInt32, 0)
+	if err != nil {
+		s.logger.Error("Failed to get starred albums", slog.String("username", username), slog.String("error", err.Error()))
+		return domain.Starred{}, err
+	}
+	songs, err := s.annotationRepo.GetStarredSongs(ctx, username, musicFolderIDs)
+	if err != nil {
+		s.logger.Error("Failed to get starred songs", slog.String("username", username), slog.String("error", err.Error()))
+		return domain.Starred{}, err
+	}
+
+	// Ratings of starred items
+	starred := domain.Starred{Artists: artists, Albums: albums, Songs: songs}
+	if err := annotateArtists(ctx, s.annotationRepo, starred.Artists); err != nil {
+		s.logger.Error("Failed to get annotations", slog.String("username", username), slog.String("error", err.Error()))
+		return domain.Starred{}, err
+	}
+	if err := annotateAlbums(ctx, s.annotationRepo, starred.Albums); err != nil {
+		s.logger.Error("Failed to get annotations", slog.String("username", username), slog.String("error", err.Error()))
+		return domain.Starred{}, err
+	}
+	if err := annotateSongs(ctx, s.annotationRepo, starred.Songs); err != nil {
+		s.logger.Error("Failed to get annotations", slog.String("username", username), slog.String("error", err.Error()))
+		return domain.Starred{}, err
+	}
+	s.logger.Info("Successfully retrieved starred items", slog.String("username", username), slog.Int("artists", len(starred.Artists)), slog.Int("albums", len(starred.Albums)), slog.Int("songs", len(starred.Songs)))
+	return starred, nil
+}
+
+func (s *MediaAnnotationService) Scrobble(ctx context.Context, songIDs []int, times []time.Time, submission bool) error {
+	username := requestingUsername(ctx)
+	s.logger.Info("Scrobbling songs", slog.String("username", username), slog.Int("songs", len(songIDs)), slog.Bool("submission", submission))
+	if username == "" {
+		return &ports.NotAuthorizedError{Action: "scrobble"}
+	}
+	if len(songIDs) == 0 {
+		return &ports.MissingOrInvalidParameterError{ParameterName: "id"}
+	}
+	if len(times) != 0 && len(times) != len(songIDs) {
+		return &ports.MissingOrInvalidParameterError{ParameterName: "time"}
+	}
+
+	songs := make([]domain.Song, 0, len(songIDs))
+	for _, id := range songIDs {
+		song, err := s.mediaBrowsingRepo.GetSongByID(ctx, id)
+		if err != nil {
+			s.logger.Error("Failed to get scrobbled song", slog.Int("id", id), slog.String("error", err.Error()))
+			return err
+		}
+		if !canAccessMusicFolder(ctx, song.MusicFolderId) {
+			s.logger.Warn("Scrobbled song outside the allowed music folders", slog.Int("id", id))
+			return &ports.NotFoundError{Message: "song not found"}
+		}
+		songs = append(songs, song)
+	}
+
+	// Now playing notifications only tell which song a player started, the last one of the request
+	if !submission {
+		nowPlaying := domain.NowPlaying{Song: songs[len(songs)-1], Username: username, PlayerName: requestingPlayer(ctx), Started: time.Now()}
+		if err := s.nowPlayingRepo.SetNowPlaying(ctx, nowPlaying); err != nil {
+			s.logger.Error("Failed to set now playing", slog.String("username", username), slog.String("error", err.Error()))
+			return err
+		}
+		s.logger.Info("Successfully set now playing", slog.String("username", username), slog.Int("id", nowPlaying.Song.Id))
+		return nil
+	}
+
+	for i, song := range songs {
+		played := time.Now()
+		if len(times) != 0 {
+			played = times[i]
+		}
+		if err := s.annotationRepo.RecordPlay(ctx, username, song.Id, song.AlbumId, played); err != nil {
+			s.logger.Error("Failed to record play", slog.String("username", username), slog.Int("id", song.Id), slog.String("error", err.Error()))
+			return err
+		}
+	}
+	s.logger.Info("Successfully scrobbled songs", slog.String("username", username), slog.Int("songs", len(songs)))
+	return nil
+}
+
+func (s *MediaAnnotationService) GetNowPlaying(ctx context.Context) ([]domain.NowPlaying, error) {
+	username := requestingUsername(ctx)
+	s.logger.Info("Getting now playing", slog.String("username", username))
+	if username == "" {
+		return nil, &ports.NotAuthorizedError{Action: "get now playing"}
+	}
+
+	entries, err := s.nowPlayingRepo.GetNowPlaying(ctx)
+	if err != nil {
+		s.logger.Error("Failed to get now playing", slog.String("username", username), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// Songs stay playing for their duration, and only show to users who may access them
+	now := time.Now()
+	playing := make([]domain.NowPlaying, 0, len(entries))
+	songs := make([]domain.Song, 0, len(entries))
+	for _, entry := range entries {
+		ends := entry.Started.Add(time.Duration(entry.Song.Duration)*time.Second + nowPlayingGracePeriod)
+		if now.After(ends) || !canAccessMusicFolder(ctx, entry.Song.MusicFolderId) {
+			continue
+		}
+		playing = append(playing, entry)
+		songs = append(songs, entry.Song)
+	}
+	if err := annotateSongs(ctx, s.annotationRepo, songs); err != nil {
+		s.logger.Error("Failed to get annotations", slog.String("username", username), slog.String("error", err.Error()))
+		return nil, err
+	}
+	for i := range playing {
+		playing[i].Song = songs[i]
+	}
+	s.logger.Info("Successfully retrieved now playing", slog.String("username", username), slog.Int("count", len(playing)))
+	return playing, nil
+}
+
+// checkItem makes sure the item of a type with the ID exists in a music folder the requesting user may access.
+func (s *MediaAnnotationService) checkItem(ctx context.Context, itemType domain.AnnotationItemType, id int) error {
+	var (
+		musicFolderID int
+		err           error
+	)
+	switch itemType {
+	case domain.AnnotationItemArtist:
+		var artist domain.Artist
+		artist, err = s.mediaBrowsingRepo.GetArtistByID(ctx, id)
+		musicFolderID = artist.MusicFolderId
+	case domain.AnnotationItemAlbum:
+		var album domain.Album
+		album, err = s.mediaBrowsingRepo.GetAlbumByID(ctx, id)
+		musicFolderID = album.MusicFolderId
+	case domain.AnnotationItemSong:
+		var song domain.Song
+		song, err = s.mediaBrowsingRepo.GetSongByID(ctx, id)
+		musicFolderID = song.MusicFolderId
+	default:
+		return &ports.MissingOrInvalidParameterError{ParameterName: "id"}
+	}
+	if err != nil {
+		s.logger.Error("Failed to get annotated item", slog.String("type", string(itemType)), slog.Int("id", id), slog.String("error", err.Error()))
+		return err
+	}
+	if !canAccessMusicFolder(ctx, musicFolderID) {
+		s.logger.Warn("Annotated item outside the allowed music folders", slog.String("type", string(itemType)), slog.Int("id", id))
+		return &ports.NotFoundError{Message: string(itemType) + " not found"}
+	}
+	return nil
+}
+
+// requestingUsername returns the name of the requesting user, empty without one.
+func requestingUsername(ctx context.Context) string {
+	requestingUser, ok := ctx.Value(ports.KeyRequestingUserID).(*domain.User)
+	if !ok || requestingUser == nil {
+		return ""
+	}
+	return requestingUser.Username
+}
+
+// requestingPlayer returns the name of the client application making the request, empty when unknown.
+func requestingPlayer(ctx context.Context) string {
+	playerName, _ := ctx.Value(ports.KeyPlayerName).(string)
+	return playerName
+}
+
+// annotate sets the star, rating and plays of the requesting user on items of a type,
+// leaving items untouched without a requesting user.
+func annotate[T any](ctx context.Context, repo ports.MediaAnnotationRepository, itemType domain.AnnotationItemType, items []T, id func(*T) int, apply func(*T, domain.Annotation)) error {
+	username := requestingUsername(ctx)
+	if username == "" || len(items) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(items))
+	for i := range items {
+		ids = append(ids, id(&items[i]))
+	}
+	annotations, err := repo.GetAnnotations(ctx, username, itemType, ids)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		if annotation, exists := annotations[id(&items[i])]; exists {
+			apply(&items[i], annotation)
+		}
+	}
+	return nil
+}
+
+// annotateArtists sets the star and rating the requesting user gave artists.
+func annotateArtists(ctx context.Context, repo ports.MediaAnnotationRepository, artists []domain.Artist) error {
+	return annotate(ctx, repo, domain.AnnotationItemArtist, artists,
+		func(artist *domain.Artist) int { return artist.Id },
+		func(artist *domain.Artist, annotation domain.Annotation) {
+			artist.Starred, artist.UserRating = annotation.Starred, annotation.Rating
+		})
+}
+
+// annotateAlbums sets the star, rating and plays of the requesting user on albums.
+func annotateAlbums(ctx context.Context, repo ports.MediaAnnotationRepository, albums []domain.Album) error {
+	return annotate(ctx, repo, domain.AnnotationItemAlbum, albums,
+		func(album *domain.Album) int { return album.Id },
+		func(album *domain.Album, annotation domain.Annotation) {
+			album.Starred, album.UserRating = annotation.Starred, annotation.Rating
+			album.PlayCount, album.Played = annotation.PlayCount, annotation.Played
+		})
+}
+
+// annotateSongs sets the star, rating and plays of the requesting user on songs.
+func annotateSongs(ctx context.Context, repo ports.MediaAnnotationRepository, songs []domain.Song) error {
+	return annotate(ctx, repo, domain.AnnotationItemSong, songs,
+		func(song *domain.Song) int { return song.Id },
+		func(song *domain.Song, annotation domain.Annotation) {
+			song.Starred, song.UserRating = annotation.Starred, annotation.Rating
+			song.PlayCount, song.Played = annotation.PlayCount, annotation.Played
+		})
+}
@@ -0,0 +1,625 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"music-streaming/internal/core/services/mocks"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMediaAnnotationService_Star(t *testing.T) {
+	tests := []struct {
+		name             string
+		user             *domain.User
+		songIDs          []int
+		albumIDs         []int
+		artistIDs        []int
+		setupMock        func(*mocks.MockMediaBrowsingRepository)
+		setupAnnotations func(*mocks.MockMediaAnnotationRepository)
+		expectedError    error
+	}{
+		{
+			name:     "songs and albums",
+			user:     &domain.User{Username: "alice"},
+			songIDs:  []int{1, 2},
+			albumIDs: []int{3},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 1).Return(domain.Song{Id: 1, MusicFolderId: 1}, nil)
+				m.EXPECT().GetSongByID(mock.Anything, 2).Return(domain.Song{Id: 2, MusicFolderId: 1}, nil)
+				m.EXPECT().GetAlbumByID(mock.Anything, 3).Return(domain.Album{Id: 3, MusicFolderId: 1}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().StarItems(mock.Anything, "alice", domain.AnnotationItemSong, []int{1, 2}, mock.AnythingOfType("time.Time")).Return(nil)
+				m.EXPECT().StarItems(mock.Anything, "alice", domain.AnnotationItemAlbum, []int{3}, mock.AnythingOfType("time.Time")).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:      "artist outside the allowed music folders",
+			user:      &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			artistIDs: []int{4},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetArtistByID(mock.Anything, 4).Return(domain.Artist{Id: 4, MusicFolderId: 1}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.NotFoundError{Message: "artist not found"},
+		},
+		{
+			name:    "unknown song",
+			user:    &domain.User{Username: "alice"},
+			songIDs: []int{9},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 9).Return(domain.Song{}, &ports.NotFoundError{Message: "song not found"})
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.NotFoundError{Message: "song not found"},
+		},
+		{
+			name:     "valid song with an unknown album",
+			user:     &domain.User{Username: "alice"},
+			songIDs:  []int{1},
+			albumIDs: []int{9},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 1).Return(domain.Song{Id: 1, MusicFolderId: 1}, nil)
+				m.EXPECT().GetAlbumByID(mock.Anything, 9).Return(domain.Album{}, &ports.NotFoundError{Message: "album not found"})
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.NotFoundError{Message: "album not found"},
+		},
+		{
+			name:             "no ids",
+			user:             &domain.User{Username: "alice"},
+			setupMock:        func(m *mocks.MockMediaBrowsingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.MissingOrInvalidParameterError{ParameterName: "id, albumId or artistId"},
+		},
+		{
+			name:             "no requesting user",
+			songIDs:          []int{1},
+			setupMock:        func(m *mocks.MockMediaBrowsingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.NotAuthorizedError{Action: "star"},
+		},
+		{
+			name:    "repository error",
+			user:    &domain.User{Username: "alice"},
+			songIDs: []int{1},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 1).Return(domain.Song{Id: 1, MusicFolderId: 1}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().StarItems(mock.Anything, "alice", domain.AnnotationItemSong, []int{1}, mock.AnythingOfType("time.Time")).Return(errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			annotations := mocks.NewMockMediaAnnotationRepository(t)
+			tt.setupAnnotations(annotations)
+			service := NewMediaAnnotationService(repo, annotations, mocks.NewMockNowPlayingRepository(t), slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			err := service.Star(ctx, tt.songIDs, tt.albumIDs, tt.artistIDs)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMediaAnnotationService_Unstar(t *testing.T) {
+	tests := []struct {
+		name             string
+		user             *domain.User
+		songIDs          []int
+		artistIDs        []int
+		setupAnnotations func(*mocks.MockMediaAnnotationRepository)
+		expectedError    error
+	}{
+		{
+			name:      "songs and artists",
+			user:      &domain.User{Username: "alice"},
+			songIDs:   []int{1},
+			artistIDs: []int{2, 3},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().UnstarItems(mock.Anything, "alice", domain.AnnotationItemSong, []int{1}).Return(nil)
+				m.EXPECT().UnstarItems(mock.Anything, "alice", domain.AnnotationItemArtist, []int{2, 3}).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "no ids",
+			user:             &domain.User{Username: "alice"},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.MissingOrInvalidParameterError{ParameterName: "id, albumId or artistId"},
+		},
+		{
+			name:             "no requesting user",
+			songIDs:          []int{1},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.NotAuthorizedError{Action: "unstar"},
+		},
+		{
+			name:    "repository error",
+			user:    &domain.User{Username: "alice"},
+			songIDs: []int{1},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().UnstarItems(mock.Anything, "alice", domain.AnnotationItemSong, []int{1}).Return(errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := mocks.NewMockMediaAnnotationRepository(t)
+			tt.setupAnnotations(annotations)
+			service := NewMediaAnnotationService(mocks.NewMockMediaBrowsingRepository(t), annotations, mocks.NewMockNowPlayingRepository(t), slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			err := service.Unstar(ctx, tt.songIDs, nil, tt.artistIDs)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMediaAnnotationService_SetRating(t *testing.T) {
+	tests := []struct {
+		name             string
+		user             *domain.User
+		itemType         domain.AnnotationItemType
+		id               int
+		rating           int
+		setupMock        func(*mocks.MockMediaBrowsingRepository)
+		setupAnnotations func(*mocks.MockMediaAnnotationRepository)
+		expectedError    error
+	}{
+		{
+			name:     "album rated",
+			user:     &domain.User{Username: "alice"},
+			itemType: domain.AnnotationItemAlbum,
+			id:       3,
+			rating:   4,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetAlbumByID(mock.Anything, 3).Return(domain.Album{Id: 3, MusicFolderId: 1}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().RateItem(mock.Anything, "alice", domain.AnnotationItemAlbum, 3, 4).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "rating removed",
+			user:     &domain.User{Username: "alice"},
+			itemType: domain.AnnotationItemSong,
+			id:       1,
+			rating:   0,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 1).Return(domain.Song{Id: 1, MusicFolderId: 1}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().RateItem(mock.Anything, "alice", domain.AnnotationItemSong, 1, 0).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "rating too high",
+			user:             &domain.User{Username: "alice"},
+			itemType:         domain.AnnotationItemSong,
+			id:               1,
+			rating:           domain.MaxRating + 1,
+			setupMock:        func(m *mocks.MockMediaBrowsingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.MissingOrInvalidParameterError{ParameterName: "rating"},
+		},
+		{
+			name:     "song outside the allowed music folders",
+			user:     &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			itemType: domain.AnnotationItemSong,
+			id:       1,
+			rating:   5,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 1).Return(domain.Song{Id: 1, MusicFolderId: 1}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.NotFoundError{Message: "song not found"},
+		},
+		{
+			name:             "no requesting user",
+			itemType:         domain.AnnotationItemSong,
+			id:               1,
+			rating:           3,
+			setupMock:        func(m *mocks.MockMediaBrowsingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.NotAuthorizedError{Action: "set rating"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			annotations := mocks.NewMockMediaAnnotationRepository(t)
+			tt.setupAnnotations(annotations)
+			service := NewMediaAnnotationService(repo, annotations, mocks.NewMockNowPlayingRepository(t), slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			err := service.SetRating(ctx, tt.itemType, tt.id, tt.rating)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMediaAnnotationService_GetStarred(t *testing.T) {
+	tests := []struct {
+		name             string
+		user             *domain.User
+		musicFolderID    int
+		setupAnnotations func(*mocks.MockMediaAnnotationRepository)
+		expectedStarred  domain.Starred
+		expectedError    error
+	}{
+		{
+			name: "starred items with their ratings",
+			user: &domain.User{Username: "alice"},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().GetStarredArtists(mock.Anything, "alice", []int(nil)).Return([]domain.Artist{
+					{Id: 1, Name: "Radiohead", Starred: "2024-01-01T00:00:00Z"},
+				}, nil)
+				m.EXPECT().GetStarredAlbums(mock.Anything, "alice", []int(nil), math.MaxInt32, 0).Return([]domain.Album{}, nil)
+				m.EXPECT().GetStarredSongs(mock.Anything, "alice", []int(nil)).Return([]domain.Song{
+					{Id: 2, Title: "Airbag", Starred: "2024-01-02T00:00:00Z"},
+				}, nil)
+				m.EXPECT().GetAnnotations(mock.Anything, "alice", domain.AnnotationItemArtist, []int{1}).Return(map[int]domain.Annotation{
+					1: {ItemId: 1, Starred: "2024-01-01T00:00:00Z", Rating: 5},
+				}, nil)
+				m.EXPECT().GetAnnotations(mock.Anything, "alice", domain.AnnotationItemSong, []int{2}).Return(map[int]domain.Annotation{
+					2: {ItemId: 2, Starred: "2024-01-02T00:00:00Z"},
+				}, nil)
+			},
+			expectedStarred: domain.Starred{
+				Artists: []domain.Artist{{Id: 1, Name: "Radiohead", Starred: "2024-01-01T00:00:00Z", UserRating: 5}},
+				Albums:  []domain.Album{},
+				Songs:   []domain.Song{{Id: 2, Title: "Airbag", Starred: "2024-01-02T00:00:00Z"}},
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "starred items of a music folder",
+			user:          &domain.User{Username: "kid", MusicfolderId: []string{"1", "2"}},
+			musicFolderID: 2,
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().GetStarredArtists(mock.Anything, "kid", []int{2}).Return([]domain.Artist{}, nil)
+				m.EXPECT().GetStarredAlbums(mock.Anything, "kid", []int{2}, math.MaxInt32, 0).Return([]domain.Album{}, nil)
+				m.EXPECT().GetStarredSongs(mock.Anything, "kid", []int{2}).Return([]domain.Song{}, nil)
+			},
+			expectedStarred: domain.Starred{Artists: []domain.Artist{}, Albums: []domain.Album{}, Songs: []domain.Song{}},
+			expectedError:   nil,
+		},
+		{
+			name:             "music folder not allowed",
+			user:             &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			musicFolderID:    1,
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.NotFoundError{Message: "music folder not found"},
+		},
+		{
+			name:             "no requesting user",
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.NotAuthorizedError{Action: "get starred"},
+		},
+		{
+			name: "repository error",
+			user: &domain.User{Username: "alice"},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().GetStarredArtists(mock.Anything, "alice", []int(nil)).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := mocks.NewMockMediaAnnotationRepository(t)
+			tt.setupAnnotations(annotations)
+			service := NewMediaAnnotationService(mocks.NewMockMediaBrowsingRepository(t), annotations, mocks.NewMockNowPlayingRepository(t), slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetStarred(ctx, tt.musicFolderID)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(result, tt.expectedStarred) {
+					t.Errorf("expected starred %+v, got %+v", tt.expectedStarred, result)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaAnnotationService_Scrobble(t *testing.T) {
+	played := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name             string
+		user             *domain.User
+		songIDs          []int
+		times            []time.Time
+		submission       bool
+		setupMock        func(*mocks.MockMediaBrowsingRepository)
+		setupAnnotations func(*mocks.MockMediaAnnotationRepository)
+		setupNowPlaying  func(*mocks.MockNowPlayingRepository)
+		expectedError    error
+	}{
+		{
+			name:       "submissions at the given times",
+			user:       &domain.User{Username: "alice"},
+			songIDs:    []int{1, 2},
+			times:      []time.Time{played, played.Add(4 * time.Minute)},
+			submission: true,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 1).Return(domain.Song{Id: 1, AlbumId: 3, MusicFolderId: 1}, nil)
+				m.EXPECT().GetSongByID(mock.Anything, 2).Return(domain.Song{Id: 2, AlbumId: 3, MusicFolderId: 1}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().RecordPlay(mock.Anything, "alice", 1, 3, played).Return(nil)
+				m.EXPECT().RecordPlay(mock.Anything, "alice", 2, 3, played.Add(4*time.Minute)).Return(nil)
+			},
+			setupNowPlaying: func(m *mocks.MockNowPlayingRepository) {},
+			expectedError:   nil,
+		},
+		{
+			name:       "submission without time played now",
+			user:       &domain.User{Username: "alice"},
+			songIDs:    []int{1},
+			submission: true,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 1).Return(domain.Song{Id: 1, AlbumId: 3, MusicFolderId: 1}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().RecordPlay(mock.Anything, "alice", 1, 3, mock.AnythingOfType("time.Time")).Return(nil)
+			},
+			setupNowPlaying: func(m *mocks.MockNowPlayingRepository) {},
+			expectedError:   nil,
+		},
+		{
+			name:       "submission recorded with scrobbling disabled",
+			user:       &domain.User{Username: "bob", ScrobblingEnabled: false},
+			songIDs:    []int{1},
+			submission: true,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 1).Return(domain.Song{Id: 1, AlbumId: 3, MusicFolderId: 1}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().RecordPlay(mock.Anything, "bob", 1, 3, mock.AnythingOfType("time.Time")).Return(nil)
+			},
+			setupNowPlaying: func(m *mocks.MockNowPlayingRepository) {},
+			expectedError:   nil,
+		},
+		{
+			name:    "now playing notification",
+			user:    &domain.User{Username: "bob"},
+			songIDs: []int{1},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 1).Return(domain.Song{Id: 1, AlbumId: 3, MusicFolderId: 1}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			setupNowPlaying: func(m *mocks.MockNowPlayingRepository) {
+				m.EXPECT().SetNowPlaying(mock.Anything, mock.MatchedBy(func(entry domain.NowPlaying) bool {
+					return entry.Song.Id == 1 && entry.Username == "bob" && entry.PlayerName == "DSub"
+				})).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:       "song outside the allowed music folders",
+			user:       &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			songIDs:    []int{1},
+			submission: true,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 1).Return(domain.Song{Id: 1, AlbumId: 3, MusicFolderId: 1}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			setupNowPlaying:  func(m *mocks.MockNowPlayingRepository) {},
+			expectedError:    &ports.NotFoundError{Message: "song not found"},
+		},
+		{
+			name:             "times not matching ids",
+			user:             &domain.User{Username: "alice"},
+			songIDs:          []int{1, 2},
+			times:            []time.Time{played},
+			submission:       true,
+			setupMock:        func(m *mocks.MockMediaBrowsingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			setupNowPlaying:  func(m *mocks.MockNowPlayingRepository) {},
+			expectedError:    &ports.MissingOrInvalidParameterError{ParameterName: "time"},
+		},
+		{
+			name:             "no ids",
+			user:             &domain.User{Username: "alice"},
+			setupMock:        func(m *mocks.MockMediaBrowsingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			setupNowPlaying:  func(m *mocks.MockNowPlayingRepository) {},
+			expectedError:    &ports.MissingOrInvalidParameterError{ParameterName: "id"},
+		},
+		{
+			name:             "no requesting user",
+			songIDs:          []int{1},
+			setupMock:        func(m *mocks.MockMediaBrowsingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			setupNowPlaying:  func(m *mocks.MockNowPlayingRepository) {},
+			expectedError:    &ports.NotAuthorizedError{Action: "scrobble"},
+		},
+		{
+			name:       "repository error",
+			user:       &domain.User{Username: "alice"},
+			songIDs:    []int{1},
+			submission: true,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 1).Return(domain.Song{Id: 1, AlbumId: 3, MusicFolderId: 1}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().RecordPlay(mock.Anything, "alice", 1, 3, mock.AnythingOfType("time.Time")).Return(errors.New("database error"))
+			},
+			setupNowPlaying: func(m *mocks.MockNowPlayingRepository) {},
+			expectedError:   errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			annotations := mocks.NewMockMediaAnnotationRepository(t)
+			tt.setupAnnotations(annotations)
+			nowPlaying := mocks.NewMockNowPlayingRepository(t)
+			tt.setupNowPlaying(nowPlaying)
+			service := NewMediaAnnotationService(repo, annotations, nowPlaying, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+			ctx = context.WithValue(ctx, ports.KeyPlayerName, "DSub")
+
+			err := service.Scrobble(ctx, tt.songIDs, tt.times, tt.submission)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMediaAnnotationService_GetNowPlaying(t *testing.T) {
+	started := time.Now().Add(-2 * time.Minute)
+	tests := []struct {
+		name             string
+		user             *domain.User
+		setupNowPlaying  func(*mocks.MockNowPlayingRepository)
+		setupAnnotations func(*mocks.MockMediaAnnotationRepository)
+		expectedPlaying  []domain.NowPlaying
+		expectedError    error
+	}{
+		{
+			name: "playing songs annotated",
+			user: &domain.User{Username: "alice"},
+			setupNowPlaying: func(m *mocks.MockNowPlayingRepository) {
+				m.EXPECT().GetNowPlaying(mock.Anything).Return([]domain.NowPlaying{
+					{Song: domain.Song{Id: 1, Duration: 300, MusicFolderId: 1}, Username: "bob", PlayerName: "DSub", Started: started},
+				}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().GetAnnotations(mock.Anything, "alice", domain.AnnotationItemSong, []int{1}).Return(map[int]domain.Annotation{
+					1: {ItemId: 1, Rating: 4},
+				}, nil)
+			},
+			expectedPlaying: []domain.NowPlaying{
+				{Song: domain.Song{Id: 1, Duration: 300, MusicFolderId: 1, UserRating: 4}, Username: "bob", PlayerName: "DSub", Started: started},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "finished and hidden songs left out",
+			user: &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			setupNowPlaying: func(m *mocks.MockNowPlayingRepository) {
+				m.EXPECT().GetNowPlaying(mock.Anything).Return([]domain.NowPlaying{
+					{Song: domain.Song{Id: 1, Duration: 300, MusicFolderId: 1}, Username: "bob", Started: started},
+					{Song: domain.Song{Id: 2, Duration: 30, MusicFolderId: 2}, Username: "bob", Started: started.Add(-time.Hour)},
+				}, nil)
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedPlaying:  []domain.NowPlaying{},
+			expectedError:    nil,
+		},
+		{
+			name:             "no requesting user",
+			setupNowPlaying:  func(m *mocks.MockNowPlayingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    &ports.NotAuthorizedError{Action: "get now playing"},
+		},
+		{
+			name: "repository error",
+			user: &domain.User{Username: "alice"},
+			setupNowPlaying: func(m *mocks.MockNowPlayingRepository) {
+				m.EXPECT().GetNowPlaying(mock.Anything).Return(nil, errors.New("registry error"))
+			},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {},
+			expectedError:    errors.New("registry error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := mocks.NewMockMediaAnnotationRepository(t)
+			tt.setupAnnotations(annotations)
+			nowPlaying := mocks.NewMockNowPlayingRepository(t)
+			tt.setupNowPlaying(nowPlaying)
+			service := NewMediaAnnotationService(mocks.NewMockMediaBrowsingRepository(t), annotations, nowPlaying, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetNowPlaying(ctx)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(result, tt.expectedPlaying) {
+					t.Errorf("expected %v, got %v", tt.expectedPlaying, result)
+				}
+			}
+		})
+	}
+}
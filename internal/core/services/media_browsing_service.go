@@ -1,24 +1,204 @@
 package services
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"log/slog"
+	"music-streaming/internal/core/config"
 	"music-streaming/internal/core/domain"
 	"music-streaming/internal/core/ports"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// maxSongsByGenreCount bounds the number of songs of a genre returned at once.
+const maxSongsByGenreCount = 500
+
+// maxAlbumListSize bounds the number of albums of an album list returned at once.
+const maxAlbumListSize = 500
+
+// maxSearchCount bounds the number of artists, albums or songs of a search returned at once.
+const maxSearchCount = 500
+
 type MediaBrowsingService struct {
 	mediaBrowsingRepo ports.MediaBrowsingRepository
+	annotationRepo    ports.MediaAnnotationRepository
+	config            *config.Config
 	logger            *slog.Logger
 }
 
-func NewMediaBrowsingService(repo ports.MediaBrowsingRepository, logger *slog.Logger) *MediaBrowsingService {
+func NewMediaBrowsingService(repo ports.MediaBrowsingRepository, annotationRepo ports.MediaAnnotationRepository, config *config.Config, logger *slog.Logger) *MediaBrowsingService {
 	return &MediaBrowsingService{
 		mediaBrowsingRepo: repo,
+		annotationRepo:    annotationRepo,
+		config:            config,
 		logger:            logger,
 	}
 }
 
+func (s *MediaBrowsingService) GetMusicFolders(ctx context.Context) ([]domain.MusicFolder, error) {
+	s.logger.Info("Getting music folders")
+	folders, err := s.configuredMusicFolders(ctx)
+	if err != nil {
+		s.logger.Error("Failed to get music folders", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	allowed := make([]domain.MusicFolder, 0, len(folders))
+	for _, folder := range folders {
+		if canAccessMusicFolder(ctx, folder.Id) {
+			allowed = append(allowed, folder)
+		}
+	}
+	s.logger.Info("Successfully retrieved music folders", slog.Int("count", len(allowed)))
+	return allowed, nil
+}
+
+func (s *MediaBrowsingService) GetIndexes(ctx context.Context, musicFolderID int, ifModifiedSince int64) (domain.Indexes, error) {
+	s.logger.Info("Getting indexes", slog.Int("musicFolderId", musicFolderID), slog.Int64("ifModifiedSince", ifModifiedSince))
+	if musicFolderID != 0 && !canAccessMusicFolder(ctx, musicFolderID) {
+		s.logger.Warn("Music folder not allowed", slog.Int("musicFolderId", musicFolderID))
+		return domain.Indexes{}, &ports.NotFoundError{Message: "music folder not found"}
+	}
+	folders, err := s.configuredMusicFolders(ctx)
+	if err != nil {
+		s.logger.Error("Failed to get indexes", slog.String("error", err.Error()))
+		return domain.Indexes{}, err
+	}
+	if musicFolderID != 0 && !slices.ContainsFunc(folders, func(folder domain.MusicFolder) bool { return folder.Id == musicFolderID }) {
+		s.logger.Warn("Music folder not configured", slog.Int("musicFolderId", musicFolderID))
+		return domain.Indexes{}, &ports.NotFoundError{Message: "music folder not found"}
+	}
+
+	indexes := domain.Indexes{IgnoredArticles: s.ignoredArticles()}
+	var rootIDs []int
+	for _, folder := range folders {
+		if !canAccessMusicFolder(ctx, folder.Id) || musicFolderID != 0 && folder.Id != musicFolderID {
+			continue
+		}
+		root, err := s.mediaBrowsingRepo.GetDirectoryByPath(ctx, folder.Path)
+		var notFoundErr *ports.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			// Not scanned yet
+			continue
+		}
+		if err != nil {
+			s.logger.Error("Failed to get indexes", slog.String("path", folder.Path), slog.String("error", err.Error()))
+			return domain.Indexes{}, err
+		}
+		rootIDs = append(rootIDs, root.Id)
+		indexes.LastModified = max(indexes.LastModified, root.Updated)
+	}
+	if len(rootIDs) == 0 {
+		s.logger.Info("Successfully retrieved indexes", slog.Int("count", 0))
+		return indexes, nil
+	}
+
+	directories, err := s.mediaBrowsingRepo.GetSubdirectories(ctx, rootIDs)
+	if err != nil {
+		s.logger.Error("Failed to get indexes", slog.String("error", err.Error()))
+		return domain.Indexes{}, err
+	}
+	for _, directory := range directories {
+		indexes.LastModified = max(indexes.LastModified, directory.Updated)
+	}
+	if ifModifiedSince > 0 && indexes.LastModified <= ifModifiedSince {
+		s.logger.Info("Indexes not modified", slog.Int64("lastModified", indexes.LastModified))
+		return indexes, nil
+	}
+
+	indexes.Index = indexDirectories(directories, indexes.IgnoredArticles)
+	for _, rootID := range rootIDs {
+		songs, err := s.mediaBrowsingRepo.GetSongsByDirectory(ctx, rootID)
+		if err != nil {
+			s.logger.Error("Failed to get indexes", slog.Int("directoryId", rootID), slog.String("error", err.Error()))
+			return domain.Indexes{}, err
+		}
+		indexes.Songs = append(indexes.Songs, songs...)
+	}
+	if err := annotateSongs(ctx, s.annotationRepo, indexes.Songs); err != nil {
+		s.logger.Error("Failed to get annotations", slog.String("error", err.Error()))
+		return domain.Indexes{}, err
+	}
+	s.logger.Info("Successfully retrieved indexes", slog.Int("count", len(directories)))
+	return indexes, nil
+}
+
+func (s *MediaBrowsingService) GetMusicDirectory(ctx context.Context, id int) (domain.Directory, error) {
+	s.logger.Info("Getting music directory", slog.Int("id", id))
+	directory, err := s.mediaBrowsingRepo.GetDirectory(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get music directory", slog.Int("id", id), slog.String("error", err.Error()))
+		return directory, err
+	}
+	if !canAccessMusicFolder(ctx, directory.MusicFolderId) {
+		s.logger.Warn("Directory outside the allowed music folders", slog.Int("id", id))
+		return domain.Directory{}, &ports.NotFoundError{Message: "directory not found"}
+	}
+
+	subdirectories, err := s.mediaBrowsingRepo.GetSubdirectories(ctx, []int{id})
+	if err != nil {
+		s.logger.Error("Failed to get subdirectories", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Directory{}, err
+	}
+	songs, err := s.mediaBrowsingRepo.GetSongsByDirectory(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get directory songs", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Directory{}, err
+	}
+	if err := annotateSongs(ctx, s.annotationRepo, songs); err != nil {
+		s.logger.Error("Failed to get annotations", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Directory{}, err
+	}
+	directory.Children = make([]domain.Song, 0, len(subdirectories)+len(songs))
+	for _, subdirectory := range subdirectories {
+		directory.Children = append(directory.Children, directoryChild(subdirectory))
+	}
+	directory.Children = append(directory.Children, songs...)
+	s.logger.Info("Successfully retrieved music directory", slog.Int("id", id), slog.String("name", directory.Name))
+	return directory, nil
+}
+
+func (s *MediaBrowsingService) GetArtists(ctx context.Context, musicFolderID int) (domain.ArtistIndexes, error) {
+	s.logger.Info("Getting artists", slog.Int("musicFolderId", musicFolderID))
+	musicFolderIDs := allowedMusicFolders(ctx)
+	if musicFolderID != 0 {
+		if !canAccessMusicFolder(ctx, musicFolderID) {
+			s.logger.Warn("Music folder not allowed", slog.Int("musicFolderId", musicFolderID))
+			return domain.ArtistIndexes{}, &ports.NotFoundError{Message: "music folder not found"}
+		}
+		musicFolderIDs = []int{musicFolderID}
+	}
+
+	artists, err := s.mediaBrowsingRepo.GetArtists(ctx, musicFolderIDs)
+	if err != nil {
+		s.logger.Error("Failed to get artists", slog.String("error", err.Error()))
+		return domain.ArtistIndexes{}, err
+	}
+	if err := annotateArtists(ctx, s.annotationRepo, artists); err != nil {
+		s.logger.Error("Failed to get annotations", slog.String("error", err.Error()))
+		return domain.ArtistIndexes{}, err
+	}
+
+	articles := s.ignoredArticles()
+	indexes := domain.ArtistIndexes{IgnoredArticles: articles}
+	groups := groupByIndex(artists, func(artist domain.Artist) string {
+		if artist.SortName != "" {
+			return artist.SortName
+		}
+		return sortNameIgnoringArticles(artist.Name, articles)
+	})
+	for _, group := range groups {
+		indexes.Index = append(indexes.Index, domain.ArtistIndex{Name: group.name, Artists: group.items})
+	}
+	s.logger.Info("Successfully retrieved artists", slog.Int("count", len(artists)))
+	return indexes, nil
+}
+
 func (s *MediaBrowsingService) GetArtist(ctx context.Context, id int) (domain.Artist, error) {
 	s.logger.Info("Getting artist", slog.Int("id", id))
 	artist, err := s.mediaBrowsingRepo.GetArtistByID(ctx, id)
@@ -26,6 +206,25 @@ func (s *MediaBrowsingService) GetArtist(ctx context.Context, id int) (domain.Ar
 		s.logger.Error("Failed to get artist", slog.Int("id", id), slog.String("error", err.Error()))
 		return artist, err
 	}
+	if !canAccessMusicFolder(ctx, artist.MusicFolderId) {
+		s.logger.Warn("Artist outside the allowed music folders", slog.Int("id", id))
+		return domain.Artist{}, &ports.NotFoundError{Message: "artist not found"}
+	}
+	artist.Albums, err = s.mediaBrowsingRepo.GetAlbumsByArtistID(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get artist albums", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Artist{}, err
+	}
+	artists := []domain.Artist{artist}
+	if err := annotateArtists(ctx, s.annotationRepo, artists); err != nil {
+		s.logger.Error("Failed to get annotations", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Artist{}, err
+	}
+	artist = artists[0]
+	if err := annotateAlbums(ctx, s.annotationRepo, artist.Albums); err != nil {
+		s.logger.Error("Failed to get annotations", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Artist{}, err
+	}
 	s.logger.Info("Successfully retrieved artist", slog.Int("id", id), slog.String("name", artist.Name))
 	return artist, err
 }
@@ -37,6 +236,25 @@ func (s *MediaBrowsingService) GetAlbum(ctx context.Context, id int) (domain.Alb
 		s.logger.Error("Failed to get album", slog.Int("id", id), slog.String("error", err.Error()))
 		return album, err
 	}
+	if !canAccessMusicFolder(ctx, album.MusicFolderId) {
+		s.logger.Warn("Album outside the allowed music folders", slog.Int("id", id))
+		return domain.Album{}, &ports.NotFoundError{Message: "album not found"}
+	}
+	album.Songs, err = s.mediaBrowsingRepo.GetSongsByAlbumID(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get album songs", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Album{}, err
+	}
+	albums := []domain.Album{album}
+	if err := annotateAlbums(ctx, s.annotationRepo, albums); err != nil {
+		s.logger.Error("Failed to get annotations", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Album{}, err
+	}
+	album = albums[0]
+	if err := annotateSongs(ctx, s.annotationRepo, album.Songs); err != nil {
+		s.logger.Error("Failed to get annotations", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Album{}, err
+	}
 	s.logger.Info("Successfully retrieved album", slog.Int("id", id), slog.String("name", album.Name))
 	return album, err
 }
@@ -48,6 +266,16 @@ func (s *MediaBrowsingService) GetSong(ctx context.Context, id int) (domain.Song
 		s.logger.Error("Failed to get song", slog.Int("id", id), slog.String("error", err.Error()))
 		return song, err
 	}
+	if !canAccessMusicFolder(ctx, song.MusicFolderId) {
+		s.logger.Warn("Song outside the allowed music folders", slog.Int("id", id))
+		return domain.Song{}, &ports.NotFoundError{Message: "song not found"}
+	}
+	songs := []domain.Song{song}
+	if err := annotateSongs(ctx, s.annotationRepo, songs); err != nil {
+		s.logger.Error("Failed to get annotations", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Song{}, err
+	}
+	song = songs[0]
 	s.logger.Info("Successfully retrieved song", slog.Int("id", id), slog.String("title", song.Title))
 	return song, err
 }
@@ -62,3 +290,271 @@ func (s *MediaBrowsingService) GetCover(ctx context.Context, id string) (domain.
 	s.logger.Info("Successfully retrieved cover", slog.String("id", id))
 	return cover, err
 }
+
+func (s *MediaBrowsingService) GetGenres(ctx context.Context) ([]domain.Genre, error) {
+	s.logger.Info("Getting genres")
+	genres, err := s.mediaBrowsingRepo.GetGenres(ctx, allowedMusicFolders(ctx))
+	if err != nil {
+		s.logger.Error("Failed to get genres", slog.String("error", err.Error()))
+		return nil, err
+	}
+	s.logger.Info("Successfully retrieved genres", slog.Int("count", len(genres)))
+	return genres, nil
+}
+
+func (s *MediaBrowsingService) GetSongsByGenre(ctx context.Context, genre string, count int, offset int, musicFolderID int) ([]domain.Song, error) {
+	s.logger.Info("Getting songs by genre", slog.String("genre", genre), slog.Int("count", count), slog.Int("offset", offset), slog.Int("musicFolderId", musicFolderID))
+	if genre == "" {
+		return nil, &ports.MissingOrInvalidParameterError{ParameterName: "genre"}
+	}
+	if count <= 0 || count > maxSongsByGenreCount || offset < 0 {
+		return nil, &ports.MissingOrInvalidParameterError{ParameterName: "count or offset"}
+	}
+	musicFolderIDs := allowedMusicFolders(ctx)
+	if musicFolderID != 0 {
+		if !canAccessMusicFolder(ctx, musicFolderID) {
+			s.logger.Warn("Music folder not allowed", slog.Int("musicFolderId", musicFolderID))
+			return nil, &ports.NotFoundError{Message: "music folder not found"}
+		}
+		musicFolderIDs = []int{musicFolderID}
+	}
+
+	songs, err := s.mediaBrowsingRepo.GetSongsByGenre(ctx, genre, musicFolderIDs, count, offset)
+	if err != nil {
+		s.logger.Error("Failed to get songs by genre", slog.String("genre", genre), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if err := annotateSongs(ctx, s.annotationRepo, songs); err != nil {
+		s.logger.Error("Failed to get annotations", slog.String("genre", genre), slog.String("error", err.Error()))
+		return nil, err
+	}
+	s.logger.Info("Successfully retrieved songs by genre", slog.String("genre", genre), slog.Int("count", len(songs)))
+	return songs, nil
+}
+
+func (s *MediaBrowsingService) GetAlbumList(ctx context.Context, query domain.AlbumListQuery) ([]domain.Album, error) {
+	s.logger.Info("Getting album list", slog.String("type", string(query.Type)), slog.Int("size", query.Size), slog.Int("offset", query.Offset), slog.Int("musicFolderId", query.MusicFolderId))
+	switch query.Type {
+	case domain.AlbumListRandom, domain.AlbumListNewest, domain.AlbumListHighest, domain.AlbumListFrequent, domain.AlbumListRecent,
+		domain.AlbumListAlphabeticalByName, domain.AlbumListAlphabeticalByArtist, domain.AlbumListStarred, domain.AlbumListByYear:
+	case domain.AlbumListByGenre:
+		if query.Genre == "" {
+			return nil, &ports.MissingOrInvalidParameterError{ParameterName: "genre"}
+		}
+	default:
+		return nil, &ports.MissingOrInvalidParameterError{ParameterName: "type"}
+	}
+	if query.Size <= 0 || query.Size > maxAlbumListSize || query.Offset < 0 {
+		return nil, &ports.MissingOrInvalidParameterError{ParameterName: "size or offset"}
+	}
+	musicFolderIDs := allowedMusicFolders(ctx)
+	if query.MusicFolderId != 0 {
+		if !canAccessMusicFolder(ctx, query.MusicFolderId) {
+			s.logger.Warn("Music folder not allowed", slog.Int("musicFolderId", query.MusicFolderId))
+			return nil, &ports.NotFoundError{Message: "music folder not found"}
+		}
+		musicFolderIDs = []int{query.MusicFolderId}
+	}
+
+	var (
+		albums []domain.Album
+		err    error
+	)
+	switch query.Type {
+	case domain.AlbumListFrequent:
+		albums, err = s.annotationRepo.GetMostPlayedAlbums(ctx, requestingUsername(ctx), musicFolderIDs, query.Size, query.Offset)
+	case domain.AlbumListRecent:
+		albums, err = s.annotationRepo.GetRecentlyPlayedAlbums(ctx, requestingUsername(ctx), musicFolderIDs, query.Size, query.Offset)
+	case domain.AlbumListHighest:
+		albums, err = s.annotationRepo.GetHighestRatedAlbums(ctx, requestingUsername(ctx), musicFolderIDs, query.Size, query.Offset)
+	case domain.AlbumListStarred:
+		albums, err = s.annotationRepo.GetStarredAlbums(ctx, requestingUsername(ctx), musicFolderIDs, query.Size, query.Offset)
+	default:
+		albums, err = s.mediaBrowsingRepo.GetAlbumList(ctx, query, musicFolderIDs)
+	}
+	if err != nil {
+		s.logger.Error("Failed to get album list", slog.String("type", string(query.Type)), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if err := annotateAlbums(ctx, s.annotationRepo, albums); err != nil {
+		s.logger.Error("Failed to get annotations", slog.String("type", string(query.Type)), slog.String("error", err.Error()))
+		return nil, err
+	}
+	s.logger.Info("Successfully retrieved album list", slog.String("type", string(query.Type)), slog.Int("count", len(albums)))
+	return albums, nil
+}
+
+func (s *MediaBrowsingService) Search(ctx context.Context, query domain.SearchQuery) (domain.SearchResult, error) {
+	s.logger.Info("Searching", slog.String("query", query.Query), slog.Int("musicFolderId", query.MusicFolderId))
+	for _, page := range [][2]int{{query.ArtistCount, query.ArtistOffset}, {query.AlbumCount, query.AlbumOffset}, {query.SongCount, query.SongOffset}} {
+		if page[0] < 0 || page[0] > maxSearchCount || page[1] < 0 {
+			return domain.SearchResult{}, &ports.MissingOrInvalidParameterError{ParameterName: "count or offset"}
+		}
+	}
+	musicFolderIDs := allowedMusicFolders(ctx)
+	if query.MusicFolderId != 0 {
+		if !canAccessMusicFolder(ctx, query.MusicFolderId) {
+			s.logger.Warn("Music folder not allowed", slog.Int("musicFolderId", query.MusicFolderId))
+			return domain.SearchResult{}, &ports.NotFoundError{Message: "music folder not found"}
+		}
+		musicFolderIDs = []int{query.MusicFolderId}
+	}
+	// some clients quote the query, an empty quoted query lists everything
+	terms := strings.TrimSpace(strings.Trim(strings.TrimSpace(query.Query), `"`))
+
+	result := domain.SearchResult{Artists: []domain.Artist{}, Albums: []domain.Album{}, Songs: []domain.Song{}}
+	var err error
+	if query.ArtistCount > 0 {
+		result.Artists, err = s.mediaBrowsingRepo.SearchArtists(ctx, terms, musicFolderIDs, query.ArtistCount, query.ArtistOffset)
+		if err != nil {
+			s.logger.Error("Failed to search artists", slog.String("query", terms), slog.String("error", err.Error()))
+			return domain.SearchResult{}, err
+		}
+	}
+	if query.AlbumCount > 0 {
+		result.Albums, err = s.mediaBrowsingRepo.SearchAlbums(ctx, terms, musicFolderIDs, query.AlbumCount, query.AlbumOffset)
+		if err != nil {
+			s.logger.Error("Failed to search albums", slog.String("query", terms), slog.String("error", err.Error()))
+			return domain.SearchResult{}, err
+		}
+	}
+	if query.SongCount > 0 {
+		result.Songs, err = s.mediaBrowsingRepo.SearchSongs(ctx, terms, musicFolderIDs, query.SongCount, query.SongOffset)
+		if err != nil {
+			s.logger.Error("Failed to search songs", slog.String("query", terms), slog.String("error", err.Error()))
+			return domain.SearchResult{}, err
+		}
+	}
+	if err := annotateArtists(ctx, s.annotationRepo, result.Artists); err != nil {
+		s.logger.Error("Failed to get annotations", slog.String("query", terms), slog.String("error", err.Error()))
+		return domain.SearchResult{}, err
+	}
+	if err := annotateAlbums(ctx, s.annotationRepo, result.Albums); err != nil {
+		s.logger.Error("Failed to get annotations", slog.String("query", terms), slog.String("error", err.Error()))
+		return domain.SearchResult{}, err
+	}
+	if err := annotateSongs(ctx, s.annotationRepo, result.Songs); err != nil {
+		s.logger.Error("Failed to get annotations", slog.String("query", terms), slog.String("error", err.Error()))
+		return domain.SearchResult{}, err
+	}
+	s.logger.Info("Successfully searched", slog.String("query", terms), slog.Int("artists", len(result.Artists)), slog.Int("albums", len(result.Albums)), slog.Int("songs", len(result.Songs)))
+	return result, nil
+}
+
+func (s *MediaBrowsingService) musicDirectories() []string {
+	if s.config == nil {
+		return nil
+	}
+	return s.config.MusicDirectories
+}
+
+// configuredMusicFolders retrieves the stored music folders of the configured music directories, in configuration order.
+// Folders are stored when the scanning service starts and at every scan, those removed from the configuration are left out.
+func (s *MediaBrowsingService) configuredMusicFolders(ctx context.Context) ([]domain.MusicFolder, error) {
+	stored, err := s.mediaBrowsingRepo.GetMusicFolders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	folders := make([]domain.MusicFolder, 0, len(s.musicDirectories()))
+	for _, path := range s.musicDirectories() {
+		if i := slices.IndexFunc(stored, func(folder domain.MusicFolder) bool { return folder.Path == path }); i >= 0 {
+			folders = append(folders, stored[i])
+		}
+	}
+	return folders, nil
+}
+
+func (s *MediaBrowsingService) ignoredArticles() []string {
+	if s.config == nil || len(s.config.IgnoredArticles) == 0 {
+		return defaultIgnoredArticles
+	}
+	return s.config.IgnoredArticles
+}
+
+// indexGroup holds the items listed under an index.
+type indexGroup[T any] struct {
+	name  string
+	items []T
+}
+
+// groupByIndex groups items under the uppercased first letter of their sort name, or "#" for sort names
+// not starting with a letter. Groups and their items are ordered by sort name, ignoring case.
+func groupByIndex[T any](items []T, sortName func(T) string) []indexGroup[T] {
+	type indexed struct {
+		item     T
+		index    string
+		sortName string
+	}
+	entries := make([]indexed, 0, len(items))
+	for _, item := range items {
+		name := sortName(item)
+		entries = append(entries, indexed{item: item, index: indexName(name), sortName: strings.ToLower(name)})
+	}
+	slices.SortStableFunc(entries, func(a, b indexed) int {
+		return cmp.Or(strings.Compare(a.index, b.index), strings.Compare(a.sortName, b.sortName))
+	})
+
+	var groups []indexGroup[T]
+	for _, entry := range entries {
+		if len(groups) == 0 || groups[len(groups)-1].name != entry.index {
+			groups = append(groups, indexGroup[T]{name: entry.index})
+		}
+		last := &groups[len(groups)-1]
+		last.items = append(last.items, entry.item)
+	}
+	return groups
+}
+
+// indexDirectories groups directories by the first letter of their name with the leading article ignored.
+func indexDirectories(directories []domain.Directory, articles []string) []domain.Index {
+	var indexes []domain.Index
+	for _, group := range groupByIndex(directories, func(directory domain.Directory) string {
+		return sortNameIgnoringArticles(directory.Name, articles)
+	}) {
+		indexes = append(indexes, domain.Index{Name: group.name, Directories: group.items})
+	}
+	return indexes
+}
+
+// indexName returns the uppercased first letter of name, or "#" when name does not start with a letter.
+func indexName(name string) string {
+	if r, _ := utf8.DecodeRuneInString(name); unicode.IsLetter(r) {
+		return string(unicode.ToUpper(r))
+	}
+	return "#"
+}
+
+// directoryChild represents a subdirectory among the children of its parent, as Subsonic clients expect.
+// Its ID is the directory ID, IsDir telling it apart from the ID of a song.
+func directoryChild(directory domain.Directory) domain.Song {
+	return domain.Song{
+		Id:            directory.Id,
+		Title:         directory.Name,
+		IsDir:         true,
+		Created:       directory.Created,
+		MusicFolderId: directory.MusicFolderId,
+		DirectoryId:   directory.ParentId,
+	}
+}
+
+// allowedMusicFolders returns the IDs of the music folders the requesting user may access,
+// nil when they may access every music folder as users without music folders set do.
+func allowedMusicFolders(ctx context.Context) []int {
+	requestingUser, ok := ctx.Value(ports.KeyRequestingUserID).(*domain.User)
+	if !ok || requestingUser == nil || len(requestingUser.MusicfolderId) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(requestingUser.MusicfolderId))
+	for _, value := range requestingUser.MusicfolderId {
+		if id, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// canAccessMusicFolder reports whether the requesting user may access the content of a music folder.
+func canAccessMusicFolder(ctx context.Context, musicFolderID int) bool {
+	ids := allowedMusicFolders(ctx)
+	return ids == nil || slices.Contains(ids, musicFolderID)
+}
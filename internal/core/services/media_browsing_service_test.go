@@ -2,19 +2,427 @@ package services
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"music-streaming/internal/core/config"
 	"music-streaming/internal/core/domain"
 	"music-streaming/internal/core/ports"
 	"music-streaming/internal/core/services/mocks"
+	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
 )
 
+func TestMediaBrowsingService_GetMusicFolders(t *testing.T) {
+	musicDirectories := []string{"/music/adults", "/music/kids"}
+	folders := []domain.MusicFolder{
+		{Id: 1, Name: "adults", Path: "/music/adults"},
+		{Id: 2, Name: "kids", Path: "/music/kids"},
+	}
+
+	tests := []struct {
+		name            string
+		user            *domain.User
+		setupMock       func(*mocks.MockMediaBrowsingRepository)
+		expectedFolders []domain.MusicFolder
+		expectedError   error
+	}{
+		{
+			name: "user allowed every music folder",
+			user: &domain.User{Username: "parent"},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetMusicFolders(mock.Anything).Return(folders, nil)
+			},
+			expectedFolders: folders,
+			expectedError:   nil,
+		},
+		{
+			name: "user restricted to music folders",
+			user: &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetMusicFolders(mock.Anything).Return(folders, nil)
+			},
+			expectedFolders: folders[1:],
+			expectedError:   nil,
+		},
+		{
+			name: "music folders removed from the configuration or not stored yet left out",
+			user: &domain.User{Username: "parent"},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetMusicFolders(mock.Anything).Return([]domain.MusicFolder{
+					{Id: 1, Name: "adults", Path: "/music/adults"},
+					{Id: 3, Name: "old", Path: "/music/old"},
+				}, nil)
+			},
+			expectedFolders: folders[:1],
+			expectedError:   nil,
+		},
+		{
+			name: "repository error",
+			user: &domain.User{Username: "parent"},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetMusicFolders(mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			service := NewMediaBrowsingService(repo, unannotatedRepository(t), &config.Config{MusicDirectories: musicDirectories}, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetMusicFolders(ctx)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !slices.Equal(result, tt.expectedFolders) {
+					t.Errorf("expected music folders %+v, got %+v", tt.expectedFolders, result)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaBrowsingService_GetIndexes(t *testing.T) {
+	musicDirectories := []string{"/music/adults", "/music/kids"}
+	folders := []domain.MusicFolder{
+		{Id: 1, Name: "adults", Path: "/music/adults"},
+		{Id: 2, Name: "kids", Path: "/music/kids"},
+	}
+	adults := domain.Directory{Id: 10, MusicFolderId: 1, Path: "/music/adults", Name: "adults", Updated: 1000}
+	kids := domain.Directory{Id: 20, MusicFolderId: 2, Path: "/music/kids", Name: "kids", Updated: 1000}
+	beatles := domain.Directory{Id: 11, ParentId: 10, MusicFolderId: 1, Name: "The Beatles", Updated: 3000}
+	abba := domain.Directory{Id: 12, ParentId: 10, MusicFolderId: 1, Name: "ABBA", Updated: 2000}
+	bach := domain.Directory{Id: 13, ParentId: 10, MusicFolderId: 1, Name: "bach", Updated: 2000}
+	numbers := domain.Directory{Id: 21, ParentId: 20, MusicFolderId: 2, Name: "10 Songs", Updated: 2000}
+	intro := domain.Song{Id: 5, Title: "Intro", Path: "/music/adults/intro.mp3", MusicFolderId: 1, DirectoryId: 10}
+	articles := []string{"The"}
+
+	tests := []struct {
+		name            string
+		user            *domain.User
+		musicFolderID   int
+		ifModifiedSince int64
+		setupMock       func(*mocks.MockMediaBrowsingRepository)
+		expectedIndexes domain.Indexes
+		expectedError   error
+	}{
+		{
+			name: "directories indexed by letter ignoring articles",
+			user: &domain.User{Username: "parent"},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetMusicFolders(mock.Anything).Return(folders, nil)
+				m.EXPECT().GetDirectoryByPath(mock.Anything, "/music/adults").Return(adults, nil)
+				m.EXPECT().GetDirectoryByPath(mock.Anything, "/music/kids").Return(kids, nil)
+				m.EXPECT().GetSubdirectories(mock.Anything, []int{10, 20}).Return([]domain.Directory{numbers, abba, bach, beatles}, nil)
+				m.EXPECT().GetSongsByDirectory(mock.Anything, 10).Return([]domain.Song{intro}, nil)
+				m.EXPECT().GetSongsByDirectory(mock.Anything, 20).Return([]domain.Song{}, nil)
+			},
+			expectedIndexes: domain.Indexes{
+				LastModified:    3000,
+				IgnoredArticles: articles,
+				Index: []domain.Index{
+					{Name: "#", Directories: []domain.Directory{numbers}},
+					{Name: "A", Directories: []domain.Directory{abba}},
+					{Name: "B", Directories: []domain.Directory{bach, beatles}},
+				},
+				Songs: []domain.Song{intro},
+			},
+		},
+		{
+			name: "user restricted to music folders",
+			user: &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetMusicFolders(mock.Anything).Return(folders, nil)
+				m.EXPECT().GetDirectoryByPath(mock.Anything, "/music/kids").Return(kids, nil)
+				m.EXPECT().GetSubdirectories(mock.Anything, []int{20}).Return([]domain.Directory{numbers}, nil)
+				m.EXPECT().GetSongsByDirectory(mock.Anything, 20).Return([]domain.Song{}, nil)
+			},
+			expectedIndexes: domain.Indexes{
+				LastModified:    2000,
+				IgnoredArticles: articles,
+				Index:           []domain.Index{{Name: "#", Directories: []domain.Directory{numbers}}},
+			},
+		},
+		{
+			name:            "not modified since",
+			user:            &domain.User{Username: "parent"},
+			musicFolderID:   2,
+			ifModifiedSince: 2000,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetMusicFolders(mock.Anything).Return(folders, nil)
+				m.EXPECT().GetDirectoryByPath(mock.Anything, "/music/kids").Return(kids, nil)
+				m.EXPECT().GetSubdirectories(mock.Anything, []int{20}).Return([]domain.Directory{numbers}, nil)
+			},
+			expectedIndexes: domain.Indexes{LastModified: 2000, IgnoredArticles: articles},
+		},
+		{
+			name: "music folder not scanned yet",
+			user: &domain.User{Username: "parent"},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetMusicFolders(mock.Anything).Return(folders, nil)
+				m.EXPECT().GetDirectoryByPath(mock.Anything, mock.Anything).Return(domain.Directory{}, &ports.NotFoundError{Message: "directory not found"})
+			},
+			expectedIndexes: domain.Indexes{IgnoredArticles: articles},
+		},
+		{
+			name:          "music folder not allowed",
+			user:          &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			musicFolderID: 1,
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.NotFoundError{Message: "music folder not found"},
+		},
+		{
+			name:          "unknown music folder",
+			user:          &domain.User{Username: "parent"},
+			musicFolderID: 3,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetMusicFolders(mock.Anything).Return(folders, nil)
+			},
+			expectedError: &ports.NotFoundError{Message: "music folder not found"},
+		},
+		{
+			name: "repository error",
+			user: &domain.User{Username: "parent"},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetMusicFolders(mock.Anything).Return(folders, nil)
+				m.EXPECT().GetDirectoryByPath(mock.Anything, "/music/adults").Return(domain.Directory{}, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			service := NewMediaBrowsingService(repo, unannotatedRepository(t), &config.Config{MusicDirectories: musicDirectories, IgnoredArticles: articles}, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetIndexes(ctx, tt.musicFolderID, tt.ifModifiedSince)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(result, tt.expectedIndexes) {
+					t.Errorf("expected indexes %+v, got %+v", tt.expectedIndexes, result)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaBrowsingService_GetMusicDirectory(t *testing.T) {
+	album := domain.Directory{Id: 11, ParentId: 10, MusicFolderId: 1, Path: "/music/Artist", Name: "Artist"}
+	disc := domain.Directory{Id: 12, ParentId: 11, MusicFolderId: 1, Path: "/music/Artist/CD1", Name: "CD1", Created: "2024-01-01T00:00:00Z"}
+	song := domain.Song{Id: 5, Title: "Song", Path: "/music/Artist/song.mp3", MusicFolderId: 1, DirectoryId: 11}
+
+	tests := []struct {
+		name              string
+		id                int
+		user              *domain.User
+		setupMock         func(*mocks.MockMediaBrowsingRepository)
+		expectedDirectory domain.Directory
+		expectedError     error
+	}{
+		{
+			name: "subdirectories then songs",
+			id:   11,
+			user: &domain.User{Username: "parent"},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetDirectory(mock.Anything, 11).Return(album, nil)
+				m.EXPECT().GetSubdirectories(mock.Anything, []int{11}).Return([]domain.Directory{disc}, nil)
+				m.EXPECT().GetSongsByDirectory(mock.Anything, 11).Return([]domain.Song{song}, nil)
+			},
+			expectedDirectory: domain.Directory{
+				Id:            11,
+				ParentId:      10,
+				MusicFolderId: 1,
+				Path:          "/music/Artist",
+				Name:          "Artist",
+				Children: []domain.Song{
+					{Id: 12, Title: "CD1", IsDir: true, Created: "2024-01-01T00:00:00Z", MusicFolderId: 1, DirectoryId: 11},
+					song,
+				},
+			},
+		},
+		{
+			name: "directory outside the allowed music folders",
+			id:   11,
+			user: &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetDirectory(mock.Anything, 11).Return(album, nil)
+			},
+			expectedError: &ports.NotFoundError{Message: "directory not found"},
+		},
+		{
+			name: "directory not found",
+			id:   99,
+			user: &domain.User{Username: "parent"},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetDirectory(mock.Anything, 99).Return(domain.Directory{}, &ports.NotFoundError{Message: "directory not found"})
+			},
+			expectedError: &ports.NotFoundError{Message: "directory not found"},
+		},
+		{
+			name: "repository error on songs",
+			id:   11,
+			user: &domain.User{Username: "parent"},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetDirectory(mock.Anything, 11).Return(album, nil)
+				m.EXPECT().GetSubdirectories(mock.Anything, []int{11}).Return([]domain.Directory{}, nil)
+				m.EXPECT().GetSongsByDirectory(mock.Anything, 11).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			service := NewMediaBrowsingService(repo, unannotatedRepository(t), &config.Config{}, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetMusicDirectory(ctx, tt.id)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(result, tt.expectedDirectory) {
+					t.Errorf("expected directory %+v, got %+v", tt.expectedDirectory, result)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaBrowsingService_GetArtists(t *testing.T) {
+	beatles := domain.Artist{Id: 1, Name: "The Beatles", SortName: "Beatles, The", MusicFolderId: 1}
+	abba := domain.Artist{Id: 2, Name: "ABBA", SortName: "ABBA", MusicFolderId: 1}
+	bach := domain.Artist{Id: 3, Name: "bach", MusicFolderId: 2}
+	numbers := domain.Artist{Id: 4, Name: "10cc", SortName: "10cc", MusicFolderId: 2}
+	articles := []string{"The"}
+
+	tests := []struct {
+		name            string
+		user            *domain.User
+		musicFolderID   int
+		setupMock       func(*mocks.MockMediaBrowsingRepository)
+		expectedIndexes domain.ArtistIndexes
+		expectedError   error
+	}{
+		{
+			name: "artists indexed by sort name",
+			user: &domain.User{Username: "parent"},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetArtists(mock.Anything, []int(nil)).Return([]domain.Artist{numbers, abba, bach, beatles}, nil)
+			},
+			expectedIndexes: domain.ArtistIndexes{
+				IgnoredArticles: articles,
+				Index: []domain.ArtistIndex{
+					{Name: "#", Artists: []domain.Artist{numbers}},
+					{Name: "A", Artists: []domain.Artist{abba}},
+					{Name: "B", Artists: []domain.Artist{bach, beatles}},
+				},
+			},
+		},
+		{
+			name: "user restricted to music folders",
+			user: &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetArtists(mock.Anything, []int{2}).Return([]domain.Artist{bach}, nil)
+			},
+			expectedIndexes: domain.ArtistIndexes{
+				IgnoredArticles: articles,
+				Index:           []domain.ArtistIndex{{Name: "B", Artists: []domain.Artist{bach}}},
+			},
+		},
+		{
+			name:          "music folder requested",
+			user:          &domain.User{Username: "parent"},
+			musicFolderID: 1,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetArtists(mock.Anything, []int{1}).Return([]domain.Artist{}, nil)
+			},
+			expectedIndexes: domain.ArtistIndexes{IgnoredArticles: articles},
+		},
+		{
+			name:          "music folder not allowed",
+			user:          &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			musicFolderID: 1,
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.NotFoundError{Message: "music folder not found"},
+		},
+		{
+			name: "repository error",
+			user: &domain.User{Username: "parent"},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetArtists(mock.Anything, []int(nil)).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			service := NewMediaBrowsingService(repo, unannotatedRepository(t), &config.Config{IgnoredArticles: articles}, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetArtists(ctx, tt.musicFolderID)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(result, tt.expectedIndexes) {
+					t.Errorf("expected artist indexes %+v, got %+v", tt.expectedIndexes, result)
+				}
+			}
+		})
+	}
+}
+
 func TestMediaBrowsingService_GetArtist(t *testing.T) {
 	tests := []struct {
 		name           string
 		id             int
+		user           *domain.User
 		setupMock      func(*mocks.MockMediaBrowsingRepository)
 		expectedArtist domain.Artist
 		expectedError  error
@@ -29,15 +437,26 @@ func TestMediaBrowsingService_GetArtist(t *testing.T) {
 					CoverArt:   "cover1",
 					AlbumCount: 5,
 				}, nil)
+				m.EXPECT().GetAlbumsByArtistID(mock.Anything, 1).Return([]domain.Album{{Id: 7, ArtistId: 1, Name: "First", Year: 1999}, {Id: 8, ArtistId: 1, Name: "Second", Year: 2004}}, nil)
 			},
 			expectedArtist: domain.Artist{
 				Id:         1,
 				Name:       "Test Artist",
 				CoverArt:   "cover1",
 				AlbumCount: 5,
+				Albums:     []domain.Album{{Id: 7, ArtistId: 1, Name: "First", Year: 1999}, {Id: 8, ArtistId: 1, Name: "Second", Year: 2004}},
 			},
 			expectedError: nil,
 		},
+		{
+			name: "repository error on albums",
+			id:   1,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetArtistByID(mock.Anything, 1).Return(domain.Artist{Id: 1, Name: "Test Artist"}, nil)
+				m.EXPECT().GetAlbumsByArtistID(mock.Anything, 1).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
 		{
 			name: "not found error",
 			id:   999,
@@ -47,14 +466,35 @@ func TestMediaBrowsingService_GetArtist(t *testing.T) {
 			expectedArtist: domain.Artist{},
 			expectedError:  &ports.NotFoundError{Message: "artist not found"},
 		},
+		{
+			name: "artist outside the allowed music folders",
+			id:   2,
+			user: &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetArtistByID(mock.Anything, 2).Return(domain.Artist{Id: 2, Name: "Adult Artist", MusicFolderId: 1}, nil)
+			},
+			expectedArtist: domain.Artist{},
+			expectedError:  &ports.NotFoundError{Message: "artist not found"},
+		},
+		{
+			name: "artist in an allowed music folder",
+			id:   3,
+			user: &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetArtistByID(mock.Anything, 3).Return(domain.Artist{Id: 3, Name: "Kids Artist", MusicFolderId: 2}, nil)
+				m.EXPECT().GetAlbumsByArtistID(mock.Anything, 3).Return([]domain.Album{}, nil)
+			},
+			expectedArtist: domain.Artist{Id: 3, Name: "Kids Artist", MusicFolderId: 2, Albums: []domain.Album{}},
+			expectedError:  nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := mocks.NewMockMediaBrowsingRepository(t)
 			tt.setupMock(repo)
-			service := NewMediaBrowsingService(repo, slog.Default())
-			ctx := context.Background()
+			service := NewMediaBrowsingService(repo, unannotatedRepository(t), nil, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
 
 			result, err := service.GetArtist(ctx, tt.id)
 
@@ -74,6 +514,9 @@ func TestMediaBrowsingService_GetArtist(t *testing.T) {
 				if result.Name != tt.expectedArtist.Name {
 					t.Errorf("expected artist name %s, got %s", tt.expectedArtist.Name, result.Name)
 				}
+				if !reflect.DeepEqual(result.Albums, tt.expectedArtist.Albums) {
+					t.Errorf("expected albums %+v, got %+v", tt.expectedArtist.Albums, result.Albums)
+				}
 			}
 		})
 	}
@@ -101,6 +544,10 @@ func TestMediaBrowsingService_GetAlbum(t *testing.T) {
 					Duration:  3600,
 					Artist:    "Test Artist",
 				}, nil)
+				m.EXPECT().GetSongsByAlbumID(mock.Anything, 1).Return([]domain.Song{
+					{Id: 2, AlbumId: 1, Title: "Disc 1 Track 1", DiscNumber: 1, Track: 1},
+					{Id: 1, AlbumId: 1, Title: "Disc 2 Track 1", DiscNumber: 2, Track: 1},
+				}, nil)
 			},
 			expectedAlbum: domain.Album{
 				Id:        1,
@@ -111,9 +558,23 @@ func TestMediaBrowsingService_GetAlbum(t *testing.T) {
 				Created:   "2024-01-01",
 				Duration:  3600,
 				Artist:    "Test Artist",
+				Songs: []domain.Song{
+					{Id: 2, AlbumId: 1, Title: "Disc 1 Track 1", DiscNumber: 1, Track: 1},
+					{Id: 1, AlbumId: 1, Title: "Disc 2 Track 1", DiscNumber: 2, Track: 1},
+				},
 			},
 			expectedError: nil,
 		},
+		{
+			name: "songs error",
+			id:   2,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetAlbumByID(mock.Anything, 2).Return(domain.Album{Id: 2, Name: "Test Album"}, nil)
+				m.EXPECT().GetSongsByAlbumID(mock.Anything, 2).Return(nil, errors.New("database error"))
+			},
+			expectedAlbum: domain.Album{},
+			expectedError: errors.New("database error"),
+		},
 		{
 			name: "not found error",
 			id:   999,
@@ -129,7 +590,7 @@ func TestMediaBrowsingService_GetAlbum(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := mocks.NewMockMediaBrowsingRepository(t)
 			tt.setupMock(repo)
-			service := NewMediaBrowsingService(repo, slog.Default())
+			service := NewMediaBrowsingService(repo, unannotatedRepository(t), nil, slog.Default())
 			ctx := context.Background()
 
 			result, err := service.GetAlbum(ctx, tt.id)
@@ -150,6 +611,9 @@ func TestMediaBrowsingService_GetAlbum(t *testing.T) {
 				if result.Name != tt.expectedAlbum.Name {
 					t.Errorf("expected album name %s, got %s", tt.expectedAlbum.Name, result.Name)
 				}
+				if !reflect.DeepEqual(result.Songs, tt.expectedAlbum.Songs) {
+					t.Errorf("expected album songs %+v, got %+v", tt.expectedAlbum.Songs, result.Songs)
+				}
 			}
 		})
 	}
@@ -219,7 +683,7 @@ func TestMediaBrowsingService_GetSong(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := mocks.NewMockMediaBrowsingRepository(t)
 			tt.setupMock(repo)
-			service := NewMediaBrowsingService(repo, slog.Default())
+			service := NewMediaBrowsingService(repo, unannotatedRepository(t), nil, slog.Default())
 			ctx := context.Background()
 
 			result, err := service.GetSong(ctx, tt.id)
@@ -283,7 +747,7 @@ func TestMediaBrowsingService_GetCover(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := mocks.NewMockMediaBrowsingRepository(t)
 			tt.setupMock(repo)
-			service := NewMediaBrowsingService(repo, slog.Default())
+			service := NewMediaBrowsingService(repo, unannotatedRepository(t), nil, slog.Default())
 			ctx := context.Background()
 
 			result, err := service.GetCover(ctx, tt.id)
@@ -308,3 +772,517 @@ func TestMediaBrowsingService_GetCover(t *testing.T) {
 		})
 	}
 }
+
+func TestMediaBrowsingService_GetGenres(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *domain.User
+		setupMock      func(*mocks.MockMediaBrowsingRepository)
+		expectedGenres []domain.Genre
+		expectedError  error
+	}{
+		{
+			name: "successful retrieval",
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetGenres(mock.Anything, []int(nil)).Return([]domain.Genre{
+					{Name: "Jazz", SongCount: 12, AlbumCount: 2},
+					{Name: "Rock", SongCount: 30, AlbumCount: 3},
+				}, nil)
+			},
+			expectedGenres: []domain.Genre{
+				{Name: "Jazz", SongCount: 12, AlbumCount: 2},
+				{Name: "Rock", SongCount: 30, AlbumCount: 3},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "user restricted to music folders",
+			user: &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetGenres(mock.Anything, []int{2}).Return([]domain.Genre{
+					{Name: "Children's Music", SongCount: 8, AlbumCount: 1},
+				}, nil)
+			},
+			expectedGenres: []domain.Genre{
+				{Name: "Children's Music", SongCount: 8, AlbumCount: 1},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "repository error",
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetGenres(mock.Anything, []int(nil)).Return(nil, errors.New("database error"))
+			},
+			expectedGenres: nil,
+			expectedError:  errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			service := NewMediaBrowsingService(repo, unannotatedRepository(t), nil, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetGenres(ctx)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !slices.Equal(result, tt.expectedGenres) {
+					t.Errorf("expected genres %+v, got %+v", tt.expectedGenres, result)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaBrowsingService_GetSongsByGenre(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          *domain.User
+		genre         string
+		count         int
+		offset        int
+		musicFolderID int
+		setupMock     func(*mocks.MockMediaBrowsingRepository)
+		expectedSongs []domain.Song
+		expectedError error
+	}{
+		{
+			name:   "successful retrieval",
+			genre:  "Jazz",
+			count:  10,
+			offset: 20,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongsByGenre(mock.Anything, "Jazz", []int(nil), 10, 20).Return([]domain.Song{
+					{Id: 1, Title: "So What", Genres: []string{"Jazz"}},
+					{Id: 2, Title: "Blue in Green", Genres: []string{"Jazz", "Modal"}},
+				}, nil)
+			},
+			expectedSongs: []domain.Song{
+				{Id: 1, Title: "So What", Genres: []string{"Jazz"}},
+				{Id: 2, Title: "Blue in Green", Genres: []string{"Jazz", "Modal"}},
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "music folder of a restricted user",
+			user:          &domain.User{Username: "kid", MusicfolderId: []string{"1", "2"}},
+			genre:         "Jazz",
+			count:         10,
+			musicFolderID: 2,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongsByGenre(mock.Anything, "Jazz", []int{2}, 10, 0).Return([]domain.Song{
+					{Id: 3, Title: "Take Five", Genres: []string{"Jazz"}, MusicFolderId: 2},
+				}, nil)
+			},
+			expectedSongs: []domain.Song{
+				{Id: 3, Title: "Take Five", Genres: []string{"Jazz"}, MusicFolderId: 2},
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "music folder not allowed",
+			user:          &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			genre:         "Jazz",
+			count:         10,
+			musicFolderID: 1,
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.NotFoundError{Message: "music folder not found"},
+		},
+		{
+			name:          "missing genre",
+			genre:         "",
+			count:         10,
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "genre"},
+		},
+		{
+			name:          "count too large",
+			genre:         "Jazz",
+			count:         maxSongsByGenreCount + 1,
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "count or offset"},
+		},
+		{
+			name:          "negative offset",
+			genre:         "Jazz",
+			count:         10,
+			offset:        -1,
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "count or offset"},
+		},
+		{
+			name:  "repository error",
+			genre: "Jazz",
+			count: 10,
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongsByGenre(mock.Anything, "Jazz", []int(nil), 10, 0).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			service := NewMediaBrowsingService(repo, unannotatedRepository(t), nil, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetSongsByGenre(ctx, tt.genre, tt.count, tt.offset, tt.musicFolderID)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(result, tt.expectedSongs) {
+					t.Errorf("expected songs %+v, got %+v", tt.expectedSongs, result)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaBrowsingService_GetAlbumList(t *testing.T) {
+	tests := []struct {
+		name             string
+		user             *domain.User
+		query            domain.AlbumListQuery
+		setupMock        func(*mocks.MockMediaBrowsingRepository)
+		setupAnnotations func(*mocks.MockMediaAnnotationRepository)
+		expectedAlbums   []domain.Album
+		expectedError    error
+	}{
+		{
+			name:  "newest albums",
+			query: domain.AlbumListQuery{Type: domain.AlbumListNewest, Size: 10, Offset: 20},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetAlbumList(mock.Anything, domain.AlbumListQuery{Type: domain.AlbumListNewest, Size: 10, Offset: 20}, []int(nil)).Return([]domain.Album{
+					{Id: 2, Name: "In Rainbows", Created: "2024-02-01T00:00:00Z"},
+					{Id: 1, Name: "OK Computer", Created: "2024-01-01T00:00:00Z"},
+				}, nil)
+			},
+			expectedAlbums: []domain.Album{
+				{Id: 2, Name: "In Rainbows", Created: "2024-02-01T00:00:00Z"},
+				{Id: 1, Name: "OK Computer", Created: "2024-01-01T00:00:00Z"},
+			},
+			expectedError: nil,
+		},
+		{
+			name:  "albums by year in reverse order",
+			query: domain.AlbumListQuery{Type: domain.AlbumListByYear, Size: 10, FromYear: 2000, ToYear: 1990},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetAlbumList(mock.Anything, domain.AlbumListQuery{Type: domain.AlbumListByYear, Size: 10, FromYear: 2000, ToYear: 1990}, []int(nil)).Return([]domain.Album{
+					{Id: 3, Name: "Kid A", Year: 2000},
+					{Id: 1, Name: "OK Computer", Year: 1997},
+				}, nil)
+			},
+			expectedAlbums: []domain.Album{
+				{Id: 3, Name: "Kid A", Year: 2000},
+				{Id: 1, Name: "OK Computer", Year: 1997},
+			},
+			expectedError: nil,
+		},
+		{
+			name:  "albums of a restricted user",
+			user:  &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			query: domain.AlbumListQuery{Type: domain.AlbumListAlphabeticalByName, Size: 10},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetAlbumList(mock.Anything, domain.AlbumListQuery{Type: domain.AlbumListAlphabeticalByName, Size: 10}, []int{2}).Return([]domain.Album{
+					{Id: 4, Name: "Abbey Road", MusicFolderId: 2},
+				}, nil)
+			},
+			expectedAlbums: []domain.Album{
+				{Id: 4, Name: "Abbey Road", MusicFolderId: 2},
+			},
+			expectedError: nil,
+		},
+		{
+			name:  "albums of a music folder",
+			user:  &domain.User{Username: "kid", MusicfolderId: []string{"1", "2"}},
+			query: domain.AlbumListQuery{Type: domain.AlbumListByGenre, Size: 5, Genre: "Rock", MusicFolderId: 1},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetAlbumList(mock.Anything, domain.AlbumListQuery{Type: domain.AlbumListByGenre, Size: 5, Genre: "Rock", MusicFolderId: 1}, []int{1}).Return([]domain.Album{
+					{Id: 5, Name: "Nevermind", MusicFolderId: 1},
+				}, nil)
+			},
+			expectedAlbums: []domain.Album{
+				{Id: 5, Name: "Nevermind", MusicFolderId: 1},
+			},
+			expectedError: nil,
+		},
+		{
+			name:      "starred albums of the requesting user",
+			user:      &domain.User{Username: "alice"},
+			query:     domain.AlbumListQuery{Type: domain.AlbumListStarred, Size: 1, Offset: 1},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().GetStarredAlbums(mock.Anything, "alice", []int(nil), 1, 1).Return([]domain.Album{
+					{Id: 1, Name: "OK Computer", Starred: "2024-02-01T00:00:00Z"},
+				}, nil)
+				m.EXPECT().GetAnnotations(mock.Anything, "alice", domain.AnnotationItemAlbum, []int{1}).Return(map[int]domain.Annotation{
+					1: {ItemId: 1, Starred: "2024-02-01T00:00:00Z", Rating: 4},
+				}, nil)
+			},
+			expectedAlbums: []domain.Album{
+				{Id: 1, Name: "OK Computer", Starred: "2024-02-01T00:00:00Z", UserRating: 4},
+			},
+			expectedError: nil,
+		},
+		{
+			name:      "highest rated albums of the requesting user",
+			user:      &domain.User{Username: "alice"},
+			query:     domain.AlbumListQuery{Type: domain.AlbumListHighest, Size: 10},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().GetHighestRatedAlbums(mock.Anything, "alice", []int(nil), 10, 0).Return([]domain.Album{
+					{Id: 3, Name: "Kid A"},
+				}, nil)
+				m.EXPECT().GetAnnotations(mock.Anything, "alice", domain.AnnotationItemAlbum, []int{3}).Return(map[int]domain.Annotation{
+					3: {ItemId: 3, Rating: 5},
+				}, nil)
+			},
+			expectedAlbums: []domain.Album{
+				{Id: 3, Name: "Kid A", UserRating: 5},
+			},
+			expectedError: nil,
+		},
+		{
+			name:      "frequent albums",
+			user:      &domain.User{Username: "alice"},
+			query:     domain.AlbumListQuery{Type: domain.AlbumListFrequent, Size: 10},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().GetMostPlayedAlbums(mock.Anything, "alice", []int(nil), 10, 0).Return([]domain.Album{
+					{Id: 3, Name: "Kid A"},
+				}, nil)
+				m.EXPECT().GetAnnotations(mock.Anything, "alice", domain.AnnotationItemAlbum, []int{3}).Return(map[int]domain.Annotation{
+					3: {ItemId: 3, PlayCount: 12, Played: "2024-05-01T20:00:00Z"},
+				}, nil)
+			},
+			expectedAlbums: []domain.Album{
+				{Id: 3, Name: "Kid A", PlayCount: 12, Played: "2024-05-01T20:00:00Z"},
+			},
+			expectedError: nil,
+		},
+		{
+			name:      "recent albums",
+			user:      &domain.User{Username: "alice"},
+			query:     domain.AlbumListQuery{Type: domain.AlbumListRecent, Size: 5, Offset: 5},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().GetRecentlyPlayedAlbums(mock.Anything, "alice", []int(nil), 5, 5).Return([]domain.Album{}, nil)
+			},
+			expectedAlbums: []domain.Album{},
+			expectedError:  nil,
+		},
+		{
+			name:          "music folder not allowed",
+			user:          &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			query:         domain.AlbumListQuery{Type: domain.AlbumListRandom, Size: 10, MusicFolderId: 1},
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.NotFoundError{Message: "music folder not found"},
+		},
+		{
+			name:          "unknown type",
+			query:         domain.AlbumListQuery{Type: "loudest", Size: 10},
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "type"},
+		},
+		{
+			name:          "missing genre",
+			query:         domain.AlbumListQuery{Type: domain.AlbumListByGenre, Size: 10},
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "genre"},
+		},
+		{
+			name:          "size too large",
+			query:         domain.AlbumListQuery{Type: domain.AlbumListRandom, Size: maxAlbumListSize + 1},
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "size or offset"},
+		},
+		{
+			name:          "negative offset",
+			query:         domain.AlbumListQuery{Type: domain.AlbumListNewest, Size: 10, Offset: -1},
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "size or offset"},
+		},
+		{
+			name:  "repository error",
+			query: domain.AlbumListQuery{Type: domain.AlbumListRandom, Size: 10},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetAlbumList(mock.Anything, domain.AlbumListQuery{Type: domain.AlbumListRandom, Size: 10}, []int(nil)).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+		{
+			name:      "repository error on starred albums",
+			user:      &domain.User{Username: "alice"},
+			query:     domain.AlbumListQuery{Type: domain.AlbumListStarred, Size: 10},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {},
+			setupAnnotations: func(m *mocks.MockMediaAnnotationRepository) {
+				m.EXPECT().GetStarredAlbums(mock.Anything, "alice", []int(nil), 10, 0).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			annotations := unannotatedRepository(t)
+			if tt.setupAnnotations != nil {
+				annotations = mocks.NewMockMediaAnnotationRepository(t)
+				tt.setupAnnotations(annotations)
+			}
+			service := NewMediaBrowsingService(repo, annotations, nil, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetAlbumList(ctx, tt.query)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(result, tt.expectedAlbums) {
+					t.Errorf("expected albums %+v, got %+v", tt.expectedAlbums, result)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaBrowsingService_Search(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *domain.User
+		query          domain.SearchQuery
+		setupMock      func(*mocks.MockMediaBrowsingRepository)
+		expectedResult domain.SearchResult
+		expectedError  error
+	}{
+		{
+			name:  "artists, albums and songs with their own pages",
+			query: domain.SearchQuery{Query: "bjork", ArtistCount: 5, AlbumCount: 10, AlbumOffset: 10, SongCount: 20, SongOffset: 40},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().SearchArtists(mock.Anything, "bjork", []int(nil), 5, 0).Return([]domain.Artist{{Id: 1, Name: "Björk"}}, nil)
+				m.EXPECT().SearchAlbums(mock.Anything, "bjork", []int(nil), 10, 10).Return([]domain.Album{{Id: 2, Name: "Homogenic", Artist: "Björk"}}, nil)
+				m.EXPECT().SearchSongs(mock.Anything, "bjork", []int(nil), 20, 40).Return([]domain.Song{{Id: 3, Title: "Jóga", Artist: "Björk"}}, nil)
+			},
+			expectedResult: domain.SearchResult{
+				Artists: []domain.Artist{{Id: 1, Name: "Björk"}},
+				Albums:  []domain.Album{{Id: 2, Name: "Homogenic", Artist: "Björk"}},
+				Songs:   []domain.Song{{Id: 3, Title: "Jóga", Artist: "Björk"}},
+			},
+			expectedError: nil,
+		},
+		{
+			name:  "quoted empty query of a restricted user with songs only",
+			user:  &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			query: domain.SearchQuery{Query: `""`, SongCount: 10},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().SearchSongs(mock.Anything, "", []int{2}, 10, 0).Return([]domain.Song{{Id: 4, Title: "Yellow", MusicFolderId: 2}}, nil)
+			},
+			expectedResult: domain.SearchResult{
+				Artists: []domain.Artist{},
+				Albums:  []domain.Album{},
+				Songs:   []domain.Song{{Id: 4, Title: "Yellow", MusicFolderId: 2}},
+			},
+			expectedError: nil,
+		},
+		{
+			name:  "music folder",
+			user:  &domain.User{Username: "kid", MusicfolderId: []string{"1", "2"}},
+			query: domain.SearchQuery{Query: "yel", ArtistCount: 10, MusicFolderId: 1},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().SearchArtists(mock.Anything, "yel", []int{1}, 10, 0).Return([]domain.Artist{}, nil)
+			},
+			expectedResult: domain.SearchResult{Artists: []domain.Artist{}, Albums: []domain.Album{}, Songs: []domain.Song{}},
+			expectedError:  nil,
+		},
+		{
+			name:          "music folder not allowed",
+			user:          &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			query:         domain.SearchQuery{Query: "yel", ArtistCount: 10, MusicFolderId: 1},
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.NotFoundError{Message: "music folder not found"},
+		},
+		{
+			name:          "count too large",
+			query:         domain.SearchQuery{Query: "yel", AlbumCount: maxSearchCount + 1},
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "count or offset"},
+		},
+		{
+			name:          "negative offset",
+			query:         domain.SearchQuery{Query: "yel", SongCount: 10, SongOffset: -1},
+			setupMock:     func(m *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "count or offset"},
+		},
+		{
+			name:  "repository error",
+			query: domain.SearchQuery{Query: "yel", ArtistCount: 10, AlbumCount: 10},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().SearchArtists(mock.Anything, "yel", []int(nil), 10, 0).Return([]domain.Artist{}, nil)
+				m.EXPECT().SearchAlbums(mock.Anything, "yel", []int(nil), 10, 0).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			service := NewMediaBrowsingService(repo, unannotatedRepository(t), nil, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.Search(ctx, tt.query)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(result, tt.expectedResult) {
+					t.Errorf("expected result %+v, got %+v", tt.expectedResult, result)
+				}
+			}
+		})
+	}
+}
+
+// unannotatedRepository returns an annotation repository where the requesting user neither starred nor rated anything.
+func unannotatedRepository(t *testing.T) *mocks.MockMediaAnnotationRepository {
+	annotations := mocks.NewMockMediaAnnotationRepository(t)
+	annotations.EXPECT().GetAnnotations(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[int]domain.Annotation{}, nil).Maybe()
+	return annotations
+}
@@ -2,19 +2,29 @@ package services
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"music-streaming/internal/core/domain"
 	"music-streaming/internal/core/ports"
+	"slices"
+	"time"
 )
 
+// sliceFormats lists the formats songs split from a CUE sheet are transcoded to, others are served as FLAC.
+var sliceFormats = []string{"flac", "mp3", "ogg", "oga", "opus", "wav"}
+
 type MediaRetrievalService struct {
 	MediaBrowsingRepository ports.MediaBrowsingRepository
+	nowPlayingRepo          ports.NowPlayingRepository
+	transcoder              ports.MediaTranscoder
 	logger                  *slog.Logger
 }
 
-func NewMediaRetrievalService(mediaBrowsingRepository ports.MediaBrowsingRepository, logger *slog.Logger) *MediaRetrievalService {
+func NewMediaRetrievalService(mediaBrowsingRepository ports.MediaBrowsingRepository, nowPlayingRepo ports.NowPlayingRepository, transcoder ports.MediaTranscoder, logger *slog.Logger) *MediaRetrievalService {
 	return &MediaRetrievalService{
 		MediaBrowsingRepository: mediaBrowsingRepository,
+		nowPlayingRepo:          nowPlayingRepo,
+		transcoder:              transcoder,
 		logger:                  logger,
 	}
 }
@@ -37,8 +47,12 @@ func (s *MediaRetrievalService) DownloadSong(ctx context.Context, id int) (domai
 		s.logger.Error("Failed to get song for download", slog.Int("id", id), slog.String("username", username), slog.String("error", err.Error()))
 		return domain.Song{}, err
 	}
+	if !canAccessMusicFolder(ctx, song.MusicFolderId) {
+		s.logger.Warn("Download of a song outside the allowed music folders", slog.Int("id", id), slog.String("username", username))
+		return domain.Song{}, &ports.NotFoundError{Message: "song not found"}
+	}
 	s.logger.Info("Song download successful", slog.Int("id", id), slog.String("title", song.Title), slog.String("username", username))
-	return song, nil
+	return servedSong(song), nil
 }
 
 func (s *MediaRetrievalService) StreamSong(ctx context.Context, id int) (domain.Song, error) {
@@ -59,8 +73,18 @@ func (s *MediaRetrievalService) StreamSong(ctx context.Context, id int) (domain.
 		s.logger.Error("Failed to get song for streaming", slog.Int("id", id), slog.String("username", username), slog.String("error", err.Error()))
 		return domain.Song{}, err
 	}
+	if !canAccessMusicFolder(ctx, song.MusicFolderId) {
+		s.logger.Warn("Stream of a song outside the allowed music folders", slog.Int("id", id), slog.String("username", username))
+		return domain.Song{}, &ports.NotFoundError{Message: "song not found"}
+	}
+
+	// A failure to note the song as playing now should not stop the stream
+	nowPlaying := domain.NowPlaying{Song: song, Username: username, PlayerName: requestingPlayer(ctx), Started: time.Now()}
+	if err := s.nowPlayingRepo.SetNowPlaying(ctx, nowPlaying); err != nil {
+		s.logger.Warn("Failed to set now playing", slog.Int("id", id), slog.String("username", username), slog.String("error", err.Error()))
+	}
 	s.logger.Info("Song stream started", slog.Int("id", id), slog.String("title", song.Title), slog.String("username", username))
-	return song, nil
+	return servedSong(song), nil
 }
 
 func (s *MediaRetrievalService) GetCover(ctx context.Context, id string) (domain.Cover, error) {
@@ -73,3 +97,26 @@ func (s *MediaRetrievalService) GetCover(ctx context.Context, id string) (domain
 	s.logger.Info("Successfully retrieved cover", slog.String("id", id))
 	return cover, err
 }
+
+func (s *MediaRetrievalService) WriteSongSlice(ctx context.Context, song domain.Song, w io.Writer) error {
+	if !song.IsSlice() {
+		return &ports.MissingOrInvalidParameterError{ParameterName: "song"}
+	}
+
+	s.logger.Info("Transcoding song slice", slog.Int("id", song.Id), slog.String("path", song.Path), slog.Int("start", song.StartOffset), slog.Int("end", song.EndOffset))
+	if err := s.transcoder.TranscodeSlice(ctx, song.Path, song.StartOffset, song.EndOffset, song.Suffix, w); err != nil {
+		s.logger.Error("Failed to transcode song slice", slog.Int("id", song.Id), slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// servedSong returns song as it is served. Songs split from a CUE sheet are transcoded,
+// to FLAC when the format of their file cannot be encoded.
+func servedSong(song domain.Song) domain.Song {
+	if song.IsSlice() && !slices.Contains(sliceFormats, song.Suffix) {
+		song.Suffix = "flac"
+		song.ContentType = supportedAudioFormats["flac"]
+	}
+	return song
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
 	"music-streaming/internal/core/domain"
 	"music-streaming/internal/core/ports"
@@ -100,13 +102,28 @@ func TestMediaRetrievalService_DownloadSong(t *testing.T) {
 			expectedSong:  domain.Song{},
 			expectedError: &ports.NotFoundError{Message: "song not found"},
 		},
+		{
+			name: "song outside the allowed music folders",
+			id:   3,
+			user: &domain.User{
+				Username:      "kid",
+				DownloadRole:  true,
+				StreamRole:    true,
+				MusicfolderId: []string{"2"},
+			},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 3).Return(domain.Song{Id: 3, Title: "Explicit Song", Path: "/music/adults/explicit.mp3", MusicFolderId: 1}, nil)
+			},
+			expectedSong:  domain.Song{},
+			expectedError: &ports.NotFoundError{Message: "song not found"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := mocks.NewMockMediaBrowsingRepository(t)
 			tt.setupMock(repo)
-			service := NewMediaRetrievalService(repo, slog.Default())
+			service := NewMediaRetrievalService(repo, mocks.NewMockNowPlayingRepository(t), mocks.NewMockMediaTranscoder(t), slog.Default())
 			ctx := context.Background()
 			if tt.user != nil {
 				ctx = context.WithValue(ctx, ports.KeyRequestingUserID, tt.user)
@@ -162,6 +179,7 @@ func TestMediaRetrievalService_StreamSong(t *testing.T) {
 		id            int
 		user          *domain.User
 		setupMock     func(*mocks.MockMediaBrowsingRepository)
+		nowPlayingErr error
 		expectedSong  domain.Song
 		expectedError error
 	}{
@@ -245,14 +263,70 @@ func TestMediaRetrievalService_StreamSong(t *testing.T) {
 			expectedSong:  domain.Song{},
 			expectedError: &ports.NotFoundError{Message: "song not found"},
 		},
+		{
+			name: "song outside the allowed music folders",
+			id:   3,
+			user: &domain.User{
+				Username:      "kid",
+				DownloadRole:  true,
+				StreamRole:    true,
+				MusicfolderId: []string{"2"},
+			},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 3).Return(domain.Song{Id: 3, Title: "Explicit Song", Path: "/music/adults/explicit.mp3", MusicFolderId: 1}, nil)
+			},
+			expectedSong:  domain.Song{},
+			expectedError: &ports.NotFoundError{Message: "song not found"},
+		},
+		{
+			name: "now playing failure does not stop the stream",
+			id:   4,
+			user: &domain.User{Username: "user", StreamRole: true},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 4).Return(domain.Song{Id: 4, Title: "Song", Path: "/music/song.mp3"}, nil)
+			},
+			nowPlayingErr: errors.New("registry error"),
+			expectedSong:  domain.Song{Id: 4, Title: "Song", Path: "/music/song.mp3"},
+		},
+		{
+			name: "cue sheet track of a format that cannot be encoded served as flac",
+			id:   2,
+			user: &domain.User{Username: "user", StreamRole: true},
+			setupMock: func(m *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetSongByID(mock.Anything, 2).Return(domain.Song{
+					Id:          2,
+					Title:       "Track 2",
+					Path:        "/music/album.m4a",
+					Suffix:      "m4a",
+					ContentType: "audio/mp4",
+					StartOffset: 215000,
+					EndOffset:   430000,
+				}, nil)
+			},
+			expectedSong: domain.Song{
+				Id:          2,
+				Title:       "Track 2",
+				Path:        "/music/album.m4a",
+				Suffix:      "flac",
+				ContentType: "audio/flac",
+				StartOffset: 215000,
+				EndOffset:   430000,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := mocks.NewMockMediaBrowsingRepository(t)
 			tt.setupMock(repo)
-			service := NewMediaRetrievalService(repo, slog.Default())
-			ctx := context.Background()
+			nowPlaying := mocks.NewMockNowPlayingRepository(t)
+			if tt.expectedError == nil {
+				nowPlaying.EXPECT().SetNowPlaying(mock.Anything, mock.MatchedBy(func(entry domain.NowPlaying) bool {
+					return entry.Song.Id == tt.id && entry.Username == tt.user.Username && entry.PlayerName == "DSub"
+				})).Return(tt.nowPlayingErr)
+			}
+			service := NewMediaRetrievalService(repo, nowPlaying, mocks.NewMockMediaTranscoder(t), slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyPlayerName, "DSub")
 			if tt.user != nil {
 				ctx = context.WithValue(ctx, ports.KeyRequestingUserID, tt.user)
 			}
@@ -296,6 +370,58 @@ func TestMediaRetrievalService_StreamSong(t *testing.T) {
 				if result.Title != tt.expectedSong.Title {
 					t.Errorf("expected song title %s, got %s", tt.expectedSong.Title, result.Title)
 				}
+				if result.Suffix != tt.expectedSong.Suffix || result.ContentType != tt.expectedSong.ContentType {
+					t.Errorf("expected song format %s (%s), got %s (%s)", tt.expectedSong.Suffix, tt.expectedSong.ContentType, result.Suffix, result.ContentType)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaRetrievalService_WriteSongSlice(t *testing.T) {
+	tests := []struct {
+		name          string
+		song          domain.Song
+		setupMock     func(*mocks.MockMediaTranscoder)
+		expectedError error
+	}{
+		{
+			name: "slice transcoded",
+			song: domain.Song{Id: 1, Path: "/music/album.flac", Suffix: "flac", StartOffset: 215000, EndOffset: 430000},
+			setupMock: func(m *mocks.MockMediaTranscoder) {
+				m.EXPECT().TranscodeSlice(mock.Anything, "/music/album.flac", 215000, 430000, "flac", mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "transcoding error",
+			song: domain.Song{Id: 1, Path: "/music/album.flac", Suffix: "flac", StartOffset: 215000},
+			setupMock: func(m *mocks.MockMediaTranscoder) {
+				m.EXPECT().TranscodeSlice(mock.Anything, "/music/album.flac", 215000, 0, "flac", mock.Anything).Return(errors.New("ffmpeg failed"))
+			},
+			expectedError: errors.New("ffmpeg failed"),
+		},
+		{
+			name:          "whole file song",
+			song:          domain.Song{Id: 1, Path: "/music/track.flac", Suffix: "flac"},
+			setupMock:     func(m *mocks.MockMediaTranscoder) {},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "song"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcoder := mocks.NewMockMediaTranscoder(t)
+			tt.setupMock(transcoder)
+			service := NewMediaRetrievalService(mocks.NewMockMediaBrowsingRepository(t), mocks.NewMockNowPlayingRepository(t), transcoder, slog.Default())
+
+			err := service.WriteSongSlice(context.Background(), tt.song, io.Discard)
+
+			if tt.expectedError != nil {
+				if err == nil || err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
 			}
 		})
 	}
@@ -339,7 +465,7 @@ func TestMediaRetrievalService_GetCover(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := mocks.NewMockMediaBrowsingRepository(t)
 			tt.setupMock(repo)
-			service := NewMediaRetrievalService(repo, slog.Default())
+			service := NewMediaRetrievalService(repo, mocks.NewMockNowPlayingRepository(t), mocks.NewMockMediaTranscoder(t), slog.Default())
 			ctx := context.Background()
 
 			result, err := service.GetCover(ctx, tt.id)
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"sync"
+	"time"
+)
+
+// scanProgressInterval is the minimum delay between two progress updates sent to subscribers during a phase.
+const scanProgressInterval = 500 * time.Millisecond
+
+// WatchScanProgress subscribes to the progress of media library scans until ctx is done.
+func (s *MediaScanningService) WatchScanProgress(ctx context.Context) (<-chan domain.ScanProgress, error) {
+	requestingUser, ok := ctx.Value(ports.KeyRequestingUserID).(*domain.User)
+	var username string
+	if requestingUser != nil {
+		username = requestingUser.Username
+	}
+	s.logger.Info("Watch scan progress request", slog.String("username", username))
+
+	if !ok || requestingUser == nil || !requestingUser.AdminRole {
+		s.logger.Warn("Unauthorized watch scan progress attempt", slog.String("username", username))
+		return nil, &ports.NotAuthorizedError{Username: username, Action: "watch media scan progress"}
+	}
+
+	return s.progress.subscribe(ctx), nil
+}
+
+// scanProgressTracker follows the running scan and sends its progress to subscribers.
+// Counts are updated for every file while subscribers are only sent the latest progress every scanProgressInterval,
+// except for phase changes which are sent right away.
+type scanProgressTracker struct {
+	mu          sync.Mutex
+	progress    domain.ScanProgress
+	started     time.Time
+	expected    int // files indexed by the previous scan, to estimate how many are left to find
+	walked      bool
+	changed     bool
+	report      *scanReport
+	subscribers map[chan domain.ScanProgress]bool
+	stop        chan struct{}
+}
+
+func newScanProgressTracker() *scanProgressTracker {
+	return &scanProgressTracker{
+		progress:    domain.ScanProgress{Phase: domain.ScanPhaseIdle, ETA: -1},
+		subscribers: make(map[chan domain.ScanProgress]bool),
+	}
+}
+
+// start resets the progress for a new scan expected to find as many files as expected, failures being read from report.
+func (t *scanProgressTracker) start(trigger domain.ScanTrigger, expected int, report *scanReport) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.started = time.Now()
+	t.expected = expected
+	t.walked = false
+	t.report = report
+	t.progress = domain.ScanProgress{
+		Scanning: true,
+		Phase:    domain.ScanPhaseIndexing,
+		Trigger:  trigger,
+		Started:  t.started.Format(time.RFC3339),
+	}
+	t.publish()
+
+	stop := make(chan struct{})
+	t.stop = stop
+	go func() {
+		ticker := time.NewTicker(scanProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.flush()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// update applies a change to the progress, sent to subscribers with the next periodic update.
+func (t *scanProgressTracker) update(change func(progress *domain.ScanProgress)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	change(&t.progress)
+	t.changed = true
+}
+
+// setPhase moves the scan to the next phase and sends the progress right away.
+func (t *scanProgressTracker) setPhase(phase domain.ScanPhase) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.progress.Phase = phase
+	t.progress.Directory = ""
+	t.publish()
+}
+
+// walkDone records that every file to index has been found.
+func (t *scanProgressTracker) walkDone() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.walked = true
+	t.progress.Directory = ""
+	t.changed = true
+}
+
+// finish sends the final counts of the scan, marked as no longer scanning.
+func (t *scanProgressTracker) finish() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stop != nil {
+		close(t.stop)
+		t.stop = nil
+	}
+	t.progress.Scanning = false
+	t.progress.Phase = domain.ScanPhaseIdle
+	t.progress.Directory = ""
+	t.publish()
+}
+
+func (t *scanProgressTracker) flush() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// The estimate changes with time, keep it fresh while indexing
+	if t.changed || t.progress.Phase == domain.ScanPhaseIndexing {
+		t.publish()
+	}
+}
+
+// subscribe returns a channel receiving the current progress then its updates, closed once ctx is done.
+func (t *scanProgressTracker) subscribe(ctx context.Context) <-chan domain.ScanProgress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	updates := make(chan domain.ScanProgress, 1)
+	updates <- t.snapshot()
+	t.subscribers[updates] = true
+
+	go func() {
+		<-ctx.Done()
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.subscribers, updates)
+		close(updates)
+	}()
+	return updates
+}
+
+// publish sends the progress to every subscriber, replacing the update they have not received yet if any.
+// Must be called with mu held.
+func (t *scanProgressTracker) publish() {
+	t.changed = false
+	progress := t.snapshot()
+	for updates := range t.subscribers {
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- progress
+	}
+}
+
+// snapshot returns the progress along with the failures so far and the estimated time left.
+// Must be called with mu held.
+func (t *scanProgressTracker) snapshot() domain.ScanProgress {
+	progress := t.progress
+	if t.report != nil {
+		t.report.mu.Lock()
+		progress.Failed = t.report.failed
+		t.report.mu.Unlock()
+	}
+	progress.ETA = -1
+	if progress.Scanning && progress.Phase == domain.ScanPhaseIndexing {
+		progress.ETA = estimateScanETA(progress, t.expected, t.walked, time.Since(t.started))
+	}
+	return progress
+}
+
+// estimateScanETA estimates the seconds left to index the files of a scan at the pace of the elapsed time.
+// Once the walk is over the files left to index are known. Until then the files indexed by the previous scan
+// tell how many are left to find. Returns -1 when nothing was found or indexed yet to measure the pace.
+func estimateScanETA(progress domain.ScanProgress, expected int, walked bool, elapsed time.Duration) int {
+	switch {
+	case walked && progress.Indexed >= progress.Queued:
+		return 0
+	case walked && progress.Indexed > 0:
+		left := float64(progress.Queued - progress.Indexed)
+		return int(math.Ceil(elapsed.Seconds() * left / float64(progress.Indexed)))
+	case !walked && progress.Seen > 0 && expected > progress.Seen:
+		left := float64(expected - progress.Seen)
+		return int(math.Ceil(elapsed.Seconds() * left / float64(progress.Seen)))
+	default:
+		return -1
+	}
+}
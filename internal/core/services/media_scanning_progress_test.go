@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"music-streaming/internal/core/config"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"music-streaming/internal/core/services/mocks"
+	"testing"
+	"time"
+)
+
+func TestMediaScanningService_WatchScanProgress(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          *domain.User
+		expectedError error
+	}{
+		{
+			name: "admin receives progress",
+			user: &domain.User{Username: "admin", AdminRole: true},
+		},
+		{
+			name:          "non-admin user",
+			user:          &domain.User{Username: "user", AdminRole: false},
+			expectedError: &ports.NotAuthorizedError{Username: "user", Action: "watch media scan progress"},
+		},
+		{
+			name:          "no user in context",
+			expectedError: &ports.NotAuthorizedError{Username: "", Action: "watch media scan progress"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMediaScanningService(mocks.NewMockMediaBrowsingRepository(t), mocks.NewMockMediaScanningRepository(t), mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{}, slog.Default())
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.user != nil {
+				ctx = context.WithValue(ctx, ports.KeyRequestingUserID, tt.user)
+			}
+
+			updates, err := service.WatchScanProgress(ctx)
+
+			if tt.expectedError != nil {
+				var notAuthorizedErr *ports.NotAuthorizedError
+				if !errors.As(err, &notAuthorizedErr) || err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			receive := func() domain.ScanProgress {
+				t.Helper()
+				select {
+				case progress := <-updates:
+					return progress
+				case <-time.After(time.Second):
+					t.Fatalf("no progress received")
+					return domain.ScanProgress{}
+				}
+			}
+
+			if progress := receive(); progress.Scanning || progress.Phase != domain.ScanPhaseIdle || progress.ETA != -1 {
+				t.Errorf("expected idle progress first, got %+v", progress)
+			}
+
+			report := &scanReport{}
+			service.progress.start(domain.ScanTriggerManual, 10, report)
+			if progress := receive(); !progress.Scanning || progress.Phase != domain.ScanPhaseIndexing || progress.Trigger != domain.ScanTriggerManual {
+				t.Errorf("expected indexing progress, got %+v", progress)
+			}
+
+			service.progress.update(func(progress *domain.ScanProgress) {
+				progress.Seen = 4
+				progress.Queued = 2
+			})
+			report.fail("/music/broken.mp3", errors.New("unsupported format"))
+			service.progress.setPhase(domain.ScanPhaseRemoving)
+			if progress := receive(); progress.Phase != domain.ScanPhaseRemoving || progress.Seen != 4 || progress.Queued != 2 || progress.Failed != 1 {
+				t.Errorf("expected removing progress with counts, got %+v", progress)
+			}
+
+			service.progress.finish()
+			if progress := receive(); progress.Scanning || progress.Phase != domain.ScanPhaseIdle || progress.Seen != 4 {
+				t.Errorf("expected final progress of the finished scan, got %+v", progress)
+			}
+
+			cancel()
+			select {
+			case _, ok := <-updates:
+				if ok {
+					t.Errorf("expected updates to be closed")
+				}
+			case <-time.After(time.Second):
+				t.Errorf("updates not closed after cancellation")
+			}
+		})
+	}
+}
+
+func TestEstimateScanETA(t *testing.T) {
+	tests := []struct {
+		name        string
+		progress    domain.ScanProgress
+		expected    int
+		walked      bool
+		elapsed     time.Duration
+		expectedETA int
+	}{
+		{
+			name:        "walking with files left to find",
+			progress:    domain.ScanProgress{Seen: 250},
+			expected:    1000,
+			elapsed:     10 * time.Second,
+			expectedETA: 30,
+		},
+		{
+			name:        "walking a library larger than the previous one",
+			progress:    domain.ScanProgress{Seen: 1200},
+			expected:    1000,
+			elapsed:     10 * time.Second,
+			expectedETA: -1,
+		},
+		{
+			name:        "nothing found yet",
+			expected:    1000,
+			elapsed:     time.Second,
+			expectedETA: -1,
+		},
+		{
+			name:        "walked with files left to index",
+			progress:    domain.ScanProgress{Seen: 1000, Queued: 400, Indexed: 100},
+			walked:      true,
+			elapsed:     20 * time.Second,
+			expectedETA: 60,
+		},
+		{
+			name:        "walked with every file indexed",
+			progress:    domain.ScanProgress{Seen: 1000, Queued: 400, Indexed: 400},
+			walked:      true,
+			elapsed:     80 * time.Second,
+			expectedETA: 0,
+		},
+		{
+			name:        "walked before the first file is indexed",
+			progress:    domain.ScanProgress{Seen: 1000, Queued: 400},
+			walked:      true,
+			elapsed:     time.Second,
+			expectedETA: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eta := estimateScanETA(tt.progress, tt.expected, tt.walked, tt.elapsed)
+
+			if eta != tt.expectedETA {
+				t.Errorf("expected ETA %d, got %d", tt.expectedETA, eta)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// systemPrincipal is the user scheduled scans are started as.
+var systemPrincipal = &domain.User{Username: "system", AdminRole: true}
+
+// Schedule starts a media scan as the system principal each time the configured cron expression matches.
+// A scheduled scan is skipped when a scan is already in progress.
+// The time of the next scheduled scan is reported in the scan status. Blocks until ctx is cancelled.
+func (s *MediaScanningService) Schedule(ctx context.Context) error {
+	return s.schedule(ctx, func(next time.Time) <-chan time.Time {
+		return time.After(time.Until(next))
+	})
+}
+
+// schedule runs the scan schedule, wait returns a channel receiving once the next scheduled scan is due.
+func (s *MediaScanningService) schedule(ctx context.Context, wait func(next time.Time) <-chan time.Time) error {
+	spec := s.scanSchedule()
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return fmt.Errorf("invalid scan schedule %q: %w", spec, err)
+	}
+	defer s.setNextScheduledScan(time.Time{})
+
+	scanCtx := context.WithValue(ctx, ports.KeyRequestingUserID, systemPrincipal)
+	scanCtx = context.WithValue(scanCtx, ports.KeyScanTrigger, domain.ScanTriggerScheduled)
+
+	s.logger.Info("Scheduling media scans", slog.String("schedule", spec))
+	for {
+		next := schedule.Next(time.Now())
+		if next.IsZero() {
+			return errors.New("scan schedule never matches")
+		}
+		s.setNextScheduledScan(next)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wait(next):
+		}
+
+		if _, err := s.StartScan(scanCtx); err != nil {
+			s.logger.Error("Failed to start scheduled media scan", slog.String("error", err.Error()))
+		}
+	}
+}
+
+func (s *MediaScanningService) setNextScheduledScan(next time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if next.IsZero() {
+		s.scanStatus.NextScheduledScan = ""
+		return
+	}
+	s.scanStatus.NextScheduledScan = next.Format(time.RFC3339)
+}
+
+func (s *MediaScanningService) scanSchedule() string {
+	if s.config == nil {
+		return ""
+	}
+	return s.config.ScanSchedule
+}
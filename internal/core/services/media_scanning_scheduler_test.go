@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"music-streaming/internal/core/config"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/services/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMediaScanningService_Schedule(t *testing.T) {
+	tests := []struct {
+		name          string
+		schedule      string
+		scanning      bool
+		fire          bool
+		expectedScan  bool
+		expectedError bool
+	}{
+		{
+			name:          "invalid schedule",
+			schedule:      "every night",
+			expectedError: true,
+		},
+		{
+			name:     "next scan reported",
+			schedule: "@every 1h",
+		},
+		{
+			name:         "scheduled scan started as system principal",
+			schedule:     "0 3 * * *",
+			fire:         true,
+			expectedScan: true,
+		},
+		{
+			name:     "scheduled scan skipped while scanning",
+			schedule: "0 3 * * *",
+			scanning: true,
+			fire:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			scanRuns := mocks.NewMockMediaScanningRepository(t)
+			started := make(chan domain.ScanRun, 1)
+			finished := make(chan struct{})
+			if tt.expectedScan {
+				repo.EXPECT().GetSongFingerprints(mock.Anything).Return(nil, nil)
+				repo.EXPECT().SaveMusicFolders(mock.Anything, mock.Anything).Return(nil, nil)
+				repo.EXPECT().DeleteOrphans(mock.Anything).Return(0, nil)
+				repo.EXPECT().UpdateCatalogStats(mock.Anything).Return(nil)
+				scanRuns.EXPECT().CreateScanRun(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, run domain.ScanRun) (domain.ScanRun, error) {
+					run.Id = 1
+					started <- run
+					return run, nil
+				}).Once()
+				scanRuns.EXPECT().FinishScanRun(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, run domain.ScanRun) error {
+					close(finished)
+					return nil
+				}).Once()
+			}
+			service := NewMediaScanningService(repo, scanRuns, mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{ScanSchedule: tt.schedule}, slog.Default())
+			service.scanStatus.Scanning = tt.scanning
+
+			// The schedule reports each wait for the next scan and is fired by the test
+			waits := make(chan time.Time)
+			fire := make(chan time.Time)
+			wait := func(next time.Time) <-chan time.Time {
+				waits <- next
+				return fire
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				done <- service.schedule(ctx, wait)
+			}()
+
+			if tt.expectedError {
+				if err := <-done; err == nil {
+					t.Errorf("expected error for schedule %q, got nil", tt.schedule)
+				}
+				cancel()
+				return
+			}
+
+			next := receive(t, waits, "expected the schedule to wait for the next scan")
+			if reported := scanStatus(service).NextScheduledScan; reported != next.Format(time.RFC3339) {
+				t.Errorf("expected next scheduled scan %q, got %q", next.Format(time.RFC3339), reported)
+			}
+
+			if tt.fire {
+				fire <- next
+				// The schedule waits for the following scan once the scheduled one is started or skipped
+				receive(t, waits, "expected the schedule to wait for the following scan")
+			}
+			if tt.expectedScan {
+				run := receive(t, started, "expected scheduled scan to start")
+				if run.Trigger != domain.ScanTriggerScheduled {
+					t.Errorf("expected trigger %q, got %q", domain.ScanTriggerScheduled, run.Trigger)
+				}
+				receive(t, finished, "expected scheduled scan to finish")
+			}
+
+			cancel()
+			if err := <-done; err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if next := scanStatus(service).NextScheduledScan; next != "" {
+				t.Errorf("expected next scheduled scan to be cleared, got %q", next)
+			}
+		})
+	}
+}
+
+// receive returns the next value of ch, failing the test if none arrives.
+func receive[T any](t *testing.T, ch <-chan T, message string) T {
+	t.Helper()
+	select {
+	case value := <-ch:
+		return value
+	case <-time.After(time.Second):
+		t.Fatal(message)
+		var zero T
+		return zero
+	}
+}
+
+func scanStatus(service *MediaScanningService) domain.ScanStatus {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	return *service.scanStatus
+}
@@ -2,31 +2,206 @@ package services
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
 	"log/slog"
+	"maps"
+	"math"
 	"music-streaming/internal/core/domain"
 	"music-streaming/internal/core/ports"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"music-streaming/internal/core/config"
 )
 
+const (
+	unknownArtist = "Unknown Artist"
+	unknownAlbum  = "Unknown Album"
+
+	// defaultScanBatchSize is the number of songs written per transaction when not configured.
+	defaultScanBatchSize = 100
+
+	// embeddedCoverSource designates the picture embedded in the audio files in the cover art priority.
+	embeddedCoverSource = "embedded"
+
+	// maxScanErrors bounds the number of errors kept in the report of a scan run.
+	maxScanErrors = 1000
+
+	// maxScanRunsSize bounds the number of scan runs returned at once.
+	maxScanRunsSize = 500
+
+	// replayGainReference is the loudness in LUFS that ReplayGain 2.0 gains normalize to.
+	replayGainReference = -18.0
+)
+
+var (
+	// supportedAudioFormats maps the file suffixes indexed by the scanner to their content type.
+	supportedAudioFormats = map[string]string{
+		"mp3":  "audio/mpeg",
+		"flac": "audio/flac",
+		"ogg":  "audio/ogg",
+		"oga":  "audio/ogg",
+		"opus": "audio/ogg",
+		"m4a":  "audio/mp4",
+		"aac":  "audio/aac",
+		"wav":  "audio/wav",
+		"aif":  "audio/aiff",
+		"aiff": "audio/aiff",
+		"wma":  "audio/x-ms-wma",
+	}
+
+	// defaultCoverArtPriority lists the album art sources tried in order when not configured.
+	// Entries are file name patterns matched against the images of the album folder, or embeddedCoverSource.
+	defaultCoverArtPriority = []string{"cover.*", "folder.*", "front.*", "album.*", embeddedCoverSource}
+
+	// defaultIgnoredArticles lists the leading articles moved to the end of names to sort them when not configured.
+	defaultIgnoredArticles = []string{"The", "El", "La", "Los", "Las", "Le", "Les", "Os", "As", "O", "A"}
+
+	// defaultGenreSeparators lists the separators multi-valued genre tags are split on when not configured.
+	defaultGenreSeparators = []string{";", "/", ","}
+
+	// The tags holding sort names and MusicBrainz IDs, as named by the different tag formats and extractors.
+	artistSortTags      = []string{"artistsort", "artist-sort", "sort_artist"}
+	albumArtistSortTags = []string{"albumartistsort", "album_artist-sort", "sort_album_artist"}
+	albumSortTags       = []string{"albumsort", "album-sort", "sort_album"}
+	titleSortTags       = []string{"titlesort", "title-sort", "sort_name"}
+	artistIDTags        = []string{"musicbrainz_artistid", "musicbrainz artist id"}
+	albumArtistIDTags   = []string{"musicbrainz_albumartistid", "musicbrainz album artist id"}
+	releaseIDTags       = []string{"musicbrainz_albumid", "musicbrainz album id"}
+	recordingIDTags     = []string{"musicbrainz_trackid", "musicbrainz track id"}
+
+	// wholeFileTags lists the tags of a file that do not apply to the tracks a CUE sheet splits it into.
+	wholeFileTags = slices.Concat([]string{"replaygain_track_gain", "replaygain_track_peak"}, titleSortTags, recordingIDTags)
+
+	// supportedImageFormats lists the image suffixes recognized as album art.
+	supportedImageFormats = []string{"jpg", "jpeg", "png", "gif", "webp"}
+
+	// imageExtensions maps the content type of embedded pictures to the extension of their cached file.
+	imageExtensions = map[string]string{
+		"image/jpeg": ".jpg",
+		"image/png":  ".png",
+		"image/gif":  ".gif",
+		"image/webp": ".webp",
+		"image/bmp":  ".bmp",
+	}
+)
+
 type MediaScanningService struct {
 	repo       ports.MediaBrowsingRepository
+	scanRuns   ports.MediaScanningRepository
+	playlists  ports.PlaylistRepository
+	lyrics     ports.LyricsRepository
+	extractor  ports.MetadataExtractor
+	analyzer   ports.LoudnessAnalyzer
 	logger     *slog.Logger
 	config     *config.Config
 	scanStatus *domain.ScanStatus
+	progress   *scanProgressTracker
+	cancelScan context.CancelFunc
 	mu         sync.Mutex
 }
 
-func NewMediaScanningService(repo ports.MediaBrowsingRepository, config *config.Config, logger *slog.Logger) *MediaScanningService {
+// scannedFile holds a probed audio file waiting to be indexed.
+// A file described by a CUE sheet is split into one scannedFile per track, covering startOffset to endOffset.
+type scannedFile struct {
+	path          string
+	size          int64
+	modTime       time.Time
+	musicFolderID int
+	metadata      domain.MediaMetadata
+	songIDs       map[int]int // IDs of the songs indexed from the file, by start offset
+	cue           *cueSheetRef
+	startOffset   int
+	endOffset     int
+	lyricsPath    string
+	lyrics        []domain.Lyrics
+}
+
+// indexResult sums up the songs written by indexFiles.
+type indexResult struct {
+	added    int
+	updated  int
+	removed  int
+	received int
+	albums   []int // albums with saved songs
+}
+
+// artistKey identifies an album artist of a music folder as read from tags during indexing.
+type artistKey struct {
+	musicFolderID int
+	name          string
+	musicBrainzID string
+}
+
+// albumKey identifies a release of an album artist during indexing.
+type albumKey struct {
+	artistID int
+	name     string
+	year     int
+}
+
+// catalogCache remembers the artists, albums, covers and directories resolved during a scan.
+type catalogCache struct {
+	artistIDs   map[artistKey]int
+	artists     map[int]domain.Artist
+	albums      map[albumKey]domain.Album
+	covers      map[string]bool
+	directories map[string]int // directory IDs by path
+}
+
+// scanReport collects the failures of a scan run, they are reported concurrently by the walker and the workers.
+type scanReport struct {
+	mu     sync.Mutex
+	failed int
+	errors []domain.ScanError
+}
+
+// fail records that path could not be indexed. path is empty for failures not related to a single file.
+func (r *scanReport) fail(path string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failed++
+	if len(r.errors) < maxScanErrors {
+		r.errors = append(r.errors, domain.ScanError{Path: path, Message: err.Error()})
+	}
+}
+
+func newCatalogCache() *catalogCache {
+	return &catalogCache{
+		artistIDs:   make(map[artistKey]int),
+		artists:     make(map[int]domain.Artist),
+		albums:      make(map[albumKey]domain.Album),
+		covers:      make(map[string]bool),
+		directories: make(map[string]int),
+	}
+}
+
+func NewMediaScanningService(repo ports.MediaBrowsingRepository, scanRuns ports.MediaScanningRepository, playlists ports.PlaylistRepository, lyrics ports.LyricsRepository, extractor ports.MetadataExtractor, analyzer ports.LoudnessAnalyzer, config *config.Config, logger *slog.Logger) *MediaScanningService {
 	return &MediaScanningService{
-		repo:   repo,
-		logger: logger,
-		config: config,
+		repo:      repo,
+		scanRuns:  scanRuns,
+		playlists: playlists,
+		lyrics:    lyrics,
+		extractor: extractor,
+		analyzer:  analyzer,
+		logger:    logger,
+		config:    config,
 		scanStatus: &domain.ScanStatus{
 			Scanning: false,
 			Count:    0,
 		},
+		progress: newScanProgressTracker(),
 	}
 }
 
@@ -43,19 +218,46 @@ func (s *MediaScanningService) StartScan(ctx context.Context) (domain.ScanStatus
 		return domain.ScanStatus{}, &ports.NotAuthorizedError{Username: username, Action: "start media scan"}
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	trigger, ok := ctx.Value(ports.KeyScanTrigger).(domain.ScanTrigger)
+	if !ok {
+		trigger = domain.ScanTriggerManual
+	}
 
 	// If a scan is already in progress, return the current status
-	if s.scanStatus.Scanning {
-		s.logger.Warn("Scan already in progress", slog.String("username", username))
-		return *s.scanStatus, nil
+	// The scan outlives the request, so its context is not derived from ctx
+	scanCtx, status, started := s.beginScan(context.Background())
+	if !started {
+		s.logger.Warn("Scan already in progress", slog.String("username", username), slog.String("trigger", string(trigger)))
+		return status, nil
 	}
 
-	s.scanStatus.Count = 0
-	s.scanStatus.Scanning = true
-	s.logger.Info("Media scan started", slog.String("username", username))
-	go s.Scan()
+	s.logger.Info("Media scan started", slog.String("username", username), slog.String("trigger", string(trigger)))
+	go s.Scan(scanCtx, trigger)
+
+	return status, nil
+}
+
+func (s *MediaScanningService) StopScan(ctx context.Context) (domain.ScanStatus, error) {
+	requestingUser, ok := ctx.Value(ports.KeyRequestingUserID).(*domain.User)
+	var username string
+	if requestingUser != nil {
+		username = requestingUser.Username
+	}
+	s.logger.Info("Stop scan request", slog.String("username", username))
+
+	if !ok || requestingUser == nil || !requestingUser.AdminRole {
+		s.logger.Warn("Unauthorized stop scan attempt", slog.String("username", username))
+		return domain.ScanStatus{}, &ports.NotAuthorizedError{Username: username, Action: "stop media scan"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The scan winds down in the background, scanning stays true until it has
+	if s.cancelScan != nil {
+		s.cancelScan()
+		s.logger.Info("Media scan stop requested", slog.String("username", username))
+	}
 
 	return *s.scanStatus, nil
 }
@@ -79,13 +281,1423 @@ func (s *MediaScanningService) GetScanStatus(ctx context.Context) (domain.ScanSt
 	return *s.scanStatus, nil
 }
 
-// TODO: Refactor into smaller functions
-func (s *MediaScanningService) Scan() {
-	// Implementation of the scanning logic goes here
+func (s *MediaScanningService) GetScanRuns(ctx context.Context, size int, offset int) ([]domain.ScanRun, error) {
+	requestingUser, ok := ctx.Value(ports.KeyRequestingUserID).(*domain.User)
+	var username string
+	if requestingUser != nil {
+		username = requestingUser.Username
+	}
+	s.logger.Debug("Get scan runs request", slog.String("username", username), slog.Int("size", size), slog.Int("offset", offset))
+
+	if !ok || requestingUser == nil || !requestingUser.AdminRole {
+		s.logger.Warn("Unauthorized get scan runs attempt", slog.String("username", username))
+		return nil, &ports.NotAuthorizedError{Username: username, Action: "get media scan runs"}
+	}
+
+	if size <= 0 || size > maxScanRunsSize || offset < 0 {
+		return nil, &ports.MissingOrInvalidParameterError{ParameterName: "size or offset"}
+	}
+
+	runs, err := s.scanRuns.GetScanRuns(ctx, size, offset)
+	if err != nil {
+		s.logger.Error("Failed to get scan runs", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return runs, nil
 }
 
-func (s *MediaScanningService) FFProbeProcessFile(path string) (*domain.FFProbeInfo, error) {
-	s.logger.Debug("FFProbe processing file", slog.String("path", path))
-	// TODO: Implement FFProbe file processing
-	return nil, nil
+func (s *MediaScanningService) GetScanRun(ctx context.Context, id int) (domain.ScanRun, error) {
+	requestingUser, ok := ctx.Value(ports.KeyRequestingUserID).(*domain.User)
+	var username string
+	if requestingUser != nil {
+		username = requestingUser.Username
+	}
+	s.logger.Debug("Get scan run request", slog.String("username", username), slog.Int("id", id))
+
+	if !ok || requestingUser == nil || !requestingUser.AdminRole {
+		s.logger.Warn("Unauthorized get scan run attempt", slog.String("username", username))
+		return domain.ScanRun{}, &ports.NotAuthorizedError{Username: username, Action: "get media scan run"}
+	}
+
+	run, err := s.scanRuns.GetScanRun(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get scan run", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.ScanRun{}, err
+	}
+	return run, nil
+}
+
+// Scan walks every configured music directory and indexes the supported audio files
+// as artists, albums, songs and covers, and the playlist files as playlists of the configured owner.
+// Files whose size and modification time are unchanged since the previous scan are not probed again,
+// and songs whose files are gone are removed together with the albums and artists left empty.
+// Cancelling ctx stops the scan, songs saved so far are kept.
+// The scan status is reset once indexing is finished.
+func (s *MediaScanningService) Scan(ctx context.Context, trigger domain.ScanTrigger) {
+	defer s.endScan()
+	s.scanDirectories(ctx, s.musicDirectories(), false, trigger)
+}
+
+// beginScan marks a scan as running and returns its context, cancelled by StopScan, along with the resulting status.
+// Returns false if a scan is already in progress.
+func (s *MediaScanningService) beginScan(parent context.Context) (context.Context, domain.ScanStatus, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.scanStatus.Scanning {
+		return nil, *s.scanStatus, false
+	}
+	ctx, cancel := context.WithCancel(parent)
+	s.cancelScan = cancel
+	s.scanStatus.Count = 0
+	s.scanStatus.Scanning = true
+	return ctx, *s.scanStatus, true
+}
+
+func (s *MediaScanningService) endScan() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancelScan != nil {
+		s.cancelScan()
+		s.cancelScan = nil
+	}
+	s.scanStatus.Scanning = false
+}
+
+// scanDirectories indexes the audio files below dirs.
+// Directories are walked sequentially while new and modified files are probed by a pool of workers,
+// the resulting songs are saved in batches.
+// A partial scan only considers the songs already indexed below dirs for removal,
+// otherwise every indexed song that was not found is removed.
+// The outcome of the scan and the files that failed are recorded as a scan run.
+func (s *MediaScanningService) scanDirectories(ctx context.Context, dirs []string, partial bool, trigger domain.ScanTrigger) {
+	var (
+		start  = time.Now()
+		report = &scanReport{}
+		run    = s.createScanRun(ctx, domain.ScanRun{
+			Trigger: trigger,
+			Partial: partial,
+			Started: start.Format(time.RFC3339),
+		})
+	)
+	defer s.progress.finish()
+
+	fingerprints, err := s.loadFingerprints(ctx)
+	if err != nil {
+		s.logger.Error("Failed to load indexed files", slog.String("error", err.Error()))
+		report.fail("", fmt.Errorf("failed to load indexed files: %w", err))
+		s.finishScanRun(context.WithoutCancel(ctx), run, report)
+		return
+	}
+	if partial {
+		for path := range fingerprints {
+			if !isBelowAny(path, dirs) {
+				delete(fingerprints, path)
+			}
+		}
+	}
+	folders, err := s.repo.SaveMusicFolders(ctx, s.musicDirectories())
+	if err != nil {
+		s.logger.Error("Failed to save music folders", slog.String("error", err.Error()))
+		report.fail("", fmt.Errorf("failed to save music folders: %w", err))
+		s.finishScanRun(context.WithoutCancel(ctx), run, report)
+		return
+	}
+	musicFolderIDs := make(map[string]int, len(folders))
+	for _, folder := range folders {
+		musicFolderIDs[folder.Path] = folder.Id
+	}
+	s.progress.start(trigger, len(fingerprints), report)
+
+	var (
+		seen        = make(map[string]bool)
+		playlists   = make(map[string]time.Time)
+		failedRoots []string
+		pending     = make(chan scannedFile)
+		probed      = make(chan scannedFile)
+		workers     sync.WaitGroup
+	)
+
+	go func() {
+		defer close(pending)
+		defer s.progress.walkDone()
+		for _, dir := range dirs {
+			s.logger.Info("Scanning music directory", slog.String("directory", dir))
+			musicFolderID := musicFolderIDs[s.musicDirectoryOf(dir)]
+			if err := s.collectFiles(ctx, dir, musicFolderID, fingerprints, seen, playlists, pending, report); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				// Keep what was indexed below this directory, it may only be temporarily unavailable
+				s.logger.Error("Failed to scan music directory", slog.String("directory", dir), slog.String("error", err.Error()))
+				report.fail(dir, err)
+				failedRoots = append(failedRoots, dir)
+			}
+		}
+	}()
+
+	for range s.scanWorkers() {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			s.probeFiles(ctx, pending, probed, report)
+		}()
+	}
+	go func() {
+		workers.Wait()
+		close(probed)
+	}()
+
+	indexed := s.indexFiles(ctx, probed, report)
+
+	removed := indexed.removed
+	cancelled := ctx.Err() != nil
+	if cancelled {
+		// Files not visited yet cannot be told apart from deleted ones
+		s.logger.Warn("Media scan cancelled, missing files are not removed")
+	} else {
+		s.progress.setPhase(domain.ScanPhaseRemoving)
+		removed += s.removeMissingFiles(ctx, fingerprints, seen, failedRoots, report)
+	}
+
+	synced := 0
+	if !cancelled && s.playlistOwner() != "" {
+		s.progress.setPhase(domain.ScanPhasePlaylists)
+		synced = s.importPlaylists(ctx, playlists, dirs, partial, failedRoots, indexed.added > 0, report)
+	}
+
+	analyzed := 0
+	if !cancelled && s.loudnessAnalysis() {
+		s.progress.setPhase(domain.ScanPhaseLoudness)
+		analyzed = s.analyzeLoudness(ctx, indexed.albums, report)
+		cancelled = ctx.Err() != nil
+	}
+
+	// Saved batches are committed, keep albums and artists consistent with them even when cancelled
+	s.progress.setPhase(domain.ScanPhaseFinishing)
+	finishCtx := context.WithoutCancel(ctx)
+	orphans, err := s.repo.DeleteOrphans(finishCtx)
+	if err != nil {
+		s.logger.Error("Failed to delete orphaned albums and artists", slog.String("error", err.Error()))
+	}
+	if err := s.repo.UpdateCatalogStats(finishCtx); err != nil {
+		s.logger.Error("Failed to update catalog statistics", slog.String("error", err.Error()))
+	}
+
+	s.logger.Info("Media scan finished",
+		slog.Bool("partial", partial),
+		slog.Bool("cancelled", cancelled),
+		slog.Int("added", indexed.added),
+		slog.Int("updated", indexed.updated),
+		slog.Int("removed", removed),
+		slog.Int("orphans", orphans),
+		slog.Int("analyzed", analyzed),
+		slog.Int("playlists", synced),
+		slog.Int("unchanged", len(seen)-indexed.received),
+		slog.Int("failed", report.failed),
+		slog.Duration("elapsed", time.Since(start)),
+	)
+
+	run.Cancelled = cancelled
+	run.Added = indexed.added
+	run.Updated = indexed.updated
+	run.Removed = removed
+	s.finishScanRun(finishCtx, run, report)
+}
+
+// createScanRun records the start of a scan run.
+// The scan proceeds without a report if it cannot be recorded, the returned run then has no ID.
+func (s *MediaScanningService) createScanRun(ctx context.Context, run domain.ScanRun) domain.ScanRun {
+	created, err := s.scanRuns.CreateScanRun(ctx, run)
+	if err != nil {
+		s.logger.Error("Failed to record scan run", slog.String("error", err.Error()))
+		return run
+	}
+	return created
+}
+
+// finishScanRun records the end of a scan run along with the failures collected in report.
+func (s *MediaScanningService) finishScanRun(ctx context.Context, run domain.ScanRun, report *scanReport) {
+	if run.Id == 0 {
+		return
+	}
+
+	report.mu.Lock()
+	run.Failed = report.failed
+	run.Errors = report.errors
+	report.mu.Unlock()
+
+	run.Finished = time.Now().Format(time.RFC3339)
+	if err := s.scanRuns.FinishScanRun(ctx, run); err != nil {
+		s.logger.Error("Failed to record scan run", slog.Int("id", run.Id), slog.String("error", err.Error()))
+	}
+}
+
+// SyncMusicFolders stores a music folder for every configured music directory not stored yet,
+// so that music folders are listed before the first scan. Scans sync them again.
+func (s *MediaScanningService) SyncMusicFolders(ctx context.Context) error {
+	folders, err := s.repo.SaveMusicFolders(ctx, s.musicDirectories())
+	if err != nil {
+		s.logger.Error("Failed to save music folders", slog.String("error", err.Error()))
+		return err
+	}
+	s.logger.Info("Music folders synced", slog.Int("count", len(folders)))
+	return nil
+}
+
+func (s *MediaScanningService) musicDirectories() []string {
+	if s.config == nil {
+		return nil
+	}
+	return s.config.MusicDirectories
+}
+
+// musicDirectoryOf returns the configured music directory dir is in, dir itself when it is not in any.
+func (s *MediaScanningService) musicDirectoryOf(dir string) string {
+	musicDir := dir
+	for _, root := range s.musicDirectories() {
+		if isBelowAny(dir, []string{root}) && (musicDir == dir || len(root) > len(musicDir)) {
+			musicDir = root
+		}
+	}
+	return musicDir
+}
+
+// newScanIgnore returns the excludes applying to the walk of dir, with ignore files read from its music directory.
+func (s *MediaScanningService) newScanIgnore(dir string, fail func(path string, err error)) *scanIgnore {
+	excludes := defaultScanExcludes
+	if s.config != nil {
+		excludes = slices.Concat(excludes, s.config.ScanExcludes)
+	}
+	return newScanIgnore(s.musicDirectoryOf(dir), excludes, fail)
+}
+
+func (s *MediaScanningService) scanWorkers() int {
+	if s.config == nil || s.config.ScanWorkers <= 0 {
+		return runtime.NumCPU()
+	}
+	return s.config.ScanWorkers
+}
+
+func (s *MediaScanningService) playlistOwner() string {
+	if s.config == nil || s.playlists == nil {
+		return ""
+	}
+	return s.config.PlaylistOwner
+}
+
+func (s *MediaScanningService) loudnessAnalysis() bool {
+	return s.config != nil && s.config.LoudnessAnalysis && s.analyzer != nil
+}
+
+func (s *MediaScanningService) scanBatchSize() int {
+	if s.config == nil || s.config.ScanBatchSize <= 0 {
+		return defaultScanBatchSize
+	}
+	return s.config.ScanBatchSize
+}
+
+// loadFingerprints returns the fingerprints of all indexed songs grouped by path.
+func (s *MediaScanningService) loadFingerprints(ctx context.Context) (map[string][]domain.FileFingerprint, error) {
+	fingerprints, err := s.repo.GetSongFingerprints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byPath := make(map[string][]domain.FileFingerprint, len(fingerprints))
+	for _, fingerprint := range fingerprints {
+		byPath[fingerprint.Path] = append(byPath[fingerprint.Path], fingerprint)
+	}
+	return byPath, nil
+}
+
+// collectFiles walks root, in the music folder with musicFolderID, and sends every new or modified supported
+// audio file below it to pending.
+// A file described by a CUE sheet is also sent when the sheet was modified, added or removed, other files
+// when their LRC file was.
+// Every supported file found is recorded in seen, playlist files are recorded in playlists with their
+// modification time, and paths that cannot be accessed are recorded in report.
+// Paths excluded by the configured excludes or ignore files are skipped, as if they did not exist.
+func (s *MediaScanningService) collectFiles(ctx context.Context, root string, musicFolderID int, fingerprints map[string][]domain.FileFingerprint, seen map[string]bool, playlists map[string]time.Time, pending chan<- scannedFile, report *scanReport) error {
+	cues := newCueSheetIndex(func(path string, err error) {
+		s.logger.Warn("Failed to read cue sheet", slog.String("path", path), slog.String("error", err.Error()))
+		report.fail(path, err)
+	})
+	ignore := s.newScanIgnore(root, func(path string, err error) {
+		s.logger.Warn("Failed to read ignore file", slog.String("path", path), slog.String("error", err.Error()))
+		report.fail(path, err)
+	})
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			if path == root {
+				return err
+			}
+			s.logger.Warn("Failed to access path", slog.String("path", path), slog.String("error", err.Error()))
+			report.fail(path, err)
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if path == root && ignore.ignoredTree(root) || path != root && ignore.ignored(path, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			s.progress.update(func(progress *domain.ScanProgress) {
+				progress.Directory = path
+			})
+			return nil
+		}
+		if isSupportedPlaylistFile(path) {
+			if stat, err := d.Info(); err == nil {
+				playlists[path] = stat.ModTime()
+			}
+			return nil
+		}
+		if !isSupportedAudioFile(path) {
+			return nil
+		}
+
+		stat, err := d.Info()
+		if err != nil {
+			s.logger.Warn("Failed to stat file", slog.String("path", path), slog.String("error", err.Error()))
+			report.fail(path, err)
+			return nil
+		}
+		seen[path] = true
+		s.mu.Lock()
+		s.scanStatus.Count++
+		s.mu.Unlock()
+		s.progress.update(func(progress *domain.ScanProgress) {
+			progress.Seen++
+		})
+
+		file := scannedFile{
+			path:          path,
+			size:          stat.Size(),
+			modTime:       stat.ModTime(),
+			musicFolderID: musicFolderID,
+			songIDs:       make(map[int]int),
+		}
+		var cuePath string
+		if cue, ok := cues.lookup(path); ok {
+			file.cue = &cue
+			cuePath = cue.path
+			// Editing the sheet changes the songs of the file as much as editing the file
+			if cue.modTime.After(file.modTime) {
+				file.modTime = cue.modTime
+			}
+		} else if lyricsPath, modTime, ok := findLyricsSidecar(path); ok {
+			file.lyricsPath = lyricsPath
+			if modTime.After(file.modTime) {
+				file.modTime = modTime
+			}
+		}
+
+		indexed := fingerprints[path]
+		if isUnchanged(indexed, file.size, file.modTime.UnixNano(), cuePath, file.lyricsPath) {
+			return nil
+		}
+		for _, fingerprint := range indexed {
+			file.songIDs[fingerprint.StartOffset] = fingerprint.SongId
+		}
+
+		select {
+		case pending <- file:
+			s.progress.update(func(progress *domain.ScanProgress) {
+				progress.Queued++
+			})
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// isUnchanged reports whether the songs indexed from a file match its current size, modification time,
+// CUE sheet and LRC file.
+func isUnchanged(fingerprints []domain.FileFingerprint, size int64, modTime int64, cuePath string, lyricsPath string) bool {
+	if len(fingerprints) == 0 {
+		return false
+	}
+	for _, fingerprint := range fingerprints {
+		if fingerprint.Size != size || fingerprint.ModTime != modTime || fingerprint.CuePath != cuePath ||
+			fingerprint.LyricsPath != lyricsPath {
+			return false
+		}
+	}
+	return true
+}
+
+// probeFiles probes the files received from pending and reads their lyrics, then sends them to probed.
+// Files that cannot be probed are recorded in report and skipped, as are unreadable LRC files.
+// Once ctx is cancelled pending is drained without probing.
+func (s *MediaScanningService) probeFiles(ctx context.Context, pending <-chan scannedFile, probed chan<- scannedFile, report *scanReport) {
+	for file := range pending {
+		if ctx.Err() != nil {
+			continue
+		}
+		metadata, err := s.extractor.Extract(ctx, file.path)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Warn("Failed to read file metadata", slog.String("path", file.path), slog.String("error", err.Error()))
+				report.fail(file.path, err)
+			}
+			continue
+		}
+		file.metadata = metadata
+		if file.lyricsPath != "" {
+			lyrics, err := readLyricsFile(file.lyricsPath)
+			if err != nil {
+				s.logger.Warn("Failed to read lyrics", slog.String("path", file.lyricsPath), slog.String("error", err.Error()))
+				report.fail(file.lyricsPath, err)
+			} else {
+				file.lyrics = append(file.lyrics, lyrics)
+			}
+		}
+		// Lyrics embedded in a file described by a CUE sheet cannot be told apart between its tracks
+		if file.cue == nil {
+			for _, lyrics := range lyricsFromTags(metadata) {
+				if !slices.ContainsFunc(file.lyrics, func(l domain.Lyrics) bool { return sameLyrics(l, lyrics) }) {
+					file.lyrics = append(file.lyrics, lyrics)
+				}
+			}
+			sortLyrics(file.lyrics)
+		}
+		probed <- file
+	}
+}
+
+// indexFiles creates or updates the songs of the probed files, along with their artists, albums, covers
+// and directories.
+// A file described by a CUE sheet gets one song per track, songs of tracks no longer in the sheet are removed.
+// Songs are saved in batches, each batch being written entirely or not at all, then their lyrics replace
+// those previously indexed. Files that cannot be indexed are recorded in report.
+func (s *MediaScanningService) indexFiles(ctx context.Context, probed <-chan scannedFile, report *scanReport) indexResult {
+	var (
+		catalog   = newCatalogCache()
+		batchSize = s.scanBatchSize()
+		batch     = make([]domain.Song, 0, batchSize)
+		lyrics    = make([][]domain.Lyrics, 0, batchSize) // lyrics of the songs of batch
+		saved     = make(map[int]bool)
+		stale     []int
+		result    indexResult
+	)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		savedSongs, err := s.repo.SaveSongs(ctx, batch)
+		if err != nil {
+			s.logger.Error("Failed to save songs", slog.Int("songs", len(batch)), slog.String("error", err.Error()))
+			for _, song := range batch {
+				report.fail(song.Path, err)
+			}
+		} else {
+			savedLyrics := make(map[int][]domain.Lyrics)
+			for i, song := range batch {
+				if song.Id > 0 {
+					result.updated++
+				} else {
+					result.added++
+				}
+				saved[song.AlbumId] = true
+				// Updated songs are included to drop the lyrics they no longer have
+				if song.Id > 0 || len(lyrics[i]) > 0 {
+					savedLyrics[savedSongs[i].Id] = lyrics[i]
+				}
+			}
+			s.saveLyrics(ctx, savedLyrics, report)
+		}
+		batch = batch[:0]
+		lyrics = lyrics[:0]
+	}
+
+	for file := range probed {
+		result.received++
+		s.progress.update(func(progress *domain.ScanProgress) {
+			progress.Indexed++
+		})
+		if ctx.Err() != nil {
+			continue
+		}
+
+		directoryID, err := s.resolveDirectory(ctx, catalog, filepath.Dir(file.path), file.musicFolderID)
+		if err != nil {
+			s.logger.Error("Failed to index directory", slog.String("path", file.path), slog.String("error", err.Error()))
+			report.fail(file.path, err)
+			continue
+		}
+
+		kept := make(map[int]bool)
+		for _, track := range splitCueTracks(file) {
+			songID := file.songIDs[track.startOffset]
+			kept[songID] = true
+
+			tagged := albumFromTags(track.metadata)
+			taggedArtist := albumArtist(track.metadata, tagged.Compilation)
+			taggedArtist.MusicFolderId = track.musicFolderID
+			artist, err := s.resolveArtist(ctx, catalog, taggedArtist)
+			if err != nil {
+				s.logger.Error("Failed to index artist", slog.String("path", file.path), slog.String("error", err.Error()))
+				report.fail(file.path, err)
+				continue
+			}
+			album, err := s.resolveAlbum(ctx, catalog, artist, tagged, track)
+			if err != nil {
+				s.logger.Error("Failed to index album", slog.String("path", file.path), slog.String("error", err.Error()))
+				report.fail(file.path, err)
+				continue
+			}
+
+			song := songFromFile(track)
+			song.SortName = s.sortName(song.Title, song.SortName)
+			song.Genres = splitGenres(metadataTag(track.metadata, "genre"), s.genreSeparators())
+			song.Id = songID
+			song.AlbumId = album.Id
+			song.Album = album.Name
+			song.CoverArt = album.CoverArt
+			song.MusicFolderId = track.musicFolderID
+			song.DirectoryId = directoryID
+
+			batch = append(batch, song)
+			lyrics = append(lyrics, songLyrics(track.lyrics, song))
+			if len(batch) >= batchSize {
+				flush()
+			}
+		}
+		for _, songID := range file.songIDs {
+			if !kept[songID] {
+				stale = append(stale, songID)
+			}
+		}
+	}
+	if ctx.Err() == nil {
+		flush()
+	}
+
+	if len(stale) > 0 && ctx.Err() == nil {
+		removed, err := s.repo.DeleteSongs(ctx, stale)
+		if err != nil {
+			s.logger.Error("Failed to remove songs of former cue sheet tracks", slog.Int("songs", len(stale)), slog.String("error", err.Error()))
+			report.fail("", fmt.Errorf("failed to remove songs of former cue sheet tracks: %w", err))
+		}
+		result.removed = removed
+	}
+	result.albums = slices.Sorted(maps.Keys(saved))
+	return result
+}
+
+// saveLyrics replaces the lyrics of the songs whose IDs are keys of lyrics.
+// Failures are recorded in report, the songs staying saved without their new lyrics.
+func (s *MediaScanningService) saveLyrics(ctx context.Context, lyrics map[int][]domain.Lyrics, report *scanReport) {
+	if len(lyrics) == 0 {
+		return
+	}
+	if err := s.lyrics.SaveLyrics(ctx, lyrics); err != nil {
+		s.logger.Error("Failed to save lyrics", slog.Int("songs", len(lyrics)), slog.String("error", err.Error()))
+		report.fail("", fmt.Errorf("failed to save lyrics of %d songs: %w", len(lyrics), err))
+	}
+}
+
+// songLyrics returns the lyrics of song, displayed with its artist and title unless they name their own.
+func songLyrics(lyrics []domain.Lyrics, song domain.Song) []domain.Lyrics {
+	displayed := make([]domain.Lyrics, 0, len(lyrics))
+	for _, l := range lyrics {
+		if l.DisplayArtist == "" {
+			l.DisplayArtist = song.Artist
+		}
+		if l.DisplayTitle == "" {
+			l.DisplayTitle = song.Title
+		}
+		displayed = append(displayed, l)
+	}
+	return displayed
+}
+
+// splitCueTracks splits a file described by a CUE sheet into one file per track, the tags of the sheet
+// replacing those of the file. The last track ends with the file. Other files are returned as is.
+func splitCueTracks(file scannedFile) []scannedFile {
+	if file.cue == nil {
+		return []scannedFile{file}
+	}
+
+	sheet, tracks := file.cue.sheet, file.cue.file.tracks
+	fileEnd := int(file.metadata.Duration * 1000)
+	split := make([]scannedFile, 0, len(tracks))
+	for i, track := range tracks {
+		end := fileEnd
+		if i+1 < len(tracks) {
+			end = tracks[i+1].start
+		}
+		if end != 0 && end <= track.start {
+			// The sheet describes a longer rip than this file
+			continue
+		}
+
+		tags := make(map[string]string, len(file.metadata.Tags))
+		for key, value := range file.metadata.Tags {
+			// Values measured or identifying the whole file do not apply to a single track
+			if !slices.Contains(wholeFileTags, strings.ToLower(key)) {
+				tags[key] = value
+			}
+		}
+		maps.Copy(tags, sheet.remarks)
+		if sheet.title != "" {
+			tags["album"] = sheet.title
+		}
+		if sheet.performer != "" {
+			tags["album_artist"] = sheet.performer
+			tags["artist"] = sheet.performer
+		}
+		if track.performer != "" {
+			tags["artist"] = track.performer
+		}
+		tags["title"] = track.title
+		if track.title == "" {
+			tags["title"] = fmt.Sprintf("Track %d", track.number)
+		}
+		tags["track"] = fmt.Sprintf("%d/%d", track.number, len(tracks))
+		maps.Copy(tags, track.remarks)
+
+		slice := file
+		slice.metadata = domain.MediaMetadata{
+			Tags:       tags,
+			BitRate:    file.metadata.BitRate,
+			HasPicture: file.metadata.HasPicture,
+		}
+		if end != 0 {
+			slice.metadata.Duration = float64(end-track.start) / 1000
+		}
+		slice.startOffset = track.start
+		slice.endOffset = end
+		split = append(split, slice)
+	}
+	return split
+}
+
+// analyzeLoudness measures the songs of albums that lack ReplayGain values and saves the computed gains.
+// Album gains are derived once every song of an album has track values.
+// Returns the number of analyzed songs.
+func (s *MediaScanningService) analyzeLoudness(ctx context.Context, albumIDs []int, report *scanReport) int {
+	analyzed := 0
+	for _, albumID := range albumIDs {
+		if ctx.Err() != nil {
+			return analyzed
+		}
+		songs, err := s.repo.GetSongsByAlbumID(ctx, albumID)
+		if err != nil {
+			s.logger.Error("Failed to load album songs for loudness analysis", slog.Int("album", albumID), slog.String("error", err.Error()))
+			report.fail("", fmt.Errorf("failed to load songs of album %d: %w", albumID, err))
+			continue
+		}
+
+		changed := make([]bool, len(songs))
+		for i, song := range songs {
+			if song.IsDir || song.ReplayGain.HasTrack() {
+				continue
+			}
+			loudness, err := s.analyzer.Analyze(ctx, song.Path, song.StartOffset, song.EndOffset)
+			if err != nil {
+				if ctx.Err() != nil {
+					return analyzed
+				}
+				s.logger.Warn("Failed to analyze loudness", slog.String("path", song.Path), slog.String("error", err.Error()))
+				report.fail(song.Path, fmt.Errorf("loudness analysis failed: %w", err))
+				continue
+			}
+			songs[i].ReplayGain.TrackGain = roundGain(replayGainReference - loudness.Integrated)
+			songs[i].ReplayGain.TrackPeak = loudness.TruePeak
+			changed[i] = true
+			analyzed++
+		}
+
+		if gain, peak, ok := albumReplayGain(songs); ok {
+			for i := range songs {
+				if !songs[i].IsDir && !songs[i].ReplayGain.HasAlbum() {
+					songs[i].ReplayGain.AlbumGain = gain
+					songs[i].ReplayGain.AlbumPeak = peak
+					changed[i] = true
+				}
+			}
+		}
+
+		var updates []domain.Song
+		for i, song := range songs {
+			if changed[i] {
+				updates = append(updates, song)
+			}
+		}
+		if len(updates) == 0 {
+			continue
+		}
+		if _, err := s.repo.SaveSongs(ctx, updates); err != nil {
+			s.logger.Error("Failed to save replay gain", slog.Int("album", albumID), slog.String("error", err.Error()))
+			for _, song := range updates {
+				report.fail(song.Path, err)
+			}
+		}
+	}
+	return analyzed
+}
+
+// albumReplayGain returns the album gain and peak of songs, computed from the duration weighted
+// energy of their track loudness. ok is false while a song has no track values.
+func albumReplayGain(songs []domain.Song) (gain float64, peak float64, ok bool) {
+	var energy, weight float64
+	for _, song := range songs {
+		if song.IsDir {
+			continue
+		}
+		if !song.ReplayGain.HasTrack() {
+			return 0, 0, false
+		}
+		duration := float64(max(song.Duration, 1))
+		energy += duration * math.Pow(10, (replayGainReference-song.ReplayGain.TrackGain)/10)
+		weight += duration
+		peak = max(peak, song.ReplayGain.TrackPeak)
+	}
+	if weight == 0 {
+		return 0, 0, false
+	}
+	return roundGain(replayGainReference - 10*math.Log10(energy/weight)), peak, true
+}
+
+// roundGain rounds a gain to the hundredth of a dB written by ReplayGain taggers.
+func roundGain(gain float64) float64 {
+	return math.Round(gain*100) / 100
+}
+
+// resolveArtist returns the indexed artist matching the album artist read from tags, creating it if needed.
+// Artists tagged with a MusicBrainz ID are looked up by ID, then by name among the artists without one
+// which take the ID. Artists sharing a name but tagged with different IDs are kept apart.
+// Indexed artists get the sort name and ID of the tags when they have none yet.
+func (s *MediaScanningService) resolveArtist(ctx context.Context, catalog *catalogCache, tagged domain.Artist) (domain.Artist, error) {
+	key := artistKey{musicFolderID: tagged.MusicFolderId, name: tagged.Name, musicBrainzID: tagged.MusicBrainzId}
+	if id, ok := catalog.artistIDs[key]; ok {
+		return catalog.artists[id], nil
+	}
+	tagged.SortName = s.sortName(tagged.Name, tagged.SortName)
+
+	var (
+		artist      domain.Artist
+		err         error
+		notFoundErr *ports.NotFoundError
+	)
+	if tagged.MusicBrainzId != "" {
+		artist, err = s.repo.GetArtistByMusicBrainzID(ctx, tagged.MusicFolderId, tagged.MusicBrainzId)
+	}
+	if tagged.MusicBrainzId == "" || errors.As(err, &notFoundErr) {
+		artist, err = s.repo.GetArtistByName(ctx, tagged.MusicFolderId, tagged.Name)
+		if err == nil && tagged.MusicBrainzId != "" && artist.MusicBrainzId != "" && artist.MusicBrainzId != tagged.MusicBrainzId {
+			// Another artist with the same name
+			err = &ports.NotFoundError{Message: "artist not found"}
+		}
+	}
+	switch {
+	case errors.As(err, &notFoundErr):
+		artist, err = s.repo.CreateArtist(ctx, tagged)
+	case err == nil && (artist.SortName == "" || artist.MusicBrainzId == "" && tagged.MusicBrainzId != ""):
+		if artist.SortName == "" {
+			artist.SortName = tagged.SortName
+		}
+		if artist.MusicBrainzId == "" {
+			artist.MusicBrainzId = tagged.MusicBrainzId
+		}
+		artist, err = s.repo.UpdateArtist(ctx, artist)
+	}
+	if err != nil {
+		return domain.Artist{}, err
+	}
+
+	catalog.artistIDs[key] = artist.Id
+	catalog.artists[artist.Id] = artist
+	return artist, nil
+}
+
+// resolveDirectory returns the ID of the indexed directory at path, in the music folder with musicFolderID,
+// creating it along with its missing parents up to the directory of the music folder.
+func (s *MediaScanningService) resolveDirectory(ctx context.Context, catalog *catalogCache, path string, musicFolderID int) (int, error) {
+	if id, ok := catalog.directories[path]; ok {
+		return id, nil
+	}
+
+	var notFoundErr *ports.NotFoundError
+	directory, err := s.repo.GetDirectoryByPath(ctx, path)
+	if errors.As(err, &notFoundErr) {
+		now := time.Now()
+		directory = domain.Directory{
+			MusicFolderId: musicFolderID,
+			Path:          path,
+			Name:          filepath.Base(path),
+			Created:       now.Format(time.RFC3339),
+			Updated:       now.UnixMilli(),
+		}
+		if parent := filepath.Dir(path); path != s.musicDirectoryOf(path) && parent != path {
+			if directory.ParentId, err = s.resolveDirectory(ctx, catalog, parent, musicFolderID); err != nil {
+				return 0, err
+			}
+		}
+		directory, err = s.repo.CreateDirectory(ctx, directory)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	catalog.directories[path] = directory.Id
+	return directory.Id, nil
+}
+
+// resolveAlbum returns the indexed release of artist with the name and year read from tags, creating it if needed.
+// Albums and artists without cover art pick up the folder image next to file, and albums get the sort name
+// and MusicBrainz ID of the tags when they have none yet.
+func (s *MediaScanningService) resolveAlbum(ctx context.Context, catalog *catalogCache, artist domain.Artist, tagged domain.Album, file scannedFile) (domain.Album, error) {
+	key := albumKey{artistID: artist.Id, name: tagged.Name, year: tagged.Year}
+	if album, ok := catalog.albums[key]; ok {
+		return album, nil
+	}
+	tagged.SortName = s.sortName(tagged.Name, tagged.SortName)
+
+	album, err := s.repo.GetAlbumByRelease(ctx, artist.Id, tagged.Name, tagged.Year)
+	var notFoundErr *ports.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		album, err = s.repo.CreateAlbum(ctx, domain.Album{
+			ArtistId:      artist.Id,
+			Name:          tagged.Name,
+			Created:       file.modTime.Format(time.RFC3339),
+			Artist:        artist.Name,
+			Year:          tagged.Year,
+			Compilation:   tagged.Compilation,
+			SortName:      tagged.SortName,
+			MusicBrainzId: tagged.MusicBrainzId,
+			MusicFolderId: artist.MusicFolderId,
+		})
+	}
+	if err != nil {
+		return domain.Album{}, err
+	}
+
+	changed := false
+	if album.SortName == "" {
+		album.SortName = tagged.SortName
+		changed = true
+	}
+	if album.MusicBrainzId == "" && tagged.MusicBrainzId != "" {
+		album.MusicBrainzId = tagged.MusicBrainzId
+		changed = true
+	}
+	if album.CoverArt == "" {
+		if coverID := s.indexCover(ctx, file, catalog.covers); coverID != "" {
+			album.CoverArt = coverID
+			changed = true
+		}
+	}
+	if changed {
+		if album, err = s.repo.UpdateAlbum(ctx, album); err != nil {
+			return domain.Album{}, err
+		}
+	}
+	if artist.CoverArt == "" && album.CoverArt != "" {
+		artist.CoverArt = album.CoverArt
+		if artist, err = s.repo.UpdateArtist(ctx, artist); err != nil {
+			return domain.Album{}, err
+		}
+		catalog.artists[artist.Id] = artist
+	}
+
+	catalog.albums[key] = album
+	return album, nil
+}
+
+// removeMissingFiles deletes the indexed songs whose files were not seen during the scan.
+// Songs below a music directory that could not be walked are kept.
+// Returns the number of removed songs.
+func (s *MediaScanningService) removeMissingFiles(ctx context.Context, fingerprints map[string][]domain.FileFingerprint, seen map[string]bool, failedRoots []string, report *scanReport) int {
+	var missing []int
+	for path, indexed := range fingerprints {
+		if !seen[path] && !isBelowAny(path, failedRoots) {
+			for _, fingerprint := range indexed {
+				missing = append(missing, fingerprint.SongId)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return 0
+	}
+
+	removed, err := s.repo.DeleteSongs(ctx, missing)
+	if err != nil {
+		s.logger.Error("Failed to remove missing songs", slog.Int("songs", len(missing)), slog.String("error", err.Error()))
+		report.fail("", fmt.Errorf("failed to remove missing songs: %w", err))
+		return 0
+	}
+	return removed
+}
+
+// importPlaylists syncs the playlists imported from the playlist files found during the scan, given with
+// their modification time, and removes the imported playlists whose file is gone.
+// A playlist is synced when its file is new or modified, or when songs were added since entries that could
+// not be resolved may now be. Entries are resolved to the songs indexed from the files they point to.
+// Playlists are owned by the configured playlist owner and visible to every user.
+// Returns the number of created and updated playlists.
+func (s *MediaScanningService) importPlaylists(ctx context.Context, files map[string]time.Time, dirs []string, partial bool, failedRoots []string, songsAdded bool, report *scanReport) int {
+	owner := s.playlistOwner()
+	existing, err := s.playlists.GetImportedPlaylists(ctx)
+	if err != nil {
+		s.logger.Error("Failed to load imported playlists", slog.String("error", err.Error()))
+		report.fail("", fmt.Errorf("failed to load imported playlists: %w", err))
+		return 0
+	}
+	imported := make(map[string]domain.Playlist, len(existing))
+	for _, playlist := range existing {
+		imported[playlist.Path] = playlist
+		_, found := files[playlist.Path]
+		if found || (partial && !isBelowAny(playlist.Path, dirs)) || isBelowAny(playlist.Path, failedRoots) {
+			continue
+		}
+		if err := s.playlists.DeletePlaylist(ctx, playlist.Id); err != nil {
+			s.logger.Error("Failed to remove playlist", slog.String("path", playlist.Path), slog.String("error", err.Error()))
+			report.fail(playlist.Path, fmt.Errorf("failed to remove playlist: %w", err))
+		}
+	}
+
+	var (
+		songIDs map[string][]int
+		synced  int
+	)
+	for _, path := range slices.Sorted(maps.Keys(files)) {
+		if ctx.Err() != nil {
+			return synced
+		}
+		modTime := files[path].UnixNano()
+		playlist, exists := imported[path]
+		if exists && playlist.ModTime == modTime && playlist.Owner == owner && !songsAdded {
+			continue
+		}
+
+		parsed, err := readPlaylistFile(path)
+		if err != nil {
+			s.logger.Warn("Failed to read playlist", slog.String("path", path), slog.String("error", err.Error()))
+			report.fail(path, err)
+			continue
+		}
+		if songIDs == nil {
+			if songIDs, err = s.loadSongIDs(ctx); err != nil {
+				s.logger.Error("Failed to load indexed files", slog.String("error", err.Error()))
+				report.fail("", fmt.Errorf("failed to load indexed files: %w", err))
+				return synced
+			}
+		}
+
+		now := time.Now().Format(time.RFC3339)
+		if !exists {
+			playlist = domain.Playlist{Path: path, Public: true, Created: now}
+		}
+		playlist.Name = parsed.name
+		playlist.Owner = owner
+		playlist.Changed = now
+		playlist.ModTime = modTime
+		playlist.SongIds = nil
+		unresolved := 0
+		for _, entry := range parsed.entries {
+			entryPath, ok := resolvePlaylistEntry(filepath.Dir(path), entry)
+			if !ok || len(songIDs[entryPath]) == 0 {
+				unresolved++
+				continue
+			}
+			playlist.SongIds = append(playlist.SongIds, songIDs[entryPath]...)
+		}
+		if unresolved > 0 {
+			s.logger.Info("Playlist entries not found in the library", slog.String("path", path), slog.Int("entries", unresolved))
+		}
+
+		if exists {
+			_, err = s.playlists.UpdatePlaylist(ctx, playlist)
+		} else {
+			_, err = s.playlists.CreatePlaylist(ctx, playlist)
+		}
+		if err != nil {
+			s.logger.Error("Failed to save playlist", slog.String("path", path), slog.String("error", err.Error()))
+			report.fail(path, fmt.Errorf("failed to save playlist: %w", err))
+			continue
+		}
+		synced++
+	}
+	return synced
+}
+
+// loadSongIDs returns the IDs of all indexed songs by path. The songs split from a file by a CUE sheet
+// are ordered by start offset, so that a playlist entry pointing to the file plays all of them.
+func (s *MediaScanningService) loadSongIDs(ctx context.Context) (map[string][]int, error) {
+	fingerprints, err := s.repo.GetSongFingerprints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(fingerprints, func(a, b domain.FileFingerprint) int {
+		return a.StartOffset - b.StartOffset
+	})
+	songIDs := make(map[string][]int, len(fingerprints))
+	for _, fingerprint := range fingerprints {
+		songIDs[fingerprint.Path] = append(songIDs[fingerprint.Path], fingerprint.SongId)
+	}
+	return songIDs, nil
+}
+
+// indexCover persists the album art of the album containing file, if any, and returns its cover ID.
+// Sources are tried in the configured priority order.
+func (s *MediaScanningService) indexCover(ctx context.Context, file scannedFile, createdCovers map[string]bool) string {
+	for _, source := range s.coverArtPriority() {
+		var (
+			path string
+			id   string
+		)
+		if strings.EqualFold(source, embeddedCoverSource) {
+			// Embedded pictures are identified by the audio file carrying them
+			id = coverIDForPath(file.path)
+			if createdCovers[id] {
+				return id
+			}
+			if !file.metadata.HasPicture {
+				continue
+			}
+			var err error
+			if path, err = s.cacheEmbeddedCover(ctx, file.path, id); err != nil {
+				s.logger.Warn("Failed to extract embedded cover", slog.String("path", file.path), slog.String("error", err.Error()))
+				continue
+			}
+		} else {
+			if path = findFolderCover(filepath.Dir(file.path), source); path == "" {
+				continue
+			}
+			id = coverIDForPath(path)
+			if createdCovers[id] {
+				return id
+			}
+		}
+
+		if _, err := s.repo.CreateCover(ctx, domain.Cover{Id: id, Path: path}); err != nil {
+			s.logger.Warn("Failed to create cover", slog.String("path", path), slog.String("error", err.Error()))
+			return ""
+		}
+		createdCovers[id] = true
+		return id
+	}
+	return ""
+}
+
+// cacheEmbeddedCover writes the picture embedded in the audio file at path to the cover cache directory.
+// Returns the path of the cached image.
+func (s *MediaScanningService) cacheEmbeddedCover(ctx context.Context, path string, id string) (string, error) {
+	picture, err := s.extractor.ExtractPicture(ctx, path)
+	if err != nil {
+		return "", err
+	}
+
+	dir := s.coverCacheDirectory()
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create cover cache directory: %w", err)
+	}
+	ext, ok := imageExtensions[picture.MimeType]
+	if !ok {
+		ext = ".jpg"
+	}
+	dest := filepath.Join(dir, id+ext)
+	if err := os.WriteFile(dest, picture.Data, 0o640); err != nil {
+		return "", fmt.Errorf("failed to write cover: %w", err)
+	}
+	return dest, nil
+}
+
+func (s *MediaScanningService) ignoredArticles() []string {
+	if s.config == nil || len(s.config.IgnoredArticles) == 0 {
+		return defaultIgnoredArticles
+	}
+	return s.config.IgnoredArticles
+}
+
+// sortName returns the sort name read from tags, or the name with its leading article ignored otherwise.
+func (s *MediaScanningService) sortName(name string, tagged string) string {
+	if tagged != "" {
+		return tagged
+	}
+	return sortNameIgnoringArticles(name, s.ignoredArticles())
+}
+
+func (s *MediaScanningService) genreSeparators() []string {
+	if s.config == nil || len(s.config.GenreSeparators) == 0 {
+		return defaultGenreSeparators
+	}
+	return s.config.GenreSeparators
+}
+
+// splitGenres splits a genre tag on any of separators, dropping empty and repeated genres while keeping their order.
+func splitGenres(value string, separators []string) []string {
+	values := []string{value}
+	for _, separator := range separators {
+		if separator == "" {
+			continue
+		}
+		var split []string
+		for _, v := range values {
+			split = append(split, strings.Split(v, separator)...)
+		}
+		values = split
+	}
+
+	var genres []string
+	for _, genre := range values {
+		genre = strings.TrimSpace(genre)
+		if genre != "" && !slices.Contains(genres, genre) {
+			genres = append(genres, genre)
+		}
+	}
+	return genres
+}
+
+func (s *MediaScanningService) coverArtPriority() []string {
+	if s.config == nil || len(s.config.CoverArtPriority) == 0 {
+		return defaultCoverArtPriority
+	}
+	return s.config.CoverArtPriority
+}
+
+func (s *MediaScanningService) coverCacheDirectory() string {
+	if s.config != nil && s.config.CoverCacheDirectory != "" {
+		return s.config.CoverCacheDirectory
+	}
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "musicstreaming", "covers")
+	}
+	return filepath.Join(os.TempDir(), "musicstreaming", "covers")
+}
+
+// songFromFile builds a domain Song from the probe results of a file.
+func songFromFile(file scannedFile) domain.Song {
+	suffix := fileSuffix(file.path)
+	song := domain.Song{
+		Title:         metadataTag(file.metadata, "title"),
+		Album:         metadataTag(file.metadata, "album"),
+		Artist:        metadataTag(file.metadata, "artist"),
+		Created:       file.modTime.Format(time.RFC3339),
+		Size:          file.size,
+		Suffix:        suffix,
+		ContentType:   supportedAudioFormats[suffix],
+		Path:          file.path,
+		ModTime:       file.modTime.UnixNano(),
+		Track:         metadataNumber(file.metadata, "track", "tracknumber"),
+		DiscNumber:    metadataNumber(file.metadata, "disc", "discnumber"),
+		Year:          metadataYear(file.metadata),
+		ReplayGain:    replayGainFromTags(file.metadata),
+		StartOffset:   file.startOffset,
+		EndOffset:     file.endOffset,
+		LyricsPath:    file.lyricsPath,
+		SortName:      metadataTag(file.metadata, titleSortTags...),
+		MusicBrainzId: musicBrainzID(file.metadata, recordingIDTags...),
+	}
+	if file.cue != nil {
+		song.CuePath = file.cue.path
+	}
+	if song.Title == "" {
+		song.Title = strings.TrimSuffix(filepath.Base(file.path), filepath.Ext(file.path))
+	}
+	if song.Artist == "" {
+		song.Artist = unknownArtist
+	}
+
+	song.Duration = int(file.metadata.Duration)
+	// Extractors report bits per second, Subsonic expects kilobits per second
+	song.BitRate = file.metadata.BitRate / 1000
+	return song
+}
+
+// metadataTag returns the first non-empty tag value matching one of keys, ignoring case.
+func metadataTag(metadata domain.MediaMetadata, keys ...string) string {
+	for _, key := range keys {
+		for tag, value := range metadata.Tags {
+			if strings.EqualFold(tag, key) && strings.TrimSpace(value) != "" {
+				return strings.TrimSpace(value)
+			}
+		}
+	}
+	return ""
+}
+
+// metadataNumber returns the number in the first tag matching one of keys, ignoring a total such as "3/12".
+// Returns 0 when no tag holds a number.
+func metadataNumber(metadata domain.MediaMetadata, keys ...string) int {
+	value, _, _ := strings.Cut(metadataTag(metadata, keys...), "/")
+	number, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || number < 0 {
+		return 0
+	}
+	return number
+}
+
+// metadataYear returns the release year of a file from its date tag, which may be a full date such as "2004-05-17".
+// Returns 0 when the date is missing or malformed.
+func metadataYear(metadata domain.MediaMetadata) int {
+	date := metadataTag(metadata, "date", "year")
+	if len(date) < 4 {
+		return 0
+	}
+	year, err := strconv.Atoi(date[:4])
+	if err != nil || year < 0 {
+		return 0
+	}
+	return year
+}
+
+// replayGainFromTags returns the ReplayGain values of a file's tags, such as "-7.89 dB" and "0.988".
+// Track or album values are only kept when both their gain and peak are tagged.
+func replayGainFromTags(metadata domain.MediaMetadata) domain.ReplayGain {
+	var replayGain domain.ReplayGain
+	trackGain, hasTrackGain := metadataFloat(metadata, "replaygain_track_gain")
+	trackPeak, hasTrackPeak := metadataFloat(metadata, "replaygain_track_peak")
+	if hasTrackGain && hasTrackPeak && trackPeak > 0 {
+		replayGain.TrackGain = trackGain
+		replayGain.TrackPeak = trackPeak
+	}
+	albumGain, hasAlbumGain := metadataFloat(metadata, "replaygain_album_gain")
+	albumPeak, hasAlbumPeak := metadataFloat(metadata, "replaygain_album_peak")
+	if hasAlbumGain && hasAlbumPeak && albumPeak > 0 {
+		replayGain.AlbumGain = albumGain
+		replayGain.AlbumPeak = albumPeak
+	}
+	return replayGain
+}
+
+// metadataFloat parses the number in the first tag matching one of keys, ignoring a "dB" unit.
+func metadataFloat(metadata domain.MediaMetadata, keys ...string) (float64, bool) {
+	value := metadataTag(metadata, keys...)
+	value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(value, "dB"), "db"))
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, false
+	}
+	return number, true
+}
+
+// metadataFlag reports whether the first tag matching one of keys is set, such as a compilation flag of "1".
+func metadataFlag(metadata domain.MediaMetadata, keys ...string) bool {
+	value := metadataTag(metadata, keys...)
+	if number, err := strconv.Atoi(value); err == nil {
+		return number != 0
+	}
+	flag, _ := strconv.ParseBool(value)
+	return flag
+}
+
+// musicBrainzID returns the MusicBrainz ID in the first tag matching one of keys.
+// Returns an empty string when the tag lists the IDs of several artists credited together.
+func musicBrainzID(metadata domain.MediaMetadata, keys ...string) string {
+	id := strings.ToLower(metadataTag(metadata, keys...))
+	if strings.ContainsAny(id, ";/,") {
+		return ""
+	}
+	return id
+}
+
+// albumFromTags returns the album a file belongs to as read from its tags.
+func albumFromTags(metadata domain.MediaMetadata) domain.Album {
+	album := domain.Album{
+		Name:          metadataTag(metadata, "album"),
+		Year:          metadataYear(metadata),
+		Compilation:   metadataFlag(metadata, "compilation"),
+		SortName:      metadataTag(metadata, albumSortTags...),
+		MusicBrainzId: musicBrainzID(metadata, releaseIDTags...),
+	}
+	if album.Name == "" {
+		album.Name = unknownAlbum
+	}
+	return album
+}
+
+// albumArtist returns the artist a file's album is grouped under: the album artist tag,
+// VariousArtists for compilations without one, then the track artist.
+// The sort name and MusicBrainz ID are those tagged for the same artist, empty when not tagged.
+func albumArtist(metadata domain.MediaMetadata, compilation bool) domain.Artist {
+	if name := metadataTag(metadata, "album_artist", "albumartist"); name != "" {
+		return domain.Artist{
+			Name:          name,
+			SortName:      metadataTag(metadata, albumArtistSortTags...),
+			MusicBrainzId: musicBrainzID(metadata, albumArtistIDTags...),
+		}
+	}
+	if compilation {
+		return domain.Artist{Name: domain.VariousArtists}
+	}
+	if name := metadataTag(metadata, "artist"); name != "" {
+		return domain.Artist{
+			Name:          name,
+			SortName:      metadataTag(metadata, artistSortTags...),
+			MusicBrainzId: musicBrainzID(metadata, artistIDTags...),
+		}
+	}
+	return domain.Artist{Name: unknownArtist}
+}
+
+// sortNameIgnoringArticles moves the leading article of name to its end, as in "Beatles, The".
+// Articles are matched ignoring case and must be followed by a space.
+func sortNameIgnoringArticles(name string, articles []string) string {
+	for _, article := range articles {
+		if len(name) <= len(article)+1 || name[len(article)] != ' ' || !strings.EqualFold(name[:len(article)], article) {
+			continue
+		}
+		if rest := strings.TrimSpace(name[len(article):]); rest != "" {
+			return rest + ", " + name[:len(article)]
+		}
+	}
+	return name
+}
+
+// findFolderCover returns the path of the first image in dir whose name matches pattern, or an empty string.
+// Names are matched case-insensitively.
+func findFolderCover(dir string, pattern string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	pattern = strings.ToLower(pattern)
+	for _, entry := range entries {
+		base := entry.Name()
+		if entry.IsDir() || !slices.Contains(supportedImageFormats, fileSuffix(base)) {
+			continue
+		}
+		if matched, _ := filepath.Match(pattern, strings.ToLower(base)); matched {
+			return filepath.Join(dir, base)
+		}
+	}
+	return ""
+}
+
+// coverIDForPath derives a stable cover ID from the image location.
+func coverIDForPath(path string) string {
+	sum := sha1.Sum([]byte(path)) // #nosec G401 -- used as an identifier, not for security
+	return hex.EncodeToString(sum[:8])
+}
+
+func isSupportedAudioFile(path string) bool {
+	_, ok := supportedAudioFormats[fileSuffix(path)]
+	return ok
+}
+
+func fileSuffix(path string) string {
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// isBelowAny reports whether path is located inside one of dirs.
+func isBelowAny(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if rel, err := filepath.Rel(dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,1389 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"music-streaming/internal/core/config"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"music-streaming/internal/core/services/mocks"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMediaScanningService_StartScan(t *testing.T) {
+	tests := []struct {
+		name             string
+		user             *domain.User
+		alreadyScanning  bool
+		expectedScanning bool
+		expectedError    error
+	}{
+		{
+			name:             "successful start with admin role",
+			user:             &domain.User{Username: "admin", AdminRole: true},
+			expectedScanning: true,
+			expectedError:    nil,
+		},
+		{
+			name:             "scan already in progress",
+			user:             &domain.User{Username: "admin", AdminRole: true},
+			alreadyScanning:  true,
+			expectedScanning: true,
+			expectedError:    nil,
+		},
+		{
+			name:          "unauthorized - no admin role",
+			user:          &domain.User{Username: "user", AdminRole: false},
+			expectedError: &ports.NotAuthorizedError{Username: "user", Action: "start media scan"},
+		},
+		{
+			name:          "unauthorized - nil user",
+			user:          nil,
+			expectedError: &ports.NotAuthorizedError{Username: "", Action: "start media scan"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			// The background scan runs against an empty library
+			repo.EXPECT().GetSongFingerprints(mock.Anything).Return(nil, nil).Maybe()
+			repo.EXPECT().SaveMusicFolders(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+			repo.EXPECT().DeleteOrphans(mock.Anything).Return(0, nil).Maybe()
+			repo.EXPECT().UpdateCatalogStats(mock.Anything).Return(nil).Maybe()
+			scanRuns := mocks.NewMockMediaScanningRepository(t)
+			scanRuns.EXPECT().CreateScanRun(mock.Anything, mock.Anything).Return(domain.ScanRun{Id: 1}, nil).Maybe()
+			scanRuns.EXPECT().FinishScanRun(mock.Anything, mock.Anything).Return(nil).Maybe()
+			service := NewMediaScanningService(repo, scanRuns, mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{}, slog.Default())
+			service.scanStatus.Scanning = tt.alreadyScanning
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = context.WithValue(ctx, ports.KeyRequestingUserID, tt.user)
+			}
+
+			result, err := service.StartScan(ctx)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if result.Scanning != tt.expectedScanning {
+					t.Errorf("expected scanning %v, got %v", tt.expectedScanning, result.Scanning)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_StopScan(t *testing.T) {
+	tests := []struct {
+		name              string
+		user              *domain.User
+		scanning          bool
+		expectedCancelled bool
+		expectedError     error
+	}{
+		{
+			name:              "running scan cancelled with admin role",
+			user:              &domain.User{Username: "admin", AdminRole: true},
+			scanning:          true,
+			expectedCancelled: true,
+		},
+		{
+			name: "no scan in progress",
+			user: &domain.User{Username: "admin", AdminRole: true},
+		},
+		{
+			name:          "unauthorized - no admin role",
+			user:          &domain.User{Username: "user", AdminRole: false},
+			scanning:      true,
+			expectedError: &ports.NotAuthorizedError{Username: "user", Action: "stop media scan"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			service := NewMediaScanningService(repo, mocks.NewMockMediaScanningRepository(t), mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{}, slog.Default())
+			scanCtx := context.Background()
+			if tt.scanning {
+				scanCtx, _, _ = service.beginScan(context.Background())
+			}
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.StopScan(ctx)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if result.Scanning != tt.scanning {
+					t.Errorf("expected scanning %v, got %v", tt.scanning, result.Scanning)
+				}
+			}
+			if cancelled := scanCtx.Err() != nil; cancelled != tt.expectedCancelled {
+				t.Errorf("expected scan cancelled %v, got %v", tt.expectedCancelled, cancelled)
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_GetScanStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          *domain.User
+		status        domain.ScanStatus
+		expectedError error
+	}{
+		{
+			name:          "successful retrieval with admin role",
+			user:          &domain.User{Username: "admin", AdminRole: true},
+			status:        domain.ScanStatus{Scanning: true, Count: 42},
+			expectedError: nil,
+		},
+		{
+			name:          "unauthorized - no admin role",
+			user:          &domain.User{Username: "user", AdminRole: false},
+			expectedError: &ports.NotAuthorizedError{Username: "user", Action: "get media scan status"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			service := NewMediaScanningService(repo, mocks.NewMockMediaScanningRepository(t), mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{}, slog.Default())
+			*service.scanStatus = tt.status
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetScanStatus(ctx)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if result != tt.status {
+					t.Errorf("expected status %+v, got %+v", tt.status, result)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_GetScanRuns(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          *domain.User
+		size          int
+		offset        int
+		runs          []domain.ScanRun
+		expectedError error
+	}{
+		{
+			name: "successful retrieval with admin role",
+			user: &domain.User{Username: "admin", AdminRole: true},
+			size: 20,
+			runs: []domain.ScanRun{
+				{Id: 2, Trigger: domain.ScanTriggerWatcher, Partial: true, Added: 1},
+				{Id: 1, Trigger: domain.ScanTriggerManual, Failed: 3},
+			},
+		},
+		{
+			name:          "invalid size",
+			user:          &domain.User{Username: "admin", AdminRole: true},
+			size:          0,
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "size or offset"},
+		},
+		{
+			name:          "invalid offset",
+			user:          &domain.User{Username: "admin", AdminRole: true},
+			size:          20,
+			offset:        -1,
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "size or offset"},
+		},
+		{
+			name:          "unauthorized - no admin role",
+			user:          &domain.User{Username: "user", AdminRole: false},
+			size:          20,
+			expectedError: &ports.NotAuthorizedError{Username: "user", Action: "get media scan runs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanRuns := mocks.NewMockMediaScanningRepository(t)
+			if tt.expectedError == nil {
+				scanRuns.EXPECT().GetScanRuns(mock.Anything, tt.size, tt.offset).Return(tt.runs, nil)
+			}
+			service := NewMediaScanningService(mocks.NewMockMediaBrowsingRepository(t), scanRuns, mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{}, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetScanRuns(ctx, tt.size, tt.offset)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if len(result) != len(tt.runs) {
+					t.Errorf("expected %d scan runs, got %d", len(tt.runs), len(result))
+				}
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_GetScanRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          *domain.User
+		id            int
+		run           domain.ScanRun
+		repoError     error
+		expectedError error
+	}{
+		{
+			name: "successful retrieval with errors",
+			user: &domain.User{Username: "admin", AdminRole: true},
+			id:   1,
+			run: domain.ScanRun{
+				Id:      1,
+				Trigger: domain.ScanTriggerManual,
+				Failed:  1,
+				Errors:  []domain.ScanError{{Path: "/music/broken.mp3", Message: "invalid data found"}},
+			},
+		},
+		{
+			name:          "scan run not found",
+			user:          &domain.User{Username: "admin", AdminRole: true},
+			id:            999,
+			repoError:     &ports.NotFoundError{Message: "scan run not found"},
+			expectedError: &ports.NotFoundError{Message: "scan run not found"},
+		},
+		{
+			name:          "unauthorized - no admin role",
+			user:          &domain.User{Username: "user", AdminRole: false},
+			id:            1,
+			expectedError: &ports.NotAuthorizedError{Username: "user", Action: "get media scan run"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanRuns := mocks.NewMockMediaScanningRepository(t)
+			if tt.user.AdminRole {
+				scanRuns.EXPECT().GetScanRun(mock.Anything, tt.id).Return(tt.run, tt.repoError)
+			}
+			service := NewMediaScanningService(mocks.NewMockMediaBrowsingRepository(t), scanRuns, mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{}, slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetScanRun(ctx, tt.id)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if result.Id != tt.run.Id || len(result.Errors) != len(tt.run.Errors) {
+					t.Errorf("expected scan run %+v, got %+v", tt.run, result)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_SyncMusicFolders(t *testing.T) {
+	musicDirectories := []string{"/music/adults", "/music/kids"}
+	tests := []struct {
+		name          string
+		repoError     error
+		expectedError error
+	}{
+		{
+			name: "configured music directories saved",
+		},
+		{
+			name:          "repository error",
+			repoError:     errors.New("database error"),
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			repo.EXPECT().SaveMusicFolders(mock.Anything, musicDirectories).Return(nil, tt.repoError)
+			service := NewMediaScanningService(repo, mocks.NewMockMediaScanningRepository(t), mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{MusicDirectories: musicDirectories}, slog.Default())
+
+			err := service.SyncMusicFolders(context.Background())
+
+			if tt.expectedError != nil {
+				if err == nil || err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_Scan(t *testing.T) {
+	tests := []struct {
+		name            string
+		rootMissing     bool
+		indexedMissing  bool
+		indexedIgnored  bool
+		cancelled       bool
+		newFile         bool
+		unreadable      bool
+		expectedDeleted bool
+		expectedRun     domain.ScanRun
+	}{
+		{
+			name:        "new file indexed",
+			newFile:     true,
+			expectedRun: domain.ScanRun{Added: 1},
+		},
+		{
+			name:        "unreadable file reported",
+			newFile:     true,
+			unreadable:  true,
+			expectedRun: domain.ScanRun{Failed: 1},
+		},
+		{
+			name:            "unchanged files are kept and missing files removed",
+			indexedMissing:  true,
+			expectedDeleted: true,
+			expectedRun:     domain.ScanRun{Removed: 1},
+		},
+		{
+			name:            "indexed files excluded since are removed",
+			indexedIgnored:  true,
+			expectedDeleted: true,
+			expectedRun:     domain.ScanRun{Removed: 1},
+		},
+		{
+			name:            "nothing to remove",
+			indexedMissing:  false,
+			expectedDeleted: false,
+		},
+		{
+			name:            "songs kept when music directory is unavailable",
+			rootMissing:     true,
+			indexedMissing:  true,
+			expectedDeleted: false,
+			expectedRun:     domain.ScanRun{Failed: 1},
+		},
+		{
+			name:            "songs kept when scan is cancelled",
+			indexedMissing:  true,
+			cancelled:       true,
+			expectedDeleted: false,
+			expectedRun:     domain.ScanRun{Cancelled: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filepath.Join(t.TempDir(), "music")
+			if err := os.Mkdir(root, 0o700); err != nil {
+				t.Fatalf("failed to create music directory: %v", err)
+			}
+			unchanged := filepath.Join(root, "unchanged.mp3")
+			if err := os.WriteFile(unchanged, []byte("audio"), 0o600); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+			stat, err := os.Stat(unchanged)
+			if err != nil {
+				t.Fatalf("failed to stat test file: %v", err)
+			}
+
+			fingerprints := []domain.FileFingerprint{
+				{SongId: 1, AlbumId: 1, Path: unchanged, Size: stat.Size(), ModTime: stat.ModTime().UnixNano()},
+			}
+			if tt.indexedMissing {
+				fingerprints = append(fingerprints, domain.FileFingerprint{SongId: 2, AlbumId: 1, Path: filepath.Join(root, "gone.flac")})
+			}
+			if tt.indexedIgnored {
+				sample := filepath.Join(root, "Samples", "kick.wav")
+				if err := os.Mkdir(filepath.Dir(sample), 0o700); err != nil {
+					t.Fatalf("failed to create test folder: %v", err)
+				}
+				if err := os.WriteFile(sample, []byte("audio"), 0o600); err != nil {
+					t.Fatalf("failed to create test file: %v", err)
+				}
+				if err := os.WriteFile(filepath.Join(root, ignoreFileName), []byte("Samples/\n"), 0o600); err != nil {
+					t.Fatalf("failed to create ignore file: %v", err)
+				}
+				fingerprints = append(fingerprints, domain.FileFingerprint{SongId: 2, AlbumId: 1, Path: sample})
+			}
+			added := filepath.Join(root, "added.flac")
+			if tt.newFile {
+				if err := os.WriteFile(added, []byte("audio"), 0o600); err != nil {
+					t.Fatalf("failed to create test file: %v", err)
+				}
+			}
+			if tt.rootMissing {
+				if err := os.RemoveAll(root); err != nil {
+					t.Fatalf("failed to remove music directory: %v", err)
+				}
+			}
+
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			repo.EXPECT().GetSongFingerprints(mock.Anything).Return(fingerprints, nil)
+			repo.EXPECT().SaveMusicFolders(mock.Anything, []string{root}).Return([]domain.MusicFolder{{Id: 5, Name: "music", Path: root}}, nil)
+			if tt.expectedDeleted {
+				repo.EXPECT().DeleteSongs(mock.Anything, []int{2}).Return(1, nil)
+			}
+			repo.EXPECT().DeleteOrphans(mock.Anything).Return(0, nil)
+			repo.EXPECT().UpdateCatalogStats(mock.Anything).Return(nil)
+			extractor := mocks.NewMockMetadataExtractor(t)
+			if tt.unreadable {
+				extractor.EXPECT().Extract(mock.Anything, added).Return(domain.MediaMetadata{}, errors.New("unsupported format"))
+			} else if tt.newFile {
+				extractor.EXPECT().Extract(mock.Anything, added).Return(domain.MediaMetadata{
+					Tags:     map[string]string{"title": "Added", "artist": "Artist", "album": "Album", "date": "2004-05-17"},
+					Duration: 180.5,
+					BitRate:  320000,
+				}, nil)
+				repo.EXPECT().GetDirectoryByPath(mock.Anything, root).Return(domain.Directory{}, &ports.NotFoundError{Message: "directory not found"})
+				repo.EXPECT().CreateDirectory(mock.Anything, mock.MatchedBy(func(directory domain.Directory) bool {
+					return directory.Path == root && directory.Name == "music" && directory.ParentId == 0 && directory.MusicFolderId == 5 && directory.Updated > 0
+				})).Return(domain.Directory{Id: 9, Path: root, Name: "music", MusicFolderId: 5}, nil)
+				repo.EXPECT().GetArtistByName(mock.Anything, 5, "Artist").Return(domain.Artist{}, &ports.NotFoundError{Message: "artist not found"})
+				repo.EXPECT().CreateArtist(mock.Anything, domain.Artist{Name: "Artist", SortName: "Artist", MusicFolderId: 5}).Return(domain.Artist{Id: 3, Name: "Artist", SortName: "Artist", MusicFolderId: 5}, nil)
+				repo.EXPECT().GetAlbumByRelease(mock.Anything, 3, "Album", 2004).Return(domain.Album{}, &ports.NotFoundError{Message: "album not found"})
+				repo.EXPECT().CreateAlbum(mock.Anything, mock.MatchedBy(func(album domain.Album) bool {
+					return album.ArtistId == 3 && album.Name == "Album" && album.Year == 2004 && !album.Compilation && album.SortName == "Album" && album.MusicFolderId == 5
+				})).Return(domain.Album{Id: 4, ArtistId: 3, Name: "Album", Year: 2004, SortName: "Album", MusicFolderId: 5}, nil)
+				repo.EXPECT().SaveSongs(mock.Anything, mock.MatchedBy(func(songs []domain.Song) bool {
+					return len(songs) == 1 && songs[0].Id == 0 && songs[0].Path == added && songs[0].AlbumId == 4 &&
+						songs[0].Title == "Added" && songs[0].Duration == 180 && songs[0].BitRate == 320 && songs[0].MusicFolderId == 5 && songs[0].DirectoryId == 9
+				})).RunAndReturn(func(ctx context.Context, songs []domain.Song) ([]domain.Song, error) {
+					return songs, nil
+				})
+			}
+
+			scanRuns := mocks.NewMockMediaScanningRepository(t)
+			scanRuns.EXPECT().CreateScanRun(mock.Anything, mock.MatchedBy(func(run domain.ScanRun) bool {
+				return run.Trigger == domain.ScanTriggerManual && !run.Partial && run.Started != ""
+			})).RunAndReturn(func(ctx context.Context, run domain.ScanRun) (domain.ScanRun, error) {
+				run.Id = 7
+				return run, nil
+			})
+			var finished domain.ScanRun
+			scanRuns.EXPECT().FinishScanRun(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, run domain.ScanRun) error {
+				finished = run
+				return nil
+			})
+
+			service := NewMediaScanningService(repo, scanRuns, mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), extractor, mocks.NewMockLoudnessAnalyzer(t), &config.Config{MusicDirectories: []string{root}}, slog.Default())
+			service.scanStatus.Scanning = true
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+
+			service.Scan(ctx, domain.ScanTriggerManual)
+
+			if service.scanStatus.Scanning {
+				t.Errorf("expected scanning to be reset")
+			}
+			if finished.Id != 7 || finished.Finished == "" {
+				t.Errorf("expected scan run 7 to be finished, got %+v", finished)
+			}
+			if finished.Added != tt.expectedRun.Added || finished.Removed != tt.expectedRun.Removed ||
+				finished.Failed != tt.expectedRun.Failed || finished.Cancelled != tt.expectedRun.Cancelled {
+				t.Errorf("expected scan run %+v, got %+v", tt.expectedRun, finished)
+			}
+			if len(finished.Errors) != finished.Failed {
+				t.Errorf("expected %d scan errors, got %+v", finished.Failed, finished.Errors)
+			}
+			if tt.unreadable && (len(finished.Errors) != 1 || finished.Errors[0].Path != added) {
+				t.Errorf("expected scan error for %s, got %+v", added, finished.Errors)
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_IndexFiles_CueSheet(t *testing.T) {
+	sheet, err := parseCueSheet([]byte(testCueSheet))
+	if err != nil {
+		t.Fatalf("failed to parse cue sheet: %v", err)
+	}
+	probed := make(chan scannedFile, 1)
+	probed <- scannedFile{
+		path:     "/music/Symphonies.flac",
+		metadata: domain.MediaMetadata{Duration: 600},
+		// The file was indexed as a single song, then with a sheet listing a track that is gone since
+		songIDs: map[int]int{0: 10, 500000: 11},
+		cue:     &cueSheetRef{path: "/music/Symphonies.cue", sheet: sheet, file: &sheet.files[0]},
+	}
+	close(probed)
+
+	repo := mocks.NewMockMediaBrowsingRepository(t)
+	repo.EXPECT().GetDirectoryByPath(mock.Anything, "/music").Return(domain.Directory{Id: 3, Path: "/music", Name: "music"}, nil)
+	repo.EXPECT().GetArtistByName(mock.Anything, 0, "Orchestra").Return(domain.Artist{Id: 1, Name: "Orchestra", CoverArt: "cover", SortName: "Orchestra"}, nil)
+	repo.EXPECT().GetAlbumByRelease(mock.Anything, 1, "Symphonies", 1998).Return(domain.Album{Id: 2, ArtistId: 1, Name: "Symphonies", CoverArt: "cover", SortName: "Symphonies"}, nil)
+	repo.EXPECT().SaveSongs(mock.Anything, mock.MatchedBy(func(songs []domain.Song) bool {
+		return len(songs) == 3 && songs[0].Id == 10 && songs[0].EndOffset == 215000 &&
+			songs[1].Id == 0 && songs[1].StartOffset == 215000 && songs[2].Id == 0 && songs[2].EndOffset == 600000 &&
+			songs[0].DirectoryId == 3 && songs[1].DirectoryId == 3 && songs[2].DirectoryId == 3
+	})).RunAndReturn(func(ctx context.Context, songs []domain.Song) ([]domain.Song, error) {
+		return songs, nil
+	})
+	repo.EXPECT().DeleteSongs(mock.Anything, []int{11}).Return(1, nil)
+	// Embedded lyrics of the whole file are dropped from the song indexed before the sheet
+	lyrics := mocks.NewMockLyricsRepository(t)
+	lyrics.EXPECT().SaveLyrics(mock.Anything, map[int][]domain.Lyrics{10: {}}).Return(nil)
+	service := NewMediaScanningService(repo, mocks.NewMockMediaScanningRepository(t), mocks.NewMockPlaylistRepository(t), lyrics, mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{}, slog.Default())
+
+	result := service.indexFiles(context.Background(), probed, &scanReport{})
+
+	if result.added != 2 || result.updated != 1 || result.removed != 1 || result.received != 1 {
+		t.Errorf("expected 2 added, 1 updated and 1 removed song from 1 file, got %+v", result)
+	}
+	if !slices.Equal(result.albums, []int{2}) {
+		t.Errorf("expected album 2 to be saved, got %v", result.albums)
+	}
+}
+
+func TestMediaScanningService_IndexFiles_Lyrics(t *testing.T) {
+	probed := make(chan scannedFile, 1)
+	probed <- scannedFile{
+		path:       "/music/Artist/01 Song.mp3",
+		metadata:   domain.MediaMetadata{Tags: map[string]string{"title": "Song", "artist": "Artist", "album": "Album"}},
+		songIDs:    map[int]int{},
+		lyricsPath: "/music/Artist/01 Song.lrc",
+		lyrics: []domain.Lyrics{
+			{Lang: "eng", Synced: true, DisplayTitle: "Song (Radio Edit)", Lines: []domain.LyricsLine{{Start: 1000, Value: "Line"}}},
+		},
+	}
+	close(probed)
+
+	repo := mocks.NewMockMediaBrowsingRepository(t)
+	repo.EXPECT().GetDirectoryByPath(mock.Anything, "/music/Artist").Return(domain.Directory{Id: 3, ParentId: 1, Path: "/music/Artist", Name: "Artist"}, nil)
+	repo.EXPECT().GetArtistByName(mock.Anything, 0, "Artist").Return(domain.Artist{Id: 1, Name: "Artist", CoverArt: "cover", SortName: "Artist"}, nil)
+	repo.EXPECT().GetAlbumByRelease(mock.Anything, 1, "Album", 0).Return(domain.Album{Id: 2, ArtistId: 1, Name: "Album", CoverArt: "cover", SortName: "Album"}, nil)
+	repo.EXPECT().SaveSongs(mock.Anything, mock.MatchedBy(func(songs []domain.Song) bool {
+		return len(songs) == 1 && songs[0].LyricsPath == "/music/Artist/01 Song.lrc"
+	})).RunAndReturn(func(ctx context.Context, songs []domain.Song) ([]domain.Song, error) {
+		saved := slices.Clone(songs)
+		saved[0].Id = 5
+		return saved, nil
+	})
+	lyrics := mocks.NewMockLyricsRepository(t)
+	lyrics.EXPECT().SaveLyrics(mock.Anything, map[int][]domain.Lyrics{
+		5: {{Lang: "eng", Synced: true, DisplayArtist: "Artist", DisplayTitle: "Song (Radio Edit)", Lines: []domain.LyricsLine{{Start: 1000, Value: "Line"}}}},
+	}).Return(nil)
+	service := NewMediaScanningService(repo, mocks.NewMockMediaScanningRepository(t), mocks.NewMockPlaylistRepository(t), lyrics, mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{}, slog.Default())
+
+	result := service.indexFiles(context.Background(), probed, &scanReport{})
+
+	if result.added != 1 || result.received != 1 {
+		t.Errorf("expected 1 added song from 1 file, got %+v", result)
+	}
+}
+
+func TestSongFromFile(t *testing.T) {
+	modTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		file         scannedFile
+		expectedSong domain.Song
+	}{
+		{
+			name: "tags and format information",
+			file: scannedFile{
+				path:    "/music/artist/album/01 - track.FLAC",
+				size:    1234,
+				modTime: modTime,
+				metadata: domain.MediaMetadata{
+					Duration: 215.48,
+					BitRate:  954000,
+					Tags:     map[string]string{"TITLE": "Track", "ARTIST": "Artist", "ALBUM": "Album"},
+				},
+			},
+			expectedSong: domain.Song{
+				Title:       "Track",
+				Album:       "Album",
+				Artist:      "Artist",
+				Created:     "2024-01-01T12:00:00Z",
+				Duration:    215,
+				BitRate:     954,
+				Size:        1234,
+				Suffix:      "flac",
+				ContentType: "audio/flac",
+				Path:        "/music/artist/album/01 - track.FLAC",
+				ModTime:     modTime.UnixNano(),
+			},
+		},
+		{
+			name: "track, disc and year numbers",
+			file: scannedFile{
+				path:    "/music/artist/album/cd2/03 - track.mp3",
+				modTime: modTime,
+				metadata: domain.MediaMetadata{
+					Tags: map[string]string{"title": "Track", "artist": "Artist", "track": "3/12", "disc": "2/2", "date": "1999-10-04"},
+				},
+			},
+			expectedSong: domain.Song{
+				Title:       "Track",
+				Artist:      "Artist",
+				Created:     "2024-01-01T12:00:00Z",
+				Suffix:      "mp3",
+				ContentType: "audio/mpeg",
+				Path:        "/music/artist/album/cd2/03 - track.mp3",
+				ModTime:     modTime.UnixNano(),
+				Track:       3,
+				DiscNumber:  2,
+				Year:        1999,
+			},
+		},
+		{
+			name: "replay gain tags",
+			file: scannedFile{
+				path:    "/music/artist/album/track.ogg",
+				modTime: modTime,
+				metadata: domain.MediaMetadata{
+					Tags: map[string]string{
+						"title":                 "Track",
+						"artist":                "Artist",
+						"replaygain_track_gain": "-7.89 dB",
+						"replaygain_track_peak": "0.988",
+						"replaygain_album_gain": "-6.5 dB",
+					},
+				},
+			},
+			expectedSong: domain.Song{
+				Title:       "Track",
+				Artist:      "Artist",
+				Created:     "2024-01-01T12:00:00Z",
+				Suffix:      "ogg",
+				ContentType: "audio/ogg",
+				Path:        "/music/artist/album/track.ogg",
+				ModTime:     modTime.UnixNano(),
+				ReplayGain:  domain.ReplayGain{TrackGain: -7.89, TrackPeak: 0.988},
+			},
+		},
+		{
+			name: "missing tags fall back to file name",
+			file: scannedFile{
+				path:     "/music/untagged.mp3",
+				modTime:  modTime,
+				metadata: domain.MediaMetadata{},
+			},
+			expectedSong: domain.Song{
+				Title:       "untagged",
+				Artist:      unknownArtist,
+				Created:     "2024-01-01T12:00:00Z",
+				Suffix:      "mp3",
+				ContentType: "audio/mpeg",
+				Path:        "/music/untagged.mp3",
+				ModTime:     modTime.UnixNano(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := songFromFile(tt.file)
+			if !reflect.DeepEqual(result, tt.expectedSong) {
+				t.Errorf("expected song %+v, got %+v", tt.expectedSong, result)
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_AnalyzeLoudness(t *testing.T) {
+	tagged := domain.ReplayGain{TrackGain: -4, TrackPeak: 0.9}
+	tests := []struct {
+		name          string
+		songs         []domain.Song
+		loudness      map[string]domain.Loudness
+		analyzeErrors map[string]error
+		expectedSaved []domain.Song
+		expectedCount int
+		expectedFails int
+	}{
+		{
+			name: "track and album gains computed",
+			songs: []domain.Song{
+				{Id: 1, AlbumId: 1, Path: "/music/1.flac", Duration: 100},
+				{Id: 2, AlbumId: 1, Path: "/music/2.flac", Duration: 100, ReplayGain: tagged},
+			},
+			loudness: map[string]domain.Loudness{"/music/1.flac": {Integrated: -8, TruePeak: 1}},
+			expectedSaved: []domain.Song{
+				{Id: 1, AlbumId: 1, Path: "/music/1.flac", Duration: 100, ReplayGain: domain.ReplayGain{TrackGain: -10, TrackPeak: 1, AlbumGain: -7.96, AlbumPeak: 1}},
+				{Id: 2, AlbumId: 1, Path: "/music/2.flac", Duration: 100, ReplayGain: domain.ReplayGain{TrackGain: -4, TrackPeak: 0.9, AlbumGain: -7.96, AlbumPeak: 1}},
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "tagged songs left untouched",
+			songs: []domain.Song{
+				{Id: 1, AlbumId: 1, Path: "/music/1.flac", ReplayGain: domain.ReplayGain{TrackGain: -4, TrackPeak: 0.9, AlbumGain: -5, AlbumPeak: 0.95}},
+			},
+		},
+		{
+			name: "album gain waits for every track",
+			songs: []domain.Song{
+				{Id: 1, AlbumId: 1, Path: "/music/1.flac", Duration: 100},
+				{Id: 2, AlbumId: 1, Path: "/music/2.flac", Duration: 100},
+			},
+			loudness:      map[string]domain.Loudness{"/music/1.flac": {Integrated: -18, TruePeak: 0.5}},
+			analyzeErrors: map[string]error{"/music/2.flac": errors.New("ffmpeg failed")},
+			expectedSaved: []domain.Song{
+				{Id: 1, AlbumId: 1, Path: "/music/1.flac", Duration: 100, ReplayGain: domain.ReplayGain{TrackGain: 0, TrackPeak: 0.5}},
+			},
+			expectedCount: 1,
+			expectedFails: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			repo.EXPECT().GetSongsByAlbumID(mock.Anything, 1).Return(slices.Clone(tt.songs), nil)
+			var saved []domain.Song
+			if tt.expectedSaved != nil {
+				repo.EXPECT().SaveSongs(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, songs []domain.Song) ([]domain.Song, error) {
+					saved = songs
+					return songs, nil
+				})
+			}
+			analyzer := mocks.NewMockLoudnessAnalyzer(t)
+			for path, loudness := range tt.loudness {
+				analyzer.EXPECT().Analyze(mock.Anything, path, 0, 0).Return(loudness, nil)
+			}
+			for path, err := range tt.analyzeErrors {
+				analyzer.EXPECT().Analyze(mock.Anything, path, 0, 0).Return(domain.Loudness{}, err)
+			}
+			service := NewMediaScanningService(repo, mocks.NewMockMediaScanningRepository(t), mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), analyzer, &config.Config{LoudnessAnalysis: true}, slog.Default())
+			report := &scanReport{}
+
+			count := service.analyzeLoudness(context.Background(), []int{1}, report)
+
+			if count != tt.expectedCount {
+				t.Errorf("expected %d analyzed songs, got %d", tt.expectedCount, count)
+			}
+			if report.failed != tt.expectedFails {
+				t.Errorf("expected %d failures, got %d", tt.expectedFails, report.failed)
+			}
+			if !reflect.DeepEqual(saved, tt.expectedSaved) {
+				t.Errorf("expected saved songs %+v, got %+v", tt.expectedSaved, saved)
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_ImportPlaylists(t *testing.T) {
+	root := t.TempDir()
+	playlistPath := filepath.Join(root, "Playlists", "Mix.m3u")
+	if err := os.MkdirAll(filepath.Dir(playlistPath), 0o700); err != nil {
+		t.Fatalf("failed to create playlist directory: %v", err)
+	}
+	content := "#EXTM3U\n../Band/01 Song.mp3\n" + filepath.Join(root, "Orchestra", "Symphonies.flac") + "\n../missing.mp3\n"
+	if err := os.WriteFile(playlistPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to create playlist: %v", err)
+	}
+	modTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	fingerprints := []domain.FileFingerprint{
+		{SongId: 7, Path: filepath.Join(root, "Orchestra", "Symphonies.flac"), StartOffset: 215000},
+		{SongId: 1, Path: filepath.Join(root, "Band", "01 Song.mp3")},
+		{SongId: 6, Path: filepath.Join(root, "Orchestra", "Symphonies.flac")},
+	}
+
+	tests := []struct {
+		name            string
+		files           map[string]time.Time
+		dirs            []string
+		partial         bool
+		songsAdded      bool
+		imported        []domain.Playlist
+		expectedCreated bool
+		expectedUpdated bool
+		expectedDeleted []int
+		expectedSynced  int
+	}{
+		{
+			name:            "new playlist file imported",
+			files:           map[string]time.Time{playlistPath: modTime},
+			expectedCreated: true,
+			expectedSynced:  1,
+		},
+		{
+			name:     "unchanged playlist file skipped",
+			files:    map[string]time.Time{playlistPath: modTime},
+			imported: []domain.Playlist{{Id: 3, Name: "Mix", Owner: "curator", Path: playlistPath, ModTime: modTime.UnixNano()}},
+		},
+		{
+			name:            "unchanged playlist file resynced after songs were added",
+			files:           map[string]time.Time{playlistPath: modTime},
+			songsAdded:      true,
+			imported:        []domain.Playlist{{Id: 3, Name: "Mix", Owner: "curator", Path: playlistPath, ModTime: modTime.UnixNano()}},
+			expectedUpdated: true,
+			expectedSynced:  1,
+		},
+		{
+			name:            "modified playlist file resynced",
+			files:           map[string]time.Time{playlistPath: modTime},
+			imported:        []domain.Playlist{{Id: 3, Name: "Old Mix", Owner: "curator", Path: playlistPath, ModTime: 1}},
+			expectedUpdated: true,
+			expectedSynced:  1,
+		},
+		{
+			name:            "playlist of a removed file deleted",
+			files:           map[string]time.Time{},
+			imported:        []domain.Playlist{{Id: 4, Name: "Gone", Owner: "curator", Path: filepath.Join(root, "Gone.pls")}},
+			expectedDeleted: []int{4},
+		},
+		{
+			name:     "playlist outside of a partial scan kept",
+			files:    map[string]time.Time{},
+			dirs:     []string{filepath.Join(root, "Band")},
+			partial:  true,
+			imported: []domain.Playlist{{Id: 4, Name: "Gone", Owner: "curator", Path: filepath.Join(root, "Gone.pls")}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			playlists := mocks.NewMockPlaylistRepository(t)
+			playlists.EXPECT().GetImportedPlaylists(mock.Anything).Return(tt.imported, nil)
+			for _, id := range tt.expectedDeleted {
+				playlists.EXPECT().DeletePlaylist(mock.Anything, id).Return(nil)
+			}
+			var saved domain.Playlist
+			save := func(ctx context.Context, playlist domain.Playlist) (domain.Playlist, error) {
+				saved = playlist
+				return playlist, nil
+			}
+			if tt.expectedCreated || tt.expectedUpdated {
+				repo.EXPECT().GetSongFingerprints(mock.Anything).Return(slices.Clone(fingerprints), nil)
+			}
+			if tt.expectedCreated {
+				playlists.EXPECT().CreatePlaylist(mock.Anything, mock.Anything).RunAndReturn(save)
+			}
+			if tt.expectedUpdated {
+				playlists.EXPECT().UpdatePlaylist(mock.Anything, mock.Anything).RunAndReturn(save)
+			}
+			dirs := tt.dirs
+			if dirs == nil {
+				dirs = []string{root}
+			}
+			service := NewMediaScanningService(repo, mocks.NewMockMediaScanningRepository(t), playlists, mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{PlaylistOwner: "curator"}, slog.Default())
+			report := &scanReport{}
+
+			synced := service.importPlaylists(context.Background(), tt.files, dirs, tt.partial, nil, tt.songsAdded, report)
+
+			if synced != tt.expectedSynced {
+				t.Errorf("expected %d synced playlists, got %d", tt.expectedSynced, synced)
+			}
+			if report.failed != 0 {
+				t.Errorf("expected no failures, got %+v", report.errors)
+			}
+			if !tt.expectedCreated && !tt.expectedUpdated {
+				return
+			}
+			if saved.Name != "Mix" || saved.Owner != "curator" || saved.Path != playlistPath || saved.ModTime != modTime.UnixNano() {
+				t.Errorf("unexpected playlist %+v", saved)
+			}
+			if tt.expectedCreated && !saved.Public {
+				t.Errorf("expected imported playlist to be public")
+			}
+			if tt.expectedUpdated && saved.Id != 3 {
+				t.Errorf("expected playlist 3 to be updated, got %d", saved.Id)
+			}
+			// Songs split by a CUE sheet follow their order in the file, missing entries are skipped
+			if !slices.Equal(saved.SongIds, []int{1, 6, 7}) {
+				t.Errorf("expected songs [1 6 7], got %v", saved.SongIds)
+			}
+		})
+	}
+}
+
+func TestAlbumArtist(t *testing.T) {
+	tests := []struct {
+		name           string
+		tags           map[string]string
+		expectedArtist domain.Artist
+	}{
+		{
+			name:           "album artist preferred over track artist",
+			tags:           map[string]string{"artist": "Guest", "album_artist": "Band", "compilation": "1"},
+			expectedArtist: domain.Artist{Name: "Band"},
+		},
+		{
+			name:           "compilation without album artist",
+			tags:           map[string]string{"artist": "Guest", "compilation": "1", "MUSICBRAINZ_ARTISTID": "b10bbbfc-cf9e-42e0-be17-e2c3e1d2600d"},
+			expectedArtist: domain.Artist{Name: domain.VariousArtists},
+		},
+		{
+			name:           "track artist",
+			tags:           map[string]string{"artist": "Band", "compilation": "0"},
+			expectedArtist: domain.Artist{Name: "Band"},
+		},
+		{
+			name:           "no artist",
+			tags:           map[string]string{},
+			expectedArtist: domain.Artist{Name: unknownArtist},
+		},
+		{
+			name: "album artist sort name and id",
+			tags: map[string]string{
+				"artist": "Guest", "artist-sort": "Guest", "MusicBrainz Artist Id": "0383dadf-2a4e-4d10-a46a-e9e041da8eb3",
+				"album_artist": "The Band", "album_artist-sort": "Band, The", "MusicBrainz Album Artist Id": "5B11F4CE-A62D-471E-81FC-A69A8278C7DA",
+			},
+			expectedArtist: domain.Artist{Name: "The Band", SortName: "Band, The", MusicBrainzId: "5b11f4ce-a62d-471e-81fc-a69a8278c7da"},
+		},
+		{
+			name:           "track artist sort name and id",
+			tags:           map[string]string{"artist": "The Band", "ARTISTSORT": "Band, The", "MUSICBRAINZ_ARTISTID": "5b11f4ce-a62d-471e-81fc-a69a8278c7da"},
+			expectedArtist: domain.Artist{Name: "The Band", SortName: "Band, The", MusicBrainzId: "5b11f4ce-a62d-471e-81fc-a69a8278c7da"},
+		},
+		{
+			name:           "ids of several credited artists",
+			tags:           map[string]string{"artist": "Band feat. Guest", "MUSICBRAINZ_ARTISTID": "5b11f4ce-a62d-471e-81fc-a69a8278c7da; 0383dadf-2a4e-4d10-a46a-e9e041da8eb3"},
+			expectedArtist: domain.Artist{Name: "Band feat. Guest"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := domain.MediaMetadata{Tags: tt.tags}
+			result := albumArtist(metadata, metadataFlag(metadata, "compilation"))
+			if !reflect.DeepEqual(result, tt.expectedArtist) {
+				t.Errorf("expected album artist %+v, got %+v", tt.expectedArtist, result)
+			}
+		})
+	}
+}
+
+func TestSortNameIgnoringArticles(t *testing.T) {
+	tests := []struct {
+		name             string
+		artistName       string
+		expectedSortName string
+	}{
+		{
+			name:             "leading article",
+			artistName:       "The Beatles",
+			expectedSortName: "Beatles, The",
+		},
+		{
+			name:             "article matched ignoring case",
+			artistName:       "los Lobos",
+			expectedSortName: "Lobos, los",
+		},
+		{
+			name:             "article prefix of a word",
+			artistName:       "Theory of a Deadman",
+			expectedSortName: "Theory of a Deadman",
+		},
+		{
+			name:             "article alone",
+			artistName:       "The",
+			expectedSortName: "The",
+		},
+		{
+			name:             "no article",
+			artistName:       "Radiohead",
+			expectedSortName: "Radiohead",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := sortNameIgnoringArticles(tt.artistName, defaultIgnoredArticles)
+			if result != tt.expectedSortName {
+				t.Errorf("expected sort name %q, got %q", tt.expectedSortName, result)
+			}
+		})
+	}
+}
+
+func TestSplitGenres(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          string
+		separators     []string
+		expectedGenres []string
+	}{
+		{
+			name:           "single genre",
+			value:          "Jazz",
+			separators:     defaultGenreSeparators,
+			expectedGenres: []string{"Jazz"},
+		},
+		{
+			name:           "mixed separators",
+			value:          "Rock; Pop/Electronic , Ambient",
+			separators:     defaultGenreSeparators,
+			expectedGenres: []string{"Rock", "Pop", "Electronic", "Ambient"},
+		},
+		{
+			name:           "empty and repeated genres",
+			value:          "Rock;;Pop; Rock ;",
+			separators:     defaultGenreSeparators,
+			expectedGenres: []string{"Rock", "Pop"},
+		},
+		{
+			name:           "configured separators",
+			value:          "Drum & Bass/Jungle | Hip-Hop",
+			separators:     []string{"|"},
+			expectedGenres: []string{"Drum & Bass/Jungle", "Hip-Hop"},
+		},
+		{
+			name:       "no genre",
+			value:      "",
+			separators: defaultGenreSeparators,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := splitGenres(tt.value, tt.separators)
+			if !slices.Equal(result, tt.expectedGenres) {
+				t.Errorf("expected genres %q, got %q", tt.expectedGenres, result)
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_ResolveArtist(t *testing.T) {
+	const (
+		bandID  = "5b11f4ce-a62d-471e-81fc-a69a8278c7da"
+		otherID = "0383dadf-2a4e-4d10-a46a-e9e041da8eb3"
+	)
+	notFound := &ports.NotFoundError{Message: "artist not found"}
+
+	tests := []struct {
+		name           string
+		tagged         domain.Artist
+		setupMock      func(repo *mocks.MockMediaBrowsingRepository)
+		expectedArtist domain.Artist
+	}{
+		{
+			name:   "matched by musicbrainz id",
+			tagged: domain.Artist{Name: "Band", MusicBrainzId: bandID},
+			setupMock: func(repo *mocks.MockMediaBrowsingRepository) {
+				repo.EXPECT().GetArtistByMusicBrainzID(mock.Anything, 0, bandID).Return(domain.Artist{Id: 1, Name: "The Band", SortName: "Band, The", MusicBrainzId: bandID}, nil)
+			},
+			expectedArtist: domain.Artist{Id: 1, Name: "The Band", SortName: "Band, The", MusicBrainzId: bandID},
+		},
+		{
+			name:   "artist without id takes the tagged id",
+			tagged: domain.Artist{Name: "Band", MusicBrainzId: bandID},
+			setupMock: func(repo *mocks.MockMediaBrowsingRepository) {
+				repo.EXPECT().GetArtistByMusicBrainzID(mock.Anything, 0, bandID).Return(domain.Artist{}, notFound)
+				repo.EXPECT().GetArtistByName(mock.Anything, 0, "Band").Return(domain.Artist{Id: 1, Name: "Band", SortName: "Band"}, nil)
+				repo.EXPECT().UpdateArtist(mock.Anything, domain.Artist{Id: 1, Name: "Band", SortName: "Band", MusicBrainzId: bandID}).
+					Return(domain.Artist{Id: 1, Name: "Band", SortName: "Band", MusicBrainzId: bandID}, nil)
+			},
+			expectedArtist: domain.Artist{Id: 1, Name: "Band", SortName: "Band", MusicBrainzId: bandID},
+		},
+		{
+			name:   "artist with the same name and another id",
+			tagged: domain.Artist{Name: "Band", MusicBrainzId: bandID},
+			setupMock: func(repo *mocks.MockMediaBrowsingRepository) {
+				repo.EXPECT().GetArtistByMusicBrainzID(mock.Anything, 0, bandID).Return(domain.Artist{}, notFound)
+				repo.EXPECT().GetArtistByName(mock.Anything, 0, "Band").Return(domain.Artist{Id: 1, Name: "Band", SortName: "Band", MusicBrainzId: otherID}, nil)
+				repo.EXPECT().CreateArtist(mock.Anything, domain.Artist{Name: "Band", SortName: "Band", MusicBrainzId: bandID}).
+					Return(domain.Artist{Id: 2, Name: "Band", SortName: "Band", MusicBrainzId: bandID}, nil)
+			},
+			expectedArtist: domain.Artist{Id: 2, Name: "Band", SortName: "Band", MusicBrainzId: bandID},
+		},
+		{
+			name:   "sort name derived from the name",
+			tagged: domain.Artist{Name: "The Band"},
+			setupMock: func(repo *mocks.MockMediaBrowsingRepository) {
+				repo.EXPECT().GetArtistByName(mock.Anything, 0, "The Band").Return(domain.Artist{}, notFound)
+				repo.EXPECT().CreateArtist(mock.Anything, domain.Artist{Name: "The Band", SortName: "Band, The"}).
+					Return(domain.Artist{Id: 3, Name: "The Band", SortName: "Band, The"}, nil)
+			},
+			expectedArtist: domain.Artist{Id: 3, Name: "The Band", SortName: "Band, The"},
+		},
+		{
+			name:   "indexed artist without sort name takes the tagged one",
+			tagged: domain.Artist{Name: "The Band", SortName: "Band"},
+			setupMock: func(repo *mocks.MockMediaBrowsingRepository) {
+				repo.EXPECT().GetArtistByName(mock.Anything, 0, "The Band").Return(domain.Artist{Id: 1, Name: "The Band", MusicBrainzId: bandID}, nil)
+				repo.EXPECT().UpdateArtist(mock.Anything, domain.Artist{Id: 1, Name: "The Band", SortName: "Band", MusicBrainzId: bandID}).
+					Return(domain.Artist{Id: 1, Name: "The Band", SortName: "Band", MusicBrainzId: bandID}, nil)
+			},
+			expectedArtist: domain.Artist{Id: 1, Name: "The Band", SortName: "Band", MusicBrainzId: bandID},
+		},
+		{
+			name:   "artist of another music folder",
+			tagged: domain.Artist{Name: "Band", MusicBrainzId: bandID, MusicFolderId: 2},
+			setupMock: func(repo *mocks.MockMediaBrowsingRepository) {
+				repo.EXPECT().GetArtistByMusicBrainzID(mock.Anything, 2, bandID).Return(domain.Artist{}, notFound)
+				repo.EXPECT().GetArtistByName(mock.Anything, 2, "Band").Return(domain.Artist{}, notFound)
+				repo.EXPECT().CreateArtist(mock.Anything, domain.Artist{Name: "Band", SortName: "Band", MusicBrainzId: bandID, MusicFolderId: 2}).
+					Return(domain.Artist{Id: 4, Name: "Band", SortName: "Band", MusicBrainzId: bandID, MusicFolderId: 2}, nil)
+			},
+			expectedArtist: domain.Artist{Id: 4, Name: "Band", SortName: "Band", MusicBrainzId: bandID, MusicFolderId: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			service := NewMediaScanningService(repo, mocks.NewMockMediaScanningRepository(t), mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{}, slog.Default())
+			catalog := newCatalogCache()
+
+			// The second call is answered from the catalog
+			for range 2 {
+				artist, err := service.resolveArtist(context.Background(), catalog, tt.tagged)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(artist, tt.expectedArtist) {
+					t.Errorf("expected artist %+v, got %+v", tt.expectedArtist, artist)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_ResolveDirectory(t *testing.T) {
+	notFound := &ports.NotFoundError{Message: "directory not found"}
+	isDirectory := func(path string, parentID int) any {
+		return mock.MatchedBy(func(directory domain.Directory) bool {
+			return directory.Path == path && directory.Name == filepath.Base(path) && directory.ParentId == parentID &&
+				directory.MusicFolderId == 2 && directory.Created != "" && directory.Updated > 0
+		})
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		setupMock  func(repo *mocks.MockMediaBrowsingRepository)
+		expectedID int
+	}{
+		{
+			name: "indexed directory",
+			path: "/music/Artist/Album",
+			setupMock: func(repo *mocks.MockMediaBrowsingRepository) {
+				repo.EXPECT().GetDirectoryByPath(mock.Anything, "/music/Artist/Album").Return(domain.Directory{Id: 4, ParentId: 3, Path: "/music/Artist/Album"}, nil).Once()
+			},
+			expectedID: 4,
+		},
+		{
+			name: "missing parents created up to the music folder",
+			path: "/music/Artist/Album",
+			setupMock: func(repo *mocks.MockMediaBrowsingRepository) {
+				repo.EXPECT().GetDirectoryByPath(mock.Anything, "/music/Artist/Album").Return(domain.Directory{}, notFound).Once()
+				repo.EXPECT().GetDirectoryByPath(mock.Anything, "/music/Artist").Return(domain.Directory{}, notFound).Once()
+				repo.EXPECT().GetDirectoryByPath(mock.Anything, "/music").Return(domain.Directory{Id: 1, Path: "/music"}, nil).Once()
+				repo.EXPECT().CreateDirectory(mock.Anything, isDirectory("/music/Artist", 1)).Return(domain.Directory{Id: 5, ParentId: 1, Path: "/music/Artist"}, nil).Once()
+				repo.EXPECT().CreateDirectory(mock.Anything, isDirectory("/music/Artist/Album", 5)).Return(domain.Directory{Id: 6, ParentId: 5, Path: "/music/Artist/Album"}, nil).Once()
+			},
+			expectedID: 6,
+		},
+		{
+			name: "music folder directory created without parent",
+			path: "/music",
+			setupMock: func(repo *mocks.MockMediaBrowsingRepository) {
+				repo.EXPECT().GetDirectoryByPath(mock.Anything, "/music").Return(domain.Directory{}, notFound).Once()
+				repo.EXPECT().CreateDirectory(mock.Anything, isDirectory("/music", 0)).Return(domain.Directory{Id: 1, Path: "/music"}, nil).Once()
+			},
+			expectedID: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo)
+			service := NewMediaScanningService(repo, mocks.NewMockMediaScanningRepository(t), mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), &config.Config{MusicDirectories: []string{"/music"}}, slog.Default())
+			catalog := newCatalogCache()
+
+			// The second lookup is served by the catalog
+			for range 2 {
+				id, err := service.resolveDirectory(context.Background(), catalog, tt.path, 2)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if id != tt.expectedID {
+					t.Errorf("expected directory %d, got %d", tt.expectedID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestFindFolderCover(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         []string
+		pattern       string
+		expectedCover string
+	}{
+		{
+			name:          "pattern matched ignoring case",
+			files:         []string{"folder.jpg", "Cover.PNG", "01.flac"},
+			pattern:       "cover.*",
+			expectedCover: "Cover.PNG",
+		},
+		{
+			name:          "non image files ignored",
+			files:         []string{"front.txt", "front.jpeg"},
+			pattern:       "front.*",
+			expectedCover: "front.jpeg",
+		},
+		{
+			name:          "no cover",
+			files:         []string{"01.flac", "scan.jpg"},
+			pattern:       "cover.*",
+			expectedCover: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, file := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, file), []byte{}, 0o600); err != nil {
+					t.Fatalf("failed to create test file: %v", err)
+				}
+			}
+
+			result := findFolderCover(dir, tt.pattern)
+
+			expected := ""
+			if tt.expectedCover != "" {
+				expected = filepath.Join(dir, tt.expectedCover)
+			}
+			if result != expected {
+				t.Errorf("expected cover %q, got %q", expected, result)
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_IndexCover(t *testing.T) {
+	tests := []struct {
+		name             string
+		priority         []string
+		files            []string
+		embedded         bool
+		expectedCover    string
+		expectedEmbedded bool
+	}{
+		{
+			name:          "default priority prefers cover over folder",
+			files:         []string{"folder.jpg", "cover.png"},
+			embedded:      true,
+			expectedCover: "cover.png",
+		},
+		{
+			name:          "configured priority",
+			priority:      []string{"folder.*", "cover.*"},
+			files:         []string{"folder.jpg", "cover.png"},
+			expectedCover: "folder.jpg",
+		},
+		{
+			name:             "embedded picture extracted to cache",
+			priority:         []string{embeddedCoverSource, "front.*"},
+			files:            []string{"front.webp"},
+			embedded:         true,
+			expectedEmbedded: true,
+		},
+		{
+			name:          "file without embedded picture falls through",
+			priority:      []string{embeddedCoverSource, "front.*"},
+			files:         []string{"front.webp"},
+			expectedCover: "front.webp",
+		},
+		{
+			name:     "no matching source",
+			priority: []string{"cover.*"},
+			files:    []string{"folder.jpg"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cacheDir := t.TempDir()
+			audio := filepath.Join(dir, "01.flac")
+			for _, file := range append(tt.files, "01.flac") {
+				if err := os.WriteFile(filepath.Join(dir, file), []byte{}, 0o600); err != nil {
+					t.Fatalf("failed to create test file: %v", err)
+				}
+			}
+
+			repo := mocks.NewMockMediaBrowsingRepository(t)
+			extractor := mocks.NewMockMetadataExtractor(t)
+			expectedID := ""
+			switch {
+			case tt.expectedEmbedded:
+				expectedID = coverIDForPath(audio)
+				path := filepath.Join(cacheDir, expectedID+".png")
+				extractor.EXPECT().ExtractPicture(mock.Anything, audio).Return(domain.Picture{MimeType: "image/png", Data: []byte("png")}, nil)
+				repo.EXPECT().CreateCover(mock.Anything, domain.Cover{Id: expectedID, Path: path}).Return(domain.Cover{Id: expectedID, Path: path}, nil)
+			case tt.expectedCover != "":
+				path := filepath.Join(dir, tt.expectedCover)
+				expectedID = coverIDForPath(path)
+				repo.EXPECT().CreateCover(mock.Anything, domain.Cover{Id: expectedID, Path: path}).Return(domain.Cover{Id: expectedID, Path: path}, nil)
+			}
+			service := NewMediaScanningService(repo, mocks.NewMockMediaScanningRepository(t), mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), extractor, mocks.NewMockLoudnessAnalyzer(t), &config.Config{CoverArtPriority: tt.priority, CoverCacheDirectory: cacheDir}, slog.Default())
+			file := scannedFile{
+				path:     audio,
+				metadata: domain.MediaMetadata{HasPicture: tt.embedded},
+			}
+
+			result := service.indexCover(context.Background(), file, make(map[string]bool))
+
+			if result != expectedID {
+				t.Errorf("expected cover id %q, got %q", expectedID, result)
+			}
+			if tt.expectedEmbedded {
+				if data, err := os.ReadFile(filepath.Join(cacheDir, expectedID+".png")); err != nil || string(data) != "png" {
+					t.Errorf("expected embedded picture in cover cache, got %q (%v)", data, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"music-streaming/internal/core/domain"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// defaultWatchDebounce is the quiet period waited for after the last filesystem event
+// before the affected folders are indexed.
+const defaultWatchDebounce = 2 * time.Second
+
+// Watch subscribes to filesystem events below the configured music directories and indexes
+// the affected folders once events stop arriving for the debounce period.
+// Indexing runs in the background and waits for a running scan to finish before starting,
+// folders changed meanwhile are indexed once it is done. Blocks until ctx is cancelled.
+func (s *MediaScanningService) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close() // nolint:errcheck
+
+	roots := s.musicDirectories()
+	for _, root := range roots {
+		s.watchTree(watcher, root)
+	}
+
+	s.logger.Info("Watching music directories for changes", slog.Any("directories", roots), slog.Duration("debounce", s.watchDebounce()))
+	s.watchEvents(ctx, watcher, roots)
+	return nil
+}
+
+// watchEvents collects the folders touched by the events of watcher and indexes them after the debounce period.
+// Returns when ctx is cancelled or the watcher is closed, once the indexing in progress is finished.
+func (s *MediaScanningService) watchEvents(ctx context.Context, watcher *fsnotify.Watcher, roots []string) {
+	debounce := s.watchDebounce()
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	dirty := make(map[string]bool)
+	// indexed is closed when the indexing in progress is finished, nil when idle
+	var indexed chan struct{}
+	defer func() {
+		if indexed != nil {
+			<-indexed
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					s.watchTree(watcher, event.Name)
+				}
+			}
+			dirty[filepath.Dir(event.Name)] = true
+			timer.Reset(debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			s.logger.Warn("Filesystem watcher error", slog.String("error", err.Error()))
+		case <-timer.C:
+			if indexed != nil {
+				// The timer is armed again once the indexing in progress is finished
+				continue
+			}
+			scanCtx, _, started := s.beginScan(ctx)
+			if !started {
+				// A scan is running, try again once it had time to finish
+				timer.Reset(debounce)
+				continue
+			}
+			dirs := watchScope(dirty, roots)
+			clear(dirty)
+			s.logger.Info("Indexing changed folders", slog.Any("directories", dirs))
+			indexed = make(chan struct{})
+			go func(done chan struct{}) {
+				defer close(done)
+				defer s.endScan()
+				s.scanDirectories(scanCtx, dirs, true, domain.ScanTriggerWatcher)
+			}(indexed)
+		case <-indexed:
+			indexed = nil
+			if len(dirty) > 0 {
+				timer.Reset(debounce)
+			}
+		}
+	}
+}
+
+// watchTree adds root and every directory below it to watcher, inotify watches are not recursive.
+// Directories excluded from scans are not watched.
+func (s *MediaScanningService) watchTree(watcher *fsnotify.Watcher, root string) {
+	ignore := s.newScanIgnore(root, func(path string, err error) {
+		s.logger.Warn("Failed to read ignore file", slog.String("path", path), slog.String("error", err.Error()))
+	})
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path == root && ignore.ignoredTree(root) || path != root && ignore.ignored(path, true) {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(path); err != nil {
+			s.logger.Warn("Failed to watch directory", slog.String("directory", path), slog.String("error", err.Error()))
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Warn("Failed to watch music directory", slog.String("directory", root), slog.String("error", err.Error()))
+	}
+}
+
+func (s *MediaScanningService) watchDebounce() time.Duration {
+	if s.config == nil || s.config.WatchDebounce <= 0 {
+		return defaultWatchDebounce
+	}
+	return s.config.WatchDebounce
+}
+
+// watchScope reduces the folders touched by filesystem events to the set of directories to index.
+// Folders that no longer exist are replaced by their closest existing parent so removed songs are noticed,
+// folders outside roots are dropped and folders nested inside another one are merged into it.
+func watchScope(dirty map[string]bool, roots []string) []string {
+	var dirs []string
+	for dir := range dirty {
+		for dir != "" && !dirExists(dir) {
+			if parent := filepath.Dir(dir); parent != dir {
+				dir = parent
+			} else {
+				dir = ""
+			}
+		}
+		if dir != "" && isBelowAny(dir, roots) {
+			dirs = append(dirs, dir)
+		}
+	}
+	slices.Sort(dirs)
+
+	scope := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if !isBelowAny(dir, scope) {
+			scope = append(scope, dir)
+		}
+	}
+	return scope
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
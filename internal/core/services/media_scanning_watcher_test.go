@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"music-streaming/internal/core/config"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"music-streaming/internal/core/services/mocks"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWatchScope(t *testing.T) {
+	tests := []struct {
+		name          string
+		dirty         []string
+		expectedScope []string
+	}{
+		{
+			name:          "nested folders merged into parent",
+			dirty:         []string{"music/artist/album", "music/artist", "music/other"},
+			expectedScope: []string{"music/artist", "music/other"},
+		},
+		{
+			name:          "removed folder replaced by existing parent",
+			dirty:         []string{"music/artist/removed"},
+			expectedScope: []string{"music/artist"},
+		},
+		{
+			name:          "folders outside music directories dropped",
+			dirty:         []string{"outside", "music/artist/album"},
+			expectedScope: []string{"music/artist/album"},
+		},
+		{
+			name:          "missing music directory dropped",
+			dirty:         []string{"gone/album"},
+			expectedScope: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := t.TempDir()
+			for _, dir := range []string{"music/artist/album", "music/other", "outside"} {
+				if err := os.MkdirAll(filepath.Join(base, dir), 0o700); err != nil {
+					t.Fatalf("failed to create test directory: %v", err)
+				}
+			}
+			roots := []string{filepath.Join(base, "music"), filepath.Join(base, "gone")}
+			dirty := make(map[string]bool)
+			for _, dir := range tt.dirty {
+				dirty[filepath.Join(base, dir)] = true
+			}
+
+			result := watchScope(dirty, roots)
+
+			expected := make([]string, 0, len(tt.expectedScope))
+			for _, dir := range tt.expectedScope {
+				expected = append(expected, filepath.Join(base, dir))
+			}
+			if !slices.Equal(result, expected) {
+				t.Errorf("expected scope %v, got %v", expected, result)
+			}
+		})
+	}
+}
+
+func TestMediaScanningService_WatchEvents_Debounce(t *testing.T) {
+	root := t.TempDir()
+	release := make(chan struct{})
+	close(release)
+	service, started := newWatchingService(t, root, release)
+	watcher, stop := watchEvents(t, service, root)
+	defer stop()
+
+	for _, name := range []string{"a.mp3", "b.mp3", "a.mp3", "c.flac"} {
+		sendWatchEvent(t, watcher, filepath.Join(root, name))
+	}
+
+	run := expectWatchScan(t, started)
+	if run.Trigger != domain.ScanTriggerWatcher || !run.Partial {
+		t.Errorf("expected a partial scan triggered by the watcher, got %+v", run)
+	}
+	stop()
+	if len(started) > 0 {
+		t.Errorf("expected the events to be indexed by a single scan, got %d more", len(started))
+	}
+}
+
+func TestMediaScanningService_WatchEvents_ManualScan(t *testing.T) {
+	root := t.TempDir()
+	release := make(chan struct{})
+	service, started := newWatchingService(t, root, release)
+	watcher, stop := watchEvents(t, service, root)
+	defer stop()
+
+	// Changes are not indexed while a manual scan is running
+	if _, _, ok := service.beginScan(context.Background()); !ok {
+		t.Fatalf("expected the manual scan to start")
+	}
+	sendWatchEvent(t, watcher, filepath.Join(root, "a.mp3"))
+	select {
+	case run := <-started:
+		t.Fatalf("expected no scan while the manual scan is running, got %+v", run)
+	case <-time.After(5 * service.watchDebounce()):
+	}
+	service.endScan()
+	expectWatchScan(t, started)
+
+	// A manual scan is not started while the changes are indexed, further events keep being collected
+	status, err := service.StartScan(context.WithValue(context.Background(), ports.KeyRequestingUserID, &domain.User{Username: "admin", AdminRole: true}))
+	if err != nil || !status.Scanning {
+		t.Errorf("expected the running indexing to be reported, got %+v, %v", status, err)
+	}
+	sendWatchEvent(t, watcher, filepath.Join(root, "b.mp3"))
+	close(release)
+
+	// The folders changed while indexing are indexed once it is finished
+	run := expectWatchScan(t, started)
+	if run.Trigger != domain.ScanTriggerWatcher {
+		t.Errorf("expected trigger %q, got %q", domain.ScanTriggerWatcher, run.Trigger)
+	}
+}
+
+// newWatchingService returns a scanning service watching root over an empty library.
+// The scan runs it creates are reported on the returned channel and wait for release before going on.
+func newWatchingService(t *testing.T, root string, release <-chan struct{}) (*MediaScanningService, chan domain.ScanRun) {
+	started := make(chan domain.ScanRun, 10)
+	repo := mocks.NewMockMediaBrowsingRepository(t)
+	repo.EXPECT().GetSongFingerprints(mock.Anything).Return(nil, nil).Maybe()
+	repo.EXPECT().SaveMusicFolders(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	repo.EXPECT().DeleteOrphans(mock.Anything).Return(0, nil).Maybe()
+	repo.EXPECT().UpdateCatalogStats(mock.Anything).Return(nil).Maybe()
+	scanRuns := mocks.NewMockMediaScanningRepository(t)
+	scanRuns.EXPECT().CreateScanRun(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, run domain.ScanRun) (domain.ScanRun, error) {
+		started <- run
+		<-release
+		return run, nil
+	}).Maybe()
+	scanRuns.EXPECT().FinishScanRun(mock.Anything, mock.Anything).Return(nil).Maybe()
+	cfg := &config.Config{MusicDirectories: []string{root}, WatchDebounce: 20 * time.Millisecond}
+	service := NewMediaScanningService(repo, scanRuns, mocks.NewMockPlaylistRepository(t), mocks.NewMockLyricsRepository(t), mocks.NewMockMetadataExtractor(t), mocks.NewMockLoudnessAnalyzer(t), cfg, slog.Default())
+	return service, started
+}
+
+// watchEvents runs the event loop of service over a watcher fed by the test.
+// The returned function stops the loop and waits for it to return, it may be called more than once.
+func watchEvents(t *testing.T, service *MediaScanningService, root string) (*fsnotify.Watcher, func()) {
+	watcher := &fsnotify.Watcher{Events: make(chan fsnotify.Event), Errors: make(chan error)}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		service.watchEvents(ctx, watcher, []string{root})
+	}()
+	return watcher, func() {
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Errorf("expected the watcher to stop")
+		}
+	}
+}
+
+func sendWatchEvent(t *testing.T, watcher *fsnotify.Watcher, name string) {
+	select {
+	case watcher.Events <- fsnotify.Event{Name: name, Op: fsnotify.Write}:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the watcher to keep reading events")
+	}
+}
+
+func expectWatchScan(t *testing.T, started <-chan domain.ScanRun) domain.ScanRun {
+	select {
+	case run := <-started:
+		return run
+	case <-time.After(time.Second):
+		t.Fatalf("expected the changed folders to be indexed")
+		return domain.ScanRun{}
+	}
+}
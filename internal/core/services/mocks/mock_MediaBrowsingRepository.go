@@ -193,6 +193,63 @@ func (_c *MockMediaBrowsingRepository_CreateCover_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// CreateDirectory provides a mock function with given fields: ctx, directory
+func (_m *MockMediaBrowsingRepository) CreateDirectory(ctx context.Context, directory domain.Directory) (domain.Directory, error) {
+	ret := _m.Called(ctx, directory)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDirectory")
+	}
+
+	var r0 domain.Directory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Directory) (domain.Directory, error)); ok {
+		return rf(ctx, directory)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Directory) domain.Directory); ok {
+		r0 = rf(ctx, directory)
+	} else {
+		r0 = ret.Get(0).(domain.Directory)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Directory) error); ok {
+		r1 = rf(ctx, directory)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_CreateDirectory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDirectory'
+type MockMediaBrowsingRepository_CreateDirectory_Call struct {
+	*mock.Call
+}
+
+// CreateDirectory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - directory domain.Directory
+func (_e *MockMediaBrowsingRepository_Expecter) CreateDirectory(ctx interface{}, directory interface{}) *MockMediaBrowsingRepository_CreateDirectory_Call {
+	return &MockMediaBrowsingRepository_CreateDirectory_Call{Call: _e.mock.On("CreateDirectory", ctx, directory)}
+}
+
+func (_c *MockMediaBrowsingRepository_CreateDirectory_Call) Run(run func(ctx context.Context, directory domain.Directory)) *MockMediaBrowsingRepository_CreateDirectory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Directory))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_CreateDirectory_Call) Return(_a0 domain.Directory, _a1 error) *MockMediaBrowsingRepository_CreateDirectory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_CreateDirectory_Call) RunAndReturn(run func(context.Context, domain.Directory) (domain.Directory, error)) *MockMediaBrowsingRepository_CreateDirectory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateSong provides a mock function with given fields: ctx, song
 func (_m *MockMediaBrowsingRepository) CreateSong(ctx context.Context, song domain.Song) (domain.Song, error) {
 	ret := _m.Called(ctx, song)
@@ -250,6 +307,119 @@ func (_c *MockMediaBrowsingRepository_CreateSong_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// DeleteOrphans provides a mock function with given fields: ctx
+func (_m *MockMediaBrowsingRepository) DeleteOrphans(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOrphans")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_DeleteOrphans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOrphans'
+type MockMediaBrowsingRepository_DeleteOrphans_Call struct {
+	*mock.Call
+}
+
+// DeleteOrphans is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMediaBrowsingRepository_Expecter) DeleteOrphans(ctx interface{}) *MockMediaBrowsingRepository_DeleteOrphans_Call {
+	return &MockMediaBrowsingRepository_DeleteOrphans_Call{Call: _e.mock.On("DeleteOrphans", ctx)}
+}
+
+func (_c *MockMediaBrowsingRepository_DeleteOrphans_Call) Run(run func(ctx context.Context)) *MockMediaBrowsingRepository_DeleteOrphans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_DeleteOrphans_Call) Return(_a0 int, _a1 error) *MockMediaBrowsingRepository_DeleteOrphans_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_DeleteOrphans_Call) RunAndReturn(run func(context.Context) (int, error)) *MockMediaBrowsingRepository_DeleteOrphans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSongs provides a mock function with given fields: ctx, ids
+func (_m *MockMediaBrowsingRepository) DeleteSongs(ctx context.Context, ids []int) (int, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSongs")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) (int, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_DeleteSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSongs'
+type MockMediaBrowsingRepository_DeleteSongs_Call struct {
+	*mock.Call
+}
+
+// DeleteSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int
+func (_e *MockMediaBrowsingRepository_Expecter) DeleteSongs(ctx interface{}, ids interface{}) *MockMediaBrowsingRepository_DeleteSongs_Call {
+	return &MockMediaBrowsingRepository_DeleteSongs_Call{Call: _e.mock.On("DeleteSongs", ctx, ids)}
+}
+
+func (_c *MockMediaBrowsingRepository_DeleteSongs_Call) Run(run func(ctx context.Context, ids []int)) *MockMediaBrowsingRepository_DeleteSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_DeleteSongs_Call) Return(_a0 int, _a1 error) *MockMediaBrowsingRepository_DeleteSongs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_DeleteSongs_Call) RunAndReturn(run func(context.Context, []int) (int, error)) *MockMediaBrowsingRepository_DeleteSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAlbumByID provides a mock function with given fields: ctx, id
 func (_m *MockMediaBrowsingRepository) GetAlbumByID(ctx context.Context, id int) (domain.Album, error) {
 	ret := _m.Called(ctx, id)
@@ -307,6 +477,184 @@ func (_c *MockMediaBrowsingRepository_GetAlbumByID_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// GetAlbumByRelease provides a mock function with given fields: ctx, artistID, name, year
+func (_m *MockMediaBrowsingRepository) GetAlbumByRelease(ctx context.Context, artistID int, name string, year int) (domain.Album, error) {
+	ret := _m.Called(ctx, artistID, name, year)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbumByRelease")
+	}
+
+	var r0 domain.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) (domain.Album, error)); ok {
+		return rf(ctx, artistID, name, year)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) domain.Album); ok {
+		r0 = rf(ctx, artistID, name, year)
+	} else {
+		r0 = ret.Get(0).(domain.Album)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, int) error); ok {
+		r1 = rf(ctx, artistID, name, year)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetAlbumByRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlbumByRelease'
+type MockMediaBrowsingRepository_GetAlbumByRelease_Call struct {
+	*mock.Call
+}
+
+// GetAlbumByRelease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - artistID int
+//   - name string
+//   - year int
+func (_e *MockMediaBrowsingRepository_Expecter) GetAlbumByRelease(ctx interface{}, artistID interface{}, name interface{}, year interface{}) *MockMediaBrowsingRepository_GetAlbumByRelease_Call {
+	return &MockMediaBrowsingRepository_GetAlbumByRelease_Call{Call: _e.mock.On("GetAlbumByRelease", ctx, artistID, name, year)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetAlbumByRelease_Call) Run(run func(ctx context.Context, artistID int, name string, year int)) *MockMediaBrowsingRepository_GetAlbumByRelease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetAlbumByRelease_Call) Return(_a0 domain.Album, _a1 error) *MockMediaBrowsingRepository_GetAlbumByRelease_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetAlbumByRelease_Call) RunAndReturn(run func(context.Context, int, string, int) (domain.Album, error)) *MockMediaBrowsingRepository_GetAlbumByRelease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAlbumList provides a mock function with given fields: ctx, query, musicFolderIDs
+func (_m *MockMediaBrowsingRepository) GetAlbumList(ctx context.Context, query domain.AlbumListQuery, musicFolderIDs []int) ([]domain.Album, error) {
+	ret := _m.Called(ctx, query, musicFolderIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbumList")
+	}
+
+	var r0 []domain.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AlbumListQuery, []int) ([]domain.Album, error)); ok {
+		return rf(ctx, query, musicFolderIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AlbumListQuery, []int) []domain.Album); ok {
+		r0 = rf(ctx, query, musicFolderIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Album)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AlbumListQuery, []int) error); ok {
+		r1 = rf(ctx, query, musicFolderIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetAlbumList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlbumList'
+type MockMediaBrowsingRepository_GetAlbumList_Call struct {
+	*mock.Call
+}
+
+// GetAlbumList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.AlbumListQuery
+//   - musicFolderIDs []int
+func (_e *MockMediaBrowsingRepository_Expecter) GetAlbumList(ctx interface{}, query interface{}, musicFolderIDs interface{}) *MockMediaBrowsingRepository_GetAlbumList_Call {
+	return &MockMediaBrowsingRepository_GetAlbumList_Call{Call: _e.mock.On("GetAlbumList", ctx, query, musicFolderIDs)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetAlbumList_Call) Run(run func(ctx context.Context, query domain.AlbumListQuery, musicFolderIDs []int)) *MockMediaBrowsingRepository_GetAlbumList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AlbumListQuery), args[2].([]int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetAlbumList_Call) Return(_a0 []domain.Album, _a1 error) *MockMediaBrowsingRepository_GetAlbumList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetAlbumList_Call) RunAndReturn(run func(context.Context, domain.AlbumListQuery, []int) ([]domain.Album, error)) *MockMediaBrowsingRepository_GetAlbumList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAlbumsByArtistID provides a mock function with given fields: ctx, artistID
+func (_m *MockMediaBrowsingRepository) GetAlbumsByArtistID(ctx context.Context, artistID int) ([]domain.Album, error) {
+	ret := _m.Called(ctx, artistID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbumsByArtistID")
+	}
+
+	var r0 []domain.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Album, error)); ok {
+		return rf(ctx, artistID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Album); ok {
+		r0 = rf(ctx, artistID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Album)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, artistID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetAlbumsByArtistID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlbumsByArtistID'
+type MockMediaBrowsingRepository_GetAlbumsByArtistID_Call struct {
+	*mock.Call
+}
+
+// GetAlbumsByArtistID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - artistID int
+func (_e *MockMediaBrowsingRepository_Expecter) GetAlbumsByArtistID(ctx interface{}, artistID interface{}) *MockMediaBrowsingRepository_GetAlbumsByArtistID_Call {
+	return &MockMediaBrowsingRepository_GetAlbumsByArtistID_Call{Call: _e.mock.On("GetAlbumsByArtistID", ctx, artistID)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetAlbumsByArtistID_Call) Run(run func(ctx context.Context, artistID int)) *MockMediaBrowsingRepository_GetAlbumsByArtistID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetAlbumsByArtistID_Call) Return(_a0 []domain.Album, _a1 error) *MockMediaBrowsingRepository_GetAlbumsByArtistID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetAlbumsByArtistID_Call) RunAndReturn(run func(context.Context, int) ([]domain.Album, error)) *MockMediaBrowsingRepository_GetAlbumsByArtistID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetArtistByID provides a mock function with given fields: ctx, id
 func (_m *MockMediaBrowsingRepository) GetArtistByID(ctx context.Context, id int) (domain.Artist, error) {
 	ret := _m.Called(ctx, id)
@@ -364,27 +712,27 @@ func (_c *MockMediaBrowsingRepository_GetArtistByID_Call) RunAndReturn(run func(
 	return _c
 }
 
-// GetCoverByID provides a mock function with given fields: ctx, id
-func (_m *MockMediaBrowsingRepository) GetCoverByID(ctx context.Context, id string) (domain.Cover, error) {
-	ret := _m.Called(ctx, id)
+// GetArtistByMusicBrainzID provides a mock function with given fields: ctx, musicFolderID, musicBrainzID
+func (_m *MockMediaBrowsingRepository) GetArtistByMusicBrainzID(ctx context.Context, musicFolderID int, musicBrainzID string) (domain.Artist, error) {
+	ret := _m.Called(ctx, musicFolderID, musicBrainzID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCoverByID")
+		panic("no return value specified for GetArtistByMusicBrainzID")
 	}
 
-	var r0 domain.Cover
+	var r0 domain.Artist
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Cover, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (domain.Artist, error)); ok {
+		return rf(ctx, musicFolderID, musicBrainzID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Cover); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) domain.Artist); ok {
+		r0 = rf(ctx, musicFolderID, musicBrainzID)
 	} else {
-		r0 = ret.Get(0).(domain.Cover)
+		r0 = ret.Get(0).(domain.Artist)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, musicFolderID, musicBrainzID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -392,56 +740,57 @@ func (_m *MockMediaBrowsingRepository) GetCoverByID(ctx context.Context, id stri
 	return r0, r1
 }
 
-// MockMediaBrowsingRepository_GetCoverByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoverByID'
-type MockMediaBrowsingRepository_GetCoverByID_Call struct {
+// MockMediaBrowsingRepository_GetArtistByMusicBrainzID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtistByMusicBrainzID'
+type MockMediaBrowsingRepository_GetArtistByMusicBrainzID_Call struct {
 	*mock.Call
 }
 
-// GetCoverByID is a helper method to define mock.On call
+// GetArtistByMusicBrainzID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockMediaBrowsingRepository_Expecter) GetCoverByID(ctx interface{}, id interface{}) *MockMediaBrowsingRepository_GetCoverByID_Call {
-	return &MockMediaBrowsingRepository_GetCoverByID_Call{Call: _e.mock.On("GetCoverByID", ctx, id)}
+//   - musicFolderID int
+//   - musicBrainzID string
+func (_e *MockMediaBrowsingRepository_Expecter) GetArtistByMusicBrainzID(ctx interface{}, musicFolderID interface{}, musicBrainzID interface{}) *MockMediaBrowsingRepository_GetArtistByMusicBrainzID_Call {
+	return &MockMediaBrowsingRepository_GetArtistByMusicBrainzID_Call{Call: _e.mock.On("GetArtistByMusicBrainzID", ctx, musicFolderID, musicBrainzID)}
 }
 
-func (_c *MockMediaBrowsingRepository_GetCoverByID_Call) Run(run func(ctx context.Context, id string)) *MockMediaBrowsingRepository_GetCoverByID_Call {
+func (_c *MockMediaBrowsingRepository_GetArtistByMusicBrainzID_Call) Run(run func(ctx context.Context, musicFolderID int, musicBrainzID string)) *MockMediaBrowsingRepository_GetArtistByMusicBrainzID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockMediaBrowsingRepository_GetCoverByID_Call) Return(_a0 domain.Cover, _a1 error) *MockMediaBrowsingRepository_GetCoverByID_Call {
+func (_c *MockMediaBrowsingRepository_GetArtistByMusicBrainzID_Call) Return(_a0 domain.Artist, _a1 error) *MockMediaBrowsingRepository_GetArtistByMusicBrainzID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockMediaBrowsingRepository_GetCoverByID_Call) RunAndReturn(run func(context.Context, string) (domain.Cover, error)) *MockMediaBrowsingRepository_GetCoverByID_Call {
+func (_c *MockMediaBrowsingRepository_GetArtistByMusicBrainzID_Call) RunAndReturn(run func(context.Context, int, string) (domain.Artist, error)) *MockMediaBrowsingRepository_GetArtistByMusicBrainzID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSongByID provides a mock function with given fields: ctx, id
-func (_m *MockMediaBrowsingRepository) GetSongByID(ctx context.Context, id int) (domain.Song, error) {
-	ret := _m.Called(ctx, id)
+// GetArtistByName provides a mock function with given fields: ctx, musicFolderID, name
+func (_m *MockMediaBrowsingRepository) GetArtistByName(ctx context.Context, musicFolderID int, name string) (domain.Artist, error) {
+	ret := _m.Called(ctx, musicFolderID, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSongByID")
+		panic("no return value specified for GetArtistByName")
 	}
 
-	var r0 domain.Song
+	var r0 domain.Artist
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.Song, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (domain.Artist, error)); ok {
+		return rf(ctx, musicFolderID, name)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) domain.Song); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) domain.Artist); ok {
+		r0 = rf(ctx, musicFolderID, name)
 	} else {
-		r0 = ret.Get(0).(domain.Song)
+		r0 = ret.Get(0).(domain.Artist)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, musicFolderID, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -449,31 +798,1254 @@ func (_m *MockMediaBrowsingRepository) GetSongByID(ctx context.Context, id int)
 	return r0, r1
 }
 
-// MockMediaBrowsingRepository_GetSongByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSongByID'
-type MockMediaBrowsingRepository_GetSongByID_Call struct {
+// MockMediaBrowsingRepository_GetArtistByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtistByName'
+type MockMediaBrowsingRepository_GetArtistByName_Call struct {
 	*mock.Call
 }
 
-// GetSongByID is a helper method to define mock.On call
+// GetArtistByName is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int
-func (_e *MockMediaBrowsingRepository_Expecter) GetSongByID(ctx interface{}, id interface{}) *MockMediaBrowsingRepository_GetSongByID_Call {
-	return &MockMediaBrowsingRepository_GetSongByID_Call{Call: _e.mock.On("GetSongByID", ctx, id)}
+//   - musicFolderID int
+//   - name string
+func (_e *MockMediaBrowsingRepository_Expecter) GetArtistByName(ctx interface{}, musicFolderID interface{}, name interface{}) *MockMediaBrowsingRepository_GetArtistByName_Call {
+	return &MockMediaBrowsingRepository_GetArtistByName_Call{Call: _e.mock.On("GetArtistByName", ctx, musicFolderID, name)}
 }
 
-func (_c *MockMediaBrowsingRepository_GetSongByID_Call) Run(run func(ctx context.Context, id int)) *MockMediaBrowsingRepository_GetSongByID_Call {
+func (_c *MockMediaBrowsingRepository_GetArtistByName_Call) Run(run func(ctx context.Context, musicFolderID int, name string)) *MockMediaBrowsingRepository_GetArtistByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetArtistByName_Call) Return(_a0 domain.Artist, _a1 error) *MockMediaBrowsingRepository_GetArtistByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetArtistByName_Call) RunAndReturn(run func(context.Context, int, string) (domain.Artist, error)) *MockMediaBrowsingRepository_GetArtistByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtists provides a mock function with given fields: ctx, musicFolderIDs
+func (_m *MockMediaBrowsingRepository) GetArtists(ctx context.Context, musicFolderIDs []int) ([]domain.Artist, error) {
+	ret := _m.Called(ctx, musicFolderIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtists")
+	}
+
+	var r0 []domain.Artist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]domain.Artist, error)); ok {
+		return rf(ctx, musicFolderIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []domain.Artist); ok {
+		r0 = rf(ctx, musicFolderIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Artist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, musicFolderIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtists'
+type MockMediaBrowsingRepository_GetArtists_Call struct {
+	*mock.Call
+}
+
+// GetArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - musicFolderIDs []int
+func (_e *MockMediaBrowsingRepository_Expecter) GetArtists(ctx interface{}, musicFolderIDs interface{}) *MockMediaBrowsingRepository_GetArtists_Call {
+	return &MockMediaBrowsingRepository_GetArtists_Call{Call: _e.mock.On("GetArtists", ctx, musicFolderIDs)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetArtists_Call) Run(run func(ctx context.Context, musicFolderIDs []int)) *MockMediaBrowsingRepository_GetArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetArtists_Call) Return(_a0 []domain.Artist, _a1 error) *MockMediaBrowsingRepository_GetArtists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetArtists_Call) RunAndReturn(run func(context.Context, []int) ([]domain.Artist, error)) *MockMediaBrowsingRepository_GetArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoverByID provides a mock function with given fields: ctx, id
+func (_m *MockMediaBrowsingRepository) GetCoverByID(ctx context.Context, id string) (domain.Cover, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoverByID")
+	}
+
+	var r0 domain.Cover
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Cover, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Cover); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Cover)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetCoverByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoverByID'
+type MockMediaBrowsingRepository_GetCoverByID_Call struct {
+	*mock.Call
+}
+
+// GetCoverByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockMediaBrowsingRepository_Expecter) GetCoverByID(ctx interface{}, id interface{}) *MockMediaBrowsingRepository_GetCoverByID_Call {
+	return &MockMediaBrowsingRepository_GetCoverByID_Call{Call: _e.mock.On("GetCoverByID", ctx, id)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetCoverByID_Call) Run(run func(ctx context.Context, id string)) *MockMediaBrowsingRepository_GetCoverByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetCoverByID_Call) Return(_a0 domain.Cover, _a1 error) *MockMediaBrowsingRepository_GetCoverByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetCoverByID_Call) RunAndReturn(run func(context.Context, string) (domain.Cover, error)) *MockMediaBrowsingRepository_GetCoverByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDirectory provides a mock function with given fields: ctx, id
+func (_m *MockMediaBrowsingRepository) GetDirectory(ctx context.Context, id int) (domain.Directory, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDirectory")
+	}
+
+	var r0 domain.Directory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.Directory, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.Directory); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Directory)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetDirectory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDirectory'
+type MockMediaBrowsingRepository_GetDirectory_Call struct {
+	*mock.Call
+}
+
+// GetDirectory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockMediaBrowsingRepository_Expecter) GetDirectory(ctx interface{}, id interface{}) *MockMediaBrowsingRepository_GetDirectory_Call {
+	return &MockMediaBrowsingRepository_GetDirectory_Call{Call: _e.mock.On("GetDirectory", ctx, id)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetDirectory_Call) Run(run func(ctx context.Context, id int)) *MockMediaBrowsingRepository_GetDirectory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
 
-func (_c *MockMediaBrowsingRepository_GetSongByID_Call) Return(_a0 domain.Song, _a1 error) *MockMediaBrowsingRepository_GetSongByID_Call {
+func (_c *MockMediaBrowsingRepository_GetDirectory_Call) Return(_a0 domain.Directory, _a1 error) *MockMediaBrowsingRepository_GetDirectory_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockMediaBrowsingRepository_GetSongByID_Call) RunAndReturn(run func(context.Context, int) (domain.Song, error)) *MockMediaBrowsingRepository_GetSongByID_Call {
+func (_c *MockMediaBrowsingRepository_GetDirectory_Call) RunAndReturn(run func(context.Context, int) (domain.Directory, error)) *MockMediaBrowsingRepository_GetDirectory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDirectoryByPath provides a mock function with given fields: ctx, path
+func (_m *MockMediaBrowsingRepository) GetDirectoryByPath(ctx context.Context, path string) (domain.Directory, error) {
+	ret := _m.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDirectoryByPath")
+	}
+
+	var r0 domain.Directory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Directory, error)); ok {
+		return rf(ctx, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Directory); ok {
+		r0 = rf(ctx, path)
+	} else {
+		r0 = ret.Get(0).(domain.Directory)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetDirectoryByPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDirectoryByPath'
+type MockMediaBrowsingRepository_GetDirectoryByPath_Call struct {
+	*mock.Call
+}
+
+// GetDirectoryByPath is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+func (_e *MockMediaBrowsingRepository_Expecter) GetDirectoryByPath(ctx interface{}, path interface{}) *MockMediaBrowsingRepository_GetDirectoryByPath_Call {
+	return &MockMediaBrowsingRepository_GetDirectoryByPath_Call{Call: _e.mock.On("GetDirectoryByPath", ctx, path)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetDirectoryByPath_Call) Run(run func(ctx context.Context, path string)) *MockMediaBrowsingRepository_GetDirectoryByPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetDirectoryByPath_Call) Return(_a0 domain.Directory, _a1 error) *MockMediaBrowsingRepository_GetDirectoryByPath_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetDirectoryByPath_Call) RunAndReturn(run func(context.Context, string) (domain.Directory, error)) *MockMediaBrowsingRepository_GetDirectoryByPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGenres provides a mock function with given fields: ctx, musicFolderIDs
+func (_m *MockMediaBrowsingRepository) GetGenres(ctx context.Context, musicFolderIDs []int) ([]domain.Genre, error) {
+	ret := _m.Called(ctx, musicFolderIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGenres")
+	}
+
+	var r0 []domain.Genre
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]domain.Genre, error)); ok {
+		return rf(ctx, musicFolderIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []domain.Genre); ok {
+		r0 = rf(ctx, musicFolderIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Genre)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, musicFolderIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetGenres_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGenres'
+type MockMediaBrowsingRepository_GetGenres_Call struct {
+	*mock.Call
+}
+
+// GetGenres is a helper method to define mock.On call
+//   - ctx context.Context
+//   - musicFolderIDs []int
+func (_e *MockMediaBrowsingRepository_Expecter) GetGenres(ctx interface{}, musicFolderIDs interface{}) *MockMediaBrowsingRepository_GetGenres_Call {
+	return &MockMediaBrowsingRepository_GetGenres_Call{Call: _e.mock.On("GetGenres", ctx, musicFolderIDs)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetGenres_Call) Run(run func(ctx context.Context, musicFolderIDs []int)) *MockMediaBrowsingRepository_GetGenres_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetGenres_Call) Return(_a0 []domain.Genre, _a1 error) *MockMediaBrowsingRepository_GetGenres_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetGenres_Call) RunAndReturn(run func(context.Context, []int) ([]domain.Genre, error)) *MockMediaBrowsingRepository_GetGenres_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMusicFolders provides a mock function with given fields: ctx
+func (_m *MockMediaBrowsingRepository) GetMusicFolders(ctx context.Context) ([]domain.MusicFolder, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMusicFolders")
+	}
+
+	var r0 []domain.MusicFolder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.MusicFolder, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.MusicFolder); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.MusicFolder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetMusicFolders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMusicFolders'
+type MockMediaBrowsingRepository_GetMusicFolders_Call struct {
+	*mock.Call
+}
+
+// GetMusicFolders is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMediaBrowsingRepository_Expecter) GetMusicFolders(ctx interface{}) *MockMediaBrowsingRepository_GetMusicFolders_Call {
+	return &MockMediaBrowsingRepository_GetMusicFolders_Call{Call: _e.mock.On("GetMusicFolders", ctx)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetMusicFolders_Call) Run(run func(ctx context.Context)) *MockMediaBrowsingRepository_GetMusicFolders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetMusicFolders_Call) Return(_a0 []domain.MusicFolder, _a1 error) *MockMediaBrowsingRepository_GetMusicFolders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetMusicFolders_Call) RunAndReturn(run func(context.Context) ([]domain.MusicFolder, error)) *MockMediaBrowsingRepository_GetMusicFolders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSongByID provides a mock function with given fields: ctx, id
+func (_m *MockMediaBrowsingRepository) GetSongByID(ctx context.Context, id int) (domain.Song, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSongByID")
+	}
+
+	var r0 domain.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.Song, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.Song); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Song)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetSongByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSongByID'
+type MockMediaBrowsingRepository_GetSongByID_Call struct {
+	*mock.Call
+}
+
+// GetSongByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockMediaBrowsingRepository_Expecter) GetSongByID(ctx interface{}, id interface{}) *MockMediaBrowsingRepository_GetSongByID_Call {
+	return &MockMediaBrowsingRepository_GetSongByID_Call{Call: _e.mock.On("GetSongByID", ctx, id)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongByID_Call) Run(run func(ctx context.Context, id int)) *MockMediaBrowsingRepository_GetSongByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongByID_Call) Return(_a0 domain.Song, _a1 error) *MockMediaBrowsingRepository_GetSongByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongByID_Call) RunAndReturn(run func(context.Context, int) (domain.Song, error)) *MockMediaBrowsingRepository_GetSongByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSongFingerprints provides a mock function with given fields: ctx
+func (_m *MockMediaBrowsingRepository) GetSongFingerprints(ctx context.Context) ([]domain.FileFingerprint, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSongFingerprints")
+	}
+
+	var r0 []domain.FileFingerprint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.FileFingerprint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.FileFingerprint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.FileFingerprint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetSongFingerprints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSongFingerprints'
+type MockMediaBrowsingRepository_GetSongFingerprints_Call struct {
+	*mock.Call
+}
+
+// GetSongFingerprints is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMediaBrowsingRepository_Expecter) GetSongFingerprints(ctx interface{}) *MockMediaBrowsingRepository_GetSongFingerprints_Call {
+	return &MockMediaBrowsingRepository_GetSongFingerprints_Call{Call: _e.mock.On("GetSongFingerprints", ctx)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongFingerprints_Call) Run(run func(ctx context.Context)) *MockMediaBrowsingRepository_GetSongFingerprints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongFingerprints_Call) Return(_a0 []domain.FileFingerprint, _a1 error) *MockMediaBrowsingRepository_GetSongFingerprints_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongFingerprints_Call) RunAndReturn(run func(context.Context) ([]domain.FileFingerprint, error)) *MockMediaBrowsingRepository_GetSongFingerprints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSongsByAlbumID provides a mock function with given fields: ctx, albumID
+func (_m *MockMediaBrowsingRepository) GetSongsByAlbumID(ctx context.Context, albumID int) ([]domain.Song, error) {
+	ret := _m.Called(ctx, albumID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSongsByAlbumID")
+	}
+
+	var r0 []domain.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Song, error)); ok {
+		return rf(ctx, albumID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Song); ok {
+		r0 = rf(ctx, albumID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, albumID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetSongsByAlbumID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSongsByAlbumID'
+type MockMediaBrowsingRepository_GetSongsByAlbumID_Call struct {
+	*mock.Call
+}
+
+// GetSongsByAlbumID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - albumID int
+func (_e *MockMediaBrowsingRepository_Expecter) GetSongsByAlbumID(ctx interface{}, albumID interface{}) *MockMediaBrowsingRepository_GetSongsByAlbumID_Call {
+	return &MockMediaBrowsingRepository_GetSongsByAlbumID_Call{Call: _e.mock.On("GetSongsByAlbumID", ctx, albumID)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongsByAlbumID_Call) Run(run func(ctx context.Context, albumID int)) *MockMediaBrowsingRepository_GetSongsByAlbumID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongsByAlbumID_Call) Return(_a0 []domain.Song, _a1 error) *MockMediaBrowsingRepository_GetSongsByAlbumID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongsByAlbumID_Call) RunAndReturn(run func(context.Context, int) ([]domain.Song, error)) *MockMediaBrowsingRepository_GetSongsByAlbumID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSongsByDirectory provides a mock function with given fields: ctx, directoryID
+func (_m *MockMediaBrowsingRepository) GetSongsByDirectory(ctx context.Context, directoryID int) ([]domain.Song, error) {
+	ret := _m.Called(ctx, directoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSongsByDirectory")
+	}
+
+	var r0 []domain.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Song, error)); ok {
+		return rf(ctx, directoryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Song); ok {
+		r0 = rf(ctx, directoryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, directoryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetSongsByDirectory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSongsByDirectory'
+type MockMediaBrowsingRepository_GetSongsByDirectory_Call struct {
+	*mock.Call
+}
+
+// GetSongsByDirectory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - directoryID int
+func (_e *MockMediaBrowsingRepository_Expecter) GetSongsByDirectory(ctx interface{}, directoryID interface{}) *MockMediaBrowsingRepository_GetSongsByDirectory_Call {
+	return &MockMediaBrowsingRepository_GetSongsByDirectory_Call{Call: _e.mock.On("GetSongsByDirectory", ctx, directoryID)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongsByDirectory_Call) Run(run func(ctx context.Context, directoryID int)) *MockMediaBrowsingRepository_GetSongsByDirectory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongsByDirectory_Call) Return(_a0 []domain.Song, _a1 error) *MockMediaBrowsingRepository_GetSongsByDirectory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongsByDirectory_Call) RunAndReturn(run func(context.Context, int) ([]domain.Song, error)) *MockMediaBrowsingRepository_GetSongsByDirectory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSongsByGenre provides a mock function with given fields: ctx, genre, musicFolderIDs, limit, offset
+func (_m *MockMediaBrowsingRepository) GetSongsByGenre(ctx context.Context, genre string, musicFolderIDs []int, limit int, offset int) ([]domain.Song, error) {
+	ret := _m.Called(ctx, genre, musicFolderIDs, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSongsByGenre")
+	}
+
+	var r0 []domain.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, int, int) ([]domain.Song, error)); ok {
+		return rf(ctx, genre, musicFolderIDs, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, int, int) []domain.Song); ok {
+		r0 = rf(ctx, genre, musicFolderIDs, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []int, int, int) error); ok {
+		r1 = rf(ctx, genre, musicFolderIDs, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetSongsByGenre_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSongsByGenre'
+type MockMediaBrowsingRepository_GetSongsByGenre_Call struct {
+	*mock.Call
+}
+
+// GetSongsByGenre is a helper method to define mock.On call
+//   - ctx context.Context
+//   - genre string
+//   - musicFolderIDs []int
+//   - limit int
+//   - offset int
+func (_e *MockMediaBrowsingRepository_Expecter) GetSongsByGenre(ctx interface{}, genre interface{}, musicFolderIDs interface{}, limit interface{}, offset interface{}) *MockMediaBrowsingRepository_GetSongsByGenre_Call {
+	return &MockMediaBrowsingRepository_GetSongsByGenre_Call{Call: _e.mock.On("GetSongsByGenre", ctx, genre, musicFolderIDs, limit, offset)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongsByGenre_Call) Run(run func(ctx context.Context, genre string, musicFolderIDs []int, limit int, offset int)) *MockMediaBrowsingRepository_GetSongsByGenre_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]int), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongsByGenre_Call) Return(_a0 []domain.Song, _a1 error) *MockMediaBrowsingRepository_GetSongsByGenre_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetSongsByGenre_Call) RunAndReturn(run func(context.Context, string, []int, int, int) ([]domain.Song, error)) *MockMediaBrowsingRepository_GetSongsByGenre_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubdirectories provides a mock function with given fields: ctx, parentIDs
+func (_m *MockMediaBrowsingRepository) GetSubdirectories(ctx context.Context, parentIDs []int) ([]domain.Directory, error) {
+	ret := _m.Called(ctx, parentIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubdirectories")
+	}
+
+	var r0 []domain.Directory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]domain.Directory, error)); ok {
+		return rf(ctx, parentIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []domain.Directory); ok {
+		r0 = rf(ctx, parentIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Directory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, parentIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_GetSubdirectories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubdirectories'
+type MockMediaBrowsingRepository_GetSubdirectories_Call struct {
+	*mock.Call
+}
+
+// GetSubdirectories is a helper method to define mock.On call
+//   - ctx context.Context
+//   - parentIDs []int
+func (_e *MockMediaBrowsingRepository_Expecter) GetSubdirectories(ctx interface{}, parentIDs interface{}) *MockMediaBrowsingRepository_GetSubdirectories_Call {
+	return &MockMediaBrowsingRepository_GetSubdirectories_Call{Call: _e.mock.On("GetSubdirectories", ctx, parentIDs)}
+}
+
+func (_c *MockMediaBrowsingRepository_GetSubdirectories_Call) Run(run func(ctx context.Context, parentIDs []int)) *MockMediaBrowsingRepository_GetSubdirectories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetSubdirectories_Call) Return(_a0 []domain.Directory, _a1 error) *MockMediaBrowsingRepository_GetSubdirectories_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_GetSubdirectories_Call) RunAndReturn(run func(context.Context, []int) ([]domain.Directory, error)) *MockMediaBrowsingRepository_GetSubdirectories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveMusicFolders provides a mock function with given fields: ctx, paths
+func (_m *MockMediaBrowsingRepository) SaveMusicFolders(ctx context.Context, paths []string) ([]domain.MusicFolder, error) {
+	ret := _m.Called(ctx, paths)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMusicFolders")
+	}
+
+	var r0 []domain.MusicFolder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]domain.MusicFolder, error)); ok {
+		return rf(ctx, paths)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []domain.MusicFolder); ok {
+		r0 = rf(ctx, paths)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.MusicFolder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, paths)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_SaveMusicFolders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveMusicFolders'
+type MockMediaBrowsingRepository_SaveMusicFolders_Call struct {
+	*mock.Call
+}
+
+// SaveMusicFolders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - paths []string
+func (_e *MockMediaBrowsingRepository_Expecter) SaveMusicFolders(ctx interface{}, paths interface{}) *MockMediaBrowsingRepository_SaveMusicFolders_Call {
+	return &MockMediaBrowsingRepository_SaveMusicFolders_Call{Call: _e.mock.On("SaveMusicFolders", ctx, paths)}
+}
+
+func (_c *MockMediaBrowsingRepository_SaveMusicFolders_Call) Run(run func(ctx context.Context, paths []string)) *MockMediaBrowsingRepository_SaveMusicFolders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_SaveMusicFolders_Call) Return(_a0 []domain.MusicFolder, _a1 error) *MockMediaBrowsingRepository_SaveMusicFolders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_SaveMusicFolders_Call) RunAndReturn(run func(context.Context, []string) ([]domain.MusicFolder, error)) *MockMediaBrowsingRepository_SaveMusicFolders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveSongs provides a mock function with given fields: ctx, songs
+func (_m *MockMediaBrowsingRepository) SaveSongs(ctx context.Context, songs []domain.Song) ([]domain.Song, error) {
+	ret := _m.Called(ctx, songs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSongs")
+	}
+
+	var r0 []domain.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Song) ([]domain.Song, error)); ok {
+		return rf(ctx, songs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Song) []domain.Song); ok {
+		r0 = rf(ctx, songs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.Song) error); ok {
+		r1 = rf(ctx, songs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_SaveSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSongs'
+type MockMediaBrowsingRepository_SaveSongs_Call struct {
+	*mock.Call
+}
+
+// SaveSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songs []domain.Song
+func (_e *MockMediaBrowsingRepository_Expecter) SaveSongs(ctx interface{}, songs interface{}) *MockMediaBrowsingRepository_SaveSongs_Call {
+	return &MockMediaBrowsingRepository_SaveSongs_Call{Call: _e.mock.On("SaveSongs", ctx, songs)}
+}
+
+func (_c *MockMediaBrowsingRepository_SaveSongs_Call) Run(run func(ctx context.Context, songs []domain.Song)) *MockMediaBrowsingRepository_SaveSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Song))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_SaveSongs_Call) Return(_a0 []domain.Song, _a1 error) *MockMediaBrowsingRepository_SaveSongs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_SaveSongs_Call) RunAndReturn(run func(context.Context, []domain.Song) ([]domain.Song, error)) *MockMediaBrowsingRepository_SaveSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchAlbums provides a mock function with given fields: ctx, query, musicFolderIDs, limit, offset
+func (_m *MockMediaBrowsingRepository) SearchAlbums(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int) ([]domain.Album, error) {
+	ret := _m.Called(ctx, query, musicFolderIDs, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchAlbums")
+	}
+
+	var r0 []domain.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, int, int) ([]domain.Album, error)); ok {
+		return rf(ctx, query, musicFolderIDs, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, int, int) []domain.Album); ok {
+		r0 = rf(ctx, query, musicFolderIDs, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Album)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []int, int, int) error); ok {
+		r1 = rf(ctx, query, musicFolderIDs, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_SearchAlbums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchAlbums'
+type MockMediaBrowsingRepository_SearchAlbums_Call struct {
+	*mock.Call
+}
+
+// SearchAlbums is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - musicFolderIDs []int
+//   - limit int
+//   - offset int
+func (_e *MockMediaBrowsingRepository_Expecter) SearchAlbums(ctx interface{}, query interface{}, musicFolderIDs interface{}, limit interface{}, offset interface{}) *MockMediaBrowsingRepository_SearchAlbums_Call {
+	return &MockMediaBrowsingRepository_SearchAlbums_Call{Call: _e.mock.On("SearchAlbums", ctx, query, musicFolderIDs, limit, offset)}
+}
+
+func (_c *MockMediaBrowsingRepository_SearchAlbums_Call) Run(run func(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int)) *MockMediaBrowsingRepository_SearchAlbums_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]int), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_SearchAlbums_Call) Return(_a0 []domain.Album, _a1 error) *MockMediaBrowsingRepository_SearchAlbums_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_SearchAlbums_Call) RunAndReturn(run func(context.Context, string, []int, int, int) ([]domain.Album, error)) *MockMediaBrowsingRepository_SearchAlbums_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchArtists provides a mock function with given fields: ctx, query, musicFolderIDs, limit, offset
+func (_m *MockMediaBrowsingRepository) SearchArtists(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int) ([]domain.Artist, error) {
+	ret := _m.Called(ctx, query, musicFolderIDs, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchArtists")
+	}
+
+	var r0 []domain.Artist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, int, int) ([]domain.Artist, error)); ok {
+		return rf(ctx, query, musicFolderIDs, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, int, int) []domain.Artist); ok {
+		r0 = rf(ctx, query, musicFolderIDs, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Artist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []int, int, int) error); ok {
+		r1 = rf(ctx, query, musicFolderIDs, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_SearchArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchArtists'
+type MockMediaBrowsingRepository_SearchArtists_Call struct {
+	*mock.Call
+}
+
+// SearchArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - musicFolderIDs []int
+//   - limit int
+//   - offset int
+func (_e *MockMediaBrowsingRepository_Expecter) SearchArtists(ctx interface{}, query interface{}, musicFolderIDs interface{}, limit interface{}, offset interface{}) *MockMediaBrowsingRepository_SearchArtists_Call {
+	return &MockMediaBrowsingRepository_SearchArtists_Call{Call: _e.mock.On("SearchArtists", ctx, query, musicFolderIDs, limit, offset)}
+}
+
+func (_c *MockMediaBrowsingRepository_SearchArtists_Call) Run(run func(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int)) *MockMediaBrowsingRepository_SearchArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]int), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_SearchArtists_Call) Return(_a0 []domain.Artist, _a1 error) *MockMediaBrowsingRepository_SearchArtists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_SearchArtists_Call) RunAndReturn(run func(context.Context, string, []int, int, int) ([]domain.Artist, error)) *MockMediaBrowsingRepository_SearchArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchSongs provides a mock function with given fields: ctx, query, musicFolderIDs, limit, offset
+func (_m *MockMediaBrowsingRepository) SearchSongs(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int) ([]domain.Song, error) {
+	ret := _m.Called(ctx, query, musicFolderIDs, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSongs")
+	}
+
+	var r0 []domain.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, int, int) ([]domain.Song, error)); ok {
+		return rf(ctx, query, musicFolderIDs, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, int, int) []domain.Song); ok {
+		r0 = rf(ctx, query, musicFolderIDs, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []int, int, int) error); ok {
+		r1 = rf(ctx, query, musicFolderIDs, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_SearchSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSongs'
+type MockMediaBrowsingRepository_SearchSongs_Call struct {
+	*mock.Call
+}
+
+// SearchSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - musicFolderIDs []int
+//   - limit int
+//   - offset int
+func (_e *MockMediaBrowsingRepository_Expecter) SearchSongs(ctx interface{}, query interface{}, musicFolderIDs interface{}, limit interface{}, offset interface{}) *MockMediaBrowsingRepository_SearchSongs_Call {
+	return &MockMediaBrowsingRepository_SearchSongs_Call{Call: _e.mock.On("SearchSongs", ctx, query, musicFolderIDs, limit, offset)}
+}
+
+func (_c *MockMediaBrowsingRepository_SearchSongs_Call) Run(run func(ctx context.Context, query string, musicFolderIDs []int, limit int, offset int)) *MockMediaBrowsingRepository_SearchSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]int), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_SearchSongs_Call) Return(_a0 []domain.Song, _a1 error) *MockMediaBrowsingRepository_SearchSongs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_SearchSongs_Call) RunAndReturn(run func(context.Context, string, []int, int, int) ([]domain.Song, error)) *MockMediaBrowsingRepository_SearchSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAlbum provides a mock function with given fields: ctx, album
+func (_m *MockMediaBrowsingRepository) UpdateAlbum(ctx context.Context, album domain.Album) (domain.Album, error) {
+	ret := _m.Called(ctx, album)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAlbum")
+	}
+
+	var r0 domain.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Album) (domain.Album, error)); ok {
+		return rf(ctx, album)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Album) domain.Album); ok {
+		r0 = rf(ctx, album)
+	} else {
+		r0 = ret.Get(0).(domain.Album)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Album) error); ok {
+		r1 = rf(ctx, album)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_UpdateAlbum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlbum'
+type MockMediaBrowsingRepository_UpdateAlbum_Call struct {
+	*mock.Call
+}
+
+// UpdateAlbum is a helper method to define mock.On call
+//   - ctx context.Context
+//   - album domain.Album
+func (_e *MockMediaBrowsingRepository_Expecter) UpdateAlbum(ctx interface{}, album interface{}) *MockMediaBrowsingRepository_UpdateAlbum_Call {
+	return &MockMediaBrowsingRepository_UpdateAlbum_Call{Call: _e.mock.On("UpdateAlbum", ctx, album)}
+}
+
+func (_c *MockMediaBrowsingRepository_UpdateAlbum_Call) Run(run func(ctx context.Context, album domain.Album)) *MockMediaBrowsingRepository_UpdateAlbum_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Album))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_UpdateAlbum_Call) Return(_a0 domain.Album, _a1 error) *MockMediaBrowsingRepository_UpdateAlbum_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_UpdateAlbum_Call) RunAndReturn(run func(context.Context, domain.Album) (domain.Album, error)) *MockMediaBrowsingRepository_UpdateAlbum_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateArtist provides a mock function with given fields: ctx, artist
+func (_m *MockMediaBrowsingRepository) UpdateArtist(ctx context.Context, artist domain.Artist) (domain.Artist, error) {
+	ret := _m.Called(ctx, artist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateArtist")
+	}
+
+	var r0 domain.Artist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Artist) (domain.Artist, error)); ok {
+		return rf(ctx, artist)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Artist) domain.Artist); ok {
+		r0 = rf(ctx, artist)
+	} else {
+		r0 = ret.Get(0).(domain.Artist)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Artist) error); ok {
+		r1 = rf(ctx, artist)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_UpdateArtist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateArtist'
+type MockMediaBrowsingRepository_UpdateArtist_Call struct {
+	*mock.Call
+}
+
+// UpdateArtist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - artist domain.Artist
+func (_e *MockMediaBrowsingRepository_Expecter) UpdateArtist(ctx interface{}, artist interface{}) *MockMediaBrowsingRepository_UpdateArtist_Call {
+	return &MockMediaBrowsingRepository_UpdateArtist_Call{Call: _e.mock.On("UpdateArtist", ctx, artist)}
+}
+
+func (_c *MockMediaBrowsingRepository_UpdateArtist_Call) Run(run func(ctx context.Context, artist domain.Artist)) *MockMediaBrowsingRepository_UpdateArtist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Artist))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_UpdateArtist_Call) Return(_a0 domain.Artist, _a1 error) *MockMediaBrowsingRepository_UpdateArtist_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_UpdateArtist_Call) RunAndReturn(run func(context.Context, domain.Artist) (domain.Artist, error)) *MockMediaBrowsingRepository_UpdateArtist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCatalogStats provides a mock function with given fields: ctx
+func (_m *MockMediaBrowsingRepository) UpdateCatalogStats(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCatalogStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMediaBrowsingRepository_UpdateCatalogStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCatalogStats'
+type MockMediaBrowsingRepository_UpdateCatalogStats_Call struct {
+	*mock.Call
+}
+
+// UpdateCatalogStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMediaBrowsingRepository_Expecter) UpdateCatalogStats(ctx interface{}) *MockMediaBrowsingRepository_UpdateCatalogStats_Call {
+	return &MockMediaBrowsingRepository_UpdateCatalogStats_Call{Call: _e.mock.On("UpdateCatalogStats", ctx)}
+}
+
+func (_c *MockMediaBrowsingRepository_UpdateCatalogStats_Call) Run(run func(ctx context.Context)) *MockMediaBrowsingRepository_UpdateCatalogStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_UpdateCatalogStats_Call) Return(_a0 error) *MockMediaBrowsingRepository_UpdateCatalogStats_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_UpdateCatalogStats_Call) RunAndReturn(run func(context.Context) error) *MockMediaBrowsingRepository_UpdateCatalogStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSong provides a mock function with given fields: ctx, song
+func (_m *MockMediaBrowsingRepository) UpdateSong(ctx context.Context, song domain.Song) (domain.Song, error) {
+	ret := _m.Called(ctx, song)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSong")
+	}
+
+	var r0 domain.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Song) (domain.Song, error)); ok {
+		return rf(ctx, song)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Song) domain.Song); ok {
+		r0 = rf(ctx, song)
+	} else {
+		r0 = ret.Get(0).(domain.Song)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Song) error); ok {
+		r1 = rf(ctx, song)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMediaBrowsingRepository_UpdateSong_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSong'
+type MockMediaBrowsingRepository_UpdateSong_Call struct {
+	*mock.Call
+}
+
+// UpdateSong is a helper method to define mock.On call
+//   - ctx context.Context
+//   - song domain.Song
+func (_e *MockMediaBrowsingRepository_Expecter) UpdateSong(ctx interface{}, song interface{}) *MockMediaBrowsingRepository_UpdateSong_Call {
+	return &MockMediaBrowsingRepository_UpdateSong_Call{Call: _e.mock.On("UpdateSong", ctx, song)}
+}
+
+func (_c *MockMediaBrowsingRepository_UpdateSong_Call) Run(run func(ctx context.Context, song domain.Song)) *MockMediaBrowsingRepository_UpdateSong_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Song))
+	})
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_UpdateSong_Call) Return(_a0 domain.Song, _a1 error) *MockMediaBrowsingRepository_UpdateSong_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMediaBrowsingRepository_UpdateSong_Call) RunAndReturn(run func(context.Context, domain.Song) (domain.Song, error)) *MockMediaBrowsingRepository_UpdateSong_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// supportedPlaylistFormats lists the playlist file suffixes imported by the scanner.
+var supportedPlaylistFormats = []string{"m3u", "m3u8", "pls"}
+
+// playlistFile is a parsed playlist file. Entries are the song locations as written in the file.
+type playlistFile struct {
+	name    string
+	entries []string
+}
+
+// parsePlaylistFile parses the content of the playlist file at path, as M3U or PLS depending on its suffix.
+// The playlist is named after the file unless an extended M3U names it.
+func parsePlaylistFile(path string, data []byte) (*playlistFile, error) {
+	playlist := &playlistFile{name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))}
+	var (
+		name string
+		err  error
+	)
+	if fileSuffix(path) == "pls" {
+		playlist.entries, err = parsePLS(decodeText(data))
+	} else {
+		name, playlist.entries, err = parseM3U(decodeText(data))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		playlist.name = name
+	}
+	return playlist, nil
+}
+
+// parseM3U returns the name and entries of an M3U playlist, one location per line, lines starting with # being comments.
+// The name is only set by the #PLAYLIST directive of extended M3U.
+func parseM3U(content string) (string, []string, error) {
+	var (
+		name    string
+		entries []string
+	)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if value, ok := strings.CutPrefix(line, "#PLAYLIST:"); ok {
+			name = strings.TrimSpace(value)
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", nil, err
+	}
+	return name, entries, nil
+}
+
+// parsePLS returns the entries of a PLS playlist, the FileN keys of its [playlist] section ordered by N.
+func parsePLS(content string) ([]string, error) {
+	type plsEntry struct {
+		number   int
+		location string
+	}
+	var (
+		entries   []plsEntry
+		inSection bool
+		found     bool
+	)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			inSection = strings.EqualFold(line, "[playlist]")
+			found = found || inSection
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !inSection || !ok || len(key) <= 4 || !strings.EqualFold(key[:4], "file") {
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimSpace(key[4:]))
+		if err != nil {
+			continue
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			entries = append(entries, plsEntry{number: number, location: value})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.New("missing [playlist] section")
+	}
+
+	slices.SortStableFunc(entries, func(a, b plsEntry) int {
+		return a.number - b.number
+	})
+	locations := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		locations = append(locations, entry.location)
+	}
+	return locations, nil
+}
+
+// resolvePlaylistEntry returns the path of the file a playlist entry refers to.
+// Relative entries are relative to dir, the folder of the playlist file, and file URLs are converted to paths.
+// Returns false for entries pointing to other kinds of URLs, such as internet radio streams.
+func resolvePlaylistEntry(dir string, entry string) (string, bool) {
+	if strings.Contains(entry, "://") {
+		location, err := url.Parse(entry)
+		if err != nil || !strings.EqualFold(location.Scheme, "file") {
+			return "", false
+		}
+		entry = location.Path
+	}
+	// Playlists written on Windows separate folders with backslashes
+	entry = filepath.FromSlash(strings.ReplaceAll(entry, `\`, "/"))
+	if !filepath.IsAbs(entry) {
+		entry = filepath.Join(dir, entry)
+	}
+	return filepath.Clean(entry), true
+}
+
+func readPlaylistFile(path string) (*playlistFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	playlist, err := parsePlaylistFile(path, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid playlist: %w", err)
+	}
+	return playlist, nil
+}
+
+func isSupportedPlaylistFile(path string) bool {
+	return slices.Contains(supportedPlaylistFormats, fileSuffix(path))
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParsePlaylistFile(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		data            []byte
+		expectedName    string
+		expectedEntries []string
+		expectedError   bool
+	}{
+		{
+			name:            "simple m3u",
+			path:            "/music/Road Trip.m3u",
+			data:            []byte("Artist/Album/01 Song.mp3\n\n/music/Other/02 Song.flac\r\n"),
+			expectedName:    "Road Trip",
+			expectedEntries: []string{"Artist/Album/01 Song.mp3", "/music/Other/02 Song.flac"},
+		},
+		{
+			name:            "extended m3u with a playlist name",
+			path:            "/music/list.m3u8",
+			data:            []byte("\xef\xbb\xbf#EXTM3U\n#PLAYLIST:Friday Mix\n#EXTINF:215,Artist - Song\nsong.opus\n"),
+			expectedName:    "Friday Mix",
+			expectedEntries: []string{"song.opus"},
+		},
+		{
+			name:            "latin-1 m3u",
+			path:            "/music/old.m3u",
+			data:            []byte("Caf\xe9.mp3\n"),
+			expectedName:    "old",
+			expectedEntries: []string{"Café.mp3"},
+		},
+		{
+			name:            "pls ordered by entry number",
+			path:            "/music/Party.pls",
+			data:            []byte("[playlist]\nFile2=b.mp3\nTitle2=B\nfile1=a.mp3\nFile10=c.mp3\nNumberOfEntries=3\nVersion=2\n"),
+			expectedName:    "Party",
+			expectedEntries: []string{"a.mp3", "b.mp3", "c.mp3"},
+		},
+		{
+			name:          "pls without playlist section",
+			path:          "/music/broken.pls",
+			data:          []byte("File1=a.mp3\n"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playlist, err := parsePlaylistFile(tt.path, tt.data)
+
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if playlist.name != tt.expectedName {
+				t.Errorf("expected name %q, got %q", tt.expectedName, playlist.name)
+			}
+			if !slices.Equal(playlist.entries, tt.expectedEntries) {
+				t.Errorf("expected entries %q, got %q", tt.expectedEntries, playlist.entries)
+			}
+		})
+	}
+}
+
+func TestResolvePlaylistEntry(t *testing.T) {
+	dir := filepath.FromSlash("/music/Playlists")
+
+	tests := []struct {
+		name          string
+		entry         string
+		expectedPath  string
+		expectedFound bool
+	}{
+		{
+			name:          "relative entry",
+			entry:         "../Artist/Album/01 Song.mp3",
+			expectedPath:  filepath.FromSlash("/music/Artist/Album/01 Song.mp3"),
+			expectedFound: true,
+		},
+		{
+			name:          "absolute entry",
+			entry:         "/music/Artist/02 Song.flac",
+			expectedPath:  filepath.FromSlash("/music/Artist/02 Song.flac"),
+			expectedFound: true,
+		},
+		{
+			name:          "entry with backslashes",
+			entry:         `..\Artist\03 Song.mp3`,
+			expectedPath:  filepath.FromSlash("/music/Artist/03 Song.mp3"),
+			expectedFound: true,
+		},
+		{
+			name:          "file url",
+			entry:         "file:///music/Artist/04%20Song.ogg",
+			expectedPath:  filepath.FromSlash("/music/Artist/04 Song.ogg"),
+			expectedFound: true,
+		},
+		{
+			name:  "internet radio stream",
+			entry: "http://radio.example.com/stream.mp3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, found := resolvePlaylistEntry(dir, tt.entry)
+
+			if found != tt.expectedFound {
+				t.Fatalf("expected found %v, got %v", tt.expectedFound, found)
+			}
+			if path != tt.expectedPath {
+				t.Errorf("expected path %q, got %q", tt.expectedPath, path)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"slices"
+	"strings"
+	"time"
+)
+
+type PlaylistService struct {
+	playlistRepo      ports.PlaylistRepository
+	mediaBrowsingRepo ports.MediaBrowsingRepository
+	annotationRepo    ports.MediaAnnotationRepository
+	logger            *slog.Logger
+}
+
+func NewPlaylistService(playlistRepo ports.PlaylistRepository, mediaBrowsingRepo ports.MediaBrowsingRepository, annotationRepo ports.MediaAnnotationRepository, logger *slog.Logger) *PlaylistService {
+	return &PlaylistService{
+		playlistRepo:      playlistRepo,
+		mediaBrowsingRepo: mediaBrowsingRepo,
+		annotationRepo:    annotationRepo,
+		logger:            logger,
+	}
+}
+
+func (s *PlaylistService) GetPlaylists(ctx context.Context, username string) ([]domain.Playlist, error) {
+	requestingUser, ok := ctx.Value(ports.KeyRequestingUserID).(*domain.User)
+	if !ok || requestingUser == nil {
+		s.logger.Warn("Unauthorized get playlists attempt", slog.String("target_username", username))
+		return nil, &ports.NotAuthorizedError{Action: "get playlists"}
+	}
+	if username == "" {
+		username = requestingUser.Username
+	}
+	s.logger.Info("Getting playlists", slog.String("requesting_user", requestingUser.Username), slog.String("target_username", username))
+
+	if username != requestingUser.Username && !requestingUser.AdminRole {
+		s.logger.Warn("Unauthorized get playlists attempt", slog.String("requesting_user", requestingUser.Username), slog.String("target_username", username))
+		return nil, &ports.NotAuthorizedError{Username: requestingUser.Username, Action: "get playlists of another user"}
+	}
+
+	playlists, err := s.playlistRepo.GetPlaylists(ctx, username, allowedMusicFolders(ctx))
+	if err != nil {
+		s.logger.Error("Failed to get playlists", slog.String("target_username", username), slog.String("error", err.Error()))
+		return nil, err
+	}
+	s.logger.Info("Successfully retrieved playlists", slog.String("target_username", username), slog.Int("count", len(playlists)))
+	return playlists, nil
+}
+
+func (s *PlaylistService) GetPlaylist(ctx context.Context, id int) (domain.Playlist, error) {
+	s.logger.Info("Getting playlist", slog.Int("id", id))
+	playlist, err := s.viewablePlaylist(ctx, id)
+	if err != nil {
+		return domain.Playlist{}, err
+	}
+
+	// Songs outside the allowed music folders are left out
+	songs := make([]domain.Song, 0, len(playlist.Songs))
+	for _, song := range playlist.Songs {
+		if canAccessMusicFolder(ctx, song.MusicFolderId) {
+			songs = append(songs, song)
+		}
+	}
+	playlist.Songs = songs
+	playlist.SongIds = make([]int, 0, len(songs))
+	playlist.SongCount, playlist.Duration = len(songs), 0
+	for _, song := range songs {
+		playlist.SongIds = append(playlist.SongIds, song.Id)
+		playlist.Duration += song.Duration
+	}
+	if err := annotateSongs(ctx, s.annotationRepo, playlist.Songs); err != nil {
+		s.logger.Error("Failed to get annotations", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Playlist{}, err
+	}
+	s.logger.Info("Successfully retrieved playlist", slog.Int("id", id), slog.Int("songs", playlist.SongCount))
+	return playlist, nil
+}
+
+func (s *PlaylistService) CreatePlaylist(ctx context.Context, name string, songIDs []int) (domain.Playlist, error) {
+	requestingUser, ok := ctx.Value(ports.KeyRequestingUserID).(*domain.User)
+	var username string
+	if requestingUser != nil {
+		username = requestingUser.Username
+	}
+	s.logger.Info("Creating playlist", slog.String("username", username), slog.String("name", name), slog.Int("songs", len(songIDs)))
+
+	if !ok || requestingUser == nil || (!requestingUser.AdminRole && !requestingUser.PlaylistRole) {
+		s.logger.Warn("Unauthorized create playlist attempt", slog.String("username", username))
+		return domain.Playlist{}, &ports.NotAuthorizedError{Username: username, Action: "create playlist"}
+	}
+	if strings.TrimSpace(name) == "" {
+		return domain.Playlist{}, &ports.MissingOrInvalidParameterError{ParameterName: "name"}
+	}
+	if err := s.checkSongs(ctx, songIDs); err != nil {
+		return domain.Playlist{}, err
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	playlist := domain.Playlist{Name: name, Owner: username, Created: now, Changed: now, SongIds: songIDs}
+	if err := playlist.Validate(); err != nil {
+		s.logger.Warn("Invalid playlist data", slog.String("username", username), slog.String("error", err.Error()))
+		return domain.Playlist{}, &ports.MissingOrInvalidParameterError{ParameterName: err.Error()}
+	}
+	created, err := s.playlistRepo.CreatePlaylist(ctx, playlist)
+	if err != nil {
+		s.logger.Error("Failed to create playlist", slog.String("username", username), slog.String("error", err.Error()))
+		return domain.Playlist{}, err
+	}
+	s.logger.Info("Successfully created playlist", slog.String("username", username), slog.Int("id", created.Id))
+	return s.GetPlaylist(ctx, created.Id)
+}
+
+func (s *PlaylistService) ReplacePlaylistSongs(ctx context.Context, id int, songIDs []int) (domain.Playlist, error) {
+	s.logger.Info("Replacing playlist songs", slog.Int("id", id), slog.Int("songs", len(songIDs)))
+	playlist, err := s.editablePlaylist(ctx, id, "update playlist")
+	if err != nil {
+		return domain.Playlist{}, err
+	}
+	if err := s.checkSongs(ctx, songIDs); err != nil {
+		return domain.Playlist{}, err
+	}
+
+	// Songs the requesting user may not access are kept after the new songs
+	newSongIDs := slices.Clone(songIDs)
+	for _, song := range playlist.Songs {
+		if !canAccessMusicFolder(ctx, song.MusicFolderId) {
+			newSongIDs = append(newSongIDs, song.Id)
+		}
+	}
+	playlist.SongIds = newSongIDs
+	playlist.Changed = time.Now().Format(time.RFC3339)
+	if _, err := s.playlistRepo.UpdatePlaylist(ctx, playlist); err != nil {
+		s.logger.Error("Failed to replace playlist songs", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Playlist{}, err
+	}
+	s.logger.Info("Successfully replaced playlist songs", slog.Int("id", id))
+	return s.GetPlaylist(ctx, id)
+}
+
+func (s *PlaylistService) UpdatePlaylist(ctx context.Context, id int, update domain.PlaylistUpdate) error {
+	s.logger.Info("Updating playlist", slog.Int("id", id), slog.Int("songsToAdd", len(update.SongIdsToAdd)), slog.Int("songsToRemove", len(update.SongIndexesToRemove)), slog.Int("songsToMove", len(update.SongMoves)))
+	playlist, err := s.editablePlaylist(ctx, id, "update playlist")
+	if err != nil {
+		return err
+	}
+
+	if update.Name != nil {
+		playlist.Name = *update.Name
+	}
+	if update.Comment != nil {
+		playlist.Comment = *update.Comment
+	}
+	if update.Public != nil {
+		playlist.Public = *update.Public
+	}
+
+	// Indexes count the songs the requesting user sees, as GetPlaylist retrieves them
+	var visible []int
+	for position, song := range playlist.Songs {
+		if canAccessMusicFolder(ctx, song.MusicFolderId) {
+			visible = append(visible, position)
+		}
+	}
+	removed := make(map[int]bool, len(update.SongIndexesToRemove))
+	for _, index := range update.SongIndexesToRemove {
+		if index < 0 || index >= len(visible) {
+			return &ports.MissingOrInvalidParameterError{ParameterName: "songIndexToRemove"}
+		}
+		removed[visible[index]] = true
+	}
+	if err := s.checkSongs(ctx, update.SongIdsToAdd); err != nil {
+		return err
+	}
+
+	// Moves reorder the visible songs left, hidden songs keeping their places
+	var kept, order []int
+	for _, position := range visible {
+		if !removed[position] {
+			kept = append(kept, position)
+		}
+	}
+	order = slices.Clone(kept)
+	for _, move := range update.SongMoves {
+		if move.From < 0 || move.From >= len(order) || move.To < 0 || move.To >= len(order) {
+			return &ports.MissingOrInvalidParameterError{ParameterName: "songIndexToMove"}
+		}
+		position := order[move.From]
+		order = slices.Insert(slices.Delete(order, move.From, move.From+1), move.To, position)
+	}
+	moved := make(map[int]int, len(kept))
+	for i, position := range kept {
+		moved[position] = order[i]
+	}
+
+	songIDs := make([]int, 0, len(playlist.SongIds)+len(update.SongIdsToAdd))
+	for position, songID := range playlist.SongIds {
+		if removed[position] {
+			continue
+		}
+		if source, exists := moved[position]; exists {
+			songID = playlist.SongIds[source]
+		}
+		songIDs = append(songIDs, songID)
+	}
+	playlist.SongIds = append(songIDs, update.SongIdsToAdd...)
+	playlist.Changed = time.Now().Format(time.RFC3339)
+
+	if err := playlist.Validate(); err != nil {
+		s.logger.Warn("Invalid playlist data", slog.Int("id", id), slog.String("error", err.Error()))
+		return &ports.MissingOrInvalidParameterError{ParameterName: err.Error()}
+	}
+	if _, err := s.playlistRepo.UpdatePlaylist(ctx, playlist); err != nil {
+		s.logger.Error("Failed to update playlist", slog.Int("id", id), slog.String("error", err.Error()))
+		return err
+	}
+	s.logger.Info("Successfully updated playlist", slog.Int("id", id), slog.Int("songs", len(playlist.SongIds)))
+	return nil
+}
+
+func (s *PlaylistService) DeletePlaylist(ctx context.Context, id int) error {
+	s.logger.Info("Deleting playlist", slog.Int("id", id))
+	if _, err := s.editablePlaylist(ctx, id, "delete playlist"); err != nil {
+		return err
+	}
+
+	if err := s.playlistRepo.DeletePlaylist(ctx, id); err != nil {
+		s.logger.Error("Failed to delete playlist", slog.Int("id", id), slog.String("error", err.Error()))
+		return err
+	}
+	s.logger.Info("Successfully deleted playlist", slog.Int("id", id))
+	return nil
+}
+
+// viewablePlaylist retrieves a playlist with all its songs, when the requesting user owns it, it is public,
+// or the requesting user is an admin. Private playlists of other users are not found.
+func (s *PlaylistService) viewablePlaylist(ctx context.Context, id int) (domain.Playlist, error) {
+	requestingUser, ok := ctx.Value(ports.KeyRequestingUserID).(*domain.User)
+	if !ok || requestingUser == nil {
+		s.logger.Warn("Unauthorized get playlist attempt", slog.Int("id", id))
+		return domain.Playlist{}, &ports.NotAuthorizedError{Action: "get playlist"}
+	}
+
+	playlist, err := s.playlistRepo.GetPlaylist(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get playlist", slog.Int("id", id), slog.String("error", err.Error()))
+		return domain.Playlist{}, err
+	}
+	if !playlist.Public && playlist.Owner != requestingUser.Username && !requestingUser.AdminRole {
+		s.logger.Warn("Private playlist of another user", slog.Int("id", id), slog.String("username", requestingUser.Username))
+		return domain.Playlist{}, &ports.NotFoundError{Message: "playlist not found"}
+	}
+	return playlist, nil
+}
+
+// editablePlaylist retrieves a playlist with all its songs, when the requesting user may change it:
+// admins may change every playlist, owners with the playlist role their own playlists.
+// Playlists imported from playlist files are kept in sync with their file and may not be changed.
+func (s *PlaylistService) editablePlaylist(ctx context.Context, id int, action string) (domain.Playlist, error) {
+	playlist, err := s.viewablePlaylist(ctx, id)
+	if err != nil {
+		return domain.Playlist{}, err
+	}
+	requestingUser := ctx.Value(ports.KeyRequestingUserID).(*domain.User)
+	if !requestingUser.AdminRole && (playlist.Owner != requestingUser.Username || !requestingUser.PlaylistRole) {
+		s.logger.Warn("Unauthorized "+action+" attempt", slog.Int("id", id), slog.String("username", requestingUser.Username))
+		return domain.Playlist{}, &ports.NotAuthorizedError{Username: requestingUser.Username, Action: action}
+	}
+	if playlist.IsImported() {
+		s.logger.Warn("Imported playlist not changed", slog.Int("id", id), slog.String("path", playlist.Path))
+		return domain.Playlist{}, &ports.FailedOperationError{Description: "playlist is imported from a playlist file"}
+	}
+	return playlist, nil
+}
+
+// checkSongs makes sure the songs with the IDs exist in a music folder the requesting user may access.
+func (s *PlaylistService) checkSongs(ctx context.Context, songIDs []int) error {
+	for _, songID := range songIDs {
+		song, err := s.mediaBrowsingRepo.GetSongByID(ctx, songID)
+		if err != nil {
+			s.logger.Error("Failed to get playlist song", slog.Int("songId", songID), slog.String("error", err.Error()))
+			return err
+		}
+		if !canAccessMusicFolder(ctx, song.MusicFolderId) {
+			s.logger.Warn("Playlist song outside the allowed music folders", slog.Int("songId", songID))
+			return &ports.NotFoundError{Message: "song not found"}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,496 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"music-streaming/internal/core/domain"
+	"music-streaming/internal/core/ports"
+	"music-streaming/internal/core/services/mocks"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPlaylistService_GetPlaylists(t *testing.T) {
+	tests := []struct {
+		name              string
+		user              *domain.User
+		username          string
+		setupMock         func(*mocks.MockPlaylistRepository)
+		expectedPlaylists []domain.Playlist
+		expectedError     error
+	}{
+		{
+			name: "playlists of the requesting user",
+			user: &domain.User{Username: "alice"},
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylists(mock.Anything, "alice", []int(nil)).Return([]domain.Playlist{
+					{Id: 1, Name: "Mine", Owner: "alice", SongCount: 2},
+					{Id: 2, Name: "Shared", Owner: "bob", Public: true},
+				}, nil)
+			},
+			expectedPlaylists: []domain.Playlist{
+				{Id: 1, Name: "Mine", Owner: "alice", SongCount: 2},
+				{Id: 2, Name: "Shared", Owner: "bob", Public: true},
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "playlists of another user for an admin",
+			user:     &domain.User{Username: "admin", AdminRole: true},
+			username: "alice",
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylists(mock.Anything, "alice", []int(nil)).Return([]domain.Playlist{}, nil)
+			},
+			expectedPlaylists: []domain.Playlist{},
+			expectedError:     nil,
+		},
+		{
+			name:     "song counts of a restricted user",
+			user:     &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			username: "kid",
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylists(mock.Anything, "kid", []int{2}).Return([]domain.Playlist{}, nil)
+			},
+			expectedPlaylists: []domain.Playlist{},
+			expectedError:     nil,
+		},
+		{
+			name:          "playlists of another user",
+			user:          &domain.User{Username: "alice"},
+			username:      "bob",
+			setupMock:     func(m *mocks.MockPlaylistRepository) {},
+			expectedError: &ports.NotAuthorizedError{Username: "alice", Action: "get playlists of another user"},
+		},
+		{
+			name:          "no requesting user",
+			setupMock:     func(m *mocks.MockPlaylistRepository) {},
+			expectedError: &ports.NotAuthorizedError{Action: "get playlists"},
+		},
+		{
+			name: "repository error",
+			user: &domain.User{Username: "alice"},
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylists(mock.Anything, "alice", []int(nil)).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockPlaylistRepository(t)
+			tt.setupMock(repo)
+			service := NewPlaylistService(repo, mocks.NewMockMediaBrowsingRepository(t), unannotatedRepository(t), slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetPlaylists(ctx, tt.username)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(result, tt.expectedPlaylists) {
+					t.Errorf("expected playlists %+v, got %+v", tt.expectedPlaylists, result)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaylistService_GetPlaylist(t *testing.T) {
+	songs := []domain.Song{
+		{Id: 10, Title: "Airbag", Duration: 284, MusicFolderId: 1},
+		{Id: 20, Title: "Come Together", Duration: 259, MusicFolderId: 2},
+	}
+	tests := []struct {
+		name             string
+		user             *domain.User
+		id               int
+		setupMock        func(*mocks.MockPlaylistRepository)
+		expectedPlaylist domain.Playlist
+		expectedError    error
+	}{
+		{
+			name: "own private playlist",
+			user: &domain.User{Username: "alice"},
+			id:   1,
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(domain.Playlist{Id: 1, Name: "Mine", Owner: "alice", SongIds: []int{10, 20}, Songs: songs}, nil)
+			},
+			expectedPlaylist: domain.Playlist{Id: 1, Name: "Mine", Owner: "alice", SongIds: []int{10, 20}, SongCount: 2, Duration: 543, Songs: songs},
+			expectedError:    nil,
+		},
+		{
+			name: "songs outside the allowed music folders left out",
+			user: &domain.User{Username: "kid", MusicfolderId: []string{"2"}},
+			id:   2,
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 2).Return(domain.Playlist{Id: 2, Name: "Shared", Owner: "alice", Public: true, SongIds: []int{10, 20}, Songs: songs}, nil)
+			},
+			expectedPlaylist: domain.Playlist{Id: 2, Name: "Shared", Owner: "alice", Public: true, SongIds: []int{20}, SongCount: 1, Duration: 259, Songs: songs[1:]},
+			expectedError:    nil,
+		},
+		{
+			name: "private playlist of another user",
+			user: &domain.User{Username: "bob"},
+			id:   1,
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(domain.Playlist{Id: 1, Name: "Mine", Owner: "alice"}, nil)
+			},
+			expectedError: &ports.NotFoundError{Message: "playlist not found"},
+		},
+		{
+			name: "private playlist of another user for an admin",
+			user: &domain.User{Username: "admin", AdminRole: true},
+			id:   1,
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(domain.Playlist{Id: 1, Name: "Mine", Owner: "alice"}, nil)
+			},
+			expectedPlaylist: domain.Playlist{Id: 1, Name: "Mine", Owner: "alice", SongIds: []int{}, Songs: []domain.Song{}},
+			expectedError:    nil,
+		},
+		{
+			name: "unknown playlist",
+			user: &domain.User{Username: "alice"},
+			id:   9,
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 9).Return(domain.Playlist{}, &ports.NotFoundError{Message: "playlist not found"})
+			},
+			expectedError: &ports.NotFoundError{Message: "playlist not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockPlaylistRepository(t)
+			tt.setupMock(repo)
+			service := NewPlaylistService(repo, mocks.NewMockMediaBrowsingRepository(t), unannotatedRepository(t), slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			result, err := service.GetPlaylist(ctx, tt.id)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(result, tt.expectedPlaylist) {
+					t.Errorf("expected playlist %+v, got %+v", tt.expectedPlaylist, result)
+				}
+			}
+		})
+	}
+}
+
+func TestPlaylistService_CreatePlaylist(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          *domain.User
+		playlistName  string
+		songIDs       []int
+		setupMock     func(*mocks.MockPlaylistRepository, *mocks.MockMediaBrowsingRepository)
+		expectedError error
+	}{
+		{
+			name:         "private playlist of the requesting user",
+			user:         &domain.User{Username: "alice", PlaylistRole: true},
+			playlistName: "Road trip",
+			songIDs:      []int{10, 10},
+			setupMock: func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {
+				b.EXPECT().GetSongByID(mock.Anything, 10).Return(domain.Song{Id: 10, MusicFolderId: 1}, nil).Times(2)
+				m.EXPECT().CreatePlaylist(mock.Anything, mock.MatchedBy(func(p domain.Playlist) bool {
+					return p.Name == "Road trip" && p.Owner == "alice" && !p.Public && reflect.DeepEqual(p.SongIds, []int{10, 10}) && p.Created != ""
+				})).Return(domain.Playlist{Id: 3}, nil)
+				m.EXPECT().GetPlaylist(mock.Anything, 3).Return(domain.Playlist{Id: 3, Name: "Road trip", Owner: "alice"}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "without the playlist role",
+			user:          &domain.User{Username: "alice"},
+			playlistName:  "Road trip",
+			setupMock:     func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.NotAuthorizedError{Username: "alice", Action: "create playlist"},
+		},
+		{
+			name:         "song outside the allowed music folders",
+			user:         &domain.User{Username: "kid", PlaylistRole: true, MusicfolderId: []string{"2"}},
+			playlistName: "Road trip",
+			songIDs:      []int{10},
+			setupMock: func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {
+				b.EXPECT().GetSongByID(mock.Anything, 10).Return(domain.Song{Id: 10, MusicFolderId: 1}, nil)
+			},
+			expectedError: &ports.NotFoundError{Message: "song not found"},
+		},
+		{
+			name:          "missing name",
+			user:          &domain.User{Username: "alice", PlaylistRole: true},
+			playlistName:  " ",
+			setupMock:     func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockPlaylistRepository(t)
+			browsing := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo, browsing)
+			service := NewPlaylistService(repo, browsing, unannotatedRepository(t), slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			_, err := service.CreatePlaylist(ctx, tt.playlistName, tt.songIDs)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPlaylistService_UpdatePlaylist(t *testing.T) {
+	var (
+		newName = "Renamed"
+		public  = true
+		songs   = []domain.Song{
+			{Id: 10, MusicFolderId: 1},
+			{Id: 20, MusicFolderId: 2},
+			{Id: 30, MusicFolderId: 2},
+		}
+		playlist = domain.Playlist{Id: 1, Name: "Mine", Owner: "alice", SongIds: []int{10, 20, 30}, Songs: songs}
+	)
+	tests := []struct {
+		name          string
+		user          *domain.User
+		update        domain.PlaylistUpdate
+		setupMock     func(*mocks.MockPlaylistRepository, *mocks.MockMediaBrowsingRepository)
+		expectedError error
+	}{
+		{
+			name:   "renamed, made public, songs removed and added by the owner",
+			user:   &domain.User{Username: "alice", PlaylistRole: true},
+			update: domain.PlaylistUpdate{Name: &newName, Public: &public, SongIndexesToRemove: []int{0, 2}, SongIdsToAdd: []int{40}},
+			setupMock: func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(playlist, nil)
+				b.EXPECT().GetSongByID(mock.Anything, 40).Return(domain.Song{Id: 40, MusicFolderId: 1}, nil)
+				m.EXPECT().UpdatePlaylist(mock.Anything, mock.MatchedBy(func(p domain.Playlist) bool {
+					return p.Name == "Renamed" && p.Public && reflect.DeepEqual(p.SongIds, []int{20, 40})
+				})).Return(domain.Playlist{}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "indexes among the songs of a restricted user",
+			user:   &domain.User{Username: "kid", MusicfolderId: []string{"2"}, AdminRole: true},
+			update: domain.PlaylistUpdate{SongIndexesToRemove: []int{0}},
+			setupMock: func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(playlist, nil)
+				m.EXPECT().UpdatePlaylist(mock.Anything, mock.MatchedBy(func(p domain.Playlist) bool {
+					return reflect.DeepEqual(p.SongIds, []int{10, 30})
+				})).Return(domain.Playlist{}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "songs moved after removal",
+			user:   &domain.User{Username: "alice", PlaylistRole: true},
+			update: domain.PlaylistUpdate{SongIndexesToRemove: []int{1}, SongMoves: []domain.SongMove{{From: 1, To: 0}}, SongIdsToAdd: []int{40}},
+			setupMock: func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(playlist, nil)
+				b.EXPECT().GetSongByID(mock.Anything, 40).Return(domain.Song{Id: 40, MusicFolderId: 1}, nil)
+				m.EXPECT().UpdatePlaylist(mock.Anything, mock.MatchedBy(func(p domain.Playlist) bool {
+					return reflect.DeepEqual(p.SongIds, []int{30, 10, 40})
+				})).Return(domain.Playlist{}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "songs moved among the songs of a restricted user",
+			user:   &domain.User{Username: "kid", MusicfolderId: []string{"2"}, AdminRole: true},
+			update: domain.PlaylistUpdate{SongMoves: []domain.SongMove{{From: 0, To: 1}}},
+			setupMock: func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(playlist, nil)
+				m.EXPECT().UpdatePlaylist(mock.Anything, mock.MatchedBy(func(p domain.Playlist) bool {
+					return reflect.DeepEqual(p.SongIds, []int{10, 30, 20})
+				})).Return(domain.Playlist{}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "move index out of range",
+			user:   &domain.User{Username: "alice", PlaylistRole: true},
+			update: domain.PlaylistUpdate{SongIndexesToRemove: []int{0}, SongMoves: []domain.SongMove{{From: 0, To: 2}}},
+			setupMock: func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(playlist, nil)
+			},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "songIndexToMove"},
+		},
+		{
+			name:   "song index out of range",
+			user:   &domain.User{Username: "alice", PlaylistRole: true},
+			update: domain.PlaylistUpdate{SongIndexesToRemove: []int{3}},
+			setupMock: func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(playlist, nil)
+			},
+			expectedError: &ports.MissingOrInvalidParameterError{ParameterName: "songIndexToRemove"},
+		},
+		{
+			name:   "owner without the playlist role",
+			user:   &domain.User{Username: "alice"},
+			update: domain.PlaylistUpdate{Name: &newName},
+			setupMock: func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(playlist, nil)
+			},
+			expectedError: &ports.NotAuthorizedError{Username: "alice", Action: "update playlist"},
+		},
+		{
+			name:   "public playlist of another user",
+			user:   &domain.User{Username: "bob", PlaylistRole: true},
+			update: domain.PlaylistUpdate{Name: &newName},
+			setupMock: func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(domain.Playlist{Id: 1, Name: "Mine", Owner: "alice", Public: true}, nil)
+			},
+			expectedError: &ports.NotAuthorizedError{Username: "bob", Action: "update playlist"},
+		},
+		{
+			name:   "imported playlist",
+			user:   &domain.User{Username: "admin", AdminRole: true},
+			update: domain.PlaylistUpdate{Name: &newName},
+			setupMock: func(m *mocks.MockPlaylistRepository, b *mocks.MockMediaBrowsingRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(domain.Playlist{Id: 1, Name: "Imported", Owner: "admin", Public: true, Path: "/music/mix.m3u"}, nil)
+			},
+			expectedError: &ports.FailedOperationError{Description: "playlist is imported from a playlist file"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockPlaylistRepository(t)
+			browsing := mocks.NewMockMediaBrowsingRepository(t)
+			tt.setupMock(repo, browsing)
+			service := NewPlaylistService(repo, browsing, unannotatedRepository(t), slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			err := service.UpdatePlaylist(ctx, 1, tt.update)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPlaylistService_ReplacePlaylistSongs(t *testing.T) {
+	repo := mocks.NewMockPlaylistRepository(t)
+	browsing := mocks.NewMockMediaBrowsingRepository(t)
+	playlist := domain.Playlist{Id: 1, Name: "Mine", Owner: "kid", SongIds: []int{10, 20, 30}, Songs: []domain.Song{
+		{Id: 10, MusicFolderId: 1},
+		{Id: 20, MusicFolderId: 2},
+		{Id: 30, MusicFolderId: 2},
+	}}
+	repo.EXPECT().GetPlaylist(mock.Anything, 1).Return(playlist, nil)
+	browsing.EXPECT().GetSongByID(mock.Anything, 30).Return(domain.Song{Id: 30, MusicFolderId: 2}, nil)
+	browsing.EXPECT().GetSongByID(mock.Anything, 20).Return(domain.Song{Id: 20, MusicFolderId: 2}, nil)
+	// the song the user may not access stays after the reordered songs
+	repo.EXPECT().UpdatePlaylist(mock.Anything, mock.MatchedBy(func(p domain.Playlist) bool {
+		return reflect.DeepEqual(p.SongIds, []int{30, 20, 10})
+	})).Return(domain.Playlist{}, nil)
+	service := NewPlaylistService(repo, browsing, unannotatedRepository(t), slog.Default())
+	ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, &domain.User{Username: "kid", PlaylistRole: true, MusicfolderId: []string{"2"}})
+
+	if _, err := service.ReplacePlaylistSongs(ctx, 1, []int{30, 20}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPlaylistService_DeletePlaylist(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          *domain.User
+		setupMock     func(*mocks.MockPlaylistRepository)
+		expectedError error
+	}{
+		{
+			name: "own playlist",
+			user: &domain.User{Username: "alice", PlaylistRole: true},
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(domain.Playlist{Id: 1, Owner: "alice"}, nil)
+				m.EXPECT().DeletePlaylist(mock.Anything, 1).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "playlist of another user for an admin",
+			user: &domain.User{Username: "admin", AdminRole: true},
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(domain.Playlist{Id: 1, Owner: "alice"}, nil)
+				m.EXPECT().DeletePlaylist(mock.Anything, 1).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "private playlist of another user",
+			user: &domain.User{Username: "bob", PlaylistRole: true},
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(domain.Playlist{Id: 1, Owner: "alice"}, nil)
+			},
+			expectedError: &ports.NotFoundError{Message: "playlist not found"},
+		},
+		{
+			name: "repository error",
+			user: &domain.User{Username: "alice", PlaylistRole: true},
+			setupMock: func(m *mocks.MockPlaylistRepository) {
+				m.EXPECT().GetPlaylist(mock.Anything, 1).Return(domain.Playlist{Id: 1, Owner: "alice"}, nil)
+				m.EXPECT().DeletePlaylist(mock.Anything, 1).Return(errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockPlaylistRepository(t)
+			tt.setupMock(repo)
+			service := NewPlaylistService(repo, mocks.NewMockMediaBrowsingRepository(t), unannotatedRepository(t), slog.Default())
+			ctx := context.WithValue(context.Background(), ports.KeyRequestingUserID, tt.user)
+
+			err := service.DeletePlaylist(ctx, 1)
+
+			if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("expected error %v, got nil", tt.expectedError)
+				} else if err.Error() != tt.expectedError.Error() {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}